@@ -0,0 +1,284 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package cache stores linting results on disk, keyed by a hash of everything that produced them, so linting an
+// unchanged document again (like an editor does on every keystroke) can return the results without running rules.
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/rulesets"
+	"gopkg.in/yaml.v3"
+)
+
+// formatVersion is the version of the cache entry format, it is part of every key, so entries written in an older
+// format are never read.
+const formatVersion = "1"
+
+// entrySuffix is the file extension of cache entries, files without it are never read or evicted.
+const entrySuffix = ".vacuum-cache.json"
+
+// DefaultMaxSize is the default limit of the total size of a cache directory, 100MiB.
+const DefaultMaxSize int64 = 100 << 20
+
+// Cache is a directory of linting results. When the entries are larger than MaxSize together, the least recently
+// used entries are evicted.
+type Cache struct {
+	Dir     string
+	MaxSize int64
+}
+
+// entry is a cached set of results, with the rules that produced them. Rules are normally looked up in the
+// ruleset, but some (like the rule that reports resolving errors) are not part of any ruleset.
+type entry struct {
+	Key     string                      `json:"key"`
+	Results []*model.RuleFunctionResult `json:"results"`
+	Rules   map[string]*model.Rule      `json:"rules,omitempty"`
+}
+
+// New creates a cache in the supplied directory (creating it if needed), limited to maxSize bytes. A maxSize of
+// zero or less uses DefaultMaxSize.
+func New(dir string, maxSize int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("unable to create cache directory '%s': %w", dir, err)
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	return &Cache{Dir: dir, MaxSize: maxSize}, nil
+}
+
+// Key returns the key that results of linting a document are cached under. The key is a hash of the document,
+// of every local file it references (so changing a referenced file invalidates the results too), of every rule in
+// the ruleset, and of the supplied options (like the vacuum version, or flags that change results). specPath is
+// used to find referenced files, relative to the document, it may be empty when the document was not read from a
+// file. Remote references are not part of the key.
+func Key(spec []byte, specPath string, rs *rulesets.RuleSet, options ...string) (string, error) {
+	h := sha256.New()
+	write := func(parts ...string) {
+		for _, p := range parts {
+			_, _ = fmt.Fprintf(h, "%d:%s;", len(p), p)
+		}
+	}
+	write(formatVersion)
+	write(options...)
+
+	write(string(spec))
+	for _, dependency := range localDependencies(spec, specPath) {
+		b, err := os.ReadFile(dependency)
+		if err != nil {
+			b = nil // a missing file is part of the key too, results with a broken reference are cached.
+		}
+		write(dependency, string(b))
+	}
+
+	ids := make([]string, 0, len(rs.Rules))
+	for id := range rs.Rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		rule, err := json.Marshal(rs.Rules[id])
+		if err != nil {
+			return "", fmt.Errorf("unable to hash rule '%s': %w", id, err)
+		}
+		write(id, string(rule))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// localDependencies returns the local files a document references, and the files they reference, as absolute
+// paths, sorted.
+func localDependencies(spec []byte, specPath string) []string {
+	baseDir, _ := os.Getwd()
+	if specPath != "" {
+		if abs, err := filepath.Abs(specPath); err == nil {
+			baseDir = filepath.Dir(abs)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var visit func(doc []byte, dir string)
+	visit = func(doc []byte, dir string) {
+		var root yaml.Node
+		if yaml.Unmarshal(doc, &root) != nil {
+			return
+		}
+		for _, ref := range fileReferences(&root) {
+			file := ref
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(dir, file)
+			}
+			if seen[file] {
+				continue
+			}
+			seen[file] = true
+			if b, err := os.ReadFile(file); err == nil {
+				visit(b, filepath.Dir(file))
+			}
+		}
+	}
+	visit(spec, baseDir)
+
+	dependencies := make([]string, 0, len(seen))
+	for file := range seen {
+		dependencies = append(dependencies, file)
+	}
+	sort.Strings(dependencies)
+	return dependencies
+}
+
+// fileReferences returns the file part of every `$ref` to a local file in a document.
+func fileReferences(node *yaml.Node) []string {
+	var refs []string
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n.Kind == yaml.MappingNode {
+			for i := 0; i < len(n.Content)-1; i += 2 {
+				key, value := n.Content[i], n.Content[i+1]
+				if key.Value == "$ref" && value.Kind == yaml.ScalarNode {
+					file, _, _ := strings.Cut(value.Value, "#")
+					if file != "" && !strings.Contains(file, "://") {
+						refs = append(refs, filepath.FromSlash(file))
+					}
+					continue
+				}
+				walk(value)
+			}
+			return
+		}
+		for _, c := range n.Content {
+			walk(c)
+		}
+	}
+	walk(node)
+	return refs
+}
+
+// path returns the file an entry is stored in.
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.Dir, key+entrySuffix)
+}
+
+// Get returns the results cached under a key, and true, or false if nothing is cached. The rules of the results
+// are looked up in the supplied ruleset (the one that is part of the key), and their nodes are rebuilt from their
+// ranges, so they can be rendered like results that were just linted. A nil cache has nothing cached.
+func (c *Cache) Get(key string, rs *rulesets.RuleSet) ([]*model.RuleFunctionResult, bool) {
+	if c == nil || key == "" {
+		return nil, false
+	}
+	b, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var e entry
+	if err = json.Unmarshal(b, &e); err != nil || e.Key != key {
+		return nil, false
+	}
+	for _, res := range e.Results {
+		if res.Rule = rs.Rules[res.RuleId]; res.Rule == nil {
+			res.Rule = e.Rules[res.RuleId]
+		}
+		if res.Rule == nil {
+			return nil, false
+		}
+		res.StartNode = &yaml.Node{Line: res.Range.Start.Line, Column: res.Range.Start.Char}
+		res.EndNode = &yaml.Node{Line: res.Range.End.Line, Column: res.Range.End.Char}
+	}
+
+	// entries are evicted least recently used first.
+	now := time.Now()
+	_ = os.Chtimes(c.path(key), now, now)
+	return e.Results, true
+}
+
+// Put caches results under a key, then evicts the least recently used entries until the cache fits its MaxSize.
+// Results are stored with the range of their nodes, the nodes themselves are not stored.
+func (c *Cache) Put(key string, results []*model.RuleFunctionResult) error {
+	stored := make([]*model.RuleFunctionResult, 0, len(results))
+	rules := make(map[string]*model.Rule)
+	for _, res := range results {
+		r := *res
+		if r.Rule != nil {
+			r.RuleId, r.RuleSeverity = r.Rule.Id, r.Rule.Severity
+			rules[r.RuleId] = r.Rule
+		}
+		if r.StartNode != nil {
+			r.Range.Start = reports.RangeItem{Line: r.StartNode.Line, Char: r.StartNode.Column}
+		}
+		if r.EndNode != nil {
+			r.Range.End = reports.RangeItem{Line: r.EndNode.Line, Char: r.EndNode.Column}
+		}
+		stored = append(stored, &r)
+	}
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(entry{Key: key, Results: stored, Rules: rules}); err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so a concurrent read never sees a partial entry.
+	tmp, err := os.CreateTemp(c.Dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(tmp, &buf); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = os.Rename(tmp.Name(), c.path(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return c.evict()
+}
+
+// evict removes the least recently used entries, until the entries fit the cache's MaxSize.
+func (c *Cache) evict() error {
+	dirEntries, err := os.ReadDir(c.Dir)
+	if err != nil {
+		return err
+	}
+	var files []os.FileInfo
+	var total int64
+	for _, d := range dirEntries {
+		if d.IsDir() || !strings.HasSuffix(d.Name(), entrySuffix) {
+			continue
+		}
+		info, iErr := d.Info()
+		if iErr != nil {
+			continue
+		}
+		files = append(files, info)
+		total += info.Size()
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, f := range files {
+		if total <= c.MaxSize {
+			break
+		}
+		if rErr := os.Remove(filepath.Join(c.Dir, f.Name())); rErr == nil || os.IsNotExist(rErr) {
+			total -= f.Size()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var testSpec = []byte(`openapi: 3.1.0
+info:
+  title: pizza
+paths: {}`)
+
+// testRuleSet builds new rules every time, so tests can change them.
+func testRuleSet() *rulesets.RuleSet {
+	return &rulesets.RuleSet{
+		Rules: map[string]*model.Rule{
+			rulesets.InfoContact:     rulesets.GetInfoContactRule(),
+			rulesets.InfoDescription: rulesets.GetInfoDescriptionRule(),
+		},
+	}
+}
+
+func testResults(rs *rulesets.RuleSet) []*model.RuleFunctionResult {
+	return []*model.RuleFunctionResult{
+		{
+			Message:   "Info section is missing contact details",
+			Path:      "$.info",
+			Rule:      rs.Rules[rulesets.InfoContact],
+			StartNode: &yaml.Node{Line: 3, Column: 3},
+			EndNode:   &yaml.Node{Line: 3, Column: 10},
+		},
+	}
+}
+
+func TestCache_HitAndMiss(t *testing.T) {
+	c, err := New(t.TempDir(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultMaxSize, c.MaxSize)
+
+	rs := testRuleSet()
+	key, err := Key(testSpec, "", rs, "v1")
+	assert.NoError(t, err)
+
+	results, hit := c.Get(key, rs)
+	assert.False(t, hit)
+	assert.Nil(t, results)
+
+	assert.NoError(t, c.Put(key, testResults(rs)))
+
+	results, hit = c.Get(key, rs)
+	assert.True(t, hit)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Info section is missing contact details", results[0].Message)
+	assert.Same(t, rs.Rules[rulesets.InfoContact], results[0].Rule)
+	assert.Equal(t, 3, results[0].StartNode.Line)
+	assert.Equal(t, 10, results[0].EndNode.Column)
+	assert.Equal(t, rulesets.InfoContact, results[0].RuleId)
+	assert.Equal(t, model.SeverityWarn, results[0].RuleSeverity)
+
+	// a different document, or different options, are a miss.
+	other, _ := Key(append(testSpec, '\n'), "", rs, "v1")
+	_, hit = c.Get(other, rs)
+	assert.False(t, hit)
+	other, _ = Key(testSpec, "", rs, "v2")
+	_, hit = c.Get(other, rs)
+	assert.False(t, hit)
+}
+
+func TestCache_RuleSetChange(t *testing.T) {
+	rs := testRuleSet()
+	key, _ := Key(testSpec, "", rs, "v1")
+	same, _ := Key(testSpec, "", testRuleSet(), "v1")
+	assert.Equal(t, key, same)
+
+	rs.Rules[rulesets.InfoContact].Severity = model.SeverityError
+	changed, _ := Key(testSpec, "", rs, "v1")
+	assert.NotEqual(t, key, changed)
+
+	delete(rs.Rules, rulesets.InfoContact)
+	removed, _ := Key(testSpec, "", rs, "v1")
+	assert.NotEqual(t, changed, removed)
+}
+
+func TestCache_ReferencedFileChange(t *testing.T) {
+	dir := t.TempDir()
+	spec := []byte(`openapi: 3.1.0
+paths:
+  /pizza:
+    $ref: 'paths/pizza.yaml'`)
+	specPath := filepath.Join(dir, "openapi.yaml")
+	assert.NoError(t, os.WriteFile(specPath, spec, 0o644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "paths"), 0o755))
+	pizza := filepath.Join(dir, "paths", "pizza.yaml")
+	assert.NoError(t, os.WriteFile(pizza, []byte("get:\n  $ref: '../operations.yaml#/get'"), 0o644))
+	operations := filepath.Join(dir, "operations.yaml")
+	assert.NoError(t, os.WriteFile(operations, []byte("get: {}"), 0o644))
+
+	rs := testRuleSet()
+	key, _ := Key(spec, specPath, rs)
+
+	// a file referenced by a referenced file changed.
+	assert.NoError(t, os.WriteFile(operations, []byte("get:\n  summary: pizza"), 0o644))
+	changed, _ := Key(spec, specPath, rs)
+	assert.NotEqual(t, key, changed)
+}
+
+func TestCache_Evict(t *testing.T) {
+	dir := t.TempDir()
+	c, _ := New(dir, 0)
+	rs := testRuleSet()
+
+	// entries are the same size, the cache fits two of them.
+	first, _ := Key(testSpec, "", rs, "first")
+	assert.NoError(t, c.Put(first, testResults(rs)))
+	info, err := os.Stat(c.path(first))
+	assert.NoError(t, err)
+	c.MaxSize = info.Size() * 2
+
+	old := time.Now().Add(-time.Hour)
+	assert.NoError(t, os.Chtimes(c.path(first), old, old))
+
+	second, _ := Key(testSpec, "", rs, "second")
+	assert.NoError(t, c.Put(second, testResults(rs)))
+	_, hit := c.Get(first, rs)
+	assert.True(t, hit) // both fit, reading the first makes it the most recently used.
+	assert.NoError(t, os.Chtimes(c.path(second), old, old))
+
+	third, _ := Key(testSpec, "", rs, "third")
+	assert.NoError(t, c.Put(third, testResults(rs)))
+
+	_, hit = c.Get(second, rs)
+	assert.False(t, hit)
+	_, hit = c.Get(first, rs)
+	assert.True(t, hit)
+	_, hit = c.Get(third, rs)
+	assert.True(t, hit)
+
+	// only entries are counted, and only entries are evicted.
+	files, _ := os.ReadDir(dir)
+	for _, f := range files {
+		assert.True(t, strings.HasSuffix(f.Name(), entrySuffix))
+	}
+}
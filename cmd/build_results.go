@@ -5,7 +5,7 @@ import (
 	"github.com/daveshanley/vacuum/motor"
 	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/pterm/pterm"
-	"os"
+	"time"
 )
 
 func BuildResults(
@@ -22,6 +22,29 @@ func BuildResultsWithDocCheckSkip(
 	customFunctions map[string]model.RuleFunction,
 	base string,
 	skipCheck bool) (*model.RuleResultSet, *motor.RuleSetExecutionResult, error) {
+	return BuildResultsWithRemoteRuleSets(rulesetFlag, specBytes, customFunctions, base, skipCheck, false)
+}
+
+func BuildResultsWithRemoteRuleSets(
+	rulesetFlag string,
+	specBytes []byte,
+	customFunctions map[string]model.RuleFunction,
+	base string,
+	skipCheck bool,
+	remoteRuleSets bool) (*model.RuleResultSet, *motor.RuleSetExecutionResult, error) {
+	return BuildResultsWithTimeout(rulesetFlag, specBytes, customFunctions, base, skipCheck, remoteRuleSets, 0)
+}
+
+// BuildResultsWithTimeout builds results like BuildResultsWithRemoteRuleSets, aborting linting after the timeout
+// (if it's not zero). The execution result is TimedOut if it was aborted.
+func BuildResultsWithTimeout(
+	rulesetFlag string,
+	specBytes []byte,
+	customFunctions map[string]model.RuleFunction,
+	base string,
+	skipCheck bool,
+	remoteRuleSets bool,
+	timeout time.Duration) (*model.RuleResultSet, *motor.RuleSetExecutionResult, error) {
 
 	// read spec and parse
 	defaultRuleSets := rulesets.BuildDefaultRuleSets()
@@ -33,11 +56,8 @@ func BuildResultsWithDocCheckSkip(
 	// and see if it's valid. If so - let's go!
 	if rulesetFlag != "" {
 
-		rsBytes, rsErr := os.ReadFile(rulesetFlag)
-		if rsErr != nil {
-			return nil, nil, rsErr
-		}
-		selectedRS, rsErr = BuildRuleSetFromUserSuppliedSet(rsBytes, defaultRuleSets)
+		var rsErr error
+		selectedRS, rsErr = LoadRuleSet(rulesetFlag, remoteRuleSets, defaultRuleSets)
 		if rsErr != nil {
 			return nil, nil, rsErr
 		}
@@ -51,9 +71,11 @@ func BuildResultsWithDocCheckSkip(
 		CustomFunctions:   customFunctions,
 		Base:              base,
 		SkipDocumentCheck: skipCheck,
+		Timeout:           timeout,
 	})
 
 	resultSet := model.NewRuleResultSet(ruleset.Results)
+	resultSet.AddRuleCategories(selectedRS.Categories)
 	resultSet.SortResultsByLineNumber()
 	return resultSet, ruleset, nil
 }
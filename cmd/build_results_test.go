@@ -14,3 +14,9 @@ func TestBuildResults_SkipCheck(t *testing.T) {
 	_, _, err := BuildResultsWithDocCheckSkip("nuggets", nil, nil, "", true)
 	assert.Error(t, err)
 }
+
+func TestBuildResults_RemoteRuleSetNotAllowed(t *testing.T) {
+	_, _, err := BuildResultsWithRemoteRuleSets("https://quobix.com/vacuum/ruleset.yaml", nil, nil, "", true, false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--ruleset-remote")
+}
@@ -58,11 +58,13 @@ func GetDashboardCommand() *cobra.Command {
 			// if we have a pre-compiled report, jump straight to the end and collect $500
 			if vacuumReport == nil {
 
-				functionsFlag, _ := cmd.Flags().GetString("functions")
-				customFunctions, _ := LoadCustomFunctions(functionsFlag)
+				functionsFlag := GetCustomFunctionsFlag(cmd)
+				customFunctions, _ := LoadCustomFunctions(functionsFlag, GetWASMLimitsFlags(cmd))
 
 				rulesetFlag, _ := cmd.Flags().GetString("ruleset")
-				resultSet, ruleset, err = BuildResultsWithDocCheckSkip(rulesetFlag, specBytes, customFunctions, baseFlag, skipCheckFlag)
+				remoteRuleSetFlag, _ := cmd.Flags().GetBool("ruleset-remote")
+				resultSet, ruleset, err = BuildResultsWithRemoteRuleSets(rulesetFlag, specBytes, customFunctions,
+					baseFlag, skipCheckFlag, remoteRuleSetFlag)
 				if err != nil {
 					pterm.Error.Printf("Failed to render dashboard: %v\n\n", err)
 					return err
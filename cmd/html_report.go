@@ -44,6 +44,7 @@ func GetHTMLReportCommand() *cobra.Command {
 			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
 			baseFlag, _ := cmd.Flags().GetString("base")
 			skipCheckFlag, _ := cmd.Flags().GetBool("skip-check")
+			timeoutFlag, _ := cmd.Flags().GetDuration("timeout")
 
 			// disable color and styling, for CI/CD use.
 			// https://github.com/daveshanley/vacuum/issues/234
@@ -88,15 +89,20 @@ func GetHTMLReportCommand() *cobra.Command {
 			// if we have a pre-compiled report, jump straight to the end and collect $500
 			if vacuumReport == nil {
 
-				functionsFlag, _ := cmd.Flags().GetString("functions")
-				customFunctions, _ := LoadCustomFunctions(functionsFlag)
+				functionsFlag := GetCustomFunctionsFlag(cmd)
+				customFunctions, _ := LoadCustomFunctions(functionsFlag, GetWASMLimitsFlags(cmd))
 
 				rulesetFlag, _ := cmd.Flags().GetString("ruleset")
-				resultSet, ruleset, err = BuildResultsWithDocCheckSkip(rulesetFlag, specBytes, customFunctions, baseFlag, skipCheckFlag)
+				remoteRuleSetFlag, _ := cmd.Flags().GetBool("ruleset-remote")
+				resultSet, ruleset, err = BuildResultsWithTimeout(rulesetFlag, specBytes, customFunctions,
+					baseFlag, skipCheckFlag, remoteRuleSetFlag, timeoutFlag)
 				if err != nil {
 					pterm.Error.Printf("Failed to generate report: %v\n\n", err)
 					return err
 				}
+				if tErr := checkReportTimedOut(ruleset, args[0], timeoutFlag, false); tErr != nil {
+					return tErr
+				}
 				specIndex = ruleset.Index
 				specInfo = ruleset.SpecInfo
 
@@ -115,6 +121,7 @@ func GetHTMLReportCommand() *cobra.Command {
 
 			// generate html report
 			report := html_report.NewHTMLReport(specIndex, specInfo, resultSet, stats, disableTimestamp)
+			report.SetFileName(args[0])
 
 			generatedBytes := report.GenerateReport(false)
 			//generatedBytes := report.GenerateReport(true) // test mode
@@ -133,7 +140,7 @@ func GetHTMLReportCommand() *cobra.Command {
 			fi, _ := os.Stat(args[0])
 			RenderTime(timeFlag, duration, fi.Size())
 
-			return nil
+			return checkTimedOut(ruleset, args[0], nil)
 		},
 	}
 	cmd.Flags().BoolP("disableTimestamp", "d", false, "Disable timestamp in report")
@@ -85,3 +85,13 @@ func TestGetHTMLReportCommand_BadWrite(t *testing.T) {
 	cmdErr := cmd.Execute()
 	assert.Error(t, cmdErr)
 }
+
+func TestGetHTMLReportCommand_Timeout(t *testing.T) {
+	cmd := GetRootCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"html-report", "--timeout", "1ns", "../model/test_files/stripe.yaml", "test-timeout-report.html"})
+	cmdErr := cmd.Execute()
+	defer os.Remove("test-timeout-report.html")
+	assert.ErrorContains(t, cmdErr, "timed out")
+}
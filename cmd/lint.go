@@ -4,17 +4,26 @@
 package cmd
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/daveshanley/vacuum/cache"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/motor"
 	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/dustin/go-humanize"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"runtime/debug"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -42,12 +51,33 @@ func GetLintCommand() *cobra.Command {
 			errorsFlag, _ := cmd.Flags().GetBool("errors")
 			categoryFlag, _ := cmd.Flags().GetString("category")
 			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+			remoteRuleSetFlag, _ := cmd.Flags().GetBool("ruleset-remote")
 			silent, _ := cmd.Flags().GetBool("silent")
-			functionsFlag, _ := cmd.Flags().GetString("functions")
+			functionsFlag := GetCustomFunctionsFlag(cmd)
 			failSeverityFlag, _ := cmd.Flags().GetString("fail-severity")
 			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
 			baseFlag, _ := cmd.Flags().GetString("base")
 			skipCheckFlag, _ := cmd.Flags().GetBool("skip-check")
+			streamingFlag, _ := cmd.Flags().GetBool("streaming")
+			timeoutFlag, _ := cmd.Flags().GetDuration("timeout")
+			ndjsonFlag, _ := cmd.Flags().GetBool("ndjson")
+			fixFlag, _ := cmd.Flags().GetBool("fix")
+			fixDryRunFlag, _ := cmd.Flags().GetBool("fix-dry-run")
+			fixRulesFlag, _ := cmd.Flags().GetStringSlice("fix-rules")
+			ruleSeverityFlag, _ := cmd.Flags().GetStringArray("rule-severity")
+			stdinFormatFlag, _ := cmd.Flags().GetString("stdin-format")
+			cacheDirFlag, _ := cmd.Flags().GetString("cache-dir")
+			cacheMaxSizeFlag, _ := cmd.Flags().GetInt64("cache-max-size")
+			ignoreFlag, _ := cmd.Flags().GetStringArray("ignore")
+
+			// ndjson output is meant for machines, nothing else can be written to stdout, messages go to stderr.
+			var ndjson *model.NDJSONWriter
+			if ndjsonFlag {
+				silent = true
+				ndjson = model.NewNDJSONWriter(cmd.OutOrStdout())
+				pterm.SetDefaultOutput(os.Stderr)
+				defer pterm.SetDefaultOutput(os.Stdout)
+			}
 
 			// disable color and styling, for CI/CD use.
 			// https://github.com/daveshanley/vacuum/issues/234
@@ -67,6 +97,42 @@ func GetLintCommand() *cobra.Command {
 				return fmt.Errorf("no file supplied")
 			}
 
+			// files matching an ignore glob are not linted.
+			if args = ignoreFiles(args, ignoreFlag); len(args) == 0 {
+				if !silent {
+					pterm.Info.Println("Every file supplied is ignored, there is nothing to lint")
+					pterm.Println()
+				}
+				return nil
+			}
+
+			// a file name of '-' reads the specification from stdin, it can only be read once.
+			var stdinBytes []byte
+			stdinCount := 0
+			for _, arg := range args {
+				if arg == "-" {
+					stdinCount++
+				}
+			}
+			if stdinCount > 1 {
+				pterm.Error.Println("stdin ('-') can only be linted once")
+				pterm.Println()
+				return errors.New("stdin supplied more than once")
+			}
+			if stdinCount == 1 {
+				if fixFlag {
+					pterm.Error.Println("Fixes can't be written back to stdin, use '--fix-dry-run' to see them")
+					pterm.Println()
+					return errors.New("cannot fix stdin")
+				}
+				var stdinErr error
+				if stdinBytes, stdinErr = readStdinSpec(cmd.InOrStdin(), stdinFormatFlag); stdinErr != nil {
+					pterm.Error.Printf("Unable to read specification from stdin: %s\n", stdinErr.Error())
+					pterm.Println()
+					return stdinErr
+				}
+			}
+
 			var errs []error
 
 			mf := false
@@ -74,29 +140,63 @@ func GetLintCommand() *cobra.Command {
 				mf = true
 			}
 
-			// setup logging
-			handler := pterm.NewSlogHandler(&pterm.DefaultLogger)
+			// setup logging, errors logged while resolving references must not end up in the ndjson output.
 			pterm.DefaultLogger.Level = pterm.LogLevelError
-			logger := slog.New(handler)
+			ptermLogger := &pterm.DefaultLogger
+			if ndjsonFlag {
+				ptermLogger = pterm.DefaultLogger.WithWriter(os.Stderr)
+			}
+			logger := slog.New(pterm.NewSlogHandler(ptermLogger))
 
 			defaultRuleSets := rulesets.BuildDefaultRuleSetsWithLogger(logger)
 			selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
-			customFunctions, _ := LoadCustomFunctions(functionsFlag)
+			customFunctions, _ := LoadCustomFunctions(functionsFlag, GetWASMLimitsFlags(cmd))
 
 			// if ruleset has been supplied, lets make sure it exists, then load it in
 			// and see if it's valid. If so - let's go!
 			if rulesetFlag != "" {
 
-				rsBytes, rsErr := os.ReadFile(rulesetFlag)
+				var rsErr error
+				selectedRS, rsErr = LoadRuleSet(rulesetFlag, remoteRuleSetFlag, defaultRuleSets)
 				if rsErr != nil {
-					pterm.Error.Printf("Unable to read ruleset file '%s': %s\n", rulesetFlag, rsErr.Error())
-					pterm.Println()
 					return rsErr
 				}
+			}
 
-				selectedRS, rsErr = BuildRuleSetFromUserSuppliedSet(rsBytes, defaultRuleSets)
-				if rsErr != nil {
-					return rsErr
+			if err := ApplyRuleSeverityOverrides(selectedRS, ruleSeverityFlag); err != nil {
+				pterm.Error.Println(err.Error())
+				pterm.Println()
+				return err
+			}
+
+			// rules opt in to automatic fixes in the ruleset, or with the fix-rules flag.
+			if fixFlag || fixDryRunFlag {
+				fixable := 0
+				for _, rule := range selectedRS.Rules {
+					for _, id := range fixRulesFlag {
+						if rule.Id == id {
+							rule.AutoFix = true
+						}
+					}
+					if rule.AutoFix {
+						fixable++
+					}
+				}
+				if fixable == 0 && !silent {
+					pterm.Info.Println("No rules are opted in to automatic fixes, set 'autoFix' on rules in the " +
+						"ruleset, or use the '--fix-rules' flag")
+					pterm.Println()
+				}
+			}
+
+			// results are cached between runs, when a cache directory is supplied.
+			var resultsCache *cache.Cache
+			if cacheDirFlag != "" {
+				var cacheErr error
+				if resultsCache, cacheErr = cache.New(cacheDirFlag, cacheMaxSizeFlag<<20); cacheErr != nil {
+					pterm.Error.Println(cacheErr.Error())
+					pterm.Println()
+					return cacheErr
 				}
 			}
 
@@ -137,6 +237,8 @@ func GetLintCommand() *cobra.Command {
 						baseFlag:         baseFlag,
 						multiFile:        mf,
 						skipCheckFlag:    skipCheckFlag,
+						streamingFlag:    streamingFlag,
+						timeoutFlag:      timeoutFlag,
 						silent:           silent,
 						detailsFlag:      detailsFlag,
 						timeFlag:         timeFlag,
@@ -149,8 +251,17 @@ func GetLintCommand() *cobra.Command {
 						defaultRuleSets:  defaultRuleSets,
 						selectedRS:       selectedRS,
 						functions:        customFunctions,
+						functionsPath:    functionsFlag,
+						cache:            resultsCache,
 						lock:             &printLock,
 						logger:           logger,
+						ndjson:           ndjson,
+						fixFlag:          fixFlag,
+						fixDryRunFlag:    fixDryRunFlag,
+					}
+					if arg == "-" {
+						lfr.fileName = stdinFileName
+						lfr.spec = stdinBytes
 					}
 					errs = append(errs, lintFile(lfr))
 					doneChan <- true
@@ -163,7 +274,7 @@ func GetLintCommand() *cobra.Command {
 				completed++
 			}
 
-			if !detailsFlag {
+			if !detailsFlag && !ndjsonFlag {
 				pterm.Println()
 				pterm.Info.Println("To see full details of linting report, use the '-d' flag.")
 				pterm.Println()
@@ -171,7 +282,9 @@ func GetLintCommand() *cobra.Command {
 
 			duration := time.Since(start)
 
-			RenderTime(timeFlag, duration, size)
+			if !ndjsonFlag {
+				RenderTime(timeFlag, duration, size)
+			}
 
 			if len(errs) > 0 {
 				return errors.Join(errs...)
@@ -188,6 +301,16 @@ func GetLintCommand() *cobra.Command {
 	cmd.Flags().BoolP("silent", "x", false, "Show nothing except the result.")
 	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
 	cmd.Flags().StringP("fail-severity", "n", model.SeverityError, "Results of this level or above will trigger a failure exit code")
+	cmd.Flags().Bool("fix", false, "Apply fixes proposed by rules opted in to automatic fixes, and write the specification back")
+	cmd.Flags().Bool("fix-dry-run", false, "Print a diff of the fixes that would be applied, without writing anything")
+	cmd.Flags().StringSlice("fix-rules", nil, "Opt rules in to automatic fixes by ID (comma separated)")
+	cmd.Flags().StringArray("rule-severity", nil, "Override the severity of a rule, as rule-id=severity (repeatable)")
+	cmd.Flags().String("stdin-format", "", "The format (yaml or json) of a specification read from stdin, when the file name is '-'")
+	cmd.Flags().Bool("ndjson", false, "Write results to stdout as newline delimited JSON (one result per line), as they are found")
+	cmd.Flags().String("cache-dir", "", "Cache results in this directory, so linting an unchanged specification with an unchanged ruleset is instant")
+	cmd.Flags().Int64("cache-max-size", 100, "The maximum size of the cache directory in MiB, the least recently used results are evicted first")
+	cmd.Flags().StringArray("ignore", nil, "Don't lint files matching this glob (like 'specs/legacy/*.yaml'), matched against the path and the file name (repeatable)")
+	cmd.Flags().Bool("streaming", false, "Lint huge specifications with bounded memory, one entry at a time, only running rules that don't need the whole specification")
 
 	regErr := cmd.RegisterFlagCompletionFunc("category", cobra.FixedCompletions([]string{
 		model.CategoryAll,
@@ -217,9 +340,12 @@ func GetLintCommand() *cobra.Command {
 
 type lintFileRequest struct {
 	fileName         string
+	spec             []byte
 	baseFlag         string
 	multiFile        bool
 	skipCheckFlag    bool
+	streamingFlag    bool
+	timeoutFlag      time.Duration
 	silent           bool
 	detailsFlag      bool
 	timeFlag         bool
@@ -232,13 +358,21 @@ type lintFileRequest struct {
 	defaultRuleSets  rulesets.RuleSets
 	selectedRS       *rulesets.RuleSet
 	functions        map[string]model.RuleFunction
+	functionsPath    string
+	cache            *cache.Cache
 	lock             *sync.Mutex
 	logger           *slog.Logger
+	ndjson           *model.NDJSONWriter
+	fixFlag          bool
+	fixDryRunFlag    bool
 }
 
 func lintFile(req lintFileRequest) error {
-	// read file.
-	specBytes, ferr := os.ReadFile(req.fileName)
+	// read file, unless it has already been read from stdin.
+	specBytes, ferr := req.spec, error(nil)
+	if specBytes == nil {
+		specBytes, ferr = os.ReadFile(req.fileName)
+	}
 
 	// split up file into an array with lines.
 	specStringData := strings.Split(string(specBytes), "\n")
@@ -251,7 +385,7 @@ func lintFile(req lintFileRequest) error {
 
 	}
 
-	result := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+	execution := &motor.RuleSetExecution{
 		RuleSet:           req.selectedRS,
 		Spec:              specBytes,
 		CustomFunctions:   req.functions,
@@ -259,7 +393,59 @@ func lintFile(req lintFileRequest) error {
 		AllowLookup:       true,
 		SkipDocumentCheck: req.skipCheckFlag,
 		Logger:            req.logger,
-	})
+		Timeout:           req.timeoutFlag,
+	}
+
+	// ndjson results are written as the rules produce them, rather than once the whole file has been linted.
+	var ndjsonErr error
+	if req.ndjson != nil {
+		var ndjsonLock sync.Mutex
+		written := make(map[string]bool)
+		execution.ResultHandler = func(results []model.RuleFunctionResult) {
+			ndjsonLock.Lock()
+			defer ndjsonLock.Unlock()
+			for i := range results {
+				r := &results[i]
+				if ndjsonErr != nil || r.Rule == nil || r.StartNode == nil {
+					continue
+				}
+				if req.errorsFlag && r.Rule.Severity != model.SeverityError {
+					continue // only write errors
+				}
+				key := fmt.Sprintf("%s:%d:%d:%s", r.Rule.Id, r.StartNode.Line, r.StartNode.Column, r.Message)
+				if !written[key] {
+					written[key] = true
+					ndjsonErr = req.ndjson.Write(r, req.fileName)
+				}
+			}
+		}
+	}
+
+	// a cached result is only used when the document, the files it references and the ruleset are unchanged.
+	cacheKey, keyErr := lintCacheKey(req, specBytes)
+	if keyErr != nil {
+		pterm.Warning.Printf("Unable to cache results of '%s': %s\n", req.fileName, keyErr.Error())
+	}
+
+	var result *motor.RuleSetExecutionResult
+	cached, hit := req.cache.Get(cacheKey, req.selectedRS)
+	if hit {
+		result = &motor.RuleSetExecutionResult{}
+		for _, res := range cached {
+			result.Results = append(result.Results, *res)
+		}
+		if execution.ResultHandler != nil {
+			execution.ResultHandler(result.Results)
+		}
+	} else if req.streamingFlag {
+		result = motor.ApplyRulesToRuleSetStreaming(execution)
+		if !req.silent && len(result.DeferredRules) > 0 {
+			pterm.Info.Printf("Streaming mode: %d rules need the whole specification and were not run\n",
+				len(result.DeferredRules))
+		}
+	} else {
+		result = motor.ApplyRulesToRuleSet(execution)
+	}
 
 	results := result.Results
 
@@ -272,18 +458,62 @@ func lintFile(req lintFileRequest) error {
 	}
 
 	resultSet := model.NewRuleResultSet(results)
+	resultSet.AddRuleCategories(req.selectedRS.Categories)
+
+	// partial results are never cached, results that came from the cache are already in it.
+	if cacheKey != "" && !hit && !result.TimedOut {
+		if cacheErr := req.cache.Put(cacheKey, resultSet.Results); cacheErr != nil {
+			pterm.Warning.Printf("Unable to cache results of '%s': %s\n", req.fileName, cacheErr.Error())
+		}
+	}
 	resultSet.SortResultsByLineNumber()
 	warnings := resultSet.GetWarnCount()
 	errs := resultSet.GetErrorCount()
 	informs := resultSet.GetInfoCount()
+
+	if req.ndjson != nil {
+		if result.TimedOut {
+			pterm.Warning.Printf("Linting '%s' timed out after %v, results are partial\n", req.fileName, req.timeoutFlag)
+		}
+		if ndjsonErr != nil {
+			pterm.Error.Printf("unable to write results for '%s': %s\n", req.fileName, ndjsonErr.Error())
+			return ndjsonErr
+		}
+		// fixes are applied once every result has been written, a dry run's diff goes to stderr with the messages.
+		if req.fixFlag || req.fixDryRunFlag {
+			req.lock.Lock()
+			fixErr := fixFile(req.fileName, specBytes, resultSet.Results, req.fixDryRunFlag)
+			req.lock.Unlock()
+			if fixErr != nil {
+				return fixErr
+			}
+		}
+		return checkTimedOut(result, req.fileName, CheckFailureSeverity(req.failSeverityFlag, errs, warnings, informs))
+	}
+
 	req.lock.Lock()
 	defer req.lock.Unlock()
+
+	if req.fixFlag || req.fixDryRunFlag {
+		if fixErr := fixFile(req.fileName, specBytes, resultSet.Results, req.fixDryRunFlag); fixErr != nil {
+			return fixErr
+		}
+	}
+
+	if result.TimedOut {
+		pterm.Warning.Printf("Linting '%s' timed out after %v, results are partial\n", req.fileName, req.timeoutFlag)
+		pterm.Println()
+	}
+
 	if !req.detailsFlag {
 		RenderSummary(resultSet, req.silent, req.totalFiles, req.fileIndex, req.fileName, req.failSeverityFlag)
-		return CheckFailureSeverity(req.failSeverityFlag, errs, warnings, informs)
+		return checkTimedOut(result, req.fileName, CheckFailureSeverity(req.failSeverityFlag, errs, warnings, informs))
 	}
 
-	abs, _ := filepath.Abs(req.fileName)
+	abs := req.fileName
+	if req.spec == nil {
+		abs, _ = filepath.Abs(req.fileName)
+	}
 
 	if len(resultSet.Results) > 0 {
 		processResults(resultSet.Results, specStringData, req.snippetsFlag, req.errorsFlag, req.silent, abs, req.fileName)
@@ -291,7 +521,7 @@ func lintFile(req lintFileRequest) error {
 
 	RenderSummary(resultSet, req.silent, req.totalFiles, req.fileIndex, req.fileName, req.failSeverityFlag)
 
-	return CheckFailureSeverity(req.failSeverityFlag, errs, warnings, informs)
+	return checkTimedOut(result, req.fileName, CheckFailureSeverity(req.failSeverityFlag, errs, warnings, informs))
 }
 
 func processResults(results []*model.RuleFunctionResult, specData []string, snippets, errors bool, silent bool, abs, filename string) {
@@ -401,7 +631,7 @@ func RenderSummary(rs *model.RuleResultSet, silent bool, totalFiles, fileIndex i
 	tableData := [][]string{{"Category", pterm.LightRed("Errors"), pterm.LightYellow("Warnings"),
 		pterm.LightBlue("Info")}}
 
-	for _, cat := range model.RuleCategoriesOrdered {
+	for _, cat := range rs.GetRuleCategories() {
 		errors := rs.GetErrorsByRuleCategory(cat.Id)
 		warn := rs.GetWarningsByRuleCategory(cat.Id)
 		info := rs.GetInfoByRuleCategory(cat.Id)
@@ -488,3 +718,136 @@ func RenderSummary(rs *model.RuleResultSet, silent bool, totalFiles, fileIndex i
 	}
 
 }
+
+// stdinFileName is the name results are reported against, when the specification is read from stdin.
+const stdinFileName = "<stdin>"
+
+// ignoreFiles returns the files that don't match any of the ignore globs. A glob is matched against the path of a
+// file, as it was supplied, and against its name. Stdin ('-') is never ignored.
+func ignoreFiles(files, ignore []string) []string {
+	if len(ignore) == 0 {
+		return files
+	}
+	var linted []string
+	for _, file := range files {
+		if !isIgnored(file, ignore) {
+			linted = append(linted, file)
+		}
+	}
+	return linted
+}
+
+// isIgnored returns true if a file matches one of the ignore globs, by its path or its name.
+func isIgnored(file string, ignore []string) bool {
+	if file == "-" {
+		return false
+	}
+	for _, glob := range ignore {
+		glob = filepath.Clean(glob)
+		if ok, _ := filepath.Match(glob, filepath.Clean(file)); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(glob, filepath.Base(file)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// readStdinSpec reads a specification from stdin. The format can't be inferred from a file name, so if a format
+// is declared, the specification is checked to be valid YAML or JSON before it's linted.
+func readStdinSpec(stdin io.Reader, format string) ([]byte, error) {
+	spec, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(spec)) == 0 {
+		return nil, errors.New("stdin is empty")
+	}
+	switch strings.ToLower(format) {
+	case "":
+	case "json":
+		if !json.Valid(spec) {
+			return nil, errors.New("stdin is not valid JSON")
+		}
+	case "yaml", "yml":
+		var node yaml.Node
+		if err = yaml.Unmarshal(spec, &node); err != nil {
+			return nil, fmt.Errorf("stdin is not valid YAML: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown stdin format '%s', use 'yaml' or 'json'", format)
+	}
+	return spec, nil
+}
+
+// lintCacheKey returns the key the results of a lint request are cached under, or an empty key when results are not
+// cached. The build of vacuum, flags that change results, and the names and contents of custom functions are part
+// of the key.
+func lintCacheKey(req lintFileRequest, specBytes []byte) (string, error) {
+	if req.cache == nil {
+		return "", nil
+	}
+	specPath := req.fileName
+	if req.spec != nil {
+		specPath = "" // read from stdin.
+	}
+	functionsDigest, err := customFunctionsDigest(req.functionsPath)
+	if err != nil {
+		return "", err
+	}
+	options := []string{buildIdentifier(), req.baseFlag, strconv.FormatBool(req.skipCheckFlag),
+		strconv.FormatBool(req.streamingFlag), functionsDigest}
+	var functionNames []string
+	for name := range req.functions {
+		functionNames = append(functionNames, name)
+	}
+	sort.Strings(functionNames)
+	return cache.Key(specBytes, specPath, req.selectedRS, append(options, functionNames...)...)
+}
+
+// buildIdentifier identifies the build of vacuum that is running. The version is 'latest' in development builds,
+// so the VCS revision the binary was built from, and the size and modification time of the binary, are part of it.
+func buildIdentifier() string {
+	parts := []string{Version, Commit, Date}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" || setting.Key == "vcs.modified" {
+				parts = append(parts, setting.Value)
+			}
+		}
+	}
+	if exe, err := os.Executable(); err == nil {
+		if fi, statErr := os.Stat(exe); statErr == nil {
+			parts = append(parts, strconv.FormatInt(fi.Size(), 10), fi.ModTime().UTC().Format(time.RFC3339Nano))
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+// customFunctionsDigest returns a hash of the name and contents of every custom function file (Go plugins,
+// javascript and WASM modules) in a directory, so changing a function invalidates cached results. The digest is
+// empty when there is no directory.
+func customFunctionsDigest(dir string) (string, error) {
+	if dir == "" {
+		return "", nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".so" && ext != ".js" && ext != ".wasm") {
+			continue
+		}
+		b, readErr := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if readErr != nil {
+			return "", readErr
+		}
+		_, _ = fmt.Fprintf(h, "%s:%d:", entry.Name(), len(b))
+		_, _ = h.Write(b)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
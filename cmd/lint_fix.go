@@ -0,0 +1,56 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/pterm/pterm"
+)
+
+// fixFile applies the fixes proposed by results to a specification file. In a dry run, a unified diff of the
+// changes is printed instead, and nothing is written.
+func fixFile(fileName string, spec []byte, results []*model.RuleFunctionResult, dryRun bool) error {
+	fixed, applied, err := motor.ApplyFixes(spec, results)
+	if err != nil {
+		pterm.Error.Printf("Unable to fix '%s': %s\n", fileName, err.Error())
+		pterm.Println()
+		return err
+	}
+	if applied == 0 {
+		pterm.Info.Printf("There is nothing to fix in '%s'\n", fileName)
+		pterm.Println()
+		return nil
+	}
+
+	if dryRun {
+		diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(spec)),
+			B:        difflib.SplitLines(string(fixed)),
+			FromFile: fileName,
+			ToFile:   fileName + " (fixed)",
+			Context:  3,
+		})
+		pterm.Println(diff)
+		pterm.Info.Printf("%d fixes can be applied to '%s' (dry run, nothing was written)\n", applied, fileName)
+		pterm.Println()
+		return nil
+	}
+
+	mode := os.FileMode(0664)
+	if fi, statErr := os.Stat(fileName); statErr == nil {
+		mode = fi.Mode()
+	}
+	if err = os.WriteFile(fileName, fixed, mode); err != nil {
+		pterm.Error.Printf("Unable to write fixes to '%s': %s\n", fileName, err.Error())
+		pterm.Println()
+		return err
+	}
+	pterm.Success.Printf("Applied %d fixes to '%s'\n", applied, fileName)
+	pterm.Println()
+	return nil
+}
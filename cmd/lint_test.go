@@ -2,11 +2,20 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/pterm/pterm"
 	"github.com/stretchr/testify/assert"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -41,6 +50,92 @@ func TestGetLintCommand_Ruleset(t *testing.T) {
 	assert.NotNil(t, outBytes)
 }
 
+func TestGetLintCommand_RulesetTOML(t *testing.T) {
+	yml := `extends: [[spectral:oas, off]]
+rules:
+  info-contact: true
+  title-is-pizza:
+    description: The title must be about pizza
+    severity: warn
+    given: $.info
+    then:
+      field: title
+      function: pattern
+      functionOptions:
+        match: pizza
+  operations-documented:
+    description: Operations must be documented
+    severity: info
+    given: ['$.paths[*].get', '$.paths[*].post']
+    then:
+      - field: description
+        function: truthy
+      - field: summary
+        function: truthy`
+
+	tml := `extends = [["spectral:oas", "off"]]
+
+[rules]
+info-contact = true
+
+[rules.title-is-pizza]
+description = "The title must be about pizza"
+severity = "warn"
+given = "$.info"
+then = { field = "title", function = "pattern", functionOptions = { match = "pizza" } }
+
+[rules.operations-documented]
+description = "Operations must be documented"
+severity = "info"
+given = ["$.paths[*].get", "$.paths[*].post"]
+
+[[rules.operations-documented.then]]
+field = "description"
+function = "truthy"
+
+[[rules.operations-documented.then]]
+field = "summary"
+function = "truthy"`
+
+	spec := `openapi: 3.1.0
+info:
+  title: Burger Shop
+  version: 1.0.0
+paths:
+  /burgers:
+    get:
+      description: list burgers
+    post:
+      summary: make a burger
+`
+
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(spec), 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "ruleset.yaml"), []byte(yml), 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "ruleset.toml"), []byte(tml), 0o644)
+
+	lint := func(ruleset string) string {
+		cmd := GetLintCommand()
+		cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+		b := bytes.NewBufferString("")
+		cmd.SetOut(b)
+		cmd.SetArgs([]string{"--ndjson", "-r", filepath.Join(dir, ruleset), filepath.Join(dir, "openapi.yaml")})
+		_ = cmd.Execute()
+
+		// results are written as they are found, so their order is not fixed.
+		lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+		sort.Strings(lines)
+		return strings.Join(lines, "\n")
+	}
+
+	fromYAML := lint("ruleset.yaml")
+	fromTOML := lint("ruleset.toml")
+	assert.Contains(t, fromTOML, "title-is-pizza")
+	assert.Contains(t, fromTOML, "info-contact")
+	assert.Len(t, strings.Split(fromTOML, "\n"), 4)
+	assert.Equal(t, fromYAML, fromTOML)
+}
+
 func TestGetLintCommand_RulesetMissing(t *testing.T) {
 	cmd := GetLintCommand()
 	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
@@ -59,6 +154,46 @@ func TestGetLintCommand_RulesetMissing(t *testing.T) {
 	assert.NotNil(t, outBytes)
 }
 
+func TestGetLintCommand_RulesetRemote(t *testing.T) {
+	rsBytes, _ := os.ReadFile("../rulesets/examples/custom-ruleset.yaml")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(rsBytes)
+	}))
+	defer server.Close()
+
+	cmd := GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.PersistentFlags().Bool("ruleset-remote", false, "")
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--ruleset-remote",
+		"-r",
+		server.URL + "/custom-ruleset.yaml",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	cmdErr := cmd.Execute()
+	outBytes, err := io.ReadAll(b)
+	assert.NoError(t, cmdErr)
+	assert.NoError(t, err)
+	assert.NotNil(t, outBytes)
+}
+
+func TestGetLintCommand_RulesetRemoteNotAllowed(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.PersistentFlags().Bool("ruleset-remote", false, "")
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"-r",
+		"https://quobix.com/vacuum/ruleset.yaml",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	cmdErr := cmd.Execute()
+	assert.Error(t, cmdErr)
+}
+
 func TestGetLintCommand_NoRules(t *testing.T) {
 	cmd := GetLintCommand()
 	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
@@ -507,3 +642,373 @@ rules:
 	assert.NoError(t, err)
 	assert.NotNil(t, outBytes)
 }
+
+func TestGetLintCommand_NDJSON(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--ndjson",
+		"../model/test_files/burgershop.openapi.yaml",
+		"../model/test_files/petstorev3.json",
+	})
+	cmdErr := cmd.Execute()
+	assert.NoError(t, cmdErr)
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	assert.Greater(t, len(lines), 1)
+	sources := make(map[string]bool)
+	for _, line := range lines {
+		var report reports.SpectralReport
+		assert.NoError(t, json.Unmarshal([]byte(line), &report))
+		assert.NotEmpty(t, report.Code)
+		sources[report.Source] = true
+	}
+	assert.True(t, sources["../model/test_files/petstorev3.json"])
+}
+
+func TestGetLintCommand_NDJSON_NothingElseOnStdout(t *testing.T) {
+	malformed, _ := os.CreateTemp("", "vacuum-ndjson-*.yaml")
+	defer os.Remove(malformed.Name())
+	_, _ = malformed.WriteString("openapi: 3.1.0\npaths: [}")
+	_ = malformed.Close()
+
+	// anything pterm writes to stdout, outside of ndjson mode, ends up here.
+	var stdout bytes.Buffer
+	pterm.SetDefaultOutput(&stdout)
+	defer pterm.SetDefaultOutput(os.Stdout)
+
+	for _, spec := range []string{"../model/test_files/does-not-exist.yaml", malformed.Name()} {
+		cmd := GetLintCommand()
+		cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+		b := bytes.NewBufferString("")
+		cmd.SetOut(b)
+		cmd.SetArgs([]string{"--ndjson", spec})
+		assert.Error(t, cmd.Execute())
+		assert.Empty(t, b.String())
+		pterm.SetDefaultOutput(&stdout)
+	}
+	assert.Empty(t, stdout.String())
+}
+
+func TestGetLintCommand_NDJSON_BadReferences(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+
+	// the logger writes to stdout, outside of ndjson mode.
+	writer := pterm.DefaultLogger.Writer
+	pterm.DefaultLogger.Writer = b
+	defer func() { pterm.DefaultLogger.Writer = writer }()
+
+	cmd.SetArgs([]string{"--ndjson", "../model/test_files/badref-burgershop.openapi.yaml"})
+	_ = cmd.Execute()
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	assert.Greater(t, len(lines), 1)
+	for _, line := range lines {
+		var report reports.SpectralReport
+		assert.NoError(t, json.Unmarshal([]byte(line), &report), line)
+	}
+}
+
+func TestGetLintCommand_Fix(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      summary: "get a pizza "
+      responses:
+        "200":
+          description: ok
+`
+	tmp, _ := os.CreateTemp("", "vacuum-fix-*.yaml")
+	defer os.Remove(tmp.Name())
+	_, _ = tmp.WriteString(spec)
+	_ = tmp.Close()
+
+	// a dry run does not write anything.
+	cmd := GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.SetArgs([]string{"-x", "--fix-dry-run", "--fix-rules",
+		"operation-operationId,description-trailing-whitespace", tmp.Name()})
+	_ = cmd.Execute()
+	data, _ := os.ReadFile(tmp.Name())
+	assert.Equal(t, spec, string(data))
+
+	cmd = GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.SetArgs([]string{"-x", "--fix", "--fix-rules", "operation-operationId", tmp.Name()})
+	_ = cmd.Execute()
+	data, _ = os.ReadFile(tmp.Name())
+	assert.Contains(t, string(data), "operationId: getPizza\n")
+	assert.Contains(t, string(data), "summary: \"get a pizza \"\n") // not opted in, not fixed.
+}
+
+func TestGetLintCommand_Fix_NDJSON(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+`
+	tmp, _ := os.CreateTemp("", "vacuum-fix-*.yaml")
+	defer os.Remove(tmp.Name())
+	_, _ = tmp.WriteString(spec)
+	_ = tmp.Close()
+
+	// the diff of a dry run goes to stderr, only results are written to stdout.
+	for _, fix := range []string{"--fix-dry-run", "--fix"} {
+		cmd := GetLintCommand()
+		cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+		b := bytes.NewBufferString("")
+		cmd.SetOut(b)
+		cmd.SetArgs([]string{"--ndjson", fix, "--fix-rules", "operation-operationId", tmp.Name()})
+		_ = cmd.Execute()
+
+		assert.NotEmpty(t, b.String())
+		for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+			assert.True(t, json.Valid([]byte(line)), line)
+		}
+		data, _ := os.ReadFile(tmp.Name())
+		if fix == "--fix-dry-run" {
+			assert.Equal(t, spec, string(data))
+		} else {
+			assert.Contains(t, string(data), "operationId: getPizza\n")
+		}
+	}
+}
+
+func TestGetLintCommand_RuleSeverity(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths: {}
+`
+	ruleset := `extends: [[spectral:oas, off]]
+rules:
+  info-contact: true`
+
+	specFile, _ := os.CreateTemp("", "vacuum-severity-*.yaml")
+	defer os.Remove(specFile.Name())
+	_, _ = specFile.WriteString(spec)
+	_ = specFile.Close()
+	rsFile, _ := os.CreateTemp("", "vacuum-severity-ruleset-*.yaml")
+	defer os.Remove(rsFile.Name())
+	_, _ = rsFile.WriteString(ruleset)
+	_ = rsFile.Close()
+
+	// info-contact is a warning, so linting passes.
+	cmd := GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.SetArgs([]string{"-x", "-r", rsFile.Name(), specFile.Name()})
+	assert.NoError(t, cmd.Execute())
+
+	// promoted to an error, linting fails.
+	cmd = GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.SetArgs([]string{"-x", "-r", rsFile.Name(), "--rule-severity", "info-contact=error",
+		"--rule-severity", "no-such-rule=error", specFile.Name()})
+	assert.EqualError(t, cmd.Execute(), "failed with 1 errors")
+
+	cmd = GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.SetArgs([]string{"-x", "-r", rsFile.Name(), "--rule-severity", "info-contact=fatal", specFile.Name()})
+	assert.ErrorContains(t, cmd.Execute(), "has an unknown severity 'fatal'")
+}
+
+func TestGetLintCommand_Cache(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths: {}
+`
+	ruleset := `extends: [[spectral:oas, off]]
+rules:
+  info-contact: true`
+
+	dir := t.TempDir()
+	specFile := filepath.Join(dir, "openapi.yaml")
+	rsFile := filepath.Join(dir, "ruleset.yaml")
+	cacheDir := filepath.Join(dir, "cache")
+	_ = os.WriteFile(specFile, []byte(spec), 0o644)
+	_ = os.WriteFile(rsFile, []byte(ruleset), 0o644)
+
+	lint := func(args ...string) (string, error) {
+		cmd := GetLintCommand()
+		cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+		cmd.PersistentFlags().StringP("functions", "f", "", "")
+		b := bytes.NewBufferString("")
+		cmd.SetOut(b)
+		cmd.SetArgs(append([]string{"--ndjson", "-r", rsFile, "--cache-dir", cacheDir}, append(args, specFile)...))
+		err := cmd.Execute()
+		return b.String(), err
+	}
+	entries := func() []string {
+		files, _ := filepath.Glob(filepath.Join(cacheDir, "*.vacuum-cache.json"))
+		return files
+	}
+
+	// a miss, the results are cached.
+	out, err := lint()
+	assert.NoError(t, err)
+	assert.Contains(t, out, "info-contact")
+	assert.Len(t, entries(), 1)
+
+	// a hit, the results come from the cache, and the entry isn't written again.
+	b, _ := os.ReadFile(entries()[0])
+	b = append(bytes.ReplaceAll(b, []byte("contact details"), []byte("cached details")), '\n')
+	_ = os.WriteFile(entries()[0], b, 0o644)
+	out, err = lint()
+	assert.NoError(t, err)
+	assert.Contains(t, out, "cached details")
+	assert.Len(t, entries(), 1)
+	hit, _ := os.ReadFile(entries()[0])
+	assert.Equal(t, string(b), string(hit))
+
+	// changing the ruleset is a miss.
+	out, err = lint("--rule-severity", "info-contact=error")
+	assert.EqualError(t, err, "failed with 1 errors")
+	assert.NotContains(t, out, "cached details")
+	assert.Len(t, entries(), 2)
+
+	// changing the contents of a custom function is a miss, even though its name is the same.
+	functionsDir := filepath.Join(dir, "functions")
+	_ = os.Mkdir(functionsDir, 0o755)
+	function, _ := os.ReadFile("../plugin/sample/js/useless_func.js")
+	functionFile := filepath.Join(functionsDir, "useless_func.js")
+	_ = os.WriteFile(functionFile, function, 0o644)
+	_, err = lint("--functions", functionsDir)
+	assert.NoError(t, err)
+	assert.Len(t, entries(), 3)
+	_, err = lint("--functions", functionsDir)
+	assert.NoError(t, err)
+	assert.Len(t, entries(), 3)
+	_ = os.WriteFile(functionFile, bytes.ReplaceAll(function, []byte("another message"), []byte("new message")), 0o644)
+	_, err = lint("--functions", functionsDir)
+	assert.NoError(t, err)
+	assert.Len(t, entries(), 4)
+}
+
+func TestBuildIdentifier(t *testing.T) {
+	// the binary (a test binary here) is always part of the identifier, even when the version is 'latest'.
+	assert.NotEqual(t, strings.Join([]string{Version, Commit, Date}, ";"), buildIdentifier())
+	assert.Equal(t, buildIdentifier(), buildIdentifier())
+}
+
+func TestGetLintCommand_Stdin(t *testing.T) {
+	lint := func(stdin io.Reader, args ...string) []string {
+		cmd := GetLintCommand()
+		cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+		b := bytes.NewBufferString("")
+		cmd.SetOut(b)
+		if stdin != nil {
+			cmd.SetIn(stdin)
+		}
+		cmd.SetArgs(append([]string{"--ndjson"}, args...))
+		_ = cmd.Execute()
+
+		// results are written as they are found, so their order is not fixed.
+		lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+		sort.Strings(lines)
+		return lines
+	}
+
+	spec, _ := os.ReadFile("../model/test_files/petstorev3.json")
+	fromFile := lint(nil, "../model/test_files/petstorev3.json")
+	fromStdin := lint(bytes.NewReader(spec), "--stdin-format", "json", "-")
+
+	assert.Greater(t, len(fromFile), 1)
+	assert.Len(t, fromStdin, len(fromFile))
+	for i := range fromFile {
+		var fileReport, stdinReport reports.SpectralReport
+		assert.NoError(t, json.Unmarshal([]byte(fromFile[i]), &fileReport))
+		assert.NoError(t, json.Unmarshal([]byte(fromStdin[i]), &stdinReport))
+		assert.Equal(t, "<stdin>", stdinReport.Source)
+		fileReport.Source = stdinReport.Source
+		assert.Equal(t, fileReport, stdinReport)
+	}
+}
+
+func TestGetLintCommand_Stdin_InvalidFormat(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.SetIn(bytes.NewBufferString("openapi: 3.1.0"))
+	cmd.SetArgs([]string{"-x", "--stdin-format", "json", "-"})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestGetLintCommand_Ignore(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--ndjson",
+		"--ignore", "../model/test_files/burger*.yaml",
+		"../model/test_files/burgershop.openapi.yaml",
+		"../model/test_files/petstorev3.json",
+	})
+	assert.NoError(t, cmd.Execute())
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	assert.Greater(t, len(lines), 1)
+	for _, line := range lines {
+		var report reports.SpectralReport
+		assert.NoError(t, json.Unmarshal([]byte(line), &report))
+		assert.Equal(t, "../model/test_files/petstorev3.json", report.Source)
+	}
+}
+
+func TestIgnoreFiles(t *testing.T) {
+	files := []string{"specs/legacy/pizza.yaml", "./specs/burger.yaml", "specs/pizza.draft.yaml", "-"}
+	assert.Equal(t, files, ignoreFiles(files, nil))
+	assert.Equal(t, []string{"./specs/burger.yaml", "-"},
+		ignoreFiles(files, []string{"./specs/legacy/*.yaml", "*.draft.yaml"}))
+	assert.Equal(t, []string{"-"}, ignoreFiles(files, []string{"*"}))
+}
+
+func TestLintBytes_MatchesLintCommand(t *testing.T) {
+	for _, file := range []string{"../model/test_files/pegel-online-api.yaml", "../model/test_files/petstorev3.json"} {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			spec, err := os.ReadFile(file)
+			assert.NoError(t, err)
+			tmp := filepath.Join(t.TempDir(), filepath.Base(file))
+			assert.NoError(t, os.WriteFile(tmp, spec, 0o644))
+
+			// the lint command, with its own defaults (ruleset, base, severities), against a copy of the file.
+			b := bytes.NewBufferString("")
+			rootCmd := GetRootCommand()
+			rootCmd.SetOut(b)
+			rootCmd.SetArgs([]string{"lint", "--ndjson", tmp})
+			assert.NoError(t, rootCmd.Execute())
+			fromCommand := make(map[string]bool)
+			for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+				fromCommand[line] = true
+			}
+
+			results, err := motor.LintBytes(spec, nil)
+			assert.NoError(t, err)
+			fromBytes := make(map[string]bool)
+			for _, report := range results.GenerateSpectralReport(tmp) {
+				line, _ := json.Marshal(report)
+				fromBytes[string(line)] = true
+			}
+
+			assert.Greater(t, len(fromCommand), 1)
+			assert.Equal(t, fromCommand, fromBytes)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"log/slog"
+
+	"github.com/daveshanley/vacuum/lsp"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// GetLSPCommand returns a command that runs vacuum as a Language Server Protocol server, over stdin and stdout.
+func GetLSPCommand() *cobra.Command {
+
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "lsp",
+		Short:        "Run vacuum as a language server, for editors",
+		Long: "Run vacuum as a Language Server Protocol server, over stdin and stdout. Editors send the OpenAPI " +
+			"documents open in them as they are edited, vacuum lints them and publishes the results as diagnostics.",
+		Example: "vacuum lsp --ruleset my-ruleset.yaml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+			remoteRuleSetFlag, _ := cmd.Flags().GetBool("ruleset-remote")
+			functionsFlag := GetCustomFunctionsFlag(cmd)
+			baseFlag, _ := cmd.Flags().GetString("base")
+			remoteFlag, _ := cmd.Flags().GetBool("remote")
+			skipCheckFlag, _ := cmd.Flags().GetBool("skip-check")
+			timeoutFlag, _ := cmd.Flags().GetDuration("timeout")
+			ruleSeverityFlag, _ := cmd.Flags().GetStringArray("rule-severity")
+			debounceFlag, _ := cmd.Flags().GetDuration("debounce")
+
+			// stdout belongs to the protocol, anything else vacuum prints goes to stderr.
+			pterm.SetDefaultOutput(cmd.ErrOrStderr())
+			pterm.DisableColor()
+			pterm.DisableStyling()
+			logger := slog.New(slog.NewTextHandler(cmd.ErrOrStderr(), &slog.HandlerOptions{Level: slog.LevelError}))
+
+			defaultRuleSets := rulesets.BuildDefaultRuleSetsWithLogger(logger)
+			selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
+			if rulesetFlag != "" {
+				var rsErr error
+				selectedRS, rsErr = LoadRuleSet(rulesetFlag, remoteRuleSetFlag, defaultRuleSets)
+				if rsErr != nil {
+					return rsErr
+				}
+			}
+			if err := ApplyRuleSeverityOverrides(selectedRS, ruleSeverityFlag); err != nil {
+				return err
+			}
+			customFunctions, err := LoadCustomFunctions(functionsFlag, GetWASMLimitsFlags(cmd))
+			if err != nil {
+				return err
+			}
+
+			server := lsp.NewServer(lsp.MotorLinter(motor.RuleSetExecution{
+				RuleSet:           selectedRS,
+				CustomFunctions:   customFunctions,
+				Base:              baseFlag,
+				AllowLookup:       remoteFlag,
+				SkipDocumentCheck: skipCheckFlag,
+				SilenceLogs:       true,
+				Logger:            logger,
+				Timeout:           timeoutFlag,
+			}))
+			server.Version = Version
+			server.Logger = logger
+			if debounceFlag > 0 {
+				server.Debounce = debounceFlag
+			}
+			return server.Serve(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().Duration("debounce", lsp.DefaultDebounce, "How long to wait after an edit before linting the document")
+	cmd.Flags().StringArray("rule-severity", nil, "Override the severity of a rule, as rule-id=severity (repeatable)")
+	return cmd
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
+)
+
+func lspMessages(messages ...string) *bytes.Buffer {
+	b := bytes.NewBufferString("")
+	for _, m := range messages {
+		_, _ = fmt.Fprintf(b, "Content-Length: %d\r\n\r\n%s", len(m), m)
+	}
+	return b
+}
+
+func TestLSPCommand(t *testing.T) {
+	defer func() {
+		pterm.SetDefaultOutput(os.Stdout)
+		pterm.EnableColor()
+		pterm.EnableStyling()
+	}()
+
+	out := bytes.NewBufferString("")
+	rootCmd := GetRootCommand()
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(bytes.NewBufferString(""))
+	rootCmd.SetIn(lspMessages(
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	))
+	rootCmd.SetArgs([]string{"lsp", "--debounce", "10ms"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), `"textDocumentSync":{"openClose":true,"change":2}`)
+	assert.Contains(t, out.String(), `{"jsonrpc":"2.0","id":2,"result":null}`)
+}
+
+func TestLSPCommand_BadRuleSet(t *testing.T) {
+	defer func() {
+		pterm.SetDefaultOutput(os.Stdout)
+		pterm.EnableColor()
+		pterm.EnableStyling()
+	}()
+
+	out := bytes.NewBufferString("")
+	rootCmd := GetRootCommand()
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(bytes.NewBufferString(""))
+	rootCmd.SetIn(lspMessages())
+	rootCmd.SetArgs([]string{"lsp", "-r", "/a/non/existing/ruleset.yaml"})
+	assert.Error(t, rootCmd.Execute())
+	assert.Empty(t, out.String())
+}
@@ -6,7 +6,10 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
@@ -51,16 +54,27 @@ func GetRootCommand() *cobra.Command {
 			return nil
 		},
 	}
-	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file (defaults to ./vacuum.yaml) ")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file (defaults to the closest vacuum.conf.yaml or .vacuum.conf.yaml)")
 	rootCmd.PersistentFlags().BoolP("time", "t", false, "Show how long vacuum took to run")
-	rootCmd.PersistentFlags().StringP("ruleset", "r", "", "Path to a spectral ruleset configuration")
+	rootCmd.PersistentFlags().StringP("ruleset", "r", "", "Path (or URL, with --ruleset-remote) to a spectral ruleset configuration")
+	rootCmd.PersistentFlags().Bool("ruleset-remote", false, "Allow rulesets (and the rulesets they extend) to be fetched over HTTP(S)")
 	rootCmd.PersistentFlags().StringP("functions", "f", "", "Path to custom functions")
+	rootCmd.PersistentFlags().String("plugins", "", "Path to a directory of custom function plugins (.so, .js or .wasm), same as --functions")
+	rootCmd.PersistentFlags().Duration("wasm-timeout", 5*time.Second, "How long a custom WASM function can run for, each time it runs")
+	rootCmd.PersistentFlags().Int("wasm-memory", 128, "How much memory (in MiB) a custom WASM function can use")
 	rootCmd.PersistentFlags().StringP("base", "p", "", "Override Base URL or path to use for resolving local file based or remote references")
 	rootCmd.PersistentFlags().BoolP("remote", "u", true, "Allow local files and remote (http) references to be looked up")
 	rootCmd.PersistentFlags().BoolP("skip-check", "k", false, "Skip checking for a valid OpenAPI document, useful for linting fragments or non-OpenAPI documents")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "Abort linting after this long (e.g. 30s, 5m), returning partial results. Defaults to no timeout")
 
 	regErr := rootCmd.RegisterFlagCompletionFunc("functions", cobra.FixedCompletions(
-		[]string{"so"}, cobra.ShellCompDirectiveFilterFileExt,
+		[]string{"so", "js", "wasm"}, cobra.ShellCompDirectiveFilterFileExt,
+	))
+	if regErr != nil {
+		panic(regErr)
+	}
+	regErr = rootCmd.RegisterFlagCompletionFunc("plugins", cobra.FixedCompletions(
+		nil, cobra.ShellCompDirectiveFilterDirs,
 	))
 	if regErr != nil {
 		panic(regErr)
@@ -78,10 +92,13 @@ func GetRootCommand() *cobra.Command {
 	rootCmd.AddCommand(GetHTMLReportCommand())
 	rootCmd.AddCommand(GetDashboardCommand())
 	rootCmd.AddCommand(GetGenerateRulesetCommand())
+	rootCmd.AddCommand(GetLSPCommand())
 	return rootCmd
 }
 
 func useConfigFile(cmd *cobra.Command) error {
+	// start from a clean slate, a config file read by an earlier command must not leak into this one.
+	viper.Reset()
 	useEnvironmentConfiguration()
 	var err error
 	if len(configFile) != 0 {
@@ -92,6 +109,10 @@ func useConfigFile(cmd *cobra.Command) error {
 	if err != nil {
 		return err
 	}
+	err = checkConfigKeys(cmd.Root())
+	if err != nil {
+		return err
+	}
 	// bind global flags
 	err = bindFlags(cmd.InheritedFlags(), viper.GetViper())
 	if err != nil {
@@ -103,7 +124,59 @@ func useConfigFile(cmd *cobra.Command) error {
 	}
 	return err
 }
+
+// configFileNames are the names of the config files vacuum looks for, in each directory.
+var configFileNames = []string{"vacuum.conf.yaml", "vacuum.conf.yml", ".vacuum.conf.yaml", ".vacuum.conf.yml"}
+
+// vcsMarkers are the entries that mark the root of a repository, config files are never looked for above it.
+var vcsMarkers = []string{".git", ".hg", ".svn"}
+
+// findConfigFile walks up from a directory to the root of the repository it's in, looking for the closest config
+// file. Outside a repository, only the directory itself is checked, so a config file in a parent directory (like
+// the home directory) that has nothing to do with the specification isn't picked up. Returns an empty string if
+// there isn't one.
+func findConfigFile(dir string) string {
+	walk := false
+	for d := dir; ; d = filepath.Dir(d) {
+		if isRepositoryRoot(d) {
+			walk = true
+			break
+		}
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+	for {
+		for _, name := range configFileNames {
+			candidate := filepath.Join(dir, name)
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate
+			}
+		}
+		if !walk || isRepositoryRoot(dir) || filepath.Dir(dir) == dir {
+			return ""
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// isRepositoryRoot returns true if a directory holds one of the vcsMarkers.
+func isRepositoryRoot(dir string) bool {
+	for _, marker := range vcsMarkers {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 func useDefaultConfigFile() error {
+	if wd, err := os.Getwd(); err == nil {
+		if found := findConfigFile(wd); found != "" {
+			viper.SetConfigFile(found)
+			return viper.ReadInConfig()
+		}
+	}
 	viper.SetConfigName("vacuum.conf")
 	viper.SetConfigType("yaml")
 	viper.AddConfigPath(".")
@@ -140,12 +213,45 @@ func getXdgConfigHome() string {
 	return xdgConfigHome
 }
 
+// checkConfigKeys returns an error naming every key of the config file (and its path) that isn't a flag. Global
+// flags are top level keys, command specific flags are keys of a node with the commands name.
+func checkConfigKeys(rootCmd *cobra.Command) error {
+	var unknown []string
+	for _, key := range viper.AllKeys() {
+		if rootCmd.PersistentFlags().Lookup(key) != nil {
+			continue
+		}
+		if name, flag, nested := strings.Cut(key, "."); nested {
+			if sub, _, err := rootCmd.Find([]string{name}); err == nil && sub != rootCmd &&
+				sub.LocalFlags().Lookup(flag) != nil {
+				continue
+			}
+		}
+		unknown = append(unknown, key)
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("unknown configuration key(s) '%s' in config file '%s', keys must be the name of a flag, "+
+		"or a command with its flags", strings.Join(unknown, "', '"), viper.ConfigFileUsed())
+}
+
 // Set flag values if configuration tree has any values set
 func bindFlags(flags *pflag.FlagSet, viperTree *viper.Viper) error {
 	var err error
 	flags.VisitAll(func(f *pflag.Flag) {
 		if !f.Changed && viperTree.IsSet(f.Name) {
 			val := viperTree.Get(f.Name)
+			// a list (for flags like --ignore) sets the flag once per value.
+			if list, ok := val.([]interface{}); ok {
+				for _, v := range list {
+					if setErr := flags.Set(f.Name, fmt.Sprintf("%v", v)); setErr != nil {
+						err = setErr
+					}
+				}
+				return
+			}
 			err = flags.Set(f.Name, fmt.Sprintf("%v", val))
 		}
 	})
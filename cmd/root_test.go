@@ -3,6 +3,8 @@ package cmd
 import (
 	"bytes"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -51,3 +53,101 @@ func TestLocalFlagConfigFile(t *testing.T) {
 	assert.NotNil(t, outBytes)
 	//TODO test local flag override
 }
+
+func TestConfigFile_FlagsOverrideConfig(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "vacuum.conf.yaml")
+	err := os.WriteFile(config, []byte("base: /tmp/config-base\nlint:\n  fail-severity: info\n  silent: true\n"), 0o644)
+	assert.NoError(t, err)
+
+	rootCmd := GetRootCommand()
+	lintCmd, _, err := rootCmd.Find([]string{"lint"})
+	assert.NoError(t, err)
+	err = lintCmd.ParseFlags([]string{"--config", config, "--fail-severity", "warn"})
+	assert.NoError(t, err)
+	assert.NoError(t, useConfigFile(lintCmd))
+
+	failSeverity, _ := lintCmd.Flags().GetString("fail-severity")
+	silent, _ := lintCmd.Flags().GetBool("silent")
+	base, _ := lintCmd.Flags().GetString("base")
+	assert.Equal(t, "warn", failSeverity)
+	assert.True(t, silent)
+	assert.Equal(t, "/tmp/config-base", base)
+}
+
+func TestConfigFile_UnknownKeys(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "vacuum.conf.yaml")
+	err := os.WriteFile(config, []byte("time: true\ncolour: true\nlint:\n  silnet: true\n"), 0o644)
+	assert.NoError(t, err)
+
+	b := bytes.NewBufferString("")
+	rootCmd := GetRootCommand()
+	rootCmd.SetOut(b)
+	rootCmd.SetArgs([]string{"lint", "../model/test_files/burgershop.openapi.yaml", "--config", config})
+	exErr := rootCmd.Execute()
+	assert.Error(t, exErr)
+	assert.Contains(t, exErr.Error(), "unknown configuration key(s) 'colour', 'lint.silnet'")
+}
+
+func TestConfigFile_UnknownKeys_Found(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+	err := os.WriteFile(filepath.Join(dir, "vacuum.conf.yaml"), []byte("colour: true\nlint:\n  silent: true\n"), 0o644)
+	assert.NoError(t, err)
+	wd, _ := os.Getwd()
+	assert.NoError(t, os.Chdir(dir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	// a config file that was found, rather than asked for, is checked for unknown keys all the same.
+	rootCmd := GetRootCommand()
+	lintCmd, _, err := rootCmd.Find([]string{"lint"})
+	assert.NoError(t, err)
+	assert.NoError(t, lintCmd.ParseFlags(nil))
+	err = useConfigFile(lintCmd)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown configuration key(s) 'colour'")
+	assert.Contains(t, err.Error(), "vacuum.conf.yaml")
+}
+
+func TestConfigFile_IgnoreList(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "vacuum.conf.yaml")
+	err := os.WriteFile(config, []byte("lint:\n  ignore:\n    - specs/legacy/*.yaml\n    - '*.draft.yaml'\n"), 0o644)
+	assert.NoError(t, err)
+
+	rootCmd := GetRootCommand()
+	lintCmd, _, err := rootCmd.Find([]string{"lint"})
+	assert.NoError(t, err)
+	assert.NoError(t, lintCmd.ParseFlags([]string{"--config", config}))
+	assert.NoError(t, useConfigFile(lintCmd))
+	ignore, _ := lintCmd.Flags().GetStringArray("ignore")
+	assert.Equal(t, []string{"specs/legacy/*.yaml", "*.draft.yaml"}, ignore)
+}
+
+func TestFindConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "specs", "pizza", "v1")
+	assert.NoError(t, os.MkdirAll(nested, 0o755))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".vacuum.conf.yaml"), []byte("time: true\n"), 0o644))
+
+	assert.Equal(t, filepath.Join(dir, ".vacuum.conf.yaml"), findConfigFile(nested))
+
+	closer := filepath.Join(dir, "specs", "vacuum.conf.yaml")
+	assert.NoError(t, os.WriteFile(closer, []byte("time: true\n"), 0o644))
+	assert.Equal(t, closer, findConfigFile(nested))
+}
+
+func TestFindConfigFile_RepositoryRoot(t *testing.T) {
+	home := t.TempDir()
+	repo := filepath.Join(home, "pizza")
+	nested := filepath.Join(repo, "specs")
+	assert.NoError(t, os.MkdirAll(nested, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(home, "vacuum.conf.yaml"), []byte("time: true\n"), 0o644))
+
+	// outside a repository, only the directory itself is checked.
+	assert.Empty(t, findConfigFile(nested))
+	assert.Equal(t, filepath.Join(home, "vacuum.conf.yaml"), findConfigFile(home))
+
+	// inside one, the walk stops at its root.
+	assert.NoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0o755))
+	assert.Empty(t, findConfigFile(nested))
+}
@@ -4,11 +4,18 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
 	"github.com/daveshanley/vacuum/plugin"
+	"github.com/daveshanley/vacuum/plugin/wasm"
 	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -17,7 +24,7 @@ import (
 func BuildRuleSetFromUserSuppliedSet(rsBytes []byte, rs rulesets.RuleSets) (*rulesets.RuleSet, error) {
 
 	// load in our user supplied ruleset and try to validate it.
-	userRS, userErr := rulesets.CreateRuleSetFromData(rsBytes)
+	userRS, userErr := rulesets.ParseRuleSet("", rsBytes)
 	if userErr != nil {
 		pterm.Error.Printf("Unable to parse ruleset file: %s\n", userErr.Error())
 		pterm.Println()
@@ -27,6 +34,55 @@ func BuildRuleSetFromUserSuppliedSet(rsBytes []byte, rs rulesets.RuleSets) (*rul
 	return rs.GenerateRuleSetFromSuppliedRuleSet(userRS), nil
 }
 
+// LoadRuleSet reads the ruleset at the supplied location, and builds it into a ready to run ruleset. The location
+// can be a file path, or an HTTP(S) URL if remote rulesets are allowed. Remote rulesets extended by the ruleset are
+// fetched as well, if allowed. Fetched rulesets are cached for an hour.
+func LoadRuleSet(location string, allowRemote bool, rs rulesets.RuleSets) (*rulesets.RuleSet, error) {
+	var config *rulesets.RemoteRuleSetConfig
+	if allowRemote {
+		config = &rulesets.RemoteRuleSetConfig{}
+		if cacheDir, err := os.UserCacheDir(); err == nil {
+			config.CacheDir = filepath.Join(cacheDir, "vacuum", "rulesets")
+		}
+	}
+
+	if rulesets.IsRemoteRuleSet(location) {
+		if config == nil {
+			err := fmt.Errorf("ruleset '%s' is remote, use --ruleset-remote to allow rulesets to be fetched", location)
+			pterm.Error.Println(err.Error())
+			pterm.Println()
+			return nil, err
+		}
+		userRS, err := rulesets.FetchRemoteRuleSet(location, config)
+		if err != nil {
+			pterm.Error.Printf("Unable to load remote ruleset: %s\n", err.Error())
+			pterm.Println()
+			return nil, err
+		}
+		return rs.GenerateRuleSetFromSuppliedRuleSet(userRS), nil
+	}
+
+	rsBytes, err := os.ReadFile(location)
+	if err != nil {
+		pterm.Error.Printf("Unable to read ruleset file '%s': %s\n", location, err.Error())
+		pterm.Println()
+		return nil, err
+	}
+	userRS, err := rulesets.ParseRuleSet(location, rsBytes)
+	if err != nil {
+		pterm.Error.Printf("Unable to parse ruleset file: %s\n", err.Error())
+		pterm.Println()
+		return nil, err
+	}
+	userRS, err = rulesets.ResolveExtends(userRS, location, config)
+	if err != nil {
+		pterm.Error.Printf("Unable to load ruleset file '%s': %s\n", location, err.Error())
+		pterm.Println()
+		return nil, err
+	}
+	return rs.GenerateRuleSetFromSuppliedRuleSet(userRS), nil
+}
+
 // RenderTime will render out the time taken to process a specification, and the size of the file in kb.
 func RenderTime(timeFlag bool, duration time.Duration, fi int64) {
 	if timeFlag {
@@ -62,11 +118,32 @@ func PrintBanner() {
 	pterm.Println()
 }
 
+// GetCustomFunctionsFlag returns the path custom functions are loaded from, set with --functions or --plugins.
+func GetCustomFunctionsFlag(cmd *cobra.Command) string {
+	functionsFlag, _ := cmd.Flags().GetString("functions")
+	if functionsFlag == "" {
+		functionsFlag, _ = cmd.Flags().GetString("plugins")
+	}
+	return functionsFlag
+}
+
+// GetWASMLimitsFlags returns the limits of custom WASM functions, set with --wasm-timeout and --wasm-memory.
+func GetWASMLimitsFlags(cmd *cobra.Command) wasm.Limits {
+	limits := wasm.DefaultLimits()
+	if timeout, _ := cmd.Flags().GetDuration("wasm-timeout"); timeout > 0 {
+		limits.Timeout = timeout
+	}
+	if memory, _ := cmd.Flags().GetInt("wasm-memory"); memory > 0 {
+		limits.MemoryPages = uint32(memory * 16) // 16 pages to a MiB.
+	}
+	return limits
+}
+
 // LoadCustomFunctions will scan for (and load) custom functions defined as vacuum plugins.
-func LoadCustomFunctions(functionsFlag string) (map[string]model.RuleFunction, error) {
+func LoadCustomFunctions(functionsFlag string, wasmLimits wasm.Limits) (map[string]model.RuleFunction, error) {
 	// check custom functions
 	if functionsFlag != "" {
-		pm, err := plugin.LoadFunctions(functionsFlag)
+		pm, err := plugin.LoadFunctionsWithLimits(functionsFlag, wasmLimits)
 		if err != nil {
 			pterm.Error.Printf("Unable to open custom functions: %v\n", err)
 			pterm.Println()
@@ -78,6 +155,33 @@ func LoadCustomFunctions(functionsFlag string) (map[string]model.RuleFunction, e
 	return nil, nil
 }
 
+// ApplyRuleSeverityOverrides changes the severity of rules in a ruleset, overrides are rule-id=severity pairs
+// (like 'operation-tags=error'). Rules that are not in the ruleset are warned about, a malformed override or an
+// unknown severity is an error.
+func ApplyRuleSeverityOverrides(rs *rulesets.RuleSet, overrides []string) error {
+	for _, override := range overrides {
+		id, severity, ok := strings.Cut(override, "=")
+		id, severity = strings.TrimSpace(id), strings.ToLower(strings.TrimSpace(severity))
+		if !ok || id == "" {
+			return fmt.Errorf("rule severity override '%s' must be in the form rule-id=severity", override)
+		}
+		switch severity {
+		case model.SeverityError, model.SeverityWarn, model.SeverityInfo, model.SeverityHint:
+		default:
+			return fmt.Errorf("rule severity override '%s' has an unknown severity '%s', it must be one of "+
+				"'error', 'warn', 'info' or 'hint'", override, severity)
+		}
+		rule, found := rs.Rules[id]
+		if !found || rule == nil {
+			pterm.Warning.Printf("Unable to override the severity of rule '%s', the ruleset has no rule with "+
+				"that id\n", id)
+			continue
+		}
+		rule.Severity = severity
+	}
+	return nil
+}
+
 func CheckFailureSeverity(failSeverityFlag string, errors int, warnings int, informs int) error {
 	if failSeverityFlag != model.SeverityError {
 		switch failSeverityFlag {
@@ -99,3 +203,38 @@ func CheckFailureSeverity(failSeverityFlag string, errors int, warnings int, inf
 	}
 	return nil
 }
+
+// checkReportTimedOut warns a report is built from partial results, if the execution timed out. When it timed out
+// before the specification was parsed there is nothing to report, and an error is returned instead. The warning is
+// written to stderr when the report is written to stdout.
+func checkReportTimedOut(result *motor.RuleSetExecutionResult, fileName string, timeout time.Duration,
+	stdOut bool) error {
+	if result == nil || !result.TimedOut {
+		return nil
+	}
+	errorPrinter, warningPrinter := &pterm.Error, &pterm.Warning
+	if stdOut {
+		errorPrinter, warningPrinter = pterm.Error.WithWriter(os.Stderr), pterm.Warning.WithWriter(os.Stderr)
+	}
+	if result.SpecInfo == nil || result.Index == nil {
+		err := fmt.Errorf("linting '%s' timed out after %v, before the specification was parsed, there is "+
+			"nothing to report", fileName, timeout)
+		errorPrinter.Println(err.Error())
+		return err
+	}
+	warningPrinter.Printf("Linting timed out after %v, report results are partial\n", timeout)
+	return nil
+}
+
+// checkTimedOut returns an error if the execution timed out, joined with any error returned from checking
+// the failure severity. A timed out run never passes, as the results are not complete.
+func checkTimedOut(result *motor.RuleSetExecutionResult, fileName string, severityErr error) error {
+	if result == nil || !result.TimedOut {
+		return severityErr
+	}
+	timeoutErr := fmt.Errorf("linting '%s' timed out, results are partial", fileName)
+	if severityErr != nil {
+		return errors.Join(timeoutErr, severityErr)
+	}
+	return timeoutErr
+}
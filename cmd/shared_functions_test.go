@@ -1,9 +1,23 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/plugin"
+	"github.com/daveshanley/vacuum/plugin/wasm"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func TestRenderTime(t *testing.T) {
@@ -12,3 +26,73 @@ func TestRenderTime(t *testing.T) {
 	fi, _ := os.Stat("shared_functions.go")
 	RenderTime(true, time.Since(start), fi.Size())
 }
+
+// buildTestPlugin compiles one of the plugins in plugin/testdata into dir, go plugins are only supported on some
+// platforms, and need the go toolchain to build.
+func buildTestPlugin(t *testing.T, name, dir string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("windows does not support go plugins")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the go toolchain is needed to build test plugins")
+	}
+	build := exec.Command(goBin, "build", "-buildmode=plugin", "-o", filepath.Join(dir, name+".so"),
+		"../plugin/testdata/"+name)
+	out, err := build.CombinedOutput()
+	if err != nil {
+		t.Fatalf("unable to build test plugin '%s': %v\n%s", name, err, out)
+	}
+}
+
+func TestLoadCustomFunctions_Plugin(t *testing.T) {
+	dir := t.TempDir()
+	buildTestPlugin(t, "single_path", dir)
+
+	functions, err := LoadCustomFunctions(dir, wasm.DefaultLimits())
+	assert.NoError(t, err)
+	assert.Len(t, functions, 1)
+
+	spec := `openapi: 3.1.0
+paths:
+  /pizza: {}
+  /burger: {}`
+
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	res := functions["singlePath"].RunRule([]*yaml.Node{&root}, model.RuleFunctionContext{})
+	assert.Len(t, res, 1)
+	assert.Equal(t, "more than a single path exists, there are 2", res[0].Message)
+}
+
+func TestLoadCustomFunctions_PluginVersionMismatch(t *testing.T) {
+	dir := t.TempDir()
+	buildTestPlugin(t, "future_api", dir)
+
+	functions, err := LoadCustomFunctions(dir, wasm.DefaultLimits())
+	assert.Nil(t, functions)
+	assert.ErrorContains(t, err, fmt.Sprintf("was built for plugin API version %d, this version of vacuum "+
+		"supports version %d", plugin.APIVersion+1, plugin.APIVersion))
+}
+
+func TestCheckReportTimedOut(t *testing.T) {
+	assert.NoError(t, checkReportTimedOut(nil, "pizza.yaml", time.Second, false))
+	assert.NoError(t, checkReportTimedOut(&motor.RuleSetExecutionResult{}, "pizza.yaml", time.Second, false))
+
+	// timed out before the spec was parsed, there is nothing to report.
+	err := checkReportTimedOut(&motor.RuleSetExecutionResult{TimedOut: true}, "pizza.yaml", time.Second, true)
+	assert.ErrorContains(t, err, "linting 'pizza.yaml' timed out after 1s, before the specification was parsed")
+
+	// timed out while rules were running, the results are partial.
+	partial := &motor.RuleSetExecutionResult{TimedOut: true, SpecInfo: &datamodel.SpecInfo{}, Index: &index.SpecIndex{}}
+	assert.NoError(t, checkReportTimedOut(partial, "pizza.yaml", time.Second, false))
+	assert.Error(t, checkTimedOut(partial, "pizza.yaml", nil))
+}
+
+func TestBuildRuleSetFromUserSuppliedSet_TOML(t *testing.T) {
+	tml, _ := os.ReadFile("../rulesets/examples/custom-ruleset.toml")
+	rs, err := BuildRuleSetFromUserSuppliedSet(tml, rulesets.BuildDefaultRuleSets())
+	assert.NoError(t, err)
+	assert.Len(t, rs.Rules, 1)
+	assert.NotNil(t, rs.Rules["check-title-is-exactly-this"])
+}
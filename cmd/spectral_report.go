@@ -44,6 +44,7 @@ func GetSpectralReportCommand() *cobra.Command {
 			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
 			baseFlag, _ := cmd.Flags().GetString("base")
 			skipCheckFlag, _ := cmd.Flags().GetBool("skip-check")
+			timeoutFlag, _ := cmd.Flags().GetDuration("timeout")
 
 			// disable color and styling, for CI/CD use.
 			// https://github.com/daveshanley/vacuum/issues/234
@@ -98,6 +99,7 @@ func GetSpectralReportCommand() *cobra.Command {
 			}
 
 			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+			remoteRuleSetFlag, _ := cmd.Flags().GetBool("ruleset-remote")
 
 			// read spec and parse to dashboard.
 			defaultRuleSets := rulesets.BuildDefaultRuleSets()
@@ -105,21 +107,16 @@ func GetSpectralReportCommand() *cobra.Command {
 			// default is recommended rules, based on spectral (for now anyway)
 			selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
 
-			functionsFlag, _ := cmd.Flags().GetString("functions")
+			functionsFlag := GetCustomFunctionsFlag(cmd)
 			var customFunctions map[string]model.RuleFunction
 
 			// if ruleset has been supplied, lets make sure it exists, then load it in
 			// and see if it's valid. If so - let's go!
 			if rulesetFlag != "" {
 
-				customFunctions, _ = LoadCustomFunctions(functionsFlag)
-				rsBytes, rsErr := os.ReadFile(rulesetFlag)
-				if rsErr != nil {
-					pterm.Error.Printf("Unable to read ruleset file '%s': %s\n", rulesetFlag, rsErr.Error())
-					pterm.Println()
-					return rsErr
-				}
-				selectedRS, rsErr = BuildRuleSetFromUserSuppliedSet(rsBytes, defaultRuleSets)
+				customFunctions, _ = LoadCustomFunctions(functionsFlag, GetWASMLimitsFlags(cmd))
+				var rsErr error
+				selectedRS, rsErr = LoadRuleSet(rulesetFlag, remoteRuleSetFlag, defaultRuleSets)
 				if rsErr != nil {
 					return rsErr
 				}
@@ -136,21 +133,24 @@ func GetSpectralReportCommand() *cobra.Command {
 				SilenceLogs:       true,
 				Base:              baseFlag,
 				SkipDocumentCheck: skipCheckFlag,
+				Timeout:           timeoutFlag,
 			})
 
+			fileName := "stdin"
+			if !stdIn {
+				fileName = args[0]
+			}
+			if tErr := checkReportTimedOut(ruleset, fileName, timeoutFlag, stdOut); tErr != nil {
+				return tErr
+			}
+
 			resultSet := model.NewRuleResultSet(ruleset.Results)
 			resultSet.SortResultsByLineNumber()
 
 			duration := time.Since(start)
 
-			var source string
-			if stdIn {
-				source = "stdin"
-			} else {
-				source = args[0] // todo: convert to full path.
-			}
 			// serialize
-			spectralReport := resultSet.GenerateSpectralReport(source)
+			spectralReport := resultSet.GenerateSpectralReport(fileName) // todo: convert to full path.
 
 			var data []byte
 			if noPretty {
@@ -161,7 +161,7 @@ func GetSpectralReportCommand() *cobra.Command {
 
 			if stdOut {
 				fmt.Print(string(data))
-				return nil
+				return checkTimedOut(ruleset, fileName, nil)
 			}
 
 			err := os.WriteFile(reportOutput, data, 0664)
@@ -178,7 +178,7 @@ func GetSpectralReportCommand() *cobra.Command {
 			fi, _ := os.Stat(args[0])
 			RenderTime(timeFlag, duration, fi.Size())
 
-			return nil
+			return checkTimedOut(ruleset, fileName, nil)
 		},
 	}
 	cmd.Flags().BoolP("stdin", "i", false, "Use stdin as input, instead of a file")
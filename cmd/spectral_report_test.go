@@ -175,3 +175,12 @@ func TestGetSpectralReportCommand_NoArgs(t *testing.T) {
 	cmdErr := cmd.Execute()
 	assert.Error(t, cmdErr)
 }
+
+func TestGetSpectralReportCommand_Timeout(t *testing.T) {
+	cmd := GetRootCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"spectral-report", "--timeout", "1ns", "-o", "../model/test_files/stripe.yaml"})
+	cmdErr := cmd.Execute()
+	assert.ErrorContains(t, cmdErr, "timed out")
+}
@@ -44,6 +44,7 @@ func GetVacuumReportCommand() *cobra.Command {
 			baseFlag, _ := cmd.Flags().GetString("base")
 			junitFlag, _ := cmd.Flags().GetBool("junit")
 			skipCheckFlag, _ := cmd.Flags().GetBool("skip-check")
+			timeoutFlag, _ := cmd.Flags().GetDuration("timeout")
 
 			// disable color and styling, for CI/CD use.
 			// https://github.com/daveshanley/vacuum/issues/234
@@ -68,6 +69,7 @@ func GetVacuumReportCommand() *cobra.Command {
 			noPretty, _ := cmd.Flags().GetBool("no-pretty")
 			compress, _ := cmd.Flags().GetBool("compress")
 			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+			remoteRuleSetFlag, _ := cmd.Flags().GetBool("ruleset-remote")
 
 			extension := ".json"
 
@@ -106,22 +108,17 @@ func GetVacuumReportCommand() *cobra.Command {
 			// default is recommended rules, based on spectral (for now anyway)
 			selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
 
-			functionsFlag, _ := cmd.Flags().GetString("functions")
+			functionsFlag := GetCustomFunctionsFlag(cmd)
 			var customFunctions map[string]model.RuleFunction
 
 			// if ruleset has been supplied, lets make sure it exists, then load it in
 			// and see if it's valid. If so - let's go!
 			if rulesetFlag != "" {
 
-				customFunctions, _ = LoadCustomFunctions(functionsFlag)
+				customFunctions, _ = LoadCustomFunctions(functionsFlag, GetWASMLimitsFlags(cmd))
 
-				rsBytes, rsErr := os.ReadFile(rulesetFlag)
-				if rsErr != nil {
-					pterm.Error.Printf("Unable to read ruleset file '%s': %s\n", rulesetFlag, rsErr.Error())
-					pterm.Println()
-					return rsErr
-				}
-				selectedRS, rsErr = BuildRuleSetFromUserSuppliedSet(rsBytes, defaultRuleSets)
+				var rsErr error
+				selectedRS, rsErr = LoadRuleSet(rulesetFlag, remoteRuleSetFlag, defaultRuleSets)
 				if rsErr != nil {
 					return rsErr
 				}
@@ -138,9 +135,19 @@ func GetVacuumReportCommand() *cobra.Command {
 				SilenceLogs:       true,
 				Base:              baseFlag,
 				SkipDocumentCheck: skipCheckFlag,
+				Timeout:           timeoutFlag,
 			})
 
+			fileName := "stdin"
+			if !stdIn {
+				fileName = args[0]
+			}
+			if tErr := checkReportTimedOut(ruleset, fileName, timeoutFlag, stdOut); tErr != nil {
+				return tErr
+			}
+
 			resultSet := model.NewRuleResultSet(ruleset.Results)
+			resultSet.AddRuleCategories(selectedRS.Categories)
 			resultSet.SortResultsByLineNumber()
 
 			duration := time.Since(start)
@@ -150,7 +157,7 @@ func GetVacuumReportCommand() *cobra.Command {
 				junitXML := vacuum_report.BuildJUnitReport(resultSet, start)
 				if stdOut {
 					fmt.Print(string(junitXML))
-					return nil
+					return checkTimedOut(ruleset, fileName, nil)
 				} else {
 
 					reportOutputName := fmt.Sprintf("%s-%s%s",
@@ -165,7 +172,7 @@ func GetVacuumReportCommand() *cobra.Command {
 
 					pterm.Success.Printf("JUnit Report generated for '%s', written to '%s'\n", args[0], reportOutputName)
 					pterm.Println()
-					return nil
+					return checkTimedOut(ruleset, fileName, nil)
 				}
 			}
 
@@ -196,7 +203,7 @@ func GetVacuumReportCommand() *cobra.Command {
 
 			if stdOut {
 				fmt.Print(string(reportData))
-				return nil
+				return checkTimedOut(ruleset, fileName, nil)
 			}
 
 			if compress {
@@ -231,7 +238,7 @@ func GetVacuumReportCommand() *cobra.Command {
 			fi, _ := os.Stat(args[0])
 			RenderTime(timeFlag, duration, fi.Size())
 
-			return nil
+			return checkTimedOut(ruleset, fileName, nil)
 		},
 	}
 	cmd.Flags().BoolP("stdin", "i", false, "Use stdin as input, instead of a file")
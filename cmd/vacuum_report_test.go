@@ -190,3 +190,12 @@ func TestGetVacuumReportCommand_BadFile(t *testing.T) {
 	assert.Error(t, cmdErr)
 
 }
+
+func TestGetVacuumReportCommand_Timeout(t *testing.T) {
+	cmd := GetRootCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"report", "--timeout", "1ns", "-o", "../model/test_files/stripe.yaml"})
+	cmdErr := cmd.Execute()
+	assert.ErrorContains(t, cmdErr, "timed out")
+}
@@ -49,6 +49,10 @@ func (a Alphabetical) RunRule(nodes []*yaml.Node, context model.RuleFunctionCont
 	}
 
 	for _, node := range nodes {
+		// stop if linting has been cancelled.
+		if context.IsCancelled() {
+			break
+		}
 		pathValue := "unknown"
 		if path, ok := context.Given.(string); ok {
 			pathValue = path
@@ -42,6 +42,10 @@ func (d Defined) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext)
 	}
 
 	for _, node := range nodes {
+		// stop if linting has been cancelled.
+		if context.IsCancelled() {
+			break
+		}
 		fieldNode, _ := utils.FindKeyNode(context.RuleAction.Field, node.Content)
 		if fieldNode == nil {
 			results = append(results, model.RuleFunctionResult{
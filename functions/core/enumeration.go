@@ -59,6 +59,10 @@ func (e Enumeration) RunRule(nodes []*yaml.Node, context model.RuleFunctionConte
 	}
 
 	for _, node := range nodes {
+		// stop if linting has been cancelled.
+		if context.IsCancelled() {
+			break
+		}
 		if !e.checkValueAgainstAllowedValues(node.Value, values) {
 			results = append(results, model.RuleFunctionResult{
 				Message: fmt.Sprintf("%s: `%s` must equal to one of: %v", ruleMessage,
@@ -42,6 +42,10 @@ func (f Falsy) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []
 	}
 
 	for _, node := range nodes {
+		// stop if linting has been cancelled.
+		if context.IsCancelled() {
+			break
+		}
 
 		fieldNode, fieldNodeValue := utils.FindKeyNode(context.RuleAction.Field, node.Content)
 		if (fieldNode != nil && fieldNodeValue != nil) &&
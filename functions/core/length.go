@@ -69,6 +69,10 @@ func (l Length) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) [
 
 	// run through nodes
 	for _, node := range nodes {
+		// stop if linting has been cancelled.
+		if context.IsCancelled() {
+			break
+		}
 
 		var p *yaml.Node
 
@@ -80,6 +80,10 @@ func (p Pattern) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext)
 
 	var currentField string
 	for x, node := range nodes {
+		// stop if linting has been cancelled.
+		if context.IsCancelled() {
+			break
+		}
 		if utils.IsNodeMap(node) {
 			continue
 		}
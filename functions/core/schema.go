@@ -99,6 +99,10 @@ func (sch Schema) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext)
 	}
 
 	for x, node := range nodes {
+		// stop if linting has been cancelled.
+		if context.IsCancelled() {
+			break
+		}
 		if x%2 == 0 && len(nodes) > 1 {
 			continue
 		}
@@ -46,6 +46,10 @@ func (t *Truthy) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext)
 	}
 
 	for x, node := range nodes {
+		// stop if linting has been cancelled.
+		if context.IsCancelled() {
+			break
+		}
 
 		if node.Kind == yaml.DocumentNode {
 			node = node.Content[0]
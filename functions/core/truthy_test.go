@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/stretchr/testify/assert"
@@ -168,3 +169,52 @@ func TestTruthy_RunRule_CheckSecurity(t *testing.T) {
 
 	assert.Len(t, res, 1)
 }
+
+// cancelAfterContext is cancelled after Err() has been checked a set number of times, so a function can be
+// cancelled part way through iterating over its nodes.
+type cancelAfterContext struct {
+	context.Context
+	checks int
+}
+
+func (c *cancelAfterContext) Err() error {
+	if c.checks <= 0 {
+		return context.Canceled
+	}
+	c.checks--
+	return nil
+}
+
+func TestTruthy_RunRule_Cancelled(t *testing.T) {
+
+	sampleYaml := `
+tags:
+  - name: "bad tag 1"
+  - name: "bad tag 2"
+  - name: "bad tag 3"
+  - name: "bad tag 4"`
+
+	path := "$.tags[*]"
+
+	nodes, _ := utils.FindNodes([]byte(sampleYaml), path)
+	assert.Len(t, nodes, 4)
+
+	rule := buildCoreTestRule(path, model.SeverityError, "truthy", "description", nil)
+	ctx := buildCoreTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Given = path
+	ctx.Rule = &rule
+
+	// cancelled after two nodes have been checked.
+	ctx.Context = &cancelAfterContext{Context: context.Background(), checks: 2}
+
+	tru := Truthy{}
+	res := tru.RunRule(nodes, ctx)
+	assert.Len(t, res, 2)
+
+	// already cancelled, nothing is checked.
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx.Context = cancelled
+	res = tru.RunRule(nodes, ctx)
+	assert.Len(t, res, 0)
+}
@@ -41,6 +41,10 @@ func (u Undefined) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext
 	}
 
 	for _, node := range nodes {
+		// stop if linting has been cancelled.
+		if context.IsCancelled() {
+			break
+		}
 
 		fieldNode, _ := utils.FindKeyNode(context.RuleAction.Field, node.Content)
 		if fieldNode != nil {
@@ -68,6 +68,10 @@ func (x Xor) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []mo
 	}
 
 	for _, node := range nodes {
+		// stop if linting has been cancelled.
+		if context.IsCancelled() {
+			break
+		}
 
 		// look through our properties for a match (or no match), the end result needs to be exactly 1.
 		for _, v := range properties {
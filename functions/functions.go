@@ -94,10 +94,87 @@ func MapBuiltinFunctions() Functions {
 		funcs["noVerbsInPath"] = openapi_functions.VerbsInPaths{}
 		funcs["pathsKebabCase"] = openapi_functions.PathsKebabCase{}
 		funcs["oasOpErrorResponse"] = openapi_functions.Operation4xResponse{}
-
+		funcs["oasDeprecatedSunset"] = openapi_functions.DeprecatedSunset{}
+		funcs["oasServerVariables"] = openapi_functions.ServerVariables{}
+		funcs["oasUnresolvedRefs"] = openapi_functions.UnresolvedRefs{}
+		funcs["oasCircularRefs"] = openapi_functions.CircularRefs{}
+		funcs["oasPathCollisions"] = openapi_functions.PathCollisions{}
+		funcs["oasOperationSummary"] = openapi_functions.OperationSummary{}
+		funcs["oasSchemaKeywordConflicts"] = openapi_functions.SchemaKeywordConflicts{}
+		funcs["oasParameterNaming"] = openapi_functions.ParameterNaming{}
+		funcs["oasConflictingExamples"] = openapi_functions.ConflictingExamples{}
+		funcs["oasOAuth2Scopes"] = openapi_functions.OAuth2Scopes{}
+		funcs["oasRequestBodyRequired"] = openapi_functions.RequestBodyRequired{}
+		funcs["oasKnownFormats"] = openapi_functions.KnownFormats{}
+		funcs["oasEmptySchemasResponses"] = openapi_functions.EmptySchemasResponses{}
+		funcs["oasMediaTypeSchemas"] = openapi_functions.MediaTypeSchemas{}
+		funcs["oasEnumCasing"] = openapi_functions.EnumCasing{}
+		funcs["oasParameterRedefinition"] = openapi_functions.ParameterRedefinition{}
+		funcs["oasCollectionPagination"] = openapi_functions.CollectionPagination{}
+		funcs["oasSecuredOperationResponses"] = openapi_functions.SecuredOperationResponses{}
+		funcs["oasTimestampFormats"] = openapi_functions.TimestampFormats{}
+		funcs["oasRequiredProperties"] = openapi_functions.RequiredProperties{}
+		funcs["oasExampleStructure"] = openapi_functions.ExampleStructure{}
+		funcs["oasOperationDuplicateText"] = openapi_functions.OperationDuplicateText{}
+		funcs["oasDescriptionWhitespace"] = openapi_functions.DescriptionWhitespace{}
+		funcs["oasPropertyIdentifiers"] = openapi_functions.PropertyIdentifiers{}
+		funcs["oasMultipleOf"] = openapi_functions.MultipleOf{}
+		funcs["oasOperationSecurityRequired"] = openapi_functions.OperationSecurityRequired{}
+		funcs["oasCacheHeaders"] = openapi_functions.CacheHeaders{}
+		funcs["oasRequiredNullable"] = openapi_functions.RequiredNullable{}
+		funcs["oasArrayResponses"] = openapi_functions.ArrayResponses{}
+		funcs["oasCollectionLimits"] = openapi_functions.CollectionLimits{}
+
+		funcs["oasInfoMetadata"] = openapi_functions.InfoMetadata{}
+		funcs["oasSPDXLicense"] = openapi_functions.SPDXLicense{}
+		funcs["oasSetArrays"] = openapi_functions.SetArrays{}
+		funcs["oasDeprecatedProperties"] = openapi_functions.DeprecatedProperties{}
+		funcs["oasOperationIdConvention"] = openapi_functions.OperationIdConvention{}
+		funcs["oasSchemaExamplesArray"] = openapi_functions.SchemaExamplesArray{}
+		funcs["oasIgnoredRefSiblings"] = openapi_functions.IgnoredRefSiblings{}
+		funcs["oasNumericBounds"] = openapi_functions.NumericBounds{}
+		funcs["oasPathKeyComponents"] = openapi_functions.PathKeyComponents{}
+		funcs["oasRegisteredMediaTypes"] = openapi_functions.RegisteredMediaTypes{}
+		funcs["oasAmbiguousOneOf"] = openapi_functions.AmbiguousOneOf{}
+		funcs["oasSuccessStatusCodes"] = openapi_functions.SuccessStatusCodes{}
+		funcs["oasSchemaNestingDepth"] = openapi_functions.SchemaNestingDepth{}
+		funcs["oasDiscriminatorRequired"] = openapi_functions.DiscriminatorRequired{}
+		funcs["enumTypes"] = openapi_functions.EnumTypes{}
+		funcs["allowEmptyValue"] = openapi_functions.AllowEmptyValue{}
+		funcs["parameterStyles"] = openapi_functions.ParameterStyles{}
+		funcs["serverHttps"] = openapi_functions.ServerHTTPS{}
+		funcs["bodyContent"] = openapi_functions.BodyContent{}
+		funcs["parameterBounds"] = openapi_functions.ParameterBounds{}
+		funcs["closedObjects"] = openapi_functions.ClosedObjects{}
+		funcs["requestReadOnly"] = openapi_functions.RequestReadOnly{}
+		funcs["oasDescriptionQuality"] = openapi_functions.DescriptionQuality{}
+		funcs["oasExampleKeys"] = openapi_functions.ExampleKeys{}
+		funcs["oasTagNameCharacters"] = openapi_functions.TagNameCharacters{}
+		funcs["componentDrift"] = openapi_functions.ComponentDrift{}
+		funcs["booleanEnum"] = openapi_functions.BooleanEnum{}
+		funcs["contentNegotiation"] = openapi_functions.ContentNegotiation{}
+		funcs["paginationMetadata"] = openapi_functions.PaginationMetadata{}
+		funcs["binaryFormats"] = openapi_functions.BinaryFormats{}
+		funcs["pluralPaths"] = openapi_functions.PluralPaths{}
+		funcs["oasWebhookSecurityRequired"] = openapi_functions.WebhookSecurityRequired{}
+		funcs["createdStatus"] = openapi_functions.CreatedStatus{}
+		funcs["arrayItems"] = openapi_functions.ArrayItems{}
+		funcs["enumExamples"] = openapi_functions.EnumExamples{}
+		funcs["termsOfService"] = openapi_functions.TermsOfService{}
+		funcs["deprecatedSchemas"] = openapi_functions.DeprecatedSchemas{}
+		funcs["redosPatterns"] = openapi_functions.RedosPatterns{}
+		funcs["parameterCount"] = openapi_functions.ParameterCount{}
+		funcs["sensitiveCookies"] = openapi_functions.SensitiveCookies{}
+		funcs["schemaPropertyCount"] = openapi_functions.SchemaPropertyCount{}
+		funcs["defaultResponse"] = openapi_functions.DefaultResponse{}
+		funcs["responseStatusCodes"] = openapi_functions.ResponseStatusCodes{}
+		funcs["allOfBounds"] = openapi_functions.AllOfBounds{}
+		funcs["operationProduces"] = openapi_functions.OperationProduces{}
+		funcs["extensionPrefix"] = openapi_functions.ExtensionPrefix{}
 		// add owasp functions used by the owasp rules
 		funcs["owaspHeaderDefinition"] = owasp.HeaderDefinition{}
 		funcs["owaspDefineErrorDefinition"] = owasp.DefineErrorDefinition{}
+		funcs["owaspDefineErrorResponse"] = owasp.DefineErrorResponse{}
 		funcs["owaspCheckSecurity"] = owasp.CheckSecurity{}
 
 	})
@@ -0,0 +1,200 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// AllOfBounds checks the bounds of `allOf` compositions can be satisfied. A value must be valid against every
+// member of an `allOf`, so the bounds of the members are merged, the highest lower bound and the lowest upper bound
+// apply. Every member can look fine on its own, while the merged bounds are impossible (like a `minLength` of 10 in
+// one member, and a `maxLength` of 5 in another). `minimum` and `maximum` (and their exclusive versions, as numbers
+// in OpenAPI 3.1 or booleans in OpenAPI 3.0), `minLength` and `maxLength`, `minItems` and `maxItems`, and
+// `minProperties` and `maxProperties` are merged. Referenced members (and their own `allOf` members) are followed.
+// A conflict is reported once, by the innermost composition it's found in, not again by every composition (or
+// schema referencing it) it's nested in.
+type AllOfBounds struct {
+}
+
+// allOfBoundPairs are the lower and upper bound keywords merged by AllOfBounds.
+var allOfBoundPairs = [][2]string{
+	{"minimum", "maximum"},
+	{"minLength", "maxLength"},
+	{"minItems", "maxItems"},
+	{"minProperties", "maxProperties"},
+}
+
+// allOfBound is the tightest bound found in an allOf composition so far.
+type allOfBound struct {
+	keyword   string
+	raw       string
+	value     float64
+	exclusive bool
+	source    string
+	node      *yaml.Node
+	depth     int
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the AllOfBounds rule.
+func (ab AllOfBounds) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "allof_bounds",
+	}
+}
+
+// RunRule will execute the AllOfBounds rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ab AllOfBounds) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	// numeric returns the value of a numeric keyword of a schema.
+	numeric := func(schema *yaml.Node, keyword string) (*yaml.Node, float64, bool) {
+		_, node := utils.FindKeyNodeTop(keyword, schema.Content)
+		if node == nil || node.Kind != yaml.ScalarNode || node.Tag == "!!bool" {
+			return nil, 0, false
+		}
+		value, err := strconv.ParseFloat(node.Value, 64)
+		return node, value, err == nil
+	}
+
+	// tighten replaces a merged bound, if the bound of a member is tighter.
+	tighten := func(merged map[string]*allOfBound, pair string, b *allOfBound, lower bool) {
+		current := merged[pair]
+		if current == nil || (lower && b.value > current.value) || (!lower && b.value < current.value) ||
+			(b.value == current.value && b.exclusive && !current.exclusive) {
+			merged[pair] = b
+		}
+	}
+
+	// collect merges the bounds of a schema, and of its allOf members, by the lower or upper keyword of their pair.
+	var collect func(schema *yaml.Node, source string, depth int, merged map[string]*allOfBound,
+		seen map[*yaml.Node]bool)
+	collect = func(schema *yaml.Node, source string, depth int, merged map[string]*allOfBound,
+		seen map[*yaml.Node]bool) {
+		if schema = resolveSchemaReference(root, schema); schema == nil || seen[schema] {
+			return
+		}
+		seen[schema] = true
+		for _, pair := range allOfBoundPairs {
+			for i, keyword := range pair {
+				lower := i == 0
+				exclusiveKeyword := "exclusiveMaximum"
+				if lower {
+					exclusiveKeyword = "exclusiveMinimum"
+				}
+				if node, value, ok := numeric(schema, keyword); ok {
+					_, exclusive := utils.FindKeyNodeTop(exclusiveKeyword, schema.Content)
+					tighten(merged, keyword, &allOfBound{
+						keyword:   keyword,
+						raw:       node.Value,
+						value:     value,
+						exclusive: pair[0] == "minimum" && exclusive != nil && exclusive.Value == "true",
+						source:    source,
+						node:      node,
+						depth:     depth,
+					}, lower)
+				}
+				if pair[0] != "minimum" {
+					continue
+				}
+				if node, value, ok := numeric(schema, exclusiveKeyword); ok {
+					tighten(merged, keyword, &allOfBound{
+						keyword:   exclusiveKeyword,
+						raw:       node.Value,
+						value:     value,
+						exclusive: true,
+						source:    source,
+						node:      node,
+						depth:     depth,
+					}, lower)
+				}
+			}
+		}
+		_, allOf := utils.FindKeyNodeTop("allOf", schema.Content)
+		if allOf == nil || !utils.IsNodeArray(allOf) {
+			return
+		}
+		for i, member := range allOf.Content {
+			memberSource := fmt.Sprintf("allOf[%d]", i)
+			if source != "" {
+				memberSource = fmt.Sprintf("%s.allOf[%d]", source, i)
+			}
+			if utils.IsNodeMap(member) {
+				if _, ref := utils.FindKeyNodeTop("$ref", member.Content); ref != nil {
+					memberSource = ref.Value
+				}
+			}
+			collect(member, memberSource, depth+1, merged, seen)
+		}
+	}
+
+	// describe returns a bound as `keyword` of value, a boolean exclusive keyword and the member are noted.
+	describe := func(b *allOfBound) string {
+		description := fmt.Sprintf("`%s` of %s", b.keyword, b.raw)
+		if b.exclusive && (b.keyword == "minimum" || b.keyword == "maximum") {
+			description += " (exclusive)"
+		}
+		if b.source != "" {
+			description += fmt.Sprintf(" (from `%s`)", b.source)
+		}
+		return description
+	}
+
+	// conflicts are keyed by the nodes of their bounds, the result of the composition they are least deeply nested
+	// in is kept.
+	reported := make(map[[2]*yaml.Node]int)
+	var depths []int
+
+	walker.ForEachSchema(root, func(schema walker.Schema) bool {
+		allOfKey, allOf := utils.FindKeyNodeTop("allOf", schema.Node.Content)
+		if schema.Ref != "" || allOf == nil || !utils.IsNodeArray(allOf) {
+			return !context.IsCancelled()
+		}
+		merged := make(map[string]*allOfBound)
+		collect(schema.Node, "", 0, merged, make(map[*yaml.Node]bool))
+		for _, pair := range allOfBoundPairs {
+			lower, upper := merged[pair[0]], merged[pair[1]]
+			if lower == nil || upper == nil || lower.value < upper.value ||
+				(lower.value == upper.value && !lower.exclusive && !upper.exclusive) {
+				continue
+			}
+			result := model.RuleFunctionResult{
+				Message: fmt.Sprintf("`allOf` composition can't be satisfied, %s conflicts with %s",
+					describe(lower), describe(upper)),
+				StartNode: allOfKey,
+				EndNode:   utils.FindLastChildNodeWithLevel(allOf, 0),
+				Path:      schema.JSONPath,
+				Rule:      context.Rule,
+			}
+			key, depth := [2]*yaml.Node{lower.node, upper.node}, lower.depth+upper.depth
+			if i, ok := reported[key]; ok {
+				if depth < depths[i] {
+					results[i], depths[i] = result, depth
+				}
+				continue
+			}
+			reported[key] = len(results)
+			results = append(results, result)
+			depths = append(depths, depth)
+		}
+		return !context.IsCancelled()
+	})
+	return results
+}
@@ -0,0 +1,142 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var allOfBoundsTestSpec = `openapi: 3.1.0
+components:
+  schemas:
+    ShortName:
+      type: string
+      maxLength: 5
+    Name:
+      allOf:
+        - $ref: '#/components/schemas/ShortName'
+        - minLength: 10
+    Size:
+      type: integer
+      allOf:
+        - minimum: 10
+        - exclusiveMaximum: 10
+    Toppings:
+      type: array
+      minItems: 2
+      allOf:
+        - maxItems: 3
+        - $ref: '#/components/schemas/Chain'
+    Chain:
+      allOf:
+        - minItems: 1
+    Price:
+      allOf:
+        - minimum: 1
+          maximum: 10
+        - maximum: 20`
+
+func TestAllOfBounds_GetSchema(t *testing.T) {
+	def := AllOfBounds{}
+	assert.Equal(t, "allof_bounds", def.GetSchema().Name)
+}
+
+func TestAllOfBounds_RunRule(t *testing.T) {
+	def := AllOfBounds{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestAllOfBounds_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(allOfBoundsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "allof_bounds", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := AllOfBounds{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "`allOf` composition can't be satisfied, `minLength` of 10 (from `allOf[1]`) conflicts with "+
+		"`maxLength` of 5 (from `#/components/schemas/ShortName`)", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Name", res[0].Path)
+	assert.Equal(t, 8, res[0].StartNode.Line)
+	assert.Equal(t, "`allOf` composition can't be satisfied, `minimum` of 10 (from `allOf[0]`) conflicts with "+
+		"`exclusiveMaximum` of 10 (from `allOf[1]`)", res[1].Message)
+	assert.Equal(t, "$.components.schemas.Size", res[1].Path)
+}
+
+func TestAllOfBounds_RunRule_OpenAPI30(t *testing.T) {
+
+	yml := `openapi: 3.0.3
+components:
+  schemas:
+    Size:
+      allOf:
+        - minimum: 10
+          exclusiveMinimum: true
+        - maximum: 10
+    Weight:
+      allOf:
+        - minimum: 10
+        - maximum: 10
+        - minItems: 3
+          maxItems: 2`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "allof_bounds", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := AllOfBounds{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "`allOf` composition can't be satisfied, `minimum` of 10 (exclusive) (from `allOf[0]`) conflicts "+
+		"with `maximum` of 10 (from `allOf[1]`)", res[0].Message)
+	assert.Equal(t, "`allOf` composition can't be satisfied, `minItems` of 3 (from `allOf[2]`) conflicts with "+
+		"`maxItems` of 2 (from `allOf[2]`)", res[1].Message)
+	assert.Equal(t, "$.components.schemas.Weight", res[1].Path)
+}
+
+func TestAllOfBounds_RunRule_Nested(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Name:
+      allOf:
+        - allOf:
+            - minLength: 10
+            - type: string
+        - maxLength: 5
+    Pizza:
+      allOf:
+        - allOf:
+            - $ref: '#/components/schemas/Name'
+            - description: a pizza name
+        - type: string`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "allof_bounds", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := AllOfBounds{}
+	res := def.RunRule(nodes, ctx)
+
+	// the conflict is reported by `Name`, not again by the compositions referencing it.
+	assert.Len(t, res, 1)
+	assert.Equal(t, "`allOf` composition can't be satisfied, `minLength` of 10 (from `allOf[0].allOf[0]`) "+
+		"conflicts with `maxLength` of 5 (from `allOf[1]`)", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Name", res[0].Path)
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// AllowEmptyValue checks `allowEmptyValue` is only used by parameters it is valid for, `query` parameters (and
+// `formData` parameters in swagger). `allowEmptyValue` is deprecated in OpenAPI 3, so when the `disallow` option is
+// set, every use is reported. Referenced parameters are resolved, and reported once, where they are defined.
+type AllowEmptyValue struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the AllowEmptyValue rule.
+func (ae AllowEmptyValue) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "allow_empty_value",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "disallow",
+				Description: "report every use of 'allowEmptyValue', not just invalid ones (defaults to false)",
+			},
+		},
+		ErrorMessage: "'allow_empty_value' function has invalid options supplied. Example valid options are " +
+			"'disallow' = true",
+	}
+}
+
+// RunRule will execute the AllowEmptyValue rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ae AllowEmptyValue) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	disallow := getBoolOption("disallow", context.Options, false)
+
+	forEachParameter(root, func(param *yaml.Node, path string) bool {
+		key, value := utils.FindKeyNodeTop("allowEmptyValue", param.Content)
+		if value == nil {
+			return !context.IsCancelled()
+		}
+		var name, in string
+		if _, n := utils.FindKeyNodeTop("name", param.Content); n != nil {
+			name = n.Value
+		}
+		if _, i := utils.FindKeyNodeTop("in", param.Content); i != nil {
+			in = i.Value
+		}
+
+		var msg string
+		switch {
+		case in != "query" && in != "formData":
+			msg = fmt.Sprintf("`%s` parameter `%s` uses `allowEmptyValue`, which is only valid for `query` "+
+				"parameters", in, name)
+		case disallow:
+			msg = fmt.Sprintf("parameter `%s` uses `allowEmptyValue`, which is deprecated", name)
+		default:
+			return !context.IsCancelled()
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message:   msg,
+			StartNode: key,
+			EndNode:   value,
+			Path:      path,
+			Rule:      context.Rule,
+		})
+		return !context.IsCancelled()
+	})
+	return results
+}
@@ -0,0 +1,121 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var allowEmptyValueTestSpec = `openapi: 3.0.3
+paths:
+  /pizza:
+    parameters:
+      - $ref: '#/components/parameters/Trace'
+    get:
+      parameters:
+        - name: toppings
+          in: query
+          allowEmptyValue: true
+        - name: slices
+          in: path
+          required: true
+          allowEmptyValue: false
+        - $ref: '#/components/parameters/Trace'
+        - $ref: '#/components/parameters/Alias'
+components:
+  parameters:
+    Trace:
+      name: X-Trace
+      in: header
+      allowEmptyValue: true
+    Alias:
+      $ref: '#/components/parameters/Session'
+    Session:
+      name: session
+      in: cookie
+      allowEmptyValue: true`
+
+func TestAllowEmptyValue_GetSchema(t *testing.T) {
+	def := AllowEmptyValue{}
+	assert.Equal(t, "allow_empty_value", def.GetSchema().Name)
+}
+
+func TestAllowEmptyValue_RunRule(t *testing.T) {
+	def := AllowEmptyValue{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestAllowEmptyValue_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(allowEmptyValueTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "allow_empty_value", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := AllowEmptyValue{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "`header` parameter `X-Trace` uses `allowEmptyValue`, which is only valid for `query` parameters",
+		res[0].Message)
+	assert.Equal(t, "$.components.parameters.Trace", res[0].Path)
+	assert.Equal(t, 22, res[0].StartNode.Line)
+	assert.Equal(t, "`cookie` parameter `session` uses `allowEmptyValue`, which is only valid for `query` parameters",
+		res[1].Message)
+	assert.Equal(t, "$.components.parameters.Session", res[1].Path)
+	assert.Equal(t, "`path` parameter `slices` uses `allowEmptyValue`, which is only valid for `query` parameters",
+		res[2].Message)
+	assert.Equal(t, "$.paths./pizza.get.parameters[1]", res[2].Path)
+}
+
+func TestAllowEmptyValue_RunRule_Disallow(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(allowEmptyValueTestSpec), path)
+
+	opts := map[string]string{"disallow": "true"}
+	rule := buildOpenApiTestRuleAction(path, "allow_empty_value", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), opts)
+
+	def := AllowEmptyValue{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "parameter `toppings` uses `allowEmptyValue`, which is deprecated", res[2].Message)
+	assert.Equal(t, "$.paths./pizza.get.parameters[0]", res[2].Path)
+}
+
+func TestAllowEmptyValue_RunRule_Success(t *testing.T) {
+
+	yml := `swagger: 2.0
+paths:
+  /pizza:
+    post:
+      parameters:
+        - name: toppings
+          in: query
+          type: string
+          allowEmptyValue: true
+        - name: note
+          in: formData
+          type: string
+          allowEmptyValue: true`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "allow_empty_value", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := AllowEmptyValue{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// AmbiguousOneOf checks `oneOf` compositions for branches that are likely to match the same input, which breaks
+// the "exactly one" contract. Deciding whether two schemas overlap is undecidable in general, so this is a
+// heuristic: two branches overlap when they share a type (`integer` counts as a `number`), and nothing tells them
+// apart. Branches are told apart by `const` or `enum` values that don't intersect (on the branch, or on a property
+// both define), a required property one branch doesn't allow (`additionalProperties: false`), or a different
+// `format` or `pattern` for strings. Compositions with a `discriminator` are not checked, neither are branches
+// without a `type`, or `anyOf` (which allows overlapping branches). Local references are followed.
+type AmbiguousOneOf struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the AmbiguousOneOf rule.
+func (ao AmbiguousOneOf) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "ambiguous_one_of",
+	}
+}
+
+// RunRule will execute the AmbiguousOneOf rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ao AmbiguousOneOf) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	walker.ForEachSchema(root, func(schema walker.Schema) bool {
+		oneOfKey, oneOf := utils.FindKeyNodeTop("oneOf", schema.Node.Content)
+		if !utils.IsNodeArray(oneOf) {
+			return !context.IsCancelled()
+		}
+		if _, discriminator := utils.FindKeyNodeTop("discriminator", schema.Node.Content); discriminator != nil {
+			return !context.IsCancelled()
+		}
+		branches := make([]*yaml.Node, len(oneOf.Content))
+		for i, b := range oneOf.Content {
+			branches[i] = resolveSchemaReference(root, b)
+		}
+		for i := 0; i < len(branches); i++ {
+			for j := i + 1; j < len(branches); j++ {
+				if branches[i] == nil || branches[j] == nil {
+					continue
+				}
+				shared := sharedSchemaType(branches[i], branches[j])
+				if shared == "" || schemasDistinct(root, branches[i], branches[j], shared) {
+					continue
+				}
+				results = append(results, model.RuleFunctionResult{
+					Message: fmt.Sprintf("`oneOf` branches %d and %d can both match the same `%s`, add a "+
+						"`discriminator`, or make the branches exclusive", i, j, shared),
+					StartNode: oneOfKey,
+					EndNode:   utils.FindLastChildNodeWithLevel(oneOf, 0),
+					Path:      schema.JSONPath + ".oneOf",
+					Rule:      context.Rule,
+				})
+			}
+		}
+		return !context.IsCancelled()
+	})
+	return results
+}
+
+// sharedSchemaType returns a type two schemas both accept, or an empty string. Every integer is a number, so
+// `integer` and `number` share `integer`.
+func sharedSchemaType(a, b *yaml.Node) string {
+	for _, ta := range schemaTypesOf(a) {
+		for _, tb := range schemaTypesOf(b) {
+			switch {
+			case ta == tb:
+				return ta
+			case (ta == "integer" && tb == "number") || (ta == "number" && tb == "integer"):
+				return "integer"
+			}
+		}
+	}
+	return ""
+}
+
+// allowedValues returns the values a schema allows with `const` or `enum`, or nil if it allows any value, or
+// its values can't be compared (because they are objects or arrays).
+func allowedValues(schema *yaml.Node) map[string]bool {
+	if _, c := utils.FindKeyNodeTop("const", schema.Content); c != nil && c.Kind == yaml.ScalarNode {
+		return map[string]bool{c.Value: true}
+	}
+	_, enum := utils.FindKeyNodeTop("enum", schema.Content)
+	if !utils.IsNodeArray(enum) {
+		return nil
+	}
+	values := make(map[string]bool)
+	for _, e := range enum.Content {
+		if e.Kind != yaml.ScalarNode {
+			return nil
+		}
+		values[e.Value] = true
+	}
+	return values
+}
+
+// valuesDisjoint returns true if two schemas both restrict their values, and no value is allowed by both.
+func valuesDisjoint(a, b *yaml.Node) bool {
+	va, vb := allowedValues(a), allowedValues(b)
+	if va == nil || vb == nil {
+		return false
+	}
+	for v := range va {
+		if vb[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// schemasDistinct returns true if something tells two schemas that share a type apart.
+func schemasDistinct(root, a, b *yaml.Node, shared string) bool {
+	if valuesDisjoint(a, b) {
+		return true
+	}
+	switch shared {
+	case "string":
+		for _, keyword := range []string{"format", "pattern"} {
+			_, ka := utils.FindKeyNodeTop(keyword, a.Content)
+			_, kb := utils.FindKeyNodeTop(keyword, b.Content)
+			if ka != nil && kb != nil && ka.Value != kb.Value {
+				return true
+			}
+		}
+	case "object":
+		_, propsA := utils.FindKeyNodeTop("properties", a.Content)
+		_, propsB := utils.FindKeyNodeTop("properties", b.Content)
+		if propsA != nil && propsB != nil {
+			for i := 0; i < len(propsA.Content)-1; i += 2 {
+				_, pb := utils.FindKeyNodeTop(propsA.Content[i].Value, propsB.Content)
+				pa, pb := resolveSchemaReference(root, propsA.Content[i+1]), resolveSchemaReference(root, pb)
+				if pa != nil && pb != nil && valuesDisjoint(pa, pb) {
+					return true
+				}
+			}
+		}
+		return requiresDisallowed(a, b) || requiresDisallowed(b, a)
+	}
+	return false
+}
+
+// requiresDisallowed returns true if schema a requires a property that schema b does not allow.
+func requiresDisallowed(a, b *yaml.Node) bool {
+	_, additional := utils.FindKeyNodeTop("additionalProperties", b.Content)
+	if additional == nil || additional.Value != "false" {
+		return false
+	}
+	_, required := utils.FindKeyNodeTop("required", a.Content)
+	if !utils.IsNodeArray(required) {
+		return false
+	}
+	_, props := utils.FindKeyNodeTop("properties", b.Content)
+	for _, r := range required.Content {
+		if props == nil {
+			return true
+		}
+		if _, p := utils.FindKeyNodeTop(r.Value, props.Content); p == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var ambiguousOneOfTestSpec = `openapi: 3.1.0
+components:
+  schemas:
+    Pizza:
+      oneOf:
+        - $ref: '#/components/schemas/Margherita'
+        - $ref: '#/components/schemas/Pepperoni'
+    Margherita:
+      type: object
+      properties:
+        name:
+          type: string
+    Pepperoni:
+      type: object
+      properties:
+        spicy:
+          type: boolean
+    Kind:
+      oneOf:
+        - type: object
+          properties:
+            kind:
+              const: margherita
+        - type: object
+          properties:
+            kind:
+              enum: [pepperoni, hawaiian]
+        - type: object
+          required: [crust]
+        - type: object
+          additionalProperties: false
+          properties:
+            kind:
+              type: string
+    Discriminated:
+      discriminator:
+        propertyName: kind
+      oneOf:
+        - $ref: '#/components/schemas/Margherita'
+        - $ref: '#/components/schemas/Pepperoni'
+    Size:
+      oneOf:
+        - type: integer
+        - type: number
+        - type: string
+          format: uuid
+        - type: string
+          format: date
+        - type: string
+    Any:
+      anyOf:
+        - type: string
+        - type: string
+      oneOf:
+        - description: no type
+        - description: no type`
+
+func TestAmbiguousOneOf_GetSchema(t *testing.T) {
+	def := AmbiguousOneOf{}
+	assert.Equal(t, "ambiguous_one_of", def.GetSchema().Name)
+}
+
+func TestAmbiguousOneOf_RunRule(t *testing.T) {
+	def := AmbiguousOneOf{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestAmbiguousOneOf_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(ambiguousOneOfTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "ambiguous_one_of", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := AmbiguousOneOf{}
+	res := def.RunRule(nodes, ctx)
+
+	var messages []string
+	for _, r := range res {
+		messages = append(messages, r.Path+": "+r.Message)
+	}
+	assert.Equal(t, []string{
+		"$.components.schemas.Pizza.oneOf: `oneOf` branches 0 and 1 can both match the same `object`, add a " +
+			"`discriminator`, or make the branches exclusive",
+		"$.components.schemas.Kind.oneOf: `oneOf` branches 0 and 2 can both match the same `object`, add a " +
+			"`discriminator`, or make the branches exclusive",
+		"$.components.schemas.Kind.oneOf: `oneOf` branches 0 and 3 can both match the same `object`, add a " +
+			"`discriminator`, or make the branches exclusive",
+		"$.components.schemas.Kind.oneOf: `oneOf` branches 1 and 2 can both match the same `object`, add a " +
+			"`discriminator`, or make the branches exclusive",
+		"$.components.schemas.Kind.oneOf: `oneOf` branches 1 and 3 can both match the same `object`, add a " +
+			"`discriminator`, or make the branches exclusive",
+		"$.components.schemas.Size.oneOf: `oneOf` branches 0 and 1 can both match the same `integer`, add a " +
+			"`discriminator`, or make the branches exclusive",
+		"$.components.schemas.Size.oneOf: `oneOf` branches 2 and 4 can both match the same `string`, add a " +
+			"`discriminator`, or make the branches exclusive",
+		"$.components.schemas.Size.oneOf: `oneOf` branches 3 and 4 can both match the same `string`, add a " +
+			"`discriminator`, or make the branches exclusive",
+	}, messages)
+	assert.Equal(t, 5, res[0].StartNode.Line)
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ArrayItems checks `array` schemas define their `items`, an array without them can hold anything, and generates
+// clients with arrays of `any`. OpenAPI 3.1 tuples define their items with `prefixItems` and don't need `items`.
+// The `items` (or `prefixItems`) of an `allOf` member count, references are followed.
+type ArrayItems struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ArrayItems rule.
+func (ai ArrayItems) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "array_items",
+	}
+}
+
+// RunRule will execute the ArrayItems rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ai ArrayItems) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	// hasItems returns true if a schema, or one of its allOf members, defines items.
+	var hasItems func(schema *yaml.Node, seen map[*yaml.Node]bool) bool
+	hasItems = func(schema *yaml.Node, seen map[*yaml.Node]bool) bool {
+		if schema = resolveSchemaReference(root, schema); schema == nil || seen[schema] {
+			return false
+		}
+		seen[schema] = true
+		if _, items := utils.FindKeyNodeTop("items", schema.Content); items != nil {
+			return true
+		}
+		if _, prefixItems := utils.FindKeyNodeTop("prefixItems", schema.Content); prefixItems != nil {
+			return true
+		}
+		if _, allOf := utils.FindKeyNodeTop("allOf", schema.Content); allOf != nil && utils.IsNodeArray(allOf) {
+			for _, member := range allOf.Content {
+				if hasItems(member, seen) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	walker.ForEachSchema(root, func(schema walker.Schema) bool {
+		if schema.Ref != "" || !containsString(schemaTypesOf(schema.Node), "array") ||
+			hasItems(schema.Node, make(map[*yaml.Node]bool)) {
+			return !context.IsCancelled()
+		}
+		typeKey, typeNode := utils.FindKeyNodeTop("type", schema.Node.Content)
+		results = append(results, model.RuleFunctionResult{
+			Message:   "array schema has no `items`, the type of its items is unknown",
+			StartNode: typeKey,
+			EndNode:   utils.FindLastChildNodeWithLevel(typeNode, 0),
+			Path:      schema.JSONPath,
+			Rule:      context.Rule,
+		})
+		return !context.IsCancelled()
+	})
+	return results
+}
@@ -0,0 +1,68 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayItems_GetSchema(t *testing.T) {
+	def := ArrayItems{}
+	assert.Equal(t, "array_items", def.GetSchema().Name)
+}
+
+func TestArrayItems_RunRule(t *testing.T) {
+	def := ArrayItems{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestArrayItems_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Toppings:
+      type: array
+      items:
+        type: string
+    Pizza:
+      type: object
+      properties:
+        toppings:
+          type: array
+        sizes:
+          type:
+            - array
+            - "null"
+        location:
+          type: array
+          prefixItems:
+            - type: number
+            - type: number
+          items: false
+        extras:
+          type: array
+          allOf:
+            - $ref: '#/components/schemas/Toppings'
+        bases:
+          $ref: '#/components/schemas/Toppings'`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "array_items", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ArrayItems{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "array schema has no `items`, the type of its items is unknown", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.toppings", res[0].Path)
+	assert.Equal(t, 12, res[0].StartNode.Line)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.sizes", res[1].Path)
+}
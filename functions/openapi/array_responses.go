@@ -0,0 +1,143 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ArrayResponses checks that successful (`2xx`) responses don't return a bare array, a root object (an envelope)
+// can be extended with metadata (like pagination) later, an array can't. References to responses and schemas are
+// followed. Media types that are arrays by design (like CSV or streams) are skipped, they are listed by the
+// `exemptContentTypes` option, which accepts media types or glob patterns (like `text/*`).
+type ArrayResponses struct {
+}
+
+// defaultArrayExemptContentTypes are the media types that are not checked, unless configured otherwise.
+var defaultArrayExemptContentTypes = []string{"text/csv", "text/event-stream", "application/x-ndjson",
+	"application/jsonl", "application/stream+json"}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ArrayResponses rule.
+func (ar ArrayResponses) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "array_responses",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "exemptContentTypes",
+				Description: "media types (or glob patterns) that may return a bare array",
+			},
+		},
+		ErrorMessage: "'array_responses' function has invalid options supplied. Example valid options are " +
+			"'exemptContentTypes' = ['text/csv', 'application/x-ndjson']",
+	}
+}
+
+// RunRule will execute the ArrayResponses rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ar ArrayResponses) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	exempt := getStringArrayOption("exemptContentTypes", context.Options, defaultArrayExemptContentTypes)
+
+	isExempt := func(mediaType string) bool {
+		mediaType = strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+		for _, e := range exempt {
+			if match, _ := path.Match(strings.ToLower(e), mediaType); match {
+				return true
+			}
+		}
+		return false
+	}
+
+	checkSchema := func(schemaKey, schema *yaml.Node, location, schemaPath string) {
+		resolved := resolveSchemaReference(root, schema)
+		if resolved == nil {
+			return
+		}
+		if types := schemaTypesOf(resolved); len(types) != 1 || types[0] != "array" {
+			return
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message: fmt.Sprintf("%s returns a bare array, wrap it in an object so it can be extended later",
+				location),
+			StartNode: schemaKey,
+			EndNode:   utils.FindLastChildNodeWithLevel(schema, 0),
+			Path:      schemaPath,
+			Rule:      context.Rule,
+		})
+	}
+
+	_, paths := utils.FindKeyNodeTop("paths", root.Content)
+	if !utils.IsNodeMap(paths) {
+		return results
+	}
+	for i := 0; i < len(paths.Content)-1; i += 2 {
+		if context.IsCancelled() {
+			break
+		}
+		opPath, pathItem := paths.Content[i].Value, paths.Content[i+1]
+		for m := 0; m < len(pathItem.Content)-1; m += 2 {
+			opKey, operation := pathItem.Content[m], pathItem.Content[m+1]
+			if !isOperationMethod(opKey.Value) || !utils.IsNodeMap(operation) {
+				continue
+			}
+			_, responses := utils.FindKeyNodeTop("responses", operation.Content)
+			if !utils.IsNodeMap(responses) {
+				continue
+			}
+			for r := 0; r < len(responses.Content)-1; r += 2 {
+				code, response := responses.Content[r], responses.Content[r+1]
+				if !strings.HasPrefix(code.Value, "2") {
+					continue
+				}
+				if _, ref := utils.FindKeyNodeTop("$ref", response.Content); ref != nil {
+					if resolved := resolveLocalReference(root, ref.Value); resolved != nil {
+						response = resolved
+					}
+				}
+				if !utils.IsNodeMap(response) {
+					continue
+				}
+				responsePath := fmt.Sprintf("$.paths.%s.%s.responses.%s", opPath, opKey.Value, code.Value)
+				location := fmt.Sprintf("`%s` response of the `%s` operation at path `%s`", code.Value,
+					opKey.Value, opPath)
+
+				// swagger responses have a schema, openapi responses have a schema per media type.
+				if schemaKey, schema := utils.FindKeyNodeTop("schema", response.Content); schema != nil {
+					checkSchema(schemaKey, schema, location, responsePath+".schema")
+				}
+				_, content := utils.FindKeyNodeTop("content", response.Content)
+				if !utils.IsNodeMap(content) {
+					continue
+				}
+				for c := 0; c < len(content.Content)-1; c += 2 {
+					mediaType, mediaTypeObj := content.Content[c].Value, content.Content[c+1]
+					if isExempt(mediaType) || !utils.IsNodeMap(mediaTypeObj) {
+						continue
+					}
+					if schemaKey, schema := utils.FindKeyNodeTop("schema", mediaTypeObj.Content); schema != nil {
+						checkSchema(schemaKey, schema, fmt.Sprintf("`%s` %s", mediaType, location),
+							fmt.Sprintf("%s.content.%s.schema", responsePath, mediaType))
+					}
+				}
+			}
+		}
+	}
+	return results
+}
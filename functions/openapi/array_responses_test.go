@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var arrayResponsesTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: string
+            text/csv:
+              schema:
+                type: array
+            application/x-ndjson; charset=utf-8:
+              schema:
+                type: array
+        "400":
+          description: bad
+          content:
+            application/json:
+              schema:
+                type: array
+    post:
+      responses:
+        "201":
+          $ref: '#/components/responses/Pizzas'
+  /pizza/{id}:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pizza'
+components:
+  responses:
+    Pizzas:
+      description: pizzas
+      content:
+        application/json:
+          schema:
+            type: [array, "null"]
+  schemas:
+    Pizza:
+      type: object`
+
+func TestArrayResponses_GetSchema(t *testing.T) {
+	def := ArrayResponses{}
+	assert.Equal(t, "array_responses", def.GetSchema().Name)
+}
+
+func TestArrayResponses_RunRule(t *testing.T) {
+	def := ArrayResponses{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestArrayResponses_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(arrayResponsesTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "array_responses", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ArrayResponses{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "`application/json` `200` response of the `get` operation at path `/pizza` returns a bare "+
+		"array, wrap it in an object so it can be extended later", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get.responses.200.content.application/json.schema", res[0].Path)
+	assert.Equal(t, 10, res[0].StartNode.Line)
+	assert.Equal(t, "$.paths./pizza.post.responses.201.content.application/json.schema", res[1].Path)
+}
+
+func TestArrayResponses_RunRule_Swagger(t *testing.T) {
+
+	path := "$"
+
+	spec := `swagger: "2.0"
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+          schema:
+            type: array`
+
+	nodes, _ := utils.FindNodes([]byte(spec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "array_responses", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ArrayResponses{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.paths./pizza.get.responses.200.schema", res[0].Path)
+}
+
+func TestArrayResponses_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(arrayResponsesTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["exemptContentTypes"] = []interface{}{"application/*"}
+
+	rule := buildOpenApiTestRuleAction(path, "array_responses", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := ArrayResponses{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.paths./pizza.get.responses.200.content.text/csv.schema", res[0].Path)
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// BinaryFormats checks schemas used by JSON media types (like `application/json` or `application/problem+json`)
+// don't use a binary `format`. JSON can't hold binary data, `format: binary` belongs to media types like
+// `application/octet-stream` or `multipart/form-data`. The formats are set by the `formats` option, which is
+// `binary` and `byte` by default. Schemas nested in the media type schema are checked too, references are followed,
+// and each schema is reported once, where it is declared. OpenAPI 3.1 describes binary data with `contentEncoding`
+// and `contentMediaType`, only OpenAPI 3.0 documents are checked.
+type BinaryFormats struct {
+}
+
+var defaultBinaryFormats = []string{"binary", "byte"}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the BinaryFormats rule.
+func (bf BinaryFormats) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "binary_formats",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "formats",
+				Description: "formats that JSON media types can't use (defaults to 'binary' and 'byte')",
+			},
+		},
+		ErrorMessage: "'binary_formats' function has invalid options supplied. Example valid options are " +
+			"'formats' = ['binary']",
+	}
+}
+
+// RunRule will execute the BinaryFormats rule, based on supplied context and a supplied []*yaml.Node slice.
+func (bf BinaryFormats) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	if _, version := utils.FindKeyNodeTop("openapi", root.Content); version == nil ||
+		!strings.HasPrefix(version.Value, "3.0") {
+		return nil
+	}
+	formats := getStringArrayOption("formats", context.Options, defaultBinaryFormats)
+
+	reported := make(map[*yaml.Node]bool)
+
+	// traverse checks a schema used by a JSON media type, and the schemas nested in it.
+	var traverse func(schema *yaml.Node, path, mediaType string, seen map[*yaml.Node]bool)
+	traverse = func(schema *yaml.Node, path, mediaType string, seen map[*yaml.Node]bool) {
+		if schema == nil || !utils.IsNodeMap(schema) {
+			return
+		}
+		if _, ref := utils.FindKeyNodeTop("$ref", schema.Content); ref != nil {
+			_, path = utils.ConvertComponentIdIntoPath(ref.Value)
+		}
+		if schema = resolveSchemaReference(root, schema); schema == nil || seen[schema] {
+			return
+		}
+		seen[schema] = true
+
+		if formatKey, format := utils.FindKeyNodeTop("format", schema.Content); format != nil && !reported[schema] &&
+			containsString(formats, format.Value) {
+			reported[schema] = true
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("schema has `format: %s`, but is used by JSON media type `%s`, which can't "+
+					"hold binary data", format.Value, mediaType),
+				StartNode: formatKey,
+				EndNode:   format,
+				Path:      path,
+				Rule:      context.Rule,
+			})
+		}
+
+		for i := 0; i < len(schema.Content)-1; i += 2 {
+			key, value := schema.Content[i].Value, schema.Content[i+1]
+			switch key {
+			case "properties":
+				if utils.IsNodeMap(value) {
+					for p := 0; p < len(value.Content)-1; p += 2 {
+						traverse(value.Content[p+1], fmt.Sprintf("%s.properties.%s", path, value.Content[p].Value),
+							mediaType, seen)
+					}
+				}
+			case "allOf", "oneOf", "anyOf":
+				if utils.IsNodeArray(value) {
+					for m, member := range value.Content {
+						traverse(member, fmt.Sprintf("%s.%s[%d]", path, key, m), mediaType, seen)
+					}
+				}
+			case "items", "additionalProperties", "not":
+				traverse(value, fmt.Sprintf("%s.%s", path, key), mediaType, seen)
+			}
+		}
+	}
+
+	// walk the document looking for 'content' maps, each value is a media type.
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				switch key.Value {
+				case "example", "examples", "schema":
+					continue
+				case "content":
+					if utils.IsNodeMap(value) {
+						for m := 0; m < len(value.Content)-1; m += 2 {
+							mediaType := value.Content[m].Value
+							if mediaTypeSyntax(mediaType) != "json" || !utils.IsNodeMap(value.Content[m+1]) {
+								continue
+							}
+							_, schema := utils.FindKeyNodeTop("schema", value.Content[m+1].Content)
+							traverse(schema, fmt.Sprintf("%s.%s.schema", childPath, mediaType), mediaType,
+								make(map[*yaml.Node]bool))
+						}
+						continue
+					}
+				}
+				walk(value, childPath)
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
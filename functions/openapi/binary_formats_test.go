@@ -0,0 +1,127 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var binaryFormatsTestSpec = `openapi: 3.0.3
+paths:
+  /pizza:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pizza'
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                photo:
+                  type: string
+                  format: binary
+      responses:
+        "200":
+          content:
+            application/vnd.pizza+json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pizza'
+            application/octet-stream:
+              schema:
+                type: string
+                format: binary
+components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        photo:
+          type: string
+          format: binary
+        thumbnail:
+          type: string
+          format: byte
+        name:
+          type: string`
+
+func TestBinaryFormats_GetSchema(t *testing.T) {
+	def := BinaryFormats{}
+	assert.Equal(t, "binary_formats", def.GetSchema().Name)
+}
+
+func TestBinaryFormats_RunRule(t *testing.T) {
+	def := BinaryFormats{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestBinaryFormats_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(binaryFormatsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "binary_formats", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := BinaryFormats{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "schema has `format: binary`, but is used by JSON media type `application/json`, which "+
+		"can't hold binary data", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.photo", res[0].Path)
+	assert.Equal(t, 36, res[0].StartNode.Line)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.thumbnail", res[1].Path)
+}
+
+func TestBinaryFormats_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(binaryFormatsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "binary_formats", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = map[string]interface{}{
+		"formats": []interface{}{"byte"},
+	}
+
+	def := BinaryFormats{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.thumbnail", res[0].Path)
+}
+
+func TestBinaryFormats_RunRule_OpenAPI31(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+paths:
+  /pizza:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: string
+              format: binary`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "binary_formats", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := BinaryFormats{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
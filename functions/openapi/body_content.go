@@ -0,0 +1,136 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// BodyContent checks request bodies and responses describe their content. A request body or response with an empty
+// `content` map is meaningless, and so is a request body without `content`. Operations with a method in the
+// `methods` option (`post`, `put` and `patch` by default) are expected to have a request body, and `200`, `203` and
+// `206` responses of `get` operations are expected to have content. Operations that intentionally have no request
+// body (like triggers or actions), can be exempted with the `exemptPaths` option, which accepts paths or glob
+// patterns (like `/jobs/*/run`). Referenced request bodies and responses are resolved first.
+type BodyContent struct {
+}
+
+var defaultBodyContentMethods = []string{"post", "put", "patch"}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the BodyContent rule.
+func (bc BodyContent) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "body_content",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name: "methods",
+				Description: "methods of operations that are expected to have a request body (defaults to 'post', " +
+					"'put' and 'patch')",
+			},
+			{
+				Name:        "exemptPaths",
+				Description: "paths (or glob patterns) of operations that intentionally have no request body",
+			},
+		},
+		ErrorMessage: "'body_content' function has invalid options supplied. Example valid options are " +
+			"'methods' = ['post'] or 'exemptPaths' = ['/jobs/*/run']",
+	}
+}
+
+// RunRule will execute the BodyContent rule, based on supplied context and a supplied []*yaml.Node slice.
+func (bc BodyContent) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	methods := getStringArrayOption("methods", context.Options, defaultBodyContentMethods)
+	exemptPaths := getStringArrayOption("exemptPaths", context.Options, nil)
+
+	isExempt := func(opPath string) bool {
+		for _, p := range exemptPaths {
+			if ok, _ := path.Match(p, opPath); ok || p == opPath {
+				return true
+			}
+		}
+		return false
+	}
+
+	// content returns the content key and map of a request body or response, and false if it can't be resolved
+	// (like a reference to another file), so it can't be checked.
+	content := func(node *yaml.Node) (*yaml.Node, *yaml.Node, bool) {
+		if node = resolveSchemaReference(root, node); node == nil {
+			return nil, nil, false
+		}
+		key, value := utils.FindKeyNodeTop("content", node.Content)
+		return key, value, true
+	}
+
+	walker.ForEachOperation(root, func(op walker.Operation) bool {
+		add := func(msg string, start, end *yaml.Node, path string) {
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: start,
+				EndNode:   end,
+				Path:      path,
+				Rule:      context.Rule,
+			})
+		}
+
+		if !isExempt(op.Path) {
+			rbKey, rb := utils.FindKeyNodeTop("requestBody", op.Node.Content)
+			switch {
+			case rb == nil && containsString(methods, op.Method):
+				add(fmt.Sprintf("`%s` operation at path `%s` has no `requestBody`", op.Method, op.Path),
+					op.KeyNode, op.KeyNode, op.JSONPath)
+			case rb != nil:
+				contentKey, c, ok := content(rb)
+				switch {
+				case ok && c == nil:
+					add(fmt.Sprintf("the `requestBody` of `%s` operation at path `%s` has no `content`", op.Method,
+						op.Path), rbKey, utils.FindLastChildNodeWithLevel(rb, 0), op.JSONPath+".requestBody")
+				case ok && len(c.Content) == 0:
+					add(fmt.Sprintf("the `requestBody` of `%s` operation at path `%s` has an empty `content`",
+						op.Method, op.Path), contentKey, c, op.JSONPath+".requestBody")
+				}
+			}
+		}
+
+		_, responses := utils.FindKeyNodeTop("responses", op.Node.Content)
+		if responses == nil || !utils.IsNodeMap(responses) {
+			return !context.IsCancelled()
+		}
+		for i := 0; i < len(responses.Content)-1; i += 2 {
+			code, response := responses.Content[i], responses.Content[i+1]
+			responsePath := fmt.Sprintf("%s.responses.%s", op.JSONPath, code.Value)
+			contentKey, c, ok := content(response)
+			switch {
+			case !ok:
+				continue
+			case c != nil && utils.IsNodeMap(c) && len(c.Content) == 0:
+				add(fmt.Sprintf("response `%s` of `%s` operation at path `%s` has an empty `content`", code.Value,
+					op.Method, op.Path), contentKey, c, responsePath)
+			case contentKey == nil && op.Method == "get" &&
+				(code.Value == "200" || code.Value == "203" || code.Value == "206"):
+				add(fmt.Sprintf("response `%s` of `%s` operation at path `%s` has no `content`", code.Value,
+					op.Method, op.Path), code, utils.FindLastChildNodeWithLevel(response, 0), responsePath)
+			}
+		}
+		return !context.IsCancelled()
+	})
+	return results
+}
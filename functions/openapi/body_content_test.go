@@ -0,0 +1,100 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var bodyContentTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+        "304":
+          description: not modified
+    post:
+      requestBody:
+        content: {}
+      responses:
+        "201":
+          $ref: '#/components/responses/Created'
+    put:
+      requestBody:
+        $ref: '#/components/requestBodies/Pizza'
+  /jobs/{id}/run:
+    post:
+      responses:
+        "202":
+          description: started
+    patch:
+      requestBody:
+        $ref: 'bodies.yaml#/Job'
+components:
+  requestBodies:
+    Pizza:
+      description: a pizza
+  responses:
+    Created:
+      description: created
+      content: {}`
+
+func TestBodyContent_GetSchema(t *testing.T) {
+	def := BodyContent{}
+	assert.Equal(t, "body_content", def.GetSchema().Name)
+}
+
+func TestBodyContent_RunRule(t *testing.T) {
+	def := BodyContent{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestBodyContent_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(bodyContentTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "body_content", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := BodyContent{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 5)
+	assert.Equal(t, "response `200` of `get` operation at path `/pizza` has no `content`", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get.responses.200", res[0].Path)
+	assert.Equal(t, "the `requestBody` of `post` operation at path `/pizza` has an empty `content`", res[1].Message)
+	assert.Equal(t, 12, res[1].StartNode.Line)
+	assert.Equal(t, "response `201` of `post` operation at path `/pizza` has an empty `content`", res[2].Message)
+	assert.Equal(t, 34, res[2].StartNode.Line)
+	assert.Equal(t, "the `requestBody` of `put` operation at path `/pizza` has no `content`", res[3].Message)
+	assert.Equal(t, "$.paths./pizza.put.requestBody", res[3].Path)
+	assert.Equal(t, "`post` operation at path `/jobs/{id}/run` has no `requestBody`", res[4].Message)
+	assert.Equal(t, "$.paths./jobs/{id}/run.post", res[4].Path)
+}
+
+func TestBodyContent_RunRule_ExemptPaths(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(bodyContentTestSpec), path)
+
+	opts := map[string]interface{}{"exemptPaths": []interface{}{"/jobs/*/run"}, "methods": []interface{}{"post"}}
+	rule := buildOpenApiTestRuleAction(path, "body_content", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := BodyContent{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	for _, r := range res {
+		assert.NotContains(t, r.Path, "/jobs")
+	}
+}
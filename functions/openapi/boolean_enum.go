@@ -0,0 +1,96 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// BooleanEnum checks `boolean` schemas don't use an `enum`. An enum of `true` and `false` allows every boolean, so
+// it does nothing, and an enum of a single value is a disguised `const`, which is reported in OpenAPI 3.1 documents
+// (that have `const`). Nullable booleans (`type: [boolean, "null"]`, or `nullable: true` in 3.0) are different, an
+// enum like `[true, null]` or `[true, false]` really does constrain them, only an enum allowing every value (including
+// `null`) is reported. Members that are not booleans are left to the enum type rules.
+type BooleanEnum struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the BooleanEnum rule.
+func (be BooleanEnum) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "boolean_enum",
+	}
+}
+
+// RunRule will execute the BooleanEnum rule, based on supplied context and a supplied []*yaml.Node slice.
+func (be BooleanEnum) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	_, version := utils.FindKeyNodeTop("openapi", root.Content)
+	hasConst := version != nil && strings.HasPrefix(version.Value, "3.1")
+
+	walker.ForEachSchema(root, func(schema walker.Schema) bool {
+		enumKey, enum := utils.FindKeyNodeTop("enum", schema.Node.Content)
+		types := schemaTypesOf(schema.Node)
+		if enum == nil || !utils.IsNodeArray(enum) || len(types) != 1 || types[0] != "boolean" {
+			return !context.IsCancelled()
+		}
+
+		nullable := false
+		if _, typeNode := utils.FindKeyNodeTop("type", schema.Node.Content); utils.IsNodeArray(typeNode) {
+			for _, t := range typeNode.Content {
+				nullable = nullable || t.Value == "null"
+			}
+		}
+		if _, n := utils.FindKeyNodeTop("nullable", schema.Node.Content); n != nil && n.Value == "true" {
+			nullable = true
+		}
+
+		allowed := make(map[string]bool)
+		for _, member := range enum.Content {
+			switch enumMemberType(member) {
+			case "boolean":
+				allowed[strings.ToLower(member.Value)] = true
+			case "null":
+				allowed["null"] = true
+			}
+		}
+
+		var msg string
+		switch {
+		case allowed["true"] && allowed["false"] && (!nullable || allowed["null"]):
+			msg = "boolean schema has an `enum` that allows every value, it does nothing and can be removed"
+		case !nullable && hasConst && len(allowed) == 1 && !allowed["null"]:
+			for value := range allowed {
+				msg = fmt.Sprintf("boolean schema has an `enum` with a single value, use `const: %s`", value)
+			}
+		default:
+			return !context.IsCancelled()
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message:   msg,
+			StartNode: enumKey,
+			EndNode:   utils.FindLastChildNodeWithLevel(enum, 0),
+			Path:      schema.JSONPath,
+			Rule:      context.Rule,
+		})
+		return !context.IsCancelled()
+	})
+	return results
+}
@@ -0,0 +1,95 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBooleanEnum_GetSchema(t *testing.T) {
+	def := BooleanEnum{}
+	assert.Equal(t, "boolean_enum", def.GetSchema().Name)
+}
+
+func TestBooleanEnum_RunRule(t *testing.T) {
+	def := BooleanEnum{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestBooleanEnum_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        vegan:
+          type: boolean
+          enum: [true, false]
+        hot:
+          type: boolean
+          enum: [true]
+        spicy:
+          type: [boolean, "null"]
+          enum: [true, null]
+        cheesy:
+          type: [boolean, "null"]
+          enum: [true, false]
+        baked:
+          type: [boolean, "null"]
+          enum: [true, false, null]
+        sliced:
+          type: boolean`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "boolean_enum", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := BooleanEnum{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "boolean schema has an `enum` that allows every value, it does nothing and can be removed",
+		res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.vegan", res[0].Path)
+	assert.Equal(t, 9, res[0].StartNode.Line)
+	assert.Equal(t, "boolean schema has an `enum` with a single value, use `const: true`", res[1].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.hot", res[1].Path)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.baked", res[2].Path)
+}
+
+func TestBooleanEnum_RunRule_OpenAPI30(t *testing.T) {
+
+	yml := `openapi: 3.0.3
+components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        hot:
+          type: boolean
+          enum: [true]
+        spicy:
+          type: boolean
+          nullable: true
+          enum: [false, true]`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "boolean_enum", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := BooleanEnum{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
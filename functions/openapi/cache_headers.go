@@ -0,0 +1,118 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// CacheHeaders checks that successful (`2xx`) responses of `get` operations declare at least one caching header,
+// from the `headers` option (defaults to `Cache-Control` and `ETag`, matched without case). Responses that are local
+// references are resolved. Operations or responses that are deliberately not cacheable can be exempted with an
+// extension, named by the `exemptExtension` option (defaults to `x-no-cache`), set to anything but `false`.
+type CacheHeaders struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the CacheHeaders rule.
+func (ch CacheHeaders) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "cache_headers",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "headers",
+				Description: "the caching headers, at least one of them must be declared (defaults to Cache-Control and ETag)",
+			},
+			{
+				Name:        "exemptExtension",
+				Description: "the extension that marks an operation or response as not cacheable (defaults to x-no-cache)",
+			},
+		},
+		ErrorMessage: "'cache_headers' function has invalid options supplied. Example valid options are " +
+			"'headers' = ['Cache-Control', 'ETag', 'Last-Modified'] or 'exemptExtension' = 'x-no-cache'",
+	}
+}
+
+// RunRule will execute the CacheHeaders rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ch CacheHeaders) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	headers := getStringArrayOption("headers", context.Options, []string{"Cache-Control", "ETag"})
+	exemptExtension := getStringOption("exemptExtension", context.Options, "x-no-cache")
+
+	expected := make(map[string]bool)
+	for _, h := range headers {
+		expected[strings.ToLower(h)] = true
+	}
+
+	exempt := func(node *yaml.Node) bool {
+		_, extension := utils.FindKeyNodeTop(exemptExtension, node.Content)
+		return extension != nil && extension.Value != "false"
+	}
+
+	_, paths := utils.FindKeyNodeTop("paths", root.Content)
+	if !utils.IsNodeMap(paths) {
+		return results
+	}
+	for i := 0; i < len(paths.Content)-1; i += 2 {
+		if context.IsCancelled() {
+			break
+		}
+		opPath, pathItem := paths.Content[i].Value, paths.Content[i+1]
+		_, operation := utils.FindKeyNodeTop("get", pathItem.Content)
+		if !utils.IsNodeMap(operation) || exempt(operation) {
+			continue
+		}
+		_, responses := utils.FindKeyNodeTop("responses", operation.Content)
+		if !utils.IsNodeMap(responses) {
+			continue
+		}
+		for r := 0; r < len(responses.Content)-1; r += 2 {
+			code, response := responses.Content[r], responses.Content[r+1]
+			if !strings.HasPrefix(code.Value, "2") {
+				continue
+			}
+			if _, ref := utils.FindKeyNodeTop("$ref", response.Content); ref != nil {
+				if resolved := resolveLocalReference(root, ref.Value); resolved != nil {
+					response = resolved
+				}
+			}
+			if !utils.IsNodeMap(response) || exempt(response) {
+				continue
+			}
+			declared := false
+			if _, h := utils.FindKeyNodeTop("headers", response.Content); utils.IsNodeMap(h) {
+				for n := 0; n < len(h.Content)-1; n += 2 {
+					declared = declared || expected[strings.ToLower(h.Content[n].Value)]
+				}
+			}
+			if declared {
+				continue
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("`%s` response of the `get` operation at path `%s` declares no caching "+
+					"header (`%s`)", code.Value, opPath, strings.Join(headers, "`, `")),
+				StartNode: code,
+				EndNode:   utils.FindLastChildNodeWithLevel(response, 0),
+				Path:      fmt.Sprintf("$.paths.%s.get.responses.%s", opPath, code.Value),
+				Rule:      context.Rule,
+			})
+		}
+	}
+	return results
+}
@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var cacheHeadersTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+        "404":
+          description: not found
+    post:
+      responses:
+        "201":
+          description: created
+  /pizza/{id}:
+    get:
+      responses:
+        "200":
+          description: ok
+          headers:
+            etag:
+              schema:
+                type: string
+        "206":
+          $ref: '#/components/responses/Partial'
+  /pizza/{id}/live:
+    get:
+      x-no-cache: true
+      responses:
+        "200":
+          description: ok
+  /menu:
+    get:
+      responses:
+        2XX:
+          description: ok
+          headers:
+            Last-Modified:
+              schema:
+                type: string
+components:
+  responses:
+    Partial:
+      description: partial content
+      x-partial: true`
+
+func TestCacheHeaders_GetSchema(t *testing.T) {
+	def := CacheHeaders{}
+	assert.Equal(t, "cache_headers", def.GetSchema().Name)
+}
+
+func TestCacheHeaders_RunRule(t *testing.T) {
+	def := CacheHeaders{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestCacheHeaders_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(cacheHeadersTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "cache_headers", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := CacheHeaders{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "`200` response of the `get` operation at path `/pizza` declares no caching header "+
+		"(`Cache-Control`, `ETag`)", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get.responses.200", res[0].Path)
+	assert.Equal(t, 6, res[0].StartNode.Line)
+	assert.Equal(t, "$.paths./pizza/{id}.get.responses.206", res[1].Path)
+	assert.Equal(t, "$.paths./menu.get.responses.2XX", res[2].Path)
+}
+
+func TestCacheHeaders_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(cacheHeadersTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["headers"] = []interface{}{"Cache-Control", "ETag", "Last-Modified"}
+	opts["exemptExtension"] = "x-partial"
+
+	rule := buildOpenApiTestRuleAction(path, "cache_headers", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := CacheHeaders{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "$.paths./pizza.get.responses.200", res[0].Path)
+	assert.Equal(t, "$.paths./pizza/{id}/live.get.responses.200", res[1].Path)
+}
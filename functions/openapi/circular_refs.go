@@ -0,0 +1,225 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// CircularRefs detects cycles in the $ref graph between schemas. Each cycle is reported once, with the chain of
+// schema names involved, at the $ref where the cycle is entered. A schema that references itself directly
+// (a tree node with children of the same type for example) is reported separately, so it can be ignored with
+// the `ignoreSelfReferences` option. Schemas named in the `allow` option are never reported.
+type CircularRefs struct {
+}
+
+type schemaRefEdge struct {
+	target  string
+	keyNode *yaml.Node
+	valNode *yaml.Node
+	path    string
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the CircularRefs rule.
+func (cr CircularRefs) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "circular_refs",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "allow",
+				Description: "a list of schema names that are allowed to be part of a cycle",
+			},
+			{
+				Name:        "ignoreSelfReferences",
+				Description: "ignore schemas that directly reference themselves (defaults to false)",
+			},
+		},
+		ErrorMessage: "'circular_refs' function has invalid options supplied.",
+	}
+}
+
+// RunRule will execute the CircularRefs rule, based on supplied context and a supplied []*yaml.Node slice.
+func (cr CircularRefs) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	allowed := make(map[string]bool)
+	for _, a := range getStringArrayOption("allow", context.Options, nil) {
+		allowed[strings.TrimSpace(a)] = true
+	}
+	ignoreSelf := getBoolOption("ignoreSelfReferences", context.Options, false)
+
+	// locate the schema definitions, either swagger definitions or openapi component schemas.
+	prefix := "#/components/schemas/"
+	basePath := "$.components.schemas"
+	_, schemas := utils.FindKeyNodeTop("definitions", root.Content)
+	if schemas != nil {
+		prefix = "#/definitions/"
+		basePath = "$.definitions"
+	} else {
+		_, components := utils.FindKeyNodeTop("components", root.Content)
+		if components != nil {
+			_, schemas = utils.FindKeyNodeTop("schemas", components.Content)
+		}
+	}
+	if schemas == nil || !utils.IsNodeMap(schemas) {
+		return results
+	}
+
+	// build the graph, schema name -> outgoing references to other schemas (in document order).
+	var names []string
+	defined := make(map[string]bool)
+	graph := make(map[string][]schemaRefEdge)
+	for i := 0; i < len(schemas.Content)-1; i += 2 {
+		if context.IsCancelled() {
+			return results
+		}
+		name := schemas.Content[i].Value
+		names = append(names, name)
+		defined[name] = true
+		var collect func(node *yaml.Node, path string)
+		collect = func(node *yaml.Node, path string) {
+			switch node.Kind {
+			case yaml.SequenceNode:
+				for x, n := range node.Content {
+					collect(n, fmt.Sprintf("%s[%d]", path, x))
+				}
+			case yaml.MappingNode:
+				for x := 0; x < len(node.Content)-1; x += 2 {
+					key, value := node.Content[x], node.Content[x+1]
+					childPath := fmt.Sprintf("%s.%s", path, key.Value)
+					if key.Value == "$ref" && utils.IsNodeStringValue(value) {
+						if strings.HasPrefix(value.Value, prefix) {
+							target := strings.Split(strings.TrimPrefix(value.Value, prefix), "/")[0]
+							graph[name] = append(graph[name], schemaRefEdge{
+								target:  target,
+								keyNode: key,
+								valNode: value,
+								path:    childPath,
+							})
+						}
+						continue
+					}
+					collect(value, childPath)
+				}
+			}
+		}
+		collect(schemas.Content[i+1], fmt.Sprintf("%s.%s", basePath, name))
+	}
+
+	// walk the graph, every back edge found closes a cycle.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	seenCycles := make(map[string]bool)
+	var stack []string
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, edge := range graph[name] {
+			switch state[edge.target] {
+			case unvisited:
+				if defined[edge.target] {
+					visit(edge.target)
+				}
+			case visiting:
+				// locate where the cycle starts on the stack.
+				start := 0
+				for s := len(stack) - 1; s >= 0; s-- {
+					if stack[s] == edge.target {
+						start = s
+						break
+					}
+				}
+				cycle := append([]string{}, stack[start:]...)
+				if isAllowedCycle(cycle, allowed) {
+					continue
+				}
+				key := canonicalCycleKey(cycle)
+				if seenCycles[key] {
+					continue
+				}
+				seenCycles[key] = true
+				chain := strings.Join(append(cycle, edge.target), " -> ")
+				msg := fmt.Sprintf("circular reference detected: %s", chain)
+				if len(cycle) == 1 {
+					if ignoreSelf {
+						continue
+					}
+					msg = fmt.Sprintf("schema `%s` references itself (recursive schema)", name)
+				}
+				// the cycle is entered at the reference from the first schema in the chain.
+				var entry schemaRefEdge
+				for _, e := range graph[cycle[0]] {
+					next := edge.target
+					if len(cycle) > 1 {
+						next = cycle[1]
+					}
+					if e.target == next {
+						entry = e
+						break
+					}
+				}
+				results = append(results, model.RuleFunctionResult{
+					Message:   msg,
+					StartNode: entry.keyNode,
+					EndNode:   entry.valNode,
+					Path:      entry.path,
+					Rule:      context.Rule,
+				})
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+	}
+
+	for _, name := range names {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+	return results
+}
+
+// isAllowedCycle returns true if any of the schemas in the cycle have been allow-listed.
+func isAllowedCycle(cycle []string, allowed map[string]bool) bool {
+	for _, c := range cycle {
+		if allowed[c] {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalCycleKey rotates a cycle so it starts with the lowest name, so the same loop is only reported once,
+// regardless of where in the loop it was discovered.
+func canonicalCycleKey(cycle []string) string {
+	lowest := 0
+	for i := range cycle {
+		if cycle[i] < cycle[lowest] {
+			lowest = i
+		}
+	}
+	rotated := append(append([]string{}, cycle[lowest:]...), cycle[:lowest]...)
+	return strings.Join(rotated, "|")
+}
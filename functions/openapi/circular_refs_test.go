@@ -0,0 +1,161 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var circularRefsTestSpec = `openapi: 3.0.1
+components:
+  schemas:
+    TreeNode:
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/TreeNode'
+    Pizza:
+      type: object
+      properties:
+        topping:
+          $ref: '#/components/schemas/Topping'
+    Topping:
+      type: object
+      properties:
+        sauce:
+          $ref: '#/components/schemas/Sauce'
+    Sauce:
+      type: object
+      properties:
+        pizza:
+          $ref: '#/components/schemas/Pizza'
+    Crust:
+      type: object
+      properties:
+        topping:
+          $ref: '#/components/schemas/Topping'`
+
+func TestCircularRefs_GetSchema(t *testing.T) {
+	def := CircularRefs{}
+	assert.Equal(t, "circular_refs", def.GetSchema().Name)
+}
+
+func TestCircularRefs_RunRule(t *testing.T) {
+	def := CircularRefs{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestCircularRefs_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(circularRefsTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(circularRefsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "circular_refs", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := CircularRefs{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "schema `TreeNode` references itself (recursive schema)", res[0].Message)
+	assert.Equal(t, "$.components.schemas.TreeNode.properties.children.items.$ref", res[0].Path)
+	assert.Equal(t, "circular reference detected: Pizza -> Topping -> Sauce -> Pizza", res[1].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.topping.$ref", res[1].Path)
+}
+
+func TestCircularRefs_RunRule_IgnoreSelf(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(circularRefsTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(circularRefsTestSpec), path)
+
+	opts := map[string]interface{}{
+		"ignoreSelfReferences": true,
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "circular_refs", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := CircularRefs{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "circular reference detected: Pizza -> Topping -> Sauce -> Pizza", res[0].Message)
+}
+
+func TestCircularRefs_RunRule_Allow(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(circularRefsTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(circularRefsTestSpec), path)
+
+	opts := map[string]interface{}{
+		"allow": []interface{}{"TreeNode", "Sauce"},
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "circular_refs", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := CircularRefs{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
+
+func TestCircularRefs_RunRule_Swagger(t *testing.T) {
+
+	yml := `swagger: 2.0
+definitions:
+  Cat:
+    properties:
+      dog:
+        $ref: '#/definitions/Dog'
+  Dog:
+    properties:
+      cat:
+        $ref: '#/definitions/Cat/properties/dog'`
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "circular_refs", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := CircularRefs{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "circular reference detected: Cat -> Dog -> Cat", res[0].Message)
+	assert.Equal(t, "$.definitions.Cat.properties.dog.$ref", res[0].Path)
+}
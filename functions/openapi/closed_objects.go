@@ -0,0 +1,327 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ClosedObjects checks nothing adds properties to closed objects (schemas with `additionalProperties: false`) that
+// they would reject. Examples (of schemas, media types, parameters and headers) are checked against their schema,
+// including nested objects, and properties declared by the other members of an `allOf` (or the schema holding it)
+// are checked against each closed member, as every member is validated on its own. Properties matching one of the
+// `patternProperties` of a closed object are allowed. References are resolved.
+type ClosedObjects struct {
+}
+
+// closedProperties are the property names and patterns a closed object allows.
+type closedProperties struct {
+	names    map[string]bool
+	patterns []*regexp.Regexp
+}
+
+// allows returns true if a closed object allows a property.
+func (cp closedProperties) allows(name string) bool {
+	if cp.names[name] {
+		return true
+	}
+	for _, p := range cp.patterns {
+		if p.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// closedObject returns the properties a schema with `additionalProperties: false` allows, and false if the schema
+// is not closed.
+func closedObject(schema *yaml.Node) (closedProperties, bool) {
+	cp := closedProperties{names: make(map[string]bool)}
+	_, additional := utils.FindKeyNodeTop("additionalProperties", schema.Content)
+	if additional == nil || additional.Tag != "!!bool" || additional.Value != "false" {
+		return cp, false
+	}
+	if _, props := utils.FindKeyNodeTop("properties", schema.Content); props != nil && utils.IsNodeMap(props) {
+		for i := 0; i < len(props.Content)-1; i += 2 {
+			cp.names[props.Content[i].Value] = true
+		}
+	}
+	if _, patterns := utils.FindKeyNodeTop("patternProperties", schema.Content); patterns != nil &&
+		utils.IsNodeMap(patterns) {
+		for i := 0; i < len(patterns.Content)-1; i += 2 {
+			if p, err := regexp.Compile(patterns.Content[i].Value); err == nil {
+				cp.patterns = append(cp.patterns, p)
+			}
+		}
+	}
+	return cp, true
+}
+
+// propertySchema returns the schema of a property, property names are case-sensitive.
+func propertySchema(schema *yaml.Node, name string) *yaml.Node {
+	_, props := utils.FindKeyNodeTop("properties", schema.Content)
+	if props == nil || !utils.IsNodeMap(props) {
+		return nil
+	}
+	for i := 0; i < len(props.Content)-1; i += 2 {
+		if props.Content[i].Value == name {
+			return props.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// declaredProperty is a property declared by a schema, or one of its `allOf` members.
+type declaredProperty struct {
+	key    *yaml.Node
+	schema *yaml.Node
+	path   string
+}
+
+// ownProperties returns the properties a schema declares in `properties`.
+func ownProperties(schema *yaml.Node, path string) map[string]declaredProperty {
+	found := make(map[string]declaredProperty)
+	_, props := utils.FindKeyNodeTop("properties", schema.Content)
+	if props == nil || !utils.IsNodeMap(props) {
+		return found
+	}
+	for i := 0; i < len(props.Content)-1; i += 2 {
+		found[props.Content[i].Value] = declaredProperty{
+			key:    props.Content[i],
+			schema: props.Content[i+1],
+			path:   fmt.Sprintf("%s.properties.%s", path, props.Content[i].Value),
+		}
+	}
+	return found
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ClosedObjects rule.
+func (co ClosedObjects) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "closed_objects",
+	}
+}
+
+// RunRule will execute the ClosedObjects rule, based on supplied context and a supplied []*yaml.Node slice.
+func (co ClosedObjects) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	// checkExample checks an example value against a schema, and the values of its properties (and items) against
+	// their schemas.
+	var checkExample func(schema, value *yaml.Node, path string, depth int)
+	checkExample = func(schema, value *yaml.Node, path string, depth int) {
+		if schema = resolveSchemaReference(root, schema); schema == nil || depth > 32 {
+			return
+		}
+		switch value.Kind {
+		case yaml.MappingNode:
+			cp, closed := closedObject(schema)
+			for i := 0; i < len(value.Content)-1; i += 2 {
+				name := value.Content[i]
+				propertyPath := fmt.Sprintf("%s.%s", path, name.Value)
+				if closed && !cp.allows(name.Value) {
+					results = append(results, model.RuleFunctionResult{
+						Message: fmt.Sprintf("example property `%s` is not allowed, the schema has "+
+							"`additionalProperties: false`", name.Value),
+						StartNode: name,
+						EndNode:   utils.FindLastChildNodeWithLevel(value.Content[i+1], 0),
+						Path:      propertyPath,
+						Rule:      context.Rule,
+					})
+					continue
+				}
+				if property := propertySchema(schema, name.Value); property != nil {
+					checkExample(property, value.Content[i+1], propertyPath, depth+1)
+				}
+			}
+		case yaml.SequenceNode:
+			_, items := utils.FindKeyNodeTop("items", schema.Content)
+			if items == nil || !utils.IsNodeMap(items) {
+				return
+			}
+			for i, item := range value.Content {
+				checkExample(items, item, fmt.Sprintf("%s[%d]", path, i), depth+1)
+			}
+		}
+	}
+
+	// declared collects the properties declared by a schema, and its `allOf` members.
+	var declared func(schema *yaml.Node, path string, seen map[*yaml.Node]bool) map[string]declaredProperty
+	declared = func(schema *yaml.Node, path string, seen map[*yaml.Node]bool) map[string]declaredProperty {
+		if _, ref := utils.FindKeyNodeTop("$ref", schema.Content); ref != nil {
+			_, path = utils.ConvertComponentIdIntoPath(ref.Value)
+		}
+		if schema = resolveSchemaReference(root, schema); schema == nil || seen[schema] {
+			return nil
+		}
+		seen[schema] = true
+		found := ownProperties(schema, path)
+		if _, allOf := utils.FindKeyNodeTop("allOf", schema.Content); allOf != nil && utils.IsNodeArray(allOf) {
+			for i, member := range allOf.Content {
+				for name, p := range declared(member, fmt.Sprintf("%s.allOf[%d]", path, i), seen) {
+					if _, ok := found[name]; !ok {
+						found[name] = p
+					}
+				}
+			}
+		}
+		return found
+	}
+
+	// reject reports the properties a closed object doesn't allow, in document order.
+	reject := func(cp closedProperties, properties map[string]declaredProperty, rejectedBy string) {
+		var rejected []declaredProperty
+		for name, p := range properties {
+			if !cp.allows(name) {
+				rejected = append(rejected, p)
+			}
+		}
+		sortDeclaredProperties(rejected)
+		for _, p := range rejected {
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("property `%s` is rejected by %s, which has `additionalProperties: false`",
+					p.key.Value, rejectedBy),
+				StartNode: p.key,
+				EndNode:   p.key,
+				Path:      p.path,
+				Rule:      context.Rule,
+			})
+		}
+	}
+
+	// checkAllOf checks the properties declared by each member of an `allOf` (and the schema holding it) against
+	// the closed members, and the schema holding it, if it is closed.
+	checkAllOf := func(schema walker.Schema) {
+		_, allOf := utils.FindKeyNodeTop("allOf", schema.Node.Content)
+		if allOf == nil || !utils.IsNodeArray(allOf) {
+			return
+		}
+		membersExcept := func(skip int, seen map[*yaml.Node]bool) map[string]declaredProperty {
+			found := make(map[string]declaredProperty)
+			for j, member := range allOf.Content {
+				if j == skip {
+					continue
+				}
+				for name, p := range declared(member, fmt.Sprintf("%s.allOf[%d]", schema.JSONPath, j), seen) {
+					if _, ok := found[name]; !ok {
+						found[name] = p
+					}
+				}
+			}
+			return found
+		}
+
+		if cp, closed := closedObject(schema.Node); closed {
+			reject(cp, membersExcept(-1, map[*yaml.Node]bool{schema.Node: true}), "the schema holding the `allOf`")
+		}
+
+		own := ownProperties(schema.Node, schema.JSONPath)
+		for i, member := range allOf.Content {
+			resolved := resolveSchemaReference(root, member)
+			if resolved == nil {
+				continue
+			}
+			cp, closed := closedObject(resolved)
+			if !closed {
+				continue
+			}
+			rejectedBy := fmt.Sprintf("`allOf` member %d", i)
+			if _, ref := utils.FindKeyNodeTop("$ref", member.Content); ref != nil {
+				rejectedBy = fmt.Sprintf("%s (`%s`)", rejectedBy, ref.Value)
+			}
+			// the closed member's own properties (and allOf) are checked when the member is visited.
+			others := membersExcept(i, map[*yaml.Node]bool{schema.Node: true, resolved: true})
+			for name, p := range own {
+				others[name] = p
+			}
+			reject(cp, others, rejectedBy)
+		}
+	}
+
+	walker.ForEachSchema(root, func(schema walker.Schema) bool {
+		if _, example := utils.FindKeyNodeTop("example", schema.Node.Content); example != nil {
+			checkExample(schema.Node, example, schema.JSONPath+".example", 0)
+		}
+		if _, examples := utils.FindKeyNodeTop("examples", schema.Node.Content); examples != nil &&
+			utils.IsNodeArray(examples) {
+			for i, example := range examples.Content {
+				checkExample(schema.Node, example, fmt.Sprintf("%s.examples[%d]", schema.JSONPath, i), 0)
+			}
+		}
+		checkAllOf(schema)
+		return !context.IsCancelled()
+	})
+
+	// media types, parameters and headers have examples of their own.
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			_, schema := utils.FindKeyNodeTop("schema", node.Content)
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				switch key.Value {
+				case "example":
+					if schema != nil {
+						checkExample(schema, value, childPath, 0)
+					}
+				case "examples":
+					if schema == nil || !utils.IsNodeMap(value) {
+						continue
+					}
+					for e := 0; e < len(value.Content)-1; e += 2 {
+						example := resolveSchemaReference(root, value.Content[e+1])
+						if example == nil {
+							continue
+						}
+						if _, v := utils.FindKeyNodeTop("value", example.Content); v != nil {
+							checkExample(schema, v, fmt.Sprintf("%s.%s.value", childPath, value.Content[e].Value), 0)
+						}
+					}
+				case "schema", "properties", "schemas", "definitions":
+					// schemas are checked by the schema walker.
+				default:
+					walk(value, childPath)
+				}
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
+
+// sortDeclaredProperties sorts properties by where they are declared in the document.
+func sortDeclaredProperties(properties []declaredProperty) {
+	sort.SliceStable(properties, func(i, j int) bool {
+		if properties[i].key.Line != properties[j].key.Line {
+			return properties[i].key.Line < properties[j].key.Line
+		}
+		return properties[i].key.Column < properties[j].key.Column
+	})
+}
@@ -0,0 +1,195 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClosedObjects_GetSchema(t *testing.T) {
+	def := ClosedObjects{}
+	assert.Equal(t, "closed_objects", def.GetSchema().Name)
+}
+
+func TestClosedObjects_RunRule(t *testing.T) {
+	def := ClosedObjects{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestClosedObjects_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+paths:
+  /pizza:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pizza'
+            examples:
+              margherita:
+                $ref: '#/components/examples/Margherita'
+components:
+  examples:
+    Margherita:
+      value:
+        name: margherita
+        crust: thin
+        x-cheese: mozzarella
+        toppings:
+          - name: basil
+            fresh: true
+  schemas:
+    Pizza:
+      type: object
+      additionalProperties: false
+      properties:
+        name:
+          type: string
+        toppings:
+          type: array
+          items:
+            $ref: '#/components/schemas/Topping'
+      patternProperties:
+        "^x-":
+          type: string
+      example:
+        name: hawaiian
+        pineapple: true
+    Topping:
+      type: object
+      additionalProperties: false
+      properties:
+        name:
+          type: string
+    SpecialPizza:
+      allOf:
+        - $ref: '#/components/schemas/Pizza'
+        - type: object
+          properties:
+            x-chef:
+              type: string
+            discount:
+              type: number
+      properties:
+        special:
+          type: boolean`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "closed_objects", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ClosedObjects{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 5)
+	assert.Equal(t, "example property `pineapple` is not allowed, the schema has `additionalProperties: false`",
+		res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.example.pineapple", res[0].Path)
+	assert.Equal(t, "property `discount` is rejected by `allOf` member 0 (`#/components/schemas/Pizza`), which "+
+		"has `additionalProperties: false`", res[1].Message)
+	assert.Equal(t, "$.components.schemas.SpecialPizza.allOf[1].properties.discount", res[1].Path)
+	assert.Equal(t, "property `special` is rejected by `allOf` member 0 (`#/components/schemas/Pizza`), which "+
+		"has `additionalProperties: false`", res[2].Message)
+	assert.Equal(t, "$.components.schemas.SpecialPizza.properties.special", res[2].Path)
+	assert.Equal(t, "example property `crust` is not allowed, the schema has `additionalProperties: false`",
+		res[3].Message)
+	assert.Equal(t, "$.paths./pizza.post.requestBody.content.application/json.examples.margherita.value.crust",
+		res[3].Path)
+	assert.Equal(t, 18, res[3].StartNode.Line)
+	assert.Equal(t, "example property `fresh` is not allowed, the schema has `additionalProperties: false`",
+		res[4].Message)
+	assert.Equal(t, "$.paths./pizza.post.requestBody.content.application/json.examples.margherita.value.toppings[0].fresh",
+		res[4].Path)
+}
+
+func TestClosedObjects_RunRule_ClosedComposition(t *testing.T) {
+
+	yml := `openapi: 3.0.3
+components:
+  schemas:
+    Pizza:
+      type: object
+      additionalProperties: false
+      allOf:
+        - type: object
+          properties:
+            name:
+              type: string
+      properties:
+        size:
+          type: string`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "closed_objects", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ClosedObjects{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "property `name` is rejected by the schema holding the `allOf`, which has "+
+		"`additionalProperties: false`", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.allOf[0].properties.name", res[0].Path)
+}
+
+func TestClosedObjects_RunRule_Success(t *testing.T) {
+
+	yml := `openapi: 3.0.3
+paths:
+  /pizza:
+    get:
+      parameters:
+        - name: filter
+          in: query
+          schema:
+            type: object
+            additionalProperties: false
+            properties:
+              name:
+                type: string
+          example:
+            name: margherita
+components:
+  schemas:
+    Pizza:
+      type: object
+      additionalProperties: false
+      properties:
+        name:
+          type: string
+      example:
+        name: margherita
+    Open:
+      allOf:
+        - type: object
+          properties:
+            name:
+              type: string
+        - type: object
+          properties:
+            size:
+              type: string`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "closed_objects", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ClosedObjects{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/daveshanley/vacuum/model"
+	"gopkg.in/yaml.v3"
+)
+
+// CollectionLimits checks that `enum` lists have no more than `maxEnum` values (defaults to 100), and that
+// `examples` (maps of named examples, or lists in schemas) have no more than `maxExamples` entries (defaults to 10).
+// Huge lists bloat documentation and slow down generated code. Schemas that are legitimately large (like a list
+// of currency codes) can be allowed with the `allowedSchemas` option, which accepts component (or `definitions`)
+// schema names, or glob patterns. Everything nested in an allowed schema is allowed too.
+type CollectionLimits struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the CollectionLimits rule.
+func (cl CollectionLimits) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "collection_limits",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "maxEnum",
+				Description: "the maximum number of values in an enum (defaults to 100)",
+			},
+			{
+				Name:        "maxExamples",
+				Description: "the maximum number of entries in examples (defaults to 10)",
+			},
+			{
+				Name:        "allowedSchemas",
+				Description: "names (or glob patterns) of schemas that are allowed to be larger",
+			},
+		},
+		ErrorMessage: "'collection_limits' function has invalid options supplied. Example valid options are " +
+			"'maxEnum' = 50, 'maxExamples' = 5 or 'allowedSchemas' = ['CurrencyCode']",
+	}
+}
+
+// RunRule will execute the CollectionLimits rule, based on supplied context and a supplied []*yaml.Node slice.
+func (cl CollectionLimits) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	maxEnum := getIntOption("maxEnum", context.Options, 100)
+	maxExamples := getIntOption("maxExamples", context.Options, 10)
+	allowedSchemas := getStringArrayOption("allowedSchemas", context.Options, nil)
+
+	isAllowed := func(name string) bool {
+		for _, a := range allowedSchemas {
+			if match, _ := path.Match(a, name); match || a == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	check := func(key, value *yaml.Node, keyPath string) {
+		var size, limit int
+		switch {
+		case key.Value == "enum" && value.Kind == yaml.SequenceNode:
+			size, limit = len(value.Content), maxEnum
+		case key.Value == "examples" && value.Kind == yaml.SequenceNode:
+			size, limit = len(value.Content), maxExamples
+		case key.Value == "examples" && value.Kind == yaml.MappingNode:
+			size, limit = len(value.Content)/2, maxExamples
+		default:
+			return
+		}
+		if size <= limit {
+			return
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message:   fmt.Sprintf("`%s` has %d entries, more than the limit of %d", key.Value, size, limit),
+			StartNode: key,
+			EndNode:   value,
+			Path:      keyPath,
+			Rule:      context.Rule,
+		})
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+
+				// named schemas are `components.schemas.<name>` and `definitions.<name>`.
+				if (path == "$.components.schemas" || path == "$.definitions") && isAllowed(key.Value) {
+					continue
+				}
+				if key.Value == "example" {
+					continue
+				}
+				// a property can be named `enum`, it's a schema and is walked.
+				if key.Value == "examples" || key.Value == "enum" && value.Kind != yaml.MappingNode {
+					check(key, value, childPath)
+					continue
+				}
+				walk(value, childPath)
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
@@ -0,0 +1,116 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var collectionLimitsTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      parameters:
+        - name: size
+          in: query
+          schema:
+            type: string
+            enum: [small, medium, large, huge]
+          examples:
+            small:
+              value: small
+            medium:
+              value: medium
+            large:
+              value: large
+components:
+  schemas:
+    CurrencyCode:
+      type: string
+      enum: [EUR, GBP, USD, JPY]
+    Topping:
+      type: object
+      properties:
+        name:
+          type: string
+          enum: [cheese, ham, pineapple]
+          examples: [cheese, ham, pineapple]
+          example:
+            enum: [a, b, c, d, e]`
+
+func TestCollectionLimits_GetSchema(t *testing.T) {
+	def := CollectionLimits{}
+	assert.Equal(t, "collection_limits", def.GetSchema().Name)
+}
+
+func TestCollectionLimits_RunRule(t *testing.T) {
+	def := CollectionLimits{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestCollectionLimits_RunRule_Defaults(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(collectionLimitsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "collection_limits", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := CollectionLimits{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
+
+func TestCollectionLimits_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(collectionLimitsTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["maxEnum"] = 3
+	opts["maxExamples"] = 2
+
+	rule := buildOpenApiTestRuleAction(path, "collection_limits", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := CollectionLimits{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "`enum` has 4 entries, more than the limit of 3", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get.parameters[0].schema.enum", res[0].Path)
+	assert.Equal(t, 10, res[0].StartNode.Line)
+	assert.Equal(t, "`examples` has 3 entries, more than the limit of 2", res[1].Message)
+	assert.Equal(t, "$.paths./pizza.get.parameters[0].examples", res[1].Path)
+	assert.Equal(t, "$.components.schemas.CurrencyCode.enum", res[2].Path)
+	assert.Equal(t, "$.components.schemas.Topping.properties.name.examples", res[3].Path)
+}
+
+func TestCollectionLimits_RunRule_AllowedSchemas(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(collectionLimitsTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["maxEnum"] = 2
+	opts["maxExamples"] = 10
+	opts["allowedSchemas"] = []interface{}{"Currency*", "Topping"}
+
+	rule := buildOpenApiTestRuleAction(path, "collection_limits", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := CollectionLimits{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.paths./pizza.get.parameters[0].schema.enum", res[0].Path)
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// CollectionPagination checks that GET operations returning a collection (a successful response with an array at
+// the top level) support pagination. An operation is paginated if it defines every parameter of at least one of the
+// sets in the `parameters` option, each set is a comma separated list of names (like `limit,offset`). Names are
+// compared without case, path-level parameters count as well. Operations that return an object wrapping an array are
+// not checked, as the wrapper usually carries its own paging metadata.
+type CollectionPagination struct {
+}
+
+var defaultPaginationParameters = []string{"limit,offset", "page,pageSize", "page,per_page", "cursor"}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the CollectionPagination rule.
+func (cp CollectionPagination) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "collection_pagination",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name: "parameters",
+				Description: "sets of pagination parameters (comma separated names), an operation must define " +
+					"every parameter of at least one set",
+			},
+		},
+		ErrorMessage: "'collection_pagination' function has invalid options supplied. Example valid options are " +
+			"'parameters' = ['limit,offset', 'page,pageSize']",
+	}
+}
+
+// RunRule will execute the CollectionPagination rule, based on supplied context and a supplied []*yaml.Node slice.
+func (cp CollectionPagination) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	if context.Index.GetPathsNode() == nil {
+		return results
+	}
+
+	var sets [][]string
+	var expected []string
+	for _, set := range getStringArrayOption("parameters", context.Options, defaultPaginationParameters) {
+		var names []string
+		for _, name := range strings.Split(set, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 {
+			sets = append(sets, names)
+			expected = append(expected, fmt.Sprintf("`%s`", strings.Join(names, "` and `")))
+		}
+	}
+	if len(sets) == 0 {
+		return results
+	}
+
+	root := context.Index.GetRootNode()
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	resolve := func(node *yaml.Node) *yaml.Node {
+		if node == nil || root == nil {
+			return node
+		}
+		if _, ref := utils.FindKeyNodeTop("$ref", node.Content); ref != nil {
+			return resolveLocalReference(root, ref.Value)
+		}
+		return node
+	}
+
+	paramNames := func(params *yaml.Node, names map[string]bool) {
+		if !utils.IsNodeArray(params) {
+			return
+		}
+		for _, param := range params.Content {
+			if param = resolve(param); utils.IsNodeMap(param) {
+				if _, name := utils.FindKeyNodeTop("name", param.Content); name != nil {
+					names[strings.ToLower(name.Value)] = true
+				}
+			}
+		}
+	}
+
+	// returnsArray is true if any successful response has a top level array schema.
+	returnsArray := func(operation *yaml.Node) bool {
+		_, responses := utils.FindKeyNodeTop("responses", operation.Content)
+		if !utils.IsNodeMap(responses) {
+			return false
+		}
+		for r := 0; r < len(responses.Content)-1; r += 2 {
+			if !strings.HasPrefix(responses.Content[r].Value, "2") {
+				continue
+			}
+			response := resolve(responses.Content[r+1])
+			if !utils.IsNodeMap(response) {
+				continue
+			}
+			var schemas []*yaml.Node
+			if _, schema := utils.FindKeyNodeTop("schema", response.Content); schema != nil {
+				schemas = append(schemas, schema)
+			}
+			if _, content := utils.FindKeyNodeTop("content", response.Content); utils.IsNodeMap(content) {
+				for c := 1; c < len(content.Content); c += 2 {
+					if utils.IsNodeMap(content.Content[c]) {
+						if _, schema := utils.FindKeyNodeTop("schema", content.Content[c].Content); schema != nil {
+							schemas = append(schemas, schema)
+						}
+					}
+				}
+			}
+			for _, schema := range schemas {
+				if root != nil {
+					schema = resolveSchemaReference(root, schema)
+				}
+				if schema == nil {
+					continue
+				}
+				for _, t := range schemaTypesOf(schema) {
+					if t == "array" {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+
+	ops := context.Index.GetPathsNode().Content
+	var opPath string
+	for i, op := range ops {
+		if context.IsCancelled() {
+			break
+		}
+		if i%2 == 0 {
+			opPath = op.Value
+			continue
+		}
+		if !utils.IsNodeMap(op) {
+			continue
+		}
+		getKey, get := utils.FindKeyNodeTop("get", op.Content)
+		if !utils.IsNodeMap(get) || !returnsArray(get) {
+			continue
+		}
+
+		names := make(map[string]bool)
+		_, pathParams := utils.FindKeyNodeTop("parameters", op.Content)
+		paramNames(pathParams, names)
+		_, opParams := utils.FindKeyNodeTop("parameters", get.Content)
+		paramNames(opParams, names)
+
+		paginated := false
+		for _, set := range sets {
+			found := true
+			for _, name := range set {
+				found = found && names[strings.ToLower(name)]
+			}
+			if found {
+				paginated = true
+				break
+			}
+		}
+		if paginated {
+			continue
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message: fmt.Sprintf("`get` operation at path `%s` returns a collection, but can't be paginated "+
+				"(expected %s)", opPath, strings.Join(expected, ", or ")),
+			StartNode: getKey,
+			EndNode:   utils.FindLastChildNodeWithLevel(get, 0),
+			Path:      fmt.Sprintf("$.paths.%s.get", opPath),
+			Rule:      context.Rule,
+		})
+	}
+	return results
+}
@@ -0,0 +1,149 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var collectionPaginationTestSpec = `openapi: 3.1.0
+paths:
+  /pizzas:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pizzas'
+  /toppings:
+    parameters:
+      - in: query
+        name: Limit
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Offset'
+      responses:
+        "200":
+          $ref: '#/components/responses/Toppings'
+  /orders:
+    get:
+      parameters:
+        - in: query
+          name: page
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: string
+  /bases:
+    get:
+      responses:
+        "200":
+          description: a wrapped collection
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  items:
+                    type: array
+  /pizzas/{id}:
+    get:
+      responses:
+        "404":
+          description: not found
+          content:
+            application/json:
+              schema:
+                type: array
+components:
+  parameters:
+    Offset:
+      in: query
+      name: offset
+  responses:
+    Toppings:
+      description: ok
+      content:
+        application/json:
+          schema:
+            type: array
+  schemas:
+    Pizzas:
+      type: array
+      items:
+        type: string`
+
+func TestCollectionPagination_GetSchema(t *testing.T) {
+	def := CollectionPagination{}
+	assert.Equal(t, "collection_pagination", def.GetSchema().Name)
+}
+
+func TestCollectionPagination_RunRule(t *testing.T) {
+	def := CollectionPagination{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestCollectionPagination_RunRule_Fail(t *testing.T) {
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(collectionPaginationTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(collectionPaginationTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "collection_pagination", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := CollectionPagination{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "`get` operation at path `/pizzas` returns a collection, but can't be paginated (expected "+
+		"`limit` and `offset`, or `page` and `pageSize`, or `page` and `per_page`, or `cursor`)", res[0].Message)
+	assert.Equal(t, "$.paths./pizzas.get", res[0].Path)
+	assert.Equal(t, 4, res[0].StartNode.Line)
+	assert.Equal(t, "$.paths./orders.get", res[1].Path)
+}
+
+func TestCollectionPagination_RunRule_Parameters(t *testing.T) {
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(collectionPaginationTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(collectionPaginationTestSpec), path)
+
+	opts := map[string]interface{}{
+		"parameters": []interface{}{"page", "cursor, size"},
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "collection_pagination", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := CollectionPagination{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "`get` operation at path `/pizzas` returns a collection, but can't be paginated (expected "+
+		"`page`, or `cursor` and `size`)", res[0].Message)
+	assert.Equal(t, "$.paths./toppings.get", res[1].Path)
+}
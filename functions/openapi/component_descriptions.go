@@ -40,6 +40,9 @@ func (cd ComponentDescription) RunRule(nodes []*yaml.Node, context model.RuleFun
 
 	var componentType, componentName string
 	for i, componentNode := range components {
+		if context.IsCancelled() {
+			break
+		}
 		if i%2 == 0 {
 			componentType = componentNode.Value
 			continue
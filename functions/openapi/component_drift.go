@@ -0,0 +1,289 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ComponentDrift checks components that are defined in more than one file of a multi-file specification have the
+// same definition everywhere, copies of a component drift apart as only some of them are updated. Components are
+// the members of `components` (and swagger `definitions`) in the root document, and in every file it refers to (and
+// the files those refer to), so the rule only finds anything when external references are looked up (with a base
+// path or URL). Components that only hold a `$ref` point at another definition, they are not copies. Files holding
+// intentional duplicates (like older versions of a model) can be ignored with the `ignoreFiles` option, which
+// accepts paths or glob patterns. Results are reported against the root document, on the definition it holds, or
+// (when all copies are in other files) on the reference that leads to the first of them.
+type ComponentDrift struct {
+}
+
+// componentDefinition is a component defined in one of the files of a specification.
+type componentDefinition struct {
+	file  string
+	key   *yaml.Node
+	value *yaml.Node
+	path  string
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ComponentDrift rule.
+func (cd ComponentDrift) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "component_drift",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "ignoreFiles",
+				Description: "paths (or glob patterns) of files with intentional duplicates, like 'models/v1/*.yaml'",
+			},
+		},
+		ErrorMessage: "'component_drift' function has invalid options supplied. Example valid options are " +
+			"'ignoreFiles' = ['legacy/*.yaml']",
+	}
+}
+
+// documentComponents adds the components defined by a document to found, keyed by their section and name.
+func documentComponents(root *yaml.Node, file string, found map[string][]componentDefinition) {
+	if root == nil || !utils.IsNodeMap(root) {
+		return
+	}
+	add := func(section *yaml.Node, name, basePath string) {
+		if section == nil || !utils.IsNodeMap(section) {
+			return
+		}
+		for i := 0; i < len(section.Content)-1; i += 2 {
+			key, value := section.Content[i], section.Content[i+1]
+			if utils.IsNodeMap(value) && len(value.Content) == 2 && value.Content[0].Value == "$ref" {
+				continue
+			}
+			id := fmt.Sprintf("%s/%s", name, key.Value)
+			found[id] = append(found[id], componentDefinition{
+				file:  file,
+				key:   key,
+				value: value,
+				path:  fmt.Sprintf("%s.%s", basePath, key.Value),
+			})
+		}
+	}
+	if _, components := utils.FindKeyNodeTop("components", root.Content); components != nil &&
+		utils.IsNodeMap(components) {
+		for i := 0; i < len(components.Content)-1; i += 2 {
+			section := components.Content[i].Value
+			add(components.Content[i+1], section, "$.components."+section)
+		}
+	}
+	_, definitions := utils.FindKeyNodeTop("definitions", root.Content)
+	add(definitions, "definitions", "$.definitions")
+}
+
+// fileReference is a reference to another file, found in a document.
+type fileReference struct {
+	file  string
+	key   *yaml.Node
+	value *yaml.Node
+	path  string
+}
+
+// referencedFiles returns the files the references in a document point at, once each, in document order.
+func referencedFiles(node *yaml.Node) []fileReference {
+	var files []fileReference
+	seen := make(map[string]bool)
+	var walk func(n *yaml.Node, path string)
+	walk = func(n *yaml.Node, path string) {
+		for i, c := range n.Content {
+			if n.Kind == yaml.SequenceNode {
+				walk(c, fmt.Sprintf("%s[%d]", path, i))
+				continue
+			}
+			if n.Kind != yaml.MappingNode || i%2 == 0 || i < 1 {
+				continue
+			}
+			key := n.Content[i-1]
+			if key.Value == "$ref" {
+				if file, _, _ := strings.Cut(c.Value, "#"); file != "" && !seen[file] {
+					seen[file] = true
+					files = append(files, fileReference{file: file, key: key, value: c, path: path})
+				}
+				continue
+			}
+			walk(c, fmt.Sprintf("%s.%s", path, key.Value))
+		}
+	}
+	walk(node, "$")
+	return files
+}
+
+// RunRule will execute the ComponentDrift rule, based on supplied context and a supplied []*yaml.Node slice.
+func (cd ComponentDrift) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 || context.Index == nil {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	config := context.Index.GetConfig()
+	if config == nil || config.Rolodex == nil {
+		return nil
+	}
+
+	base := config.BasePath
+	if abs, err := filepath.Abs(base); err == nil && base != "" {
+		base = abs
+	}
+	if config.BaseURL != nil {
+		base = config.BaseURL.String()
+	}
+	ignoreFiles := getStringArrayOption("ignoreFiles", context.Options, nil)
+
+	// files are named relative to the base, so the allow-list doesn't depend on where the spec is checked out.
+	fileName := func(location string) string {
+		if rel, err := filepath.Rel(base, location); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+		return strings.TrimPrefix(strings.TrimPrefix(location, base), "/")
+	}
+	isIgnored := func(file string) bool {
+		for _, p := range ignoreFiles {
+			if ok, _ := path.Match(p, file); ok || p == file {
+				return true
+			}
+			if ok, _ := path.Match(p, path.Base(file)); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	found := make(map[string][]componentDefinition)
+	documentComponents(root, "", found)
+
+	// follow the references to other files, and the references in those files, opening each file once. Every file
+	// is reached through a reference in the root document, its origin.
+	type document struct {
+		node     *yaml.Node
+		location string
+		origin   *fileReference
+	}
+	queue := []document{{node: root, location: base}}
+	opened := make(map[string]bool)
+	origins := make(map[string]*fileReference)
+	for len(queue) > 0 && !context.IsCancelled() {
+		doc := queue[0]
+		queue = queue[1:]
+		for _, reference := range referencedFiles(doc.node) {
+			ref := reference.file
+			origin := doc.origin
+			if origin == nil {
+				r := reference
+				origin = &r
+			}
+			location := ref
+			if u, err := url.Parse(ref); err != nil || u.Scheme == "" {
+				if b, bErr := url.Parse(doc.location); bErr == nil && b.Scheme != "" && u != nil {
+					location = b.ResolveReference(u).String()
+				} else if !filepath.IsAbs(ref) {
+					dir := doc.location
+					if doc.node != root {
+						dir = filepath.Dir(doc.location)
+					}
+					location = filepath.Join(dir, ref)
+				}
+			}
+			file, err := config.Rolodex.Open(location)
+			if err != nil || file == nil || opened[file.GetFullPath()] {
+				continue
+			}
+			opened[file.GetFullPath()] = true
+			fileRoot, err := file.GetContentAsYAMLNode()
+			if err != nil || fileRoot == nil {
+				continue
+			}
+			if fileRoot.Kind == yaml.DocumentNode && len(fileRoot.Content) > 0 {
+				fileRoot = fileRoot.Content[0]
+			}
+			// a file can refer back to the root document.
+			if nodesEqual(fileRoot, root) {
+				continue
+			}
+			queue = append(queue, document{node: fileRoot, location: file.GetFullPath(), origin: origin})
+			if name := fileName(file.GetFullPath()); !isIgnored(name) {
+				origins[name] = origin
+				documentComponents(fileRoot, name, found)
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(found))
+	for id := range found {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if context.IsCancelled() {
+			break
+		}
+		definitions := found[id]
+		if len(definitions) < 2 {
+			continue
+		}
+		drifted := false
+		for _, d := range definitions[1:] {
+			drifted = drifted || !nodesEqual(definitions[0].value, d.value)
+		}
+		if !drifted {
+			continue
+		}
+
+		locations := make([]string, len(definitions))
+		for i, d := range definitions {
+			if d.file == "" {
+				locations[i] = fmt.Sprintf("the root document (line %d)", d.key.Line)
+			} else {
+				locations[i] = fmt.Sprintf("`%s` (line %d)", d.file, d.key.Line)
+			}
+		}
+		section, name, _ := strings.Cut(id, "/")
+		msg := fmt.Sprintf("`%s` component `%s` has drifted, it is defined differently in %s", section, name,
+			strings.Join(locations, ", "))
+
+		// the lines of other files mean nothing in the root document, so results are reported on its nodes.
+		reported := false
+		for _, d := range definitions {
+			if d.file == "" {
+				results = append(results, model.RuleFunctionResult{
+					Message:   msg,
+					StartNode: d.key,
+					EndNode:   utils.FindLastChildNodeWithLevel(d.value, 0),
+					Path:      d.path,
+					Rule:      context.Rule,
+				})
+				reported = true
+			}
+		}
+		if origin := origins[definitions[0].file]; !reported && origin != nil {
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: origin.key,
+				EndNode:   origin.value,
+				Path:      origin.path,
+				Rule:      context.Rule,
+			})
+		}
+	}
+	return results
+}
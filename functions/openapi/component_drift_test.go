@@ -0,0 +1,152 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var componentDriftTestFiles = map[string]string{
+	"openapi.yaml": `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: 'models/pizza.yaml#/components/schemas/Pizza'
+components:
+  schemas:
+    Topping:
+      type: object
+      properties:
+        name:
+          type: string
+    Sauce:
+      type: string
+    Crust:
+      $ref: 'models/pizza.yaml#/components/schemas/Crust'`,
+	"models/pizza.yaml": `components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        toppings:
+          type: array
+          items:
+            $ref: '#/components/schemas/Topping'
+        sauce:
+          $ref: 'legacy/sauce.yaml#/components/schemas/Sauce'
+    Topping:
+      type: object
+      properties:
+        name:
+          type: string
+        vegan:
+          type: boolean
+    Crust:
+      type: string
+    Sauce:
+      type: string
+    Cheese:
+      type: string`,
+	"models/legacy/sauce.yaml": `components:
+  schemas:
+    Sauce:
+      type: string
+      enum: [tomato]
+    Cheese:
+      type: integer`,
+}
+
+// buildComponentDriftTestContext writes the test files to a directory, and returns the root document and a context
+// with an index that can look up files in it.
+func buildComponentDriftTestContext(t *testing.T) ([]*yaml.Node, model.RuleFunctionContext) {
+	dir := t.TempDir()
+	for name, content := range componentDriftTestFiles {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	var rootNode yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(componentDriftTestFiles["openapi.yaml"]), &rootNode))
+
+	config := index.CreateOpenAPIIndexConfig()
+	config.BasePath = dir
+	config.AllowFileLookup = true
+	rolodex := index.NewRolodex(config)
+	localFS, err := index.NewLocalFS(dir, os.DirFS(dir))
+	assert.NoError(t, err)
+	rolodex.AddLocalFS(dir, localFS)
+
+	rule := buildOpenApiTestRuleAction("$", "component_drift", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, config)
+	return []*yaml.Node{&rootNode}, ctx
+}
+
+func TestComponentDrift_GetSchema(t *testing.T) {
+	def := ComponentDrift{}
+	assert.Equal(t, "component_drift", def.GetSchema().Name)
+}
+
+func TestComponentDrift_RunRule(t *testing.T) {
+	def := ComponentDrift{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestComponentDrift_RunRule_Fail(t *testing.T) {
+
+	nodes, ctx := buildComponentDriftTestContext(t)
+
+	def := ComponentDrift{}
+	res := def.RunRule(nodes, ctx)
+
+	// every result is reported on a node of the root document, once per component.
+	assert.Len(t, res, 3)
+	assert.Equal(t, "`schemas` component `Cheese` has drifted, it is defined differently in `models/pizza.yaml` "+
+		"(line 23), `models/legacy/sauce.yaml` (line 6)", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get.responses.200.content.application/json.schema", res[0].Path)
+	assert.Equal(t, 11, res[0].StartNode.Line)
+	assert.Equal(t, "`schemas` component `Sauce` has drifted, it is defined differently in the root document "+
+		"(line 19), `models/pizza.yaml` (line 21), `models/legacy/sauce.yaml` (line 3)", res[1].Message)
+	assert.Equal(t, "$.components.schemas.Sauce", res[1].Path)
+	assert.Equal(t, 19, res[1].StartNode.Line)
+	assert.Equal(t, "`schemas` component `Topping` has drifted, it is defined differently in the root document "+
+		"(line 14), `models/pizza.yaml` (line 12)", res[2].Message)
+	assert.Equal(t, 14, res[2].StartNode.Line)
+}
+
+func TestComponentDrift_RunRule_IgnoreFiles(t *testing.T) {
+
+	nodes, ctx := buildComponentDriftTestContext(t)
+	ctx.Options = map[string]interface{}{
+		"ignoreFiles": []interface{}{"models/legacy/*.yaml"},
+	}
+
+	def := ComponentDrift{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.components.schemas.Topping", res[0].Path)
+}
+
+func TestComponentDrift_RunRule_NoLookup(t *testing.T) {
+
+	nodes, ctx := buildComponentDriftTestContext(t)
+	ctx.Index = index.NewSpecIndexWithConfig(nodes[0], index.CreateOpenAPIIndexConfig())
+
+	def := ComponentDrift{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
@@ -0,0 +1,226 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ConflictingExamples checks media types that define more than one example, where those examples disagree. A media
+// type `example` is compared against each of its `examples`, and the schema `example` is compared against the media
+// type example (or each of the `examples` if there is no media type `example`). Examples conflict when they are
+// different types (an object and an array for example), or when one is missing a required property that the
+// other includes. Identical examples are never reported. A result is returned at both examples.
+type ConflictingExamples struct {
+}
+
+type namedExample struct {
+	name string
+	path string
+	node *yaml.Node
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ConflictingExamples rule.
+func (ce ConflictingExamples) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "conflicting_examples",
+	}
+}
+
+// RunRule will execute the ConflictingExamples rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ce ConflictingExamples) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	compare := func(a, b namedExample, required []string) {
+		if nodesEqual(a.node, b.node) {
+			return
+		}
+		var reason string
+		if at, bt := exampleType(a.node), exampleType(b.node); at != bt {
+			reason = fmt.Sprintf("is %s, but %s is %s", withArticle(at), b.name, withArticle(bt))
+		} else if utils.IsNodeMap(a.node) {
+			for _, r := range required {
+				_, inA := utils.FindKeyNodeTop(r, a.node.Content)
+				_, inB := utils.FindKeyNodeTop(r, b.node.Content)
+				if inA == nil && inB != nil {
+					reason = fmt.Sprintf("is missing required property `%s`, which %s includes", r, b.name)
+					break
+				}
+				if inA != nil && inB == nil {
+					reason = fmt.Sprintf("includes required property `%s`, which %s is missing", r, b.name)
+					break
+				}
+			}
+		}
+		if reason == "" {
+			return
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message: fmt.Sprintf("%s conflicts with %s (line %d): %s %s", a.name, b.name, b.node.Line,
+				a.name, reason),
+			StartNode: a.node,
+			EndNode:   utils.FindLastChildNodeWithLevel(a.node, 0),
+			Path:      a.path,
+			Rule:      context.Rule,
+		}, model.RuleFunctionResult{
+			Message: fmt.Sprintf("%s conflicts with %s (line %d): %s %s", b.name, a.name, a.node.Line,
+				a.name, reason),
+			StartNode: b.node,
+			EndNode:   utils.FindLastChildNodeWithLevel(b.node, 0),
+			Path:      b.path,
+			Rule:      context.Rule,
+		})
+	}
+
+	checkMediaType := func(mediaType *yaml.Node, path string) {
+		if !utils.IsNodeMap(mediaType) {
+			return
+		}
+		var mediaExample *namedExample
+		var examples []namedExample
+		var schemaExample *namedExample
+		var required []string
+
+		if _, ex := utils.FindKeyNodeTop("example", mediaType.Content); ex != nil {
+			mediaExample = &namedExample{name: "media type `example`", path: path + ".example", node: ex}
+		}
+		if _, exs := utils.FindKeyNodeTop("examples", mediaType.Content); utils.IsNodeMap(exs) {
+			for i := 0; i < len(exs.Content)-1; i += 2 {
+				if _, value := utils.FindKeyNodeTop("value", exs.Content[i+1].Content); value != nil {
+					examples = append(examples, namedExample{
+						name: fmt.Sprintf("`examples.%s`", exs.Content[i].Value),
+						path: fmt.Sprintf("%s.examples.%s.value", path, exs.Content[i].Value),
+						node: value,
+					})
+				}
+			}
+		}
+
+		schemaPath := path + ".schema"
+		_, schema := utils.FindKeyNodeTop("schema", mediaType.Content)
+		if schema != nil {
+			if _, ref := utils.FindKeyNodeTop("$ref", schema.Content); ref != nil {
+				_, schemaPath = utils.ConvertComponentIdIntoPath(ref.Value)
+				schema = resolveLocalReference(root, ref.Value)
+			}
+		}
+		if utils.IsNodeMap(schema) {
+			if _, ex := utils.FindKeyNodeTop("example", schema.Content); ex != nil {
+				schemaExample = &namedExample{name: "schema `example`", path: schemaPath + ".example", node: ex}
+			}
+			if _, req := utils.FindKeyNodeTop("required", schema.Content); utils.IsNodeArray(req) {
+				for _, r := range req.Content {
+					required = append(required, r.Value)
+				}
+			}
+		}
+
+		if mediaExample != nil {
+			for _, ex := range examples {
+				compare(*mediaExample, ex, required)
+			}
+		}
+		if schemaExample != nil {
+			if mediaExample != nil {
+				compare(*schemaExample, *mediaExample, required)
+			} else {
+				for _, ex := range examples {
+					compare(*schemaExample, ex, required)
+				}
+			}
+		}
+	}
+
+	// walk the document looking for 'content' maps, each value is a media type.
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				switch key.Value {
+				case "example", "examples", "schema":
+					continue
+				case "content":
+					if utils.IsNodeMap(value) {
+						for m := 0; m < len(value.Content)-1; m += 2 {
+							checkMediaType(value.Content[m+1], fmt.Sprintf("%s.%s", childPath, value.Content[m].Value))
+						}
+						continue
+					}
+				}
+				walk(value, childPath)
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
+
+// nodesEqual returns true if two nodes hold exactly the same values.
+func nodesEqual(a, b *yaml.Node) bool {
+	if a.Kind != b.Kind || len(a.Content) != len(b.Content) {
+		return false
+	}
+	if a.Kind == yaml.ScalarNode {
+		return a.Value == b.Value && a.ShortTag() == b.ShortTag()
+	}
+	for i := range a.Content {
+		if !nodesEqual(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// exampleType returns the JSON type of an example node.
+func exampleType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch node.ShortTag() {
+	case "!!int", "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
+
+func withArticle(t string) string {
+	switch t {
+	case "object", "array":
+		return "an " + t
+	case "null":
+		return t
+	}
+	return "a " + t
+}
@@ -0,0 +1,137 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestConflictingExamples_GetSchema(t *testing.T) {
+	def := ConflictingExamples{}
+	assert.Equal(t, "conflicting_examples", def.GetSchema().Name)
+}
+
+func TestConflictingExamples_RunRule(t *testing.T) {
+	def := ConflictingExamples{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestConflictingExamples_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pizza'
+              example:
+                name: margherita
+                size: large
+              examples:
+                same:
+                  value:
+                    name: margherita
+                    size: large
+                small:
+                  value:
+                    name: margherita
+                list:
+                  value:
+                    - name: margherita
+components:
+  schemas:
+    Pizza:
+      type: object
+      required: [name, size]
+      example:
+        name: margherita
+        size: large`
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "conflicting_examples", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := ConflictingExamples{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "media type `example` conflicts with `examples.small` (line 22): media type `example` "+
+		"includes required property `size`, which `examples.small` is missing", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get.responses.200.content.application/json.example", res[0].Path)
+	assert.Equal(t, 13, res[0].StartNode.Line)
+	assert.Equal(t, "`examples.small` conflicts with media type `example` (line 13): media type `example` "+
+		"includes required property `size`, which `examples.small` is missing", res[1].Message)
+	assert.Equal(t, "$.paths./pizza.get.responses.200.content.application/json.examples.small.value", res[1].Path)
+	assert.Equal(t, 22, res[1].StartNode.Line)
+	assert.Equal(t, "media type `example` conflicts with `examples.list` (line 25): media type `example` "+
+		"is an object, but `examples.list` is an array", res[2].Message)
+	assert.Equal(t, "$.paths./pizza.get.responses.200.content.application/json.examples.list.value", res[3].Path)
+}
+
+func TestConflictingExamples_RunRule_SchemaExample(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+paths:
+  /pizza:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              example:
+                name: margherita
+            example:
+              size: large
+  /pasta:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              example: 12
+            examples:
+              number:
+                value: 12`
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "conflicting_examples", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := ConflictingExamples{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "schema `example` conflicts with media type `example` (line 14): schema `example` "+
+		"includes required property `name`, which media type `example` is missing", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.post.requestBody.content.application/json.schema.example", res[0].Path)
+	assert.Equal(t, "$.paths./pizza.post.requestBody.content.application/json.example", res[1].Path)
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ContentNegotiation checks the media types an operation consumes and the media types its successful responses
+// produce are symmetric, an operation that consumes JSON but only produces XML is usually a mistake. Media types are
+// compared by their syntax, `application/json` and `application/problem+json` are both JSON. The `policy` option
+// decides what is symmetric: `overlap` (the default) needs them to share at least one syntax, and `match` needs every
+// syntax consumed to be produced, and the other way around. Uploads and downloads are expected to be asymmetric,
+// media types matching the glob patterns in the `exemptMediaTypes` option (like `multipart/*` or
+// `application/octet-stream`) are ignored. Swagger `consumes` and `produces` (inherited from the root) are
+// compared for operations that have a `body` or `formData` parameter.
+type ContentNegotiation struct {
+}
+
+var defaultContentNegotiationExempt = []string{"multipart/*", "application/octet-stream",
+	"application/x-www-form-urlencoded", "image/*", "audio/*", "video/*", "application/pdf", "application/zip"}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ContentNegotiation rule.
+func (cn ContentNegotiation) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "content_negotiation",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name: "policy",
+				Description: "'overlap' (request and response media types share a syntax, the default) or 'match' " +
+					"(they use the same syntaxes)",
+			},
+			{
+				Name:        "exemptMediaTypes",
+				Description: "glob patterns matching media types that are not compared, like 'multipart/*'",
+			},
+		},
+		ErrorMessage: "'content_negotiation' function has invalid options supplied. Example valid options are " +
+			"'policy' = 'match' or 'exemptMediaTypes' = ['multipart/*', 'text/csv']",
+	}
+}
+
+// mediaTypeSyntax returns the syntax of a media type, structured syntax suffixes (like `+json`) count, media types
+// without a known syntax are their own syntax.
+func mediaTypeSyntax(mediaType string) string {
+	mediaType, _, _ = strings.Cut(strings.ToLower(strings.TrimSpace(mediaType)), ";")
+	mediaType = strings.TrimSpace(mediaType)
+	switch {
+	case mediaType == "application/json" || mediaType == "text/json" || strings.HasSuffix(mediaType, "+json"):
+		return "json"
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return "xml"
+	case mediaType == "application/yaml" || mediaType == "application/x-yaml" || strings.HasSuffix(mediaType, "+yaml"):
+		return "yaml"
+	}
+	return mediaType
+}
+
+// RunRule will execute the ContentNegotiation rule, based on supplied context and a supplied []*yaml.Node slice.
+func (cn ContentNegotiation) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	policy := getStringOption("policy", context.Options, "overlap")
+	exempt := getStringArrayOption("exemptMediaTypes", context.Options, defaultContentNegotiationExempt)
+
+	// compared returns the media types that are compared, those that are not exempt. Ranges (like `*/*`) match
+	// anything, so they are not compared either.
+	compared := func(mediaTypes []string) []string {
+		var kept []string
+		for _, mt := range mediaTypes {
+			base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(mt)), ";")
+			isExempt := strings.Contains(base, "*")
+			for _, e := range exempt {
+				if ok, _ := path.Match(strings.ToLower(e), strings.TrimSpace(base)); ok {
+					isExempt = true
+					break
+				}
+			}
+			if !isExempt && !containsString(kept, mt) {
+				kept = append(kept, mt)
+			}
+		}
+		return kept
+	}
+	contentTypes := func(node *yaml.Node) []string {
+		if node = resolveSchemaReference(root, node); node == nil {
+			return nil
+		}
+		_, content := utils.FindKeyNodeTop("content", node.Content)
+		if content == nil || !utils.IsNodeMap(content) {
+			return nil
+		}
+		var types []string
+		for i := 0; i < len(content.Content)-1; i += 2 {
+			types = append(types, content.Content[i].Value)
+		}
+		return types
+	}
+	values := func(node *yaml.Node) []string {
+		var found []string
+		if node != nil && utils.IsNodeArray(node) {
+			for _, n := range node.Content {
+				found = append(found, n.Value)
+			}
+		}
+		return found
+	}
+	_, rootConsumes := utils.FindKeyNodeTop("consumes", root.Content)
+	_, rootProduces := utils.FindKeyNodeTop("produces", root.Content)
+
+	walker.ForEachOperation(root, func(op walker.Operation) bool {
+		var consumes, produces []string
+		if _, rb := utils.FindKeyNodeTop("requestBody", op.Node.Content); rb != nil {
+			consumes = contentTypes(rb)
+		}
+		_, responses := utils.FindKeyNodeTop("responses", op.Node.Content)
+		if responses != nil && utils.IsNodeMap(responses) {
+			for i := 0; i < len(responses.Content)-1; i += 2 {
+				if strings.HasPrefix(responses.Content[i].Value, "2") {
+					produces = append(produces, contentTypes(responses.Content[i+1])...)
+				}
+			}
+		}
+
+		// swagger operations inherit the media types of the document.
+		if _, v := utils.FindKeyNodeTop("swagger", root.Content); v != nil {
+			hasBody := false
+			_, params := utils.FindKeyNodeTop("parameters", op.Node.Content)
+			_, pathParams := utils.FindKeyNodeTop("parameters", op.PathItem.Content)
+			for _, list := range []*yaml.Node{pathParams, params} {
+				if list == nil || !utils.IsNodeArray(list) {
+					continue
+				}
+				for _, param := range list.Content {
+					if param = resolveSchemaReference(root, param); param == nil {
+						continue
+					}
+					_, in := utils.FindKeyNodeTop("in", param.Content)
+					hasBody = hasBody || (in != nil && (in.Value == "body" || in.Value == "formData"))
+				}
+			}
+			_, opConsumes := utils.FindKeyNodeTop("consumes", op.Node.Content)
+			_, opProduces := utils.FindKeyNodeTop("produces", op.Node.Content)
+			if opConsumes == nil {
+				opConsumes = rootConsumes
+			}
+			if opProduces == nil {
+				opProduces = rootProduces
+			}
+			if hasBody {
+				consumes = values(opConsumes)
+			}
+			produces = values(opProduces)
+		}
+
+		consumes, produces = compared(consumes), compared(produces)
+		if len(consumes) == 0 || len(produces) == 0 {
+			return !context.IsCancelled()
+		}
+		consumed, produced := make(map[string]bool), make(map[string]bool)
+		for _, mt := range consumes {
+			consumed[mediaTypeSyntax(mt)] = true
+		}
+		for _, mt := range produces {
+			produced[mediaTypeSyntax(mt)] = true
+		}
+		shared, missing := false, false
+		for syntax := range consumed {
+			shared = shared || produced[syntax]
+			missing = missing || !produced[syntax]
+		}
+		for syntax := range produced {
+			missing = missing || !consumed[syntax]
+		}
+
+		var msg string
+		switch {
+		case !shared:
+			msg = fmt.Sprintf("`%s` operation at path `%s` consumes %s, but only produces %s", op.Method, op.Path,
+				joinNames(consumes, "and"), joinNames(produces, "and"))
+		case policy == "match" && missing:
+			msg = fmt.Sprintf("`%s` operation at path `%s` consumes %s, but produces %s, they should match",
+				op.Method, op.Path, joinNames(consumes, "and"), joinNames(produces, "and"))
+		default:
+			return !context.IsCancelled()
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message:   msg,
+			StartNode: op.KeyNode,
+			EndNode:   utils.FindLastChildNodeWithLevel(op.Node, 0),
+			Path:      op.JSONPath,
+			Rule:      context.Rule,
+		})
+		return !context.IsCancelled()
+	})
+	return results
+}
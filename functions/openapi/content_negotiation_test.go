@@ -0,0 +1,147 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var contentNegotiationTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        "201":
+          $ref: '#/components/responses/PizzaXML'
+    put:
+      requestBody:
+        content:
+          application/json: {}
+          application/xml: {}
+      responses:
+        "200":
+          content:
+            application/problem+json: {}
+        "400":
+          content:
+            text/plain: {}
+  /pizza/photo:
+    put:
+      requestBody:
+        content:
+          image/png: {}
+      responses:
+        "200":
+          content:
+            application/json: {}
+  /pizza/menu:
+    get:
+      responses:
+        "200":
+          content:
+            application/xml: {}
+components:
+  responses:
+    PizzaXML:
+      description: created
+      content:
+        application/xml: {}`
+
+func TestContentNegotiation_GetSchema(t *testing.T) {
+	def := ContentNegotiation{}
+	assert.Equal(t, "content_negotiation", def.GetSchema().Name)
+}
+
+func TestContentNegotiation_RunRule(t *testing.T) {
+	def := ContentNegotiation{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestContentNegotiation_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(contentNegotiationTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "content_negotiation", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ContentNegotiation{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "`post` operation at path `/pizza` consumes `application/json`, but only produces "+
+		"`application/xml`", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.post", res[0].Path)
+	assert.Equal(t, 4, res[0].StartNode.Line)
+}
+
+func TestContentNegotiation_RunRule_Match(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(contentNegotiationTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "content_negotiation", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = map[string]interface{}{
+		"policy":           "match",
+		"exemptMediaTypes": []interface{}{"multipart/*"},
+	}
+
+	def := ContentNegotiation{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "`put` operation at path `/pizza` consumes `application/json` and `application/xml`, but "+
+		"produces `application/problem+json`, they should match", res[1].Message)
+	assert.Equal(t, "`put` operation at path `/pizza/photo` consumes `image/png`, but only produces "+
+		"`application/json`", res[2].Message)
+}
+
+func TestContentNegotiation_RunRule_Swagger(t *testing.T) {
+
+	yml := `swagger: "2.0"
+consumes: [application/json]
+produces: [application/xml]
+paths:
+  /pizza:
+    post:
+      parameters:
+        - in: body
+          name: pizza
+          schema:
+            type: object
+    put:
+      produces: [application/json]
+      parameters:
+        - in: body
+          name: pizza
+          schema:
+            type: object
+    get:
+      responses:
+        "200":
+          description: ok`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "content_negotiation", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ContentNegotiation{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.paths./pizza.post", res[0].Path)
+}
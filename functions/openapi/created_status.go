@@ -0,0 +1,117 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// CreatedStatus checks `post` operations that create a resource respond with `201`, not `200`. An operation creates
+// a resource when its `operationId` starts with one of the words in the `operationIdPrefixes` option (`create`, `add`,
+// `new`, `register` and `insert` by default), the word must be whole, `createPizza`, `create_pizza` and `create` all
+// match, `createdPizzas` doesn't. Operations are only reported if `200` is their only successful response. Some
+// `post` operations legitimately respond with `200`, like idempotent or RPC style operations, they can opt out with
+// the extension named by the `exemptExtension` option (`x-idempotent` by default) set to anything but `false`.
+type CreatedStatus struct {
+}
+
+var defaultCreateOperationPrefixes = []string{"create", "add", "new", "register", "insert"}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the CreatedStatus rule.
+func (cs CreatedStatus) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "created_status",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "operationIdPrefixes",
+				Description: "words an 'operationId' starts with when the operation creates a resource, like 'create'",
+			},
+			{
+				Name: "exemptExtension",
+				Description: "the extension marking operations that legitimately respond with '200' (defaults to " +
+					"'x-idempotent')",
+			},
+		},
+		ErrorMessage: "'created_status' function has invalid options supplied. Example valid options are " +
+			"'operationIdPrefixes' = ['create', 'add'] or 'exemptExtension' = 'x-rpc'",
+	}
+}
+
+// RunRule will execute the CreatedStatus rule, based on supplied context and a supplied []*yaml.Node slice.
+func (cs CreatedStatus) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	prefixes := getStringArrayOption("operationIdPrefixes", context.Options, defaultCreateOperationPrefixes)
+	exemptExtension := getStringOption("exemptExtension", context.Options, "x-idempotent")
+
+	// creates returns the prefix an operationId starts with, if it is followed by the end of the operationId, a
+	// separator or an upper case letter.
+	creates := func(operationId string) string {
+		for _, prefix := range prefixes {
+			if prefix == "" || len(operationId) < len(prefix) ||
+				!strings.EqualFold(operationId[:len(prefix)], prefix) {
+				continue
+			}
+			rest := []rune(operationId[len(prefix):])
+			if len(rest) == 0 || rest[0] == '_' || rest[0] == '-' || rest[0] == '.' || unicode.IsUpper(rest[0]) ||
+				unicode.IsDigit(rest[0]) {
+				return prefix
+			}
+		}
+		return ""
+	}
+
+	walker.ForEachOperation(root, func(op walker.Operation) bool {
+		if op.Method != "post" {
+			return !context.IsCancelled()
+		}
+		if _, ext := utils.FindKeyNodeTop(exemptExtension, op.Node.Content); ext != nil && ext.Value != "false" {
+			return !context.IsCancelled()
+		}
+		_, operationId := utils.FindKeyNodeTop("operationId", op.Node.Content)
+		if operationId == nil || creates(operationId.Value) == "" {
+			return !context.IsCancelled()
+		}
+		_, responses := utils.FindKeyNodeTop("responses", op.Node.Content)
+		if responses == nil || !utils.IsNodeMap(responses) {
+			return !context.IsCancelled()
+		}
+		var successes []string
+		for i := 0; i < len(responses.Content)-1; i += 2 {
+			if code := responses.Content[i].Value; strings.HasPrefix(code, "2") {
+				successes = append(successes, code)
+			}
+		}
+		if len(successes) != 1 || successes[0] != "200" {
+			return !context.IsCancelled()
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message: fmt.Sprintf("`post` operation `%s` at path `%s` appears to create a resource, but only "+
+				"responds with `200`, use `201`", operationId.Value, op.Path),
+			StartNode: op.KeyNode,
+			EndNode:   utils.FindLastChildNodeWithLevel(op.Node, 0),
+			Path:      op.JSONPath,
+			Rule:      context.Rule,
+		})
+		return !context.IsCancelled()
+	})
+	return results
+}
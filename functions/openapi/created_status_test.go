@@ -0,0 +1,103 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var createdStatusTestSpec = `openapi: 3.1.0
+paths:
+  /pizzas:
+    post:
+      operationId: createPizza
+      responses:
+        "200":
+          description: ok
+  /toppings:
+    post:
+      operationId: add_topping
+      responses:
+        "201":
+          description: created
+  /orders:
+    post:
+      operationId: newOrder
+      responses:
+        "200":
+          description: ok
+        "202":
+          description: accepted
+  /pizzas/search:
+    post:
+      operationId: searchPizzas
+      responses:
+        "200":
+          description: ok
+  /bases:
+    post:
+      operationId: createdBases
+      responses:
+        "200":
+          description: ok
+  /ovens:
+    post:
+      operationId: registerOven
+      x-idempotent: true
+      responses:
+        "200":
+          description: ok`
+
+func TestCreatedStatus_GetSchema(t *testing.T) {
+	def := CreatedStatus{}
+	assert.Equal(t, "created_status", def.GetSchema().Name)
+}
+
+func TestCreatedStatus_RunRule(t *testing.T) {
+	def := CreatedStatus{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestCreatedStatus_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(createdStatusTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "created_status", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := CreatedStatus{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "`post` operation `createPizza` at path `/pizzas` appears to create a resource, but only "+
+		"responds with `200`, use `201`", res[0].Message)
+	assert.Equal(t, "$.paths./pizzas.post", res[0].Path)
+	assert.Equal(t, 4, res[0].StartNode.Line)
+}
+
+func TestCreatedStatus_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(createdStatusTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["operationIdPrefixes"] = []interface{}{"register", "search"}
+	opts["exemptExtension"] = "x-rpc"
+
+	rule := buildOpenApiTestRuleAction(path, "created_status", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := CreatedStatus{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "$.paths./pizzas/search.post", res[0].Path)
+	assert.Equal(t, "$.paths./ovens.post", res[1].Path)
+}
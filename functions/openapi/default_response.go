@@ -0,0 +1,81 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultResponse checks operations define a `default` response, the catch-all for any status code an operation
+// doesn't document, so clients know what an unexpected response looks like. OpenAPI 3 allows status code ranges,
+// when `allowServerErrorRange` is true a `5XX` response (which covers every server error) is accepted instead.
+type DefaultResponse struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the DefaultResponse rule.
+func (dr DefaultResponse) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "default_response",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "allowServerErrorRange",
+				Description: "accept a `5XX` response instead of a `default` response (defaults to false)",
+			},
+		},
+		ErrorMessage: "'default_response' function has invalid options supplied. Example valid options are " +
+			"'allowServerErrorRange' = true",
+	}
+}
+
+// RunRule will execute the DefaultResponse rule, based on supplied context and a supplied []*yaml.Node slice.
+func (dr DefaultResponse) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	allowRange := getBoolOption("allowServerErrorRange", context.Options, false)
+
+	walker.ForEachOperation(root, func(op walker.Operation) bool {
+		responsesKey, responses := utils.FindKeyNodeTop("responses", op.Node.Content)
+		if !utils.IsNodeMap(responses) {
+			return !context.IsCancelled()
+		}
+		covered := false
+		walker.ForEachResponse(op, func(response walker.Response) bool {
+			class, isRange := walker.StatusCodeClass(response.Code)
+			covered = response.Code == "default" || (allowRange && isRange && class == 5)
+			return !covered
+		})
+		if covered {
+			return !context.IsCancelled()
+		}
+		message := fmt.Sprintf("`%s` operation at path `%s` has no `default` response", op.Method, op.Path)
+		if allowRange {
+			message = fmt.Sprintf("`%s` operation at path `%s` has no `default` or `5XX` response", op.Method,
+				op.Path)
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message:   message,
+			StartNode: responsesKey,
+			EndNode:   utils.FindLastChildNodeWithLevel(responses, 0),
+			Path:      op.JSONPath + ".responses",
+			Rule:      context.Rule,
+		})
+		return !context.IsCancelled()
+	})
+	return results
+}
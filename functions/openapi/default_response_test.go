@@ -0,0 +1,87 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var defaultResponseTestSpec = `openapi: 3.1.0
+paths:
+  /pizzas:
+    get:
+      responses:
+        '200':
+          description: pizzas
+        default:
+          description: something went wrong
+    post:
+      responses:
+        '201':
+          description: created
+        5XX:
+          description: the oven is broken
+  /toppings:
+    get:
+      responses:
+        '200':
+          description: toppings
+  /sauces:
+    get:
+      responses:
+        5xx:
+          description: not a range, ranges are upper case`
+
+func TestDefaultResponse_GetSchema(t *testing.T) {
+	def := DefaultResponse{}
+	assert.Equal(t, "default_response", def.GetSchema().Name)
+}
+
+func TestDefaultResponse_RunRule(t *testing.T) {
+	def := DefaultResponse{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestDefaultResponse_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(defaultResponseTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "default_response", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := DefaultResponse{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "`post` operation at path `/pizzas` has no `default` response", res[0].Message)
+	assert.Equal(t, "$.paths./pizzas.post.responses", res[0].Path)
+	assert.Equal(t, 11, res[0].StartNode.Line)
+	assert.Equal(t, "$.paths./toppings.get.responses", res[1].Path)
+	assert.Equal(t, "$.paths./sauces.get.responses", res[2].Path)
+}
+
+func TestDefaultResponse_RunRule_AllowServerErrorRange(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(defaultResponseTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["allowServerErrorRange"] = true
+
+	rule := buildOpenApiTestRuleAction(path, "default_response", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := DefaultResponse{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "`get` operation at path `/toppings` has no `default` or `5XX` response", res[0].Message)
+	assert.Equal(t, "`get` operation at path `/sauces` has no `default` or `5XX` response", res[1].Message)
+}
@@ -0,0 +1,242 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DeprecatedProperties checks that `deprecated: true` is applied consistently to schema properties, the `check`
+// option selects what is checked, so each check can be configured as its own rule (with its own severity).
+//
+// `properties` (the default) flags deprecated properties that are also `required` (clients can't stop using them),
+// and deprecated properties with no replacement hint, a description that contains one of the `hints` option (like
+// `instead`, matched without case). `responses` flags deprecated properties returned by operations that are not
+// deprecated themselves. That is allowed, but worth a note. References to schemas are followed.
+type DeprecatedProperties struct {
+}
+
+// defaultDeprecationHints are the words that describe what replaces a deprecated property, unless configured.
+var defaultDeprecationHints = []string{"instead", "replaced", "use ", "see "}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the DeprecatedProperties rule.
+func (dp DeprecatedProperties) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "deprecated_properties",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "check",
+				Description: "what to check, 'properties' or 'responses' (defaults to 'properties')",
+			},
+			{
+				Name:        "hints",
+				Description: "words that describe the replacement of a deprecated property",
+			},
+		},
+		ErrorMessage: "'deprecated_properties' function has invalid options supplied. Example valid options are " +
+			"'check' = 'responses' or 'hints' = ['instead', 'replaced by']",
+	}
+}
+
+// RunRule will execute the DeprecatedProperties rule, based on supplied context and a supplied []*yaml.Node slice.
+func (dp DeprecatedProperties) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	isDeprecated := func(node *yaml.Node) bool {
+		if node = resolveSchemaReference(root, node); node == nil {
+			return false
+		}
+		_, deprecated := utils.FindKeyNodeTop("deprecated", node.Content)
+		return deprecated != nil && deprecated.Value == "true"
+	}
+	result := func(msg string, name, property *yaml.Node, path string) {
+		results = append(results, model.RuleFunctionResult{
+			Message:   msg,
+			StartNode: name,
+			EndNode:   utils.FindLastChildNodeWithLevel(property, 0),
+			Path:      path,
+			Rule:      context.Rule,
+		})
+	}
+
+	if strings.ToLower(getStringOption("check", context.Options, "properties")) == "responses" {
+		dp.checkResponses(root, context, isDeprecated, result)
+		return results
+	}
+
+	hints := getStringArrayOption("hints", context.Options, defaultDeprecationHints)
+
+	checkProperties := func(schema, properties *yaml.Node, propertiesPath string) {
+		required := make(map[string]bool)
+		if _, req := utils.FindKeyNodeTop("required", schema.Content); utils.IsNodeArray(req) {
+			for _, r := range req.Content {
+				required[r.Value] = true
+			}
+		}
+		for i := 0; i < len(properties.Content)-1; i += 2 {
+			name, property := properties.Content[i], properties.Content[i+1]
+			if !isDeprecated(property) {
+				continue
+			}
+			propertyPath := fmt.Sprintf("%s.%s", propertiesPath, name.Value)
+			if required[name.Value] {
+				result(fmt.Sprintf("property `%s` is `required` and `deprecated`, clients can't stop using it",
+					name.Value), name, property, propertyPath)
+				continue
+			}
+			description := ""
+			if _, desc := utils.FindKeyNodeTop("description", property.Content); desc != nil {
+				description = strings.ToLower(desc.Value)
+			}
+			hinted := false
+			for _, hint := range hints {
+				hinted = hinted || strings.Contains(description, strings.ToLower(hint))
+			}
+			if !hinted {
+				result(fmt.Sprintf("deprecated property `%s` has no replacement hint, describe what to use instead",
+					name.Value), name, property, propertyPath)
+			}
+		}
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				if key.Value == "example" || key.Value == "examples" {
+					continue
+				}
+				if key.Value == "properties" && utils.IsNodeMap(value) {
+					checkProperties(node, value, childPath)
+				}
+				walk(value, childPath)
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
+
+// checkResponses reports the deprecated properties returned by every operation that is not deprecated. Properties
+// defined in a referenced schema are reported where they are defined.
+func (dp DeprecatedProperties) checkResponses(root *yaml.Node, context model.RuleFunctionContext,
+	isDeprecated func(node *yaml.Node) bool, result func(msg string, name, property *yaml.Node, path string)) {
+
+	_, paths := utils.FindKeyNodeTop("paths", root.Content)
+	if !utils.IsNodeMap(paths) {
+		return
+	}
+	for i := 0; i < len(paths.Content)-1; i += 2 {
+		if context.IsCancelled() {
+			return
+		}
+		opPath, pathItem := paths.Content[i].Value, paths.Content[i+1]
+		for m := 0; m < len(pathItem.Content)-1; m += 2 {
+			opKey, operation := pathItem.Content[m], pathItem.Content[m+1]
+			if !isOperationMethod(opKey.Value) || !utils.IsNodeMap(operation) || isDeprecated(operation) {
+				continue
+			}
+			_, responses := utils.FindKeyNodeTop("responses", operation.Content)
+			if !utils.IsNodeMap(responses) {
+				continue
+			}
+
+			// a property is only reported once per operation, even if more than one response returns it.
+			reported := make(map[*yaml.Node]bool)
+			var visit func(schema *yaml.Node, path string, seen map[*yaml.Node]bool)
+			visit = func(schema *yaml.Node, path string, seen map[*yaml.Node]bool) {
+				if !utils.IsNodeMap(schema) {
+					return
+				}
+				if _, ref := utils.FindKeyNodeTop("$ref", schema.Content); ref != nil {
+					resolved := resolveLocalReference(root, ref.Value)
+					if resolved == nil || seen[resolved] {
+						return
+					}
+					seen[resolved] = true
+					visit(resolved, "$"+strings.ReplaceAll(strings.TrimPrefix(ref.Value, "#"), "/", "."), seen)
+					return
+				}
+				if _, properties := utils.FindKeyNodeTop("properties", schema.Content); utils.IsNodeMap(properties) {
+					for p := 0; p < len(properties.Content)-1; p += 2 {
+						name, property := properties.Content[p], properties.Content[p+1]
+						propertyPath := fmt.Sprintf("%s.properties.%s", path, name.Value)
+						if isDeprecated(property) && !reported[name] {
+							reported[name] = true
+							result(fmt.Sprintf("operation `%s` at path `%s` is not deprecated, but returns the "+
+								"deprecated property `%s`", opKey.Value, opPath, name.Value), name, property,
+								propertyPath)
+						}
+						visit(property, propertyPath, seen)
+					}
+				}
+				for _, key := range []string{"items", "additionalProperties", "not"} {
+					if _, sub := utils.FindKeyNodeTop(key, schema.Content); sub != nil {
+						visit(sub, fmt.Sprintf("%s.%s", path, key), seen)
+					}
+				}
+				for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+					if _, list := utils.FindKeyNodeTop(key, schema.Content); utils.IsNodeArray(list) {
+						for n, sub := range list.Content {
+							visit(sub, fmt.Sprintf("%s.%s[%d]", path, key, n), seen)
+						}
+					}
+				}
+			}
+
+			for r := 0; r < len(responses.Content)-1; r += 2 {
+				code, response := responses.Content[r].Value, responses.Content[r+1]
+				responsePath := fmt.Sprintf("$.paths.%s.%s.responses.%s", opPath, opKey.Value, code)
+				if _, ref := utils.FindKeyNodeTop("$ref", response.Content); ref != nil {
+					if resolved := resolveLocalReference(root, ref.Value); resolved != nil {
+						response = resolved
+						responsePath = "$" + strings.ReplaceAll(strings.TrimPrefix(ref.Value, "#"), "/", ".")
+					}
+				}
+				if !utils.IsNodeMap(response) {
+					continue
+				}
+				_, content := utils.FindKeyNodeTop("content", response.Content)
+				if !utils.IsNodeMap(content) {
+					continue
+				}
+				for c := 0; c < len(content.Content)-1; c += 2 {
+					mediaType := content.Content[c+1]
+					if !utils.IsNodeMap(mediaType) {
+						continue
+					}
+					if _, schema := utils.FindKeyNodeTop("schema", mediaType.Content); schema != nil {
+						visit(schema, fmt.Sprintf("%s.content.%s.schema", responsePath, content.Content[c].Value),
+							make(map[*yaml.Node]bool))
+					}
+				}
+			}
+		}
+	}
+}
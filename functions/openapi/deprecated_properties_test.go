@@ -0,0 +1,142 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var deprecatedPropertiesTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pizza'
+        default:
+          $ref: '#/components/responses/Error'
+  /old-pizza:
+    get:
+      deprecated: true
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pizza'
+components:
+  responses:
+    Error:
+      description: error
+      content:
+        application/json:
+          schema:
+            type: object
+            properties:
+              code:
+                type: integer
+                deprecated: true
+                description: use the status instead.
+  schemas:
+    Pizza:
+      type: object
+      required: [name, size]
+      properties:
+        name:
+          type: string
+          deprecated: true
+        size:
+          type: string
+        topping:
+          type: string
+          deprecated: true
+          description: Replaced by toppings.
+        crust:
+          type: string
+          deprecated: true
+        base:
+          $ref: '#/components/schemas/Pizza'`
+
+func TestDeprecatedProperties_GetSchema(t *testing.T) {
+	def := DeprecatedProperties{}
+	assert.Equal(t, "deprecated_properties", def.GetSchema().Name)
+}
+
+func TestDeprecatedProperties_RunRule(t *testing.T) {
+	def := DeprecatedProperties{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestDeprecatedProperties_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(deprecatedPropertiesTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "deprecated_properties", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := DeprecatedProperties{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "property `name` is `required` and `deprecated`, clients can't stop using it", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.name", res[0].Path)
+	assert.Equal(t, 40, res[0].StartNode.Line)
+	assert.Equal(t, "deprecated property `crust` has no replacement hint, describe what to use instead",
+		res[1].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.crust", res[1].Path)
+}
+
+func TestDeprecatedProperties_RunRule_Responses(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(deprecatedPropertiesTestSpec), path)
+
+	opts := map[string]interface{}{
+		"check": "responses",
+	}
+	rule := buildOpenApiTestRuleAction(path, "deprecated_properties", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := DeprecatedProperties{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "operation `get` at path `/pizza` is not deprecated, but returns the deprecated property `name`",
+		res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.name", res[0].Path)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.topping", res[1].Path)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.crust", res[2].Path)
+	assert.Equal(t, "$.components.responses.Error.content.application/json.schema.properties.code", res[3].Path)
+}
+
+func TestDeprecatedProperties_RunRule_Hints(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(deprecatedPropertiesTestSpec), path)
+
+	opts := map[string]interface{}{
+		"hints": []interface{}{"toppings"},
+	}
+	rule := buildOpenApiTestRuleAction(path, "deprecated_properties", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := DeprecatedProperties{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "$.components.responses.Error.content.application/json.schema.properties.code", res[0].Path)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.name", res[1].Path)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.crust", res[2].Path)
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DeprecatedSchemas checks operations that are not deprecated don't use deprecated component schemas (schemas in
+// `components.schemas` or `definitions` with `deprecated: true`) in their request body or responses. The operation
+// keeps working after the schema is removed only if it stops using it, so it should be deprecated too, or move to
+// the replacement. Schemas are used transitively, through properties, `items`, `allOf` (and `oneOf` or `anyOf`)
+// members and other references. Each deprecated schema is reported once per operation, where it is first used.
+type DeprecatedSchemas struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the DeprecatedSchemas rule.
+func (ds DeprecatedSchemas) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "deprecated_schemas",
+	}
+}
+
+// RunRule will execute the DeprecatedSchemas rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ds DeprecatedSchemas) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	isDeprecated := func(node *yaml.Node) bool {
+		_, deprecated := utils.FindKeyNodeTop("deprecated", node.Content)
+		return deprecated != nil && deprecated.Value == "true"
+	}
+
+	walker.ForEachOperation(root, func(op walker.Operation) bool {
+		if isDeprecated(op.Node) {
+			return !context.IsCancelled()
+		}
+		seen := make(map[*yaml.Node]bool)
+
+		// traverse looks for deprecated component schemas used by a schema, and the schemas nested in it.
+		var traverse func(schema *yaml.Node, usage string)
+		traverse = func(schema *yaml.Node, usage string) {
+			if schema == nil || !utils.IsNodeMap(schema) {
+				return
+			}
+			ref := ""
+			if _, r := utils.FindKeyNodeTop("$ref", schema.Content); r != nil {
+				ref = r.Value
+			}
+			if schema = resolveSchemaReference(root, schema); schema == nil || seen[schema] {
+				return
+			}
+			seen[schema] = true
+
+			if (strings.HasPrefix(ref, "#/components/schemas/") || strings.HasPrefix(ref, "#/definitions/")) &&
+				isDeprecated(schema) {
+				_, schemaPath := utils.ConvertComponentIdIntoPath(ref)
+				results = append(results, model.RuleFunctionResult{
+					Message: fmt.Sprintf("`%s` operation at path `%s` is not deprecated, but %s uses the deprecated "+
+						"schema `%s` (`%s`)", op.Method, op.Path, usage, ref[strings.LastIndex(ref, "/")+1:],
+						schemaPath),
+					StartNode: op.KeyNode,
+					EndNode:   utils.FindLastChildNodeWithLevel(op.Node, 0),
+					Path:      op.JSONPath,
+					Rule:      context.Rule,
+				})
+			}
+
+			for i := 0; i < len(schema.Content)-1; i += 2 {
+				key, value := schema.Content[i].Value, schema.Content[i+1]
+				switch key {
+				case "properties", "patternProperties":
+					if utils.IsNodeMap(value) {
+						for p := 0; p < len(value.Content)-1; p += 2 {
+							traverse(value.Content[p+1], usage)
+						}
+					}
+				case "allOf", "oneOf", "anyOf", "prefixItems":
+					if utils.IsNodeArray(value) {
+						for _, member := range value.Content {
+							traverse(member, usage)
+						}
+					}
+				case "items", "additionalProperties", "not":
+					traverse(value, usage)
+				}
+			}
+		}
+		contentSchemas := func(node *yaml.Node, usage string) {
+			if node = resolveSchemaReference(root, node); node == nil {
+				return
+			}
+			if _, schema := utils.FindKeyNodeTop("schema", node.Content); schema != nil {
+				traverse(schema, usage)
+			}
+			_, content := utils.FindKeyNodeTop("content", node.Content)
+			if content == nil || !utils.IsNodeMap(content) {
+				return
+			}
+			for i := 0; i < len(content.Content)-1; i += 2 {
+				if utils.IsNodeMap(content.Content[i+1]) {
+					_, schema := utils.FindKeyNodeTop("schema", content.Content[i+1].Content)
+					traverse(schema, usage)
+				}
+			}
+		}
+
+		if _, rb := utils.FindKeyNodeTop("requestBody", op.Node.Content); rb != nil {
+			contentSchemas(rb, "its request body")
+		}
+		// swagger sends the request body as a `body` parameter.
+		for _, holder := range []*yaml.Node{op.PathItem, op.Node} {
+			_, params := utils.FindKeyNodeTop("parameters", holder.Content)
+			if params == nil || !utils.IsNodeArray(params) {
+				continue
+			}
+			for _, param := range params.Content {
+				if param = resolveSchemaReference(root, param); param == nil {
+					continue
+				}
+				if _, in := utils.FindKeyNodeTop("in", param.Content); in != nil && in.Value == "body" {
+					contentSchemas(param, "its request body")
+				}
+			}
+		}
+		_, responses := utils.FindKeyNodeTop("responses", op.Node.Content)
+		if responses != nil && utils.IsNodeMap(responses) {
+			for i := 0; i < len(responses.Content)-1; i += 2 {
+				contentSchemas(responses.Content[i+1], fmt.Sprintf("its `%s` response", responses.Content[i].Value))
+			}
+		}
+		return !context.IsCancelled()
+	})
+	return results
+}
@@ -0,0 +1,141 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecatedSchemas_GetSchema(t *testing.T) {
+	def := DeprecatedSchemas{}
+	assert.Equal(t, "deprecated_schemas", def.GetSchema().Name)
+}
+
+func TestDeprecatedSchemas_RunRule(t *testing.T) {
+	def := DeprecatedSchemas{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestDeprecatedSchemas_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+paths:
+  /pizzas:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Order'
+      responses:
+        "201":
+          description: created
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/LegacyPizza'
+    get:
+      deprecated: true
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/LegacyPizza'
+  /toppings:
+    get:
+      responses:
+        "200":
+          $ref: '#/components/responses/Toppings'
+components:
+  responses:
+    Toppings:
+      description: ok
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Topping'
+  schemas:
+    Order:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          properties:
+            pizza:
+              $ref: '#/components/schemas/Pizza'
+    Base:
+      type: object
+    Pizza:
+      type: object
+      properties:
+        crust:
+          $ref: '#/components/schemas/Crust'
+    Crust:
+      type: string
+      deprecated: true
+    LegacyPizza:
+      type: object
+      deprecated: true
+      properties:
+        crust:
+          $ref: '#/components/schemas/Crust'
+    Topping:
+      type: string`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "deprecated_schemas", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := DeprecatedSchemas{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "`post` operation at path `/pizzas` is not deprecated, but its request body uses the "+
+		"deprecated schema `Crust` (`$.components.schemas.Crust`)", res[0].Message)
+	assert.Equal(t, "$.paths./pizzas.post", res[0].Path)
+	assert.Equal(t, 4, res[0].StartNode.Line)
+	assert.Equal(t, "`post` operation at path `/pizzas` is not deprecated, but its `201` response uses the "+
+		"deprecated schema `LegacyPizza` (`$.components.schemas.LegacyPizza`)", res[1].Message)
+}
+
+func TestDeprecatedSchemas_RunRule_Swagger(t *testing.T) {
+
+	yml := `swagger: "2.0"
+paths:
+  /pizzas:
+    put:
+      parameters:
+        - in: body
+          name: pizza
+          schema:
+            $ref: '#/definitions/Pizza'
+      responses:
+        "200":
+          description: ok
+definitions:
+  Pizza:
+    type: object
+    deprecated: true`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "deprecated_schemas", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := DeprecatedSchemas{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.paths./pizzas.put", res[0].Path)
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DeprecatedSunset checks that deprecated operations (and optionally parameters) provide a migration hint, either
+// via a sunset extension, or a description that explains what to use instead.
+type DeprecatedSunset struct {
+}
+
+var defaultDeprecationMarkers = []string{
+	`(?i)use\s+.+\s+instead`,
+	`(?i)replaced\s+by`,
+	`(?i)superseded\s+by`,
+	`(?i)migrate\s+to`,
+}
+
+var defaultSunsetExtensions = []string{"x-sunset"}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the DeprecatedSunset rule.
+func (ds DeprecatedSunset) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "deprecated_sunset",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "markers",
+				Description: "a list of regular expressions, one of which must match the description of a deprecated item",
+			},
+			{
+				Name:        "extensions",
+				Description: "a list of extensions (like x-sunset) that satisfy the rule when present",
+			},
+			{
+				Name:        "checkParameters",
+				Description: "also check deprecated parameters (defaults to false)",
+			},
+		},
+		ErrorMessage: "'deprecated_sunset' function has invalid options supplied.",
+	}
+}
+
+// RunRule will execute the DeprecatedSunset rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ds DeprecatedSunset) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	if context.Index.GetPathsNode() == nil {
+		return results
+	}
+
+	var markers []*regexp.Regexp
+	for _, m := range getStringArrayOption("markers", context.Options, defaultDeprecationMarkers) {
+		rx := model.CompileRegex(context, m, &results)
+		if rx == nil {
+			return results
+		}
+		markers = append(markers, rx)
+	}
+	extensions := getStringArrayOption("extensions", context.Options, defaultSunsetExtensions)
+	checkParams := getBoolOption("checkParameters", context.Options, false)
+
+	// hasHint returns true if the node contains a sunset extension or a description that matches a marker.
+	hasHint := func(node *yaml.Node) bool {
+		for _, ext := range extensions {
+			if _, v := utils.FindKeyNodeTop(ext, node.Content); v != nil {
+				return true
+			}
+		}
+		_, desc := utils.FindKeyNodeTop("description", node.Content)
+		if desc != nil {
+			for _, rx := range markers {
+				if rx.MatchString(desc.Value) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	isDeprecated := func(node *yaml.Node) bool {
+		_, dep := utils.FindKeyNodeTop("deprecated", node.Content)
+		return dep != nil && dep.Value == "true"
+	}
+
+	checkParameters := func(paramsNode *yaml.Node, basePath string) {
+		if paramsNode == nil || !utils.IsNodeArray(paramsNode) {
+			return
+		}
+		for x, param := range paramsNode.Content {
+			if !isDeprecated(param) || hasHint(param) {
+				continue
+			}
+			_, name := utils.FindKeyNodeTop("name", param.Content)
+			paramName := ""
+			if name != nil {
+				paramName = name.Value
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("deprecated parameter `%s` does not provide a sunset or a replacement note",
+					paramName),
+				StartNode: param,
+				EndNode:   utils.FindLastChildNodeWithLevel(param, 0),
+				Path:      fmt.Sprintf("%s.parameters[%d]", basePath, x),
+				Rule:      context.Rule,
+			})
+		}
+	}
+
+	ops := context.Index.GetPathsNode().Content
+	var opPath, opMethod string
+	for i, op := range ops {
+		if context.IsCancelled() {
+			break
+		}
+		if i%2 == 0 {
+			opPath = op.Value
+			continue
+		}
+		pathBase := fmt.Sprintf("$.paths.%s", opPath)
+		for m, method := range op.Content {
+			if m%2 == 0 {
+				opMethod = method.Value
+				continue
+			}
+			if opMethod == "parameters" {
+				if checkParams {
+					checkParameters(method, pathBase)
+				}
+				continue
+			}
+			if !isOperationMethod(opMethod) {
+				continue
+			}
+			basePath := fmt.Sprintf("%s.%s", pathBase, opMethod)
+
+			if checkParams {
+				_, params := utils.FindKeyNodeTop("parameters", method.Content)
+				checkParameters(params, basePath)
+			}
+
+			if !isDeprecated(method) || hasHint(method) {
+				continue
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("deprecated operation `%s` at path `%s` does not provide a sunset or a replacement note",
+					opMethod, opPath),
+				StartNode: op.Content[m-1],
+				EndNode:   utils.FindLastChildNodeWithLevel(method, 0),
+				Path:      basePath,
+				Rule:      context.Rule,
+			})
+		}
+	}
+	return results
+}
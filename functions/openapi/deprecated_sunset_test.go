@@ -0,0 +1,176 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDeprecatedSunset_GetSchema(t *testing.T) {
+	def := DeprecatedSunset{}
+	assert.Equal(t, "deprecated_sunset", def.GetSchema().Name)
+}
+
+func TestDeprecatedSunset_RunRule(t *testing.T) {
+	def := DeprecatedSunset{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestDeprecatedSunset_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.0.1
+paths:
+  /pizza:
+    get:
+      deprecated: true
+      description: this is old and you should stop.
+    post:
+      deprecated: true
+      description: please use the /calzone endpoint instead.
+    put:
+      deprecated: true
+      x-sunset: 2024-01-01
+    delete:
+      description: not deprecated, nothing to see here.`
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "deprecated_sunset", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := DeprecatedSunset{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.paths./pizza.get", res[0].Path)
+}
+
+func TestDeprecatedSunset_RunRule_CustomMarkers(t *testing.T) {
+
+	yml := `openapi: 3.0.1
+paths:
+  /pizza:
+    get:
+      deprecated: true
+      description: this is old, see the calzone instead.
+    post:
+      deprecated: true
+      x-sunset: 2024-01-01`
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	opts := map[string]interface{}{
+		"markers":    []interface{}{"see the .+ instead"},
+		"extensions": []interface{}{"x-retire"},
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "deprecated_sunset", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := DeprecatedSunset{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.paths./pizza.post", res[0].Path)
+}
+
+func TestDeprecatedSunset_RunRule_BadMarker(t *testing.T) {
+
+	yml := `openapi: 3.0.1
+paths:
+  /pizza:
+    get:
+      deprecated: true`
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	opts := map[string]interface{}{
+		"markers": []interface{}{"(-(*"},
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "deprecated_sunset", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := DeprecatedSunset{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Contains(t, res[0].Message, "cannot be compiled")
+}
+
+func TestDeprecatedSunset_RunRule_Parameters(t *testing.T) {
+
+	yml := `openapi: 3.0.1
+paths:
+  /pizza:
+    parameters:
+      - name: crust
+        in: query
+        deprecated: true
+    get:
+      parameters:
+        - name: cheese
+          in: query
+          deprecated: true
+          description: use the topping parameter instead.
+        - name: sauce
+          in: query
+          deprecated: true`
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	opts := map[string]interface{}{
+		"checkParameters": true,
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "deprecated_sunset", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := DeprecatedSunset{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "$.paths./pizza.parameters[0]", res[0].Path)
+	assert.Equal(t, "$.paths./pizza.get.parameters[1]", res[1].Path)
+
+	// parameters are ignored by default.
+	ctx.Options = nil
+	res = def.RunRule(nodes, ctx)
+	assert.Len(t, res, 0)
+}
@@ -41,6 +41,9 @@ func (dd DescriptionDuplication) RunRule(nodes []*yaml.Node, context model.RuleF
 	summaries := context.Index.GetAllSummaries()
 
 	for _, description := range descriptions {
+		if context.IsCancelled() {
+			break
+		}
 
 		data := []byte(description.Node.Value)
 		md5String := fmt.Sprintf("%x", md5.Sum(data))
@@ -55,6 +58,9 @@ func (dd DescriptionDuplication) RunRule(nodes []*yaml.Node, context model.RuleF
 
 	// look through summaries
 	for _, summary := range summaries {
+		if context.IsCancelled() {
+			break
+		}
 		data := []byte(summary.Node.Value)
 		md5String := fmt.Sprintf("%x", md5.Sum(data))
 		cp := copyPasta{
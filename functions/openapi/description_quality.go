@@ -0,0 +1,119 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DescriptionQuality checks operation and parameter descriptions say something. Descriptions shorter than the
+// `minLength` option (10 characters by default) are reported, and so are descriptions that are placeholders, matching
+// (ignoring case and trailing punctuation) one of the `placeholders` option, like `TODO` or `desc`. Simple endpoints
+// can have legitimately short descriptions, so `minLength` can be lowered, or set to 0 to only check placeholders.
+// Missing descriptions are not reported, other rules check for those.
+type DescriptionQuality struct {
+}
+
+var defaultDescriptionPlaceholders = []string{"todo", "tbd", "tbc", "fixme", "desc", "description", "n/a", "na",
+	"none", "test", "placeholder", "lorem ipsum", "..."}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the DescriptionQuality rule.
+func (dq DescriptionQuality) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "description_quality",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "minLength",
+				Description: "the minimum number of characters a description must have (defaults to 10, 0 disables it)",
+			},
+			{
+				Name:        "placeholders",
+				Description: "descriptions that are placeholders, like 'TODO' or 'desc' (matched ignoring case)",
+			},
+		},
+		ErrorMessage: "'description_quality' function has invalid options supplied. Example valid options are " +
+			"'minLength' = 20 or 'placeholders' = ['TODO', 'TBD']",
+	}
+}
+
+// placeholderDescription returns the placeholder a description is, or an empty string if it isn't one.
+func placeholderDescription(description string, placeholders []string) string {
+	trimmed := strings.TrimSpace(description)
+	if bare := strings.TrimRight(trimmed, ".!?:; "); bare != "" {
+		trimmed = bare
+	}
+	for _, p := range placeholders {
+		if strings.EqualFold(trimmed, strings.TrimSpace(p)) {
+			return p
+		}
+	}
+	return ""
+}
+
+// RunRule will execute the DescriptionQuality rule, based on supplied context and a supplied []*yaml.Node slice.
+func (dq DescriptionQuality) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	minLength := getIntOption("minLength", context.Options, 10)
+	placeholders := getStringArrayOption("placeholders", context.Options, defaultDescriptionPlaceholders)
+
+	check := func(node *yaml.Node, path, described string) {
+		key, desc := utils.FindKeyNodeTop("description", node.Content)
+		if desc == nil || !utils.IsNodeStringValue(desc) {
+			return
+		}
+		var msg string
+		if p := placeholderDescription(desc.Value, placeholders); p != "" {
+			msg = fmt.Sprintf("the description of %s is a placeholder (`%s`)", described, strings.TrimSpace(desc.Value))
+		} else if length := utf8.RuneCountInString(strings.TrimSpace(desc.Value)); length < minLength {
+			msg = fmt.Sprintf("the description of %s is too short, it must be at least %d characters long "+
+				"(%d is not enough)", described, minLength, length)
+		}
+		if msg == "" {
+			return
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message:   msg,
+			StartNode: key,
+			EndNode:   desc,
+			Path:      path + ".description",
+			Rule:      context.Rule,
+		})
+	}
+
+	walker.ForEachOperation(root, func(op walker.Operation) bool {
+		check(op.Node, op.JSONPath, fmt.Sprintf("`%s` operation at path `%s`", op.Method, op.Path))
+		return !context.IsCancelled()
+	})
+
+	forEachParameter(root, func(param *yaml.Node, path string) bool {
+		var name, in string
+		if _, n := utils.FindKeyNodeTop("name", param.Content); n != nil {
+			name = n.Value
+		}
+		if _, i := utils.FindKeyNodeTop("in", param.Content); i != nil {
+			in = i.Value
+		}
+		check(param, path, fmt.Sprintf("`%s` parameter `%s`", in, name))
+		return !context.IsCancelled()
+	})
+	return results
+}
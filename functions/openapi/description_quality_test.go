@@ -0,0 +1,86 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var descriptionQualityTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      description: TODO.
+      parameters:
+        - name: limit
+          in: query
+          description: Max
+        - $ref: '#/components/parameters/Page'
+    post:
+      description: Bakes a new pizza, with the toppings in the request.
+    delete:
+      description: Deletes
+components:
+  parameters:
+    Page:
+      name: page
+      in: query
+      description: desc`
+
+func TestDescriptionQuality_GetSchema(t *testing.T) {
+	def := DescriptionQuality{}
+	assert.Equal(t, "description_quality", def.GetSchema().Name)
+}
+
+func TestDescriptionQuality_RunRule(t *testing.T) {
+	def := DescriptionQuality{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestDescriptionQuality_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(descriptionQualityTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "description_quality", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := DescriptionQuality{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "the description of `get` operation at path `/pizza` is a placeholder (`TODO.`)", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get.description", res[0].Path)
+	assert.Equal(t, 5, res[0].StartNode.Line)
+	assert.Equal(t, "the description of `delete` operation at path `/pizza` is too short, it must be at least 10 "+
+		"characters long (7 is not enough)", res[1].Message)
+	assert.Equal(t, "the description of `query` parameter `page` is a placeholder (`desc`)", res[2].Message)
+	assert.Equal(t, "$.components.parameters.Page.description", res[2].Path)
+	assert.Equal(t, "the description of `query` parameter `limit` is too short, it must be at least 10 "+
+		"characters long (3 is not enough)", res[3].Message)
+	assert.Equal(t, "$.paths./pizza.get.parameters[0].description", res[3].Path)
+}
+
+func TestDescriptionQuality_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(descriptionQualityTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "description_quality", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = map[string]interface{}{
+		"minLength":    0,
+		"placeholders": []interface{}{"max"},
+	}
+
+	def := DescriptionQuality{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "the description of `query` parameter `limit` is a placeholder (`Max`)", res[0].Message)
+}
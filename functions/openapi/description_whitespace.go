@@ -0,0 +1,103 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DescriptionWhitespace checks that descriptions and summaries don't have trailing whitespace, at the end of the
+// value or at the end of any of its lines. The final line break of a block scalar is not trailing whitespace.
+// Trailing whitespace is invisible in most editors, but it ends up in rendered documentation and generated code.
+// It can be fixed automatically.
+type DescriptionWhitespace struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the DescriptionWhitespace rule.
+func (dw DescriptionWhitespace) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "description_whitespace",
+	}
+}
+
+// trimTrailingWhitespace removes whitespace from the end of every line of a value, and blank lines from its end,
+// a single final line break is kept.
+func trimTrailingWhitespace(value string) string {
+	lines := strings.Split(value, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " \t\r")
+	}
+	trimmed := strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	if strings.HasSuffix(value, "\n") && trimmed != "" {
+		trimmed += "\n"
+	}
+	return trimmed
+}
+
+// RunRule will execute the DescriptionWhitespace rule, based on supplied context and a supplied []*yaml.Node slice.
+func (dw DescriptionWhitespace) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				if key.Value == "example" || key.Value == "examples" {
+					continue
+				}
+				if (key.Value == "description" || key.Value == "summary") && value.Kind == yaml.ScalarNode &&
+					utils.IsNodeStringValue(value) && trimTrailingWhitespace(value.Value) != value.Value {
+					results = append(results, model.RuleFunctionResult{
+						Message:   fmt.Sprintf("`%s` has trailing whitespace", key.Value),
+						StartNode: key,
+						EndNode:   value,
+						Path:      childPath,
+						Rule:      context.Rule,
+					})
+					continue
+				}
+				walk(value, childPath)
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
+
+// Fix will propose removing the trailing whitespace from a description or summary.
+func (dw DescriptionWhitespace) Fix(result model.RuleFunctionResult, _ model.RuleFunctionContext) []model.NodeEdit {
+	if result.EndNode == nil || result.EndNode.Kind != yaml.ScalarNode {
+		return nil
+	}
+	return []model.NodeEdit{{
+		Line:   result.EndNode.Line,
+		Column: result.EndNode.Column,
+		Value:  trimTrailingWhitespace(result.EndNode.Value),
+	}}
+}
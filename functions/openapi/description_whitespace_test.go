@@ -0,0 +1,66 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var descriptionWhitespaceTestSpec = "openapi: 3.1.0\n" +
+	"info:\n" +
+	"  description: \"pizza api \"\n" +
+	"paths:\n" +
+	"  /pizza:\n" +
+	"    get:\n" +
+	"      summary: get a pizza\n" +
+	"      description: |\n" +
+	"        a pizza,   \n" +
+	"        with toppings\n" +
+	"components:\n" +
+	"  schemas:\n" +
+	"    Pizza:\n" +
+	"      description: |\n" +
+	"        a pizza\n" +
+	"      properties:\n" +
+	"        description:\n" +
+	"          type: string\n" +
+	"          example: \"trailing \"\n"
+
+func TestDescriptionWhitespace_GetSchema(t *testing.T) {
+	def := DescriptionWhitespace{}
+	assert.Equal(t, "description_whitespace", def.GetSchema().Name)
+}
+
+func TestDescriptionWhitespace_RunRule(t *testing.T) {
+	def := DescriptionWhitespace{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestDescriptionWhitespace_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(descriptionWhitespaceTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "description_whitespace", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := DescriptionWhitespace{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "`description` has trailing whitespace", res[0].Message)
+	assert.Equal(t, "$.info.description", res[0].Path)
+	assert.Equal(t, 3, res[0].StartNode.Line)
+	assert.Equal(t, "$.paths./pizza.get.description", res[1].Path)
+
+	fixes := def.Fix(res[0], ctx)
+	assert.Len(t, fixes, 1)
+	assert.Equal(t, "pizza api", fixes[0].Value)
+	assert.Equal(t, 3, fixes[0].Line)
+	fixes = def.Fix(res[1], ctx)
+	assert.Equal(t, "a pizza,\nwith toppings\n", fixes[0].Value)
+}
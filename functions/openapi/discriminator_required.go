@@ -0,0 +1,134 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DiscriminatorRequired checks the `propertyName` of an OpenAPI 3 discriminator is a property that is defined and
+// required, otherwise input without it can't be told apart. A property is defined and required when the schema
+// (or an `allOf` parent it inherits from) defines it and lists it in `required`. When the schema with the
+// discriminator doesn't, every `oneOf` or `anyOf` branch must. Local references are followed.
+type DiscriminatorRequired struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the DiscriminatorRequired rule.
+func (dr DiscriminatorRequired) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "discriminator_required",
+	}
+}
+
+// RunRule will execute the DiscriminatorRequired rule, based on supplied context and a supplied []*yaml.Node slice.
+func (dr DiscriminatorRequired) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	walker.ForEachSchema(root, func(schema walker.Schema) bool {
+		discriminatorKey, discriminator := utils.FindKeyNodeTop("discriminator", schema.Node.Content)
+		if !utils.IsNodeMap(discriminator) {
+			return !context.IsCancelled()
+		}
+		_, propertyName := utils.FindKeyNodeTop("propertyName", discriminator.Content)
+		if propertyName == nil || propertyName.Value == "" {
+			return !context.IsCancelled()
+		}
+		name := propertyName.Value
+
+		report := func(msg string) {
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: discriminatorKey,
+				EndNode:   utils.FindLastChildNodeWithLevel(discriminator, 0),
+				Path:      schema.JSONPath + ".discriminator",
+				Rule:      context.Rule,
+			})
+		}
+		problem := func(defined, required bool) string {
+			switch {
+			case !defined:
+				return "is not defined"
+			case !required:
+				return "is defined, but not required"
+			}
+			return ""
+		}
+
+		defined, required := inheritedProperty(root, schema.Node, name, make(map[*yaml.Node]bool))
+		if defined && required {
+			return !context.IsCancelled()
+		}
+
+		branched := false
+		for _, composition := range []string{"oneOf", "anyOf"} {
+			_, branches := utils.FindKeyNodeTop(composition, schema.Node.Content)
+			if !utils.IsNodeArray(branches) {
+				continue
+			}
+			branched = true
+			for i, branch := range branches.Content {
+				resolved := resolveSchemaReference(root, branch)
+				if resolved == nil {
+					continue // unresolved references are reported by other rules.
+				}
+				bDefined, bRequired := inheritedProperty(root, resolved, name, make(map[*yaml.Node]bool))
+				label := fmt.Sprintf("`%s` branch %d", composition, i)
+				if _, ref := utils.FindKeyNodeTop("$ref", branch.Content); ref != nil {
+					label = fmt.Sprintf("`%s` branch %d (`%s`)", composition, i, ref.Value)
+				}
+				if p := problem(defined || bDefined, required || bRequired); p != "" {
+					report(fmt.Sprintf("discriminator property `%s` %s in %s", name, p, label))
+				}
+			}
+		}
+		if !branched {
+			report(fmt.Sprintf("discriminator property `%s` %s in the schema", name, problem(defined, required)))
+		}
+		return !context.IsCancelled()
+	})
+	return results
+}
+
+// inheritedProperty returns whether a schema, or the `allOf` parents it inherits from, defines a property, and
+// whether it requires it.
+func inheritedProperty(root, schema *yaml.Node, name string, seen map[*yaml.Node]bool) (defined, required bool) {
+	schema = resolveSchemaReference(root, schema)
+	if schema == nil || seen[schema] {
+		return false, false
+	}
+	seen[schema] = true
+
+	if _, props := utils.FindKeyNodeTop("properties", schema.Content); utils.IsNodeMap(props) {
+		for i := 0; i < len(props.Content)-1; i += 2 {
+			defined = defined || props.Content[i].Value == name
+		}
+	}
+	if _, req := utils.FindKeyNodeTop("required", schema.Content); utils.IsNodeArray(req) {
+		for _, r := range req.Content {
+			required = required || r.Value == name
+		}
+	}
+	if _, allOf := utils.FindKeyNodeTop("allOf", schema.Content); utils.IsNodeArray(allOf) {
+		for _, parent := range allOf.Content {
+			pDefined, pRequired := inheritedProperty(root, parent, name, seen)
+			defined, required = defined || pDefined, required || pRequired
+		}
+	}
+	return defined, required
+}
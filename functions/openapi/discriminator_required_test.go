@@ -0,0 +1,89 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var discriminatorRequiredTestSpec = `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      discriminator:
+        propertyName: petType
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+        - type: object
+    PetBase:
+      type: object
+      required: [petType]
+      properties:
+        petType:
+          type: string
+    Cat:
+      allOf:
+        - $ref: '#/components/schemas/PetBase'
+        - properties:
+            meow:
+              type: boolean
+    Dog:
+      properties:
+        petType:
+          type: string
+    Vehicle:
+      discriminator:
+        propertyName: kind
+      properties:
+        kind:
+          type: string
+    Shape:
+      discriminator:
+        propertyName: type
+      properties:
+        name:
+          type: string
+    Animal:
+      discriminator:
+        propertyName: kind
+      required: [kind]
+      properties:
+        kind:
+          type: string`
+
+func TestDiscriminatorRequired_GetSchema(t *testing.T) {
+	def := DiscriminatorRequired{}
+	assert.Equal(t, "discriminator_required", def.GetSchema().Name)
+}
+
+func TestDiscriminatorRequired_RunRule(t *testing.T) {
+	def := DiscriminatorRequired{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestDiscriminatorRequired_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(discriminatorRequiredTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "discriminator_required", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := DiscriminatorRequired{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "discriminator property `petType` is defined, but not required in `oneOf` branch 1 "+
+		"(`#/components/schemas/Dog`)", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pet.discriminator", res[0].Path)
+	assert.Equal(t, 5, res[0].StartNode.Line)
+	assert.Equal(t, "discriminator property `petType` is not defined in `oneOf` branch 2", res[1].Message)
+	assert.Equal(t, "discriminator property `kind` is defined, but not required in the schema", res[2].Message)
+	assert.Equal(t, "$.components.schemas.Vehicle.discriminator", res[2].Path)
+	assert.Equal(t, "discriminator property `type` is not defined in the schema", res[3].Message)
+}
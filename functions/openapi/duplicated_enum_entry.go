@@ -33,6 +33,9 @@ func (de DuplicatedEnum) RunRule(nodes []*yaml.Node, context model.RuleFunctionC
 	enums := context.Index.GetAllEnums()
 
 	for _, enum := range enums {
+		if context.IsCancelled() {
+			break
+		}
 
 		duplicates := utils.CheckEnumForDuplicates(enum.Node.Content)
 
@@ -0,0 +1,171 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// EmptySchemasResponses checks for empty schemas (`{}`, without a single keyword) and responses that have neither
+// a `description` nor `content` (or a `schema` for swagger). Both are almost always an authoring mistake. An empty
+// `additionalProperties` schema is a common way of allowing any additional property, so it's allowed by default,
+// set the `allowEmptyAdditionalProperties` option to false to report those as well.
+type EmptySchemasResponses struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the EmptySchemasResponses rule.
+func (es EmptySchemasResponses) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "empty_schemas_responses",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "allowEmptyAdditionalProperties",
+				Description: "allow `additionalProperties: {}` to permit any additional property (defaults to true)",
+			},
+		},
+		ErrorMessage: "'empty_schemas_responses' function has invalid options supplied.",
+	}
+}
+
+// RunRule will execute the EmptySchemasResponses rule, based on supplied context and a supplied []*yaml.Node slice.
+func (es EmptySchemasResponses) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	allowAdditional := getBoolOption("allowEmptyAdditionalProperties", context.Options, true)
+	seen := make(map[*yaml.Node]bool)
+
+	var checkSchema func(key, schema *yaml.Node, path string)
+	checkSchema = func(key, schema *yaml.Node, path string) {
+		if schema == nil || !utils.IsNodeMap(schema) || seen[schema] {
+			return
+		}
+		seen[schema] = true
+
+		if len(schema.Content) == 0 {
+			if key.Value == "additionalProperties" && allowAdditional {
+				return
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message:   fmt.Sprintf("schema `%s` is empty, it does not define any keywords", key.Value),
+				StartNode: key,
+				EndNode:   schema,
+				Path:      path,
+				Rule:      context.Rule,
+			})
+			return
+		}
+
+		// recurse into the schema tree.
+		for i := 0; i < len(schema.Content)-1; i += 2 {
+			k, value := schema.Content[i], schema.Content[i+1]
+			childPath := fmt.Sprintf("%s.%s", path, k.Value)
+			switch k.Value {
+			case "properties", "patternProperties":
+				if utils.IsNodeMap(value) {
+					for p := 0; p < len(value.Content)-1; p += 2 {
+						checkSchema(value.Content[p], value.Content[p+1],
+							fmt.Sprintf("%s.%s", childPath, value.Content[p].Value))
+					}
+				}
+			case "allOf", "oneOf", "anyOf", "prefixItems":
+				if utils.IsNodeArray(value) {
+					for x, s := range value.Content {
+						checkSchema(k, s, fmt.Sprintf("%s[%d]", childPath, x))
+					}
+				}
+			case "items":
+				if utils.IsNodeArray(value) {
+					for x, s := range value.Content {
+						checkSchema(k, s, fmt.Sprintf("%s[%d]", childPath, x))
+					}
+				} else {
+					checkSchema(k, value, childPath)
+				}
+			case "additionalProperties", "not", "contains", "if", "then", "else":
+				checkSchema(k, value, childPath)
+			}
+		}
+	}
+
+	checkResponses := func(responses *yaml.Node, path string) {
+		if !utils.IsNodeMap(responses) {
+			return
+		}
+		for r := 0; r < len(responses.Content)-1; r += 2 {
+			code, response := responses.Content[r], responses.Content[r+1]
+			if !utils.IsNodeMap(response) {
+				continue
+			}
+			if _, ref := utils.FindKeyNodeTop("$ref", response.Content); ref != nil {
+				continue
+			}
+			_, desc := utils.FindKeyNodeTop("description", response.Content)
+			_, content := utils.FindKeyNodeTop("content", response.Content)
+			_, schema := utils.FindKeyNodeTop("schema", response.Content)
+			if desc != nil || content != nil || schema != nil {
+				continue
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message:   fmt.Sprintf("response `%s` is empty, it has neither a `description` nor `content`", code.Value),
+				StartNode: code,
+				EndNode:   utils.FindLastChildNodeWithLevel(response, 0),
+				Path:      fmt.Sprintf("%s.%s", path, code.Value),
+				Rule:      context.Rule,
+			})
+		}
+	}
+
+	// walk the document looking for schemas, responses, component schemas, definitions and any 'schema' keys.
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				switch {
+				case key.Value == "example" || key.Value == "examples":
+					continue
+				case key.Value == "schema":
+					checkSchema(key, value, childPath)
+				case (path == "$.components" && key.Value == "schemas") || (path == "$" && key.Value == "definitions"):
+					if utils.IsNodeMap(value) {
+						for s := 0; s < len(value.Content)-1; s += 2 {
+							checkSchema(value.Content[s], value.Content[s+1],
+								fmt.Sprintf("%s.%s", childPath, value.Content[s].Value))
+						}
+					}
+				case key.Value == "responses":
+					checkResponses(value, childPath)
+					walk(value, childPath)
+				default:
+					walk(value, childPath)
+				}
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
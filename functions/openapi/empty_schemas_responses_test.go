@@ -0,0 +1,97 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var emptySchemasResponsesTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {}
+              example: {}
+        "404": {}
+        "500":
+          $ref: '#/components/responses/Error'
+components:
+  responses:
+    Error:
+      headers:
+        X-Trace: {}
+  schemas:
+    Empty: {}
+    Pizza:
+      type: object
+      additionalProperties: {}
+      properties:
+        toppings:
+          type: array
+          items: {}
+        name:
+          type: string`
+
+func TestEmptySchemasResponses_GetSchema(t *testing.T) {
+	def := EmptySchemasResponses{}
+	assert.Equal(t, "empty_schemas_responses", def.GetSchema().Name)
+}
+
+func TestEmptySchemasResponses_RunRule(t *testing.T) {
+	def := EmptySchemasResponses{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestEmptySchemasResponses_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(emptySchemasResponsesTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "empty_schemas_responses", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := EmptySchemasResponses{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 5)
+	assert.Equal(t, "response `404` is empty, it has neither a `description` nor `content`", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get.responses.404", res[0].Path)
+	assert.Equal(t, 12, res[0].StartNode.Line)
+	assert.Equal(t, "schema `schema` is empty, it does not define any keywords", res[1].Message)
+	assert.Equal(t, "$.paths./pizza.get.responses.200.content.application/json.schema", res[1].Path)
+	assert.Equal(t, "response `Error` is empty, it has neither a `description` nor `content`", res[2].Message)
+	assert.Equal(t, "$.components.responses.Error", res[2].Path)
+	assert.Equal(t, "$.components.schemas.Empty", res[3].Path)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.toppings.items", res[4].Path)
+}
+
+func TestEmptySchemasResponses_RunRule_AdditionalProperties(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(emptySchemasResponsesTestSpec), path)
+
+	opts := map[string]interface{}{
+		"allowEmptyAdditionalProperties": false,
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "empty_schemas_responses", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := EmptySchemasResponses{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 6)
+	assert.Equal(t, "schema `additionalProperties` is empty, it does not define any keywords", res[4].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.additionalProperties", res[4].Path)
+}
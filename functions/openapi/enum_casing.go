@@ -0,0 +1,165 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// EnumCasing checks the values of string enums use a consistent casing style. If a casing `type` is configured,
+// every value must use it. Otherwise the style used by most values in the enum wins, and values using another style
+// are reported. Enums that mix casing on purpose (like country or currency codes) can be exempt by adding the name of
+// the schema (or property) that defines them to the `ignoreSchemas` option.
+type EnumCasing struct {
+}
+
+// enumCasingOrder is the order casing styles are preferred in, when more than one style is used by most values.
+var enumCasingOrder = []string{"flat", "camel", "pascal", "kebab", "snake", "macro", "cobol", "train"}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the EnumCasing rule.
+func (ec EnumCasing) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "enum_casing",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name: "type",
+				Description: "the casing type every enum value must use, one of 'flat', 'camel', 'pascal', 'kebab', " +
+					"'cobol', 'snake', 'macro' or 'train' (defaults to the style used by most values of each enum)",
+			},
+			{
+				Name:        "ignoreSchemas",
+				Description: "names of schemas (or properties) with enums that are allowed to mix casing styles",
+			},
+		},
+		ErrorMessage: "'enum_casing' function has invalid options supplied. Example valid options are 'type' = 'macro' " +
+			"or 'ignoreSchemas' = ['CountryCode']",
+	}
+}
+
+// RunRule will execute the EnumCasing rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ec EnumCasing) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	casing := getStringOption("type", context.Options, "")
+	if parameterCasing[casing] == nil {
+		casing = ""
+	}
+	ignored := make(map[string]bool)
+	for _, s := range getStringArrayOption("ignoreSchemas", context.Options, nil) {
+		ignored[s] = true
+	}
+
+	checkEnum := func(schema *yaml.Node, path string) {
+		enumKey, enum := utils.FindKeyNodeTop("enum", schema.Content)
+		if enum == nil || !utils.IsNodeArray(enum) {
+			return
+		}
+		if _, typeNode := utils.FindKeyNodeTop("type", schema.Content); typeNode != nil {
+			isString := typeNode.Value == "string"
+			for _, t := range typeNode.Content {
+				isString = isString || t.Value == "string"
+			}
+			if !isString {
+				return
+			}
+		}
+		var values []*yaml.Node
+		for _, v := range enum.Content {
+			if v.Kind == yaml.ScalarNode && v.Tag == "!!str" && v.Value != "" {
+				values = append(values, v)
+			}
+		}
+		if len(values) < 2 && casing == "" {
+			return
+		}
+
+		style := casing
+		if style == "" {
+			best := 0
+			for _, c := range enumCasingOrder {
+				count := 0
+				for _, v := range values {
+					if parameterCasing[c].MatchString(v.Value) {
+						count++
+					}
+				}
+				if count > best {
+					style, best = c, count
+				}
+			}
+			if style == "" {
+				return // no value uses a known style, so there is nothing to be consistent with.
+			}
+		}
+
+		var offending []string
+		for _, v := range values {
+			if !parameterCasing[style].MatchString(v.Value) {
+				offending = append(offending, v.Value)
+			}
+		}
+		if len(offending) == 0 {
+			return
+		}
+		msg := fmt.Sprintf("enum values `%s` are not %s case", strings.Join(offending, "`, `"), style)
+		if casing == "" {
+			msg = fmt.Sprintf("%s, like the other values of the enum", msg)
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message:   msg,
+			StartNode: enumKey,
+			EndNode:   utils.FindLastChildNodeWithLevel(enum, 0),
+			Path:      fmt.Sprintf("%s.enum", path),
+			Rule:      context.Rule,
+		})
+	}
+
+	// walk the document looking for enums, the name of the schema (or property) being walked is tracked,
+	// so enums can be ignored.
+	var walk func(node *yaml.Node, path string, name string)
+	walk = func(node *yaml.Node, path string, name string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i), name)
+			}
+		case yaml.MappingNode:
+			if !ignored[name] {
+				checkEnum(node, path)
+			}
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if key.Value == "example" || key.Value == "examples" || key.Value == "enum" {
+					continue
+				}
+				childName := name
+				if strings.HasSuffix(path, ".properties") || path == "$.components.schemas" ||
+					path == "$.definitions" {
+					childName = key.Value
+				}
+				walk(value, fmt.Sprintf("%s.%s", path, key.Value), childName)
+			}
+		}
+	}
+	walk(root, "$", "")
+	return results
+}
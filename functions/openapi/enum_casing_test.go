@@ -0,0 +1,104 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var enumCasingTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      parameters:
+        - in: query
+          name: size
+          schema:
+            type: string
+            enum: [small, MEDIUM, large]
+components:
+  schemas:
+    Status:
+      type: string
+      enum: [Active, active, ACTIVE, pending]
+    Order:
+      type: object
+      properties:
+        state:
+          type: string
+          enum: [in_progress, done, Cancelled]
+        country:
+          type: string
+          enum: [GB, us, DE]
+        slices:
+          type: integer
+          enum: [4, 8]
+    CountryCode:
+      type: string
+      enum: [GB, us, DE]
+    Phrases:
+      enum: [Hello World, Good Bye]`
+
+func TestEnumCasing_GetSchema(t *testing.T) {
+	def := EnumCasing{}
+	assert.Equal(t, "enum_casing", def.GetSchema().Name)
+}
+
+func TestEnumCasing_RunRule(t *testing.T) {
+	def := EnumCasing{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestEnumCasing_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(enumCasingTestSpec), path)
+
+	opts := map[string]interface{}{
+		"ignoreSchemas": []interface{}{"CountryCode", "country"},
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "enum_casing", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := EnumCasing{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "enum values `MEDIUM` are not flat case, like the other values of the enum", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get.parameters[0].schema.enum", res[0].Path)
+	assert.Equal(t, 10, res[0].StartNode.Line)
+	assert.Equal(t, "enum values `Active`, `ACTIVE` are not flat case, like the other values of the enum",
+		res[1].Message)
+	assert.Equal(t, "$.components.schemas.Status.enum", res[1].Path)
+	assert.Equal(t, "enum values `Cancelled` are not snake case, like the other values of the enum", res[2].Message)
+	assert.Equal(t, "$.components.schemas.Order.properties.state.enum", res[2].Path)
+}
+
+func TestEnumCasing_RunRule_Type(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(enumCasingTestSpec), path)
+
+	opts := map[string]interface{}{
+		"type": "macro",
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "enum_casing", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := EnumCasing{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 6)
+	assert.Equal(t, "enum values `small`, `large` are not macro case", res[0].Message)
+	assert.Equal(t, "enum values `us` are not macro case", res[4].Message)
+	assert.Equal(t, "$.components.schemas.CountryCode.enum", res[4].Path)
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// EnumExamples checks the `default` and `example` (and 3.1 `examples`) of a schema with an `enum` are members of the
+// enum, `null` is allowed for nullable schemas. Long enums are hard to read in documentation, so enums with at least
+// `minEnumSize` values (defaults to 5, 0 turns this off) must have an `example`, showing what a typical value looks
+// like.
+type EnumExamples struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the EnumExamples rule.
+func (ee EnumExamples) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "enum_examples",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "minEnumSize",
+				Description: "the number of enum values that needs an example (defaults to 5, 0 turns it off)",
+			},
+		},
+		ErrorMessage: "'enum_examples' function has invalid options supplied. Example valid options are " +
+			"'minEnumSize' = 10",
+	}
+}
+
+// RunRule will execute the EnumExamples rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ee EnumExamples) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	minEnumSize := getIntOption("minEnumSize", context.Options, 5)
+
+	walker.ForEachSchema(root, func(schema walker.Schema) bool {
+		_, enum := utils.FindKeyNodeTop("enum", schema.Node.Content)
+		if schema.Ref != "" || enum == nil || !utils.IsNodeArray(enum) || len(enum.Content) == 0 {
+			return !context.IsCancelled()
+		}
+
+		nullable := false
+		if _, typeNode := utils.FindKeyNodeTop("type", schema.Node.Content); utils.IsNodeArray(typeNode) {
+			for _, t := range typeNode.Content {
+				nullable = nullable || t.Value == "null"
+			}
+		}
+		if _, n := utils.FindKeyNodeTop("nullable", schema.Node.Content); n != nil && n.Value == "true" {
+			nullable = true
+		}
+		member := func(value *yaml.Node) bool {
+			if nullable && enumMemberType(value) == "null" {
+				return true
+			}
+			for _, m := range enum.Content {
+				if nodesEqual(m, value) {
+					return true
+				}
+			}
+			return false
+		}
+		report := func(msg string, key, value *yaml.Node) {
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: key,
+				EndNode:   utils.FindLastChildNodeWithLevel(value, 0),
+				Path:      schema.JSONPath,
+				Rule:      context.Rule,
+			})
+		}
+
+		if defaultKey, def := utils.FindKeyNodeTop("default", schema.Node.Content); def != nil && !member(def) {
+			report(fmt.Sprintf("schema `default` value `%s` is not a member of its `enum`", def.Value), defaultKey, def)
+		}
+		exampleKey, example := utils.FindKeyNodeTop("example", schema.Node.Content)
+		if example != nil && !member(example) {
+			report(fmt.Sprintf("schema `example` value `%s` is not a member of its `enum`", example.Value),
+				exampleKey, example)
+		}
+		examplesKey, examples := utils.FindKeyNodeTop("examples", schema.Node.Content)
+		if examples != nil && utils.IsNodeArray(examples) {
+			for i, e := range examples.Content {
+				if !member(e) {
+					report(fmt.Sprintf("schema `examples[%d]` value `%s` is not a member of its `enum`", i, e.Value),
+						examplesKey, e)
+				}
+			}
+		}
+
+		if minEnumSize > 0 && len(enum.Content) >= minEnumSize && example == nil &&
+			(examples == nil || len(examples.Content) == 0) {
+			enumKey, _ := utils.FindKeyNodeTop("enum", schema.Node.Content)
+			report(fmt.Sprintf("schema has an `enum` of %d values, but no `example` showing a typical value",
+				len(enum.Content)), enumKey, enum)
+		}
+		return !context.IsCancelled()
+	})
+	return results
+}
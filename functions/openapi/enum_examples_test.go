@@ -0,0 +1,89 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var enumExamplesTestSpec = `openapi: 3.1.0
+components:
+  schemas:
+    Size:
+      type: string
+      enum: [small, medium, large]
+      default: regular
+      example: medium
+    Topping:
+      type: string
+      enum: [cheese, ham, pineapple, olives, basil, mushroom]
+    Crust:
+      type: [string, "null"]
+      enum: [thin, thick, stuffed, deep, cracker]
+      default: null
+      examples:
+        - thin
+        - soggy
+    Slices:
+      type: integer
+      enum: [4, 6, 8]
+      example: "6"`
+
+func TestEnumExamples_GetSchema(t *testing.T) {
+	def := EnumExamples{}
+	assert.Equal(t, "enum_examples", def.GetSchema().Name)
+}
+
+func TestEnumExamples_RunRule(t *testing.T) {
+	def := EnumExamples{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestEnumExamples_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(enumExamplesTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "enum_examples", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := EnumExamples{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "schema `default` value `regular` is not a member of its `enum`", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Size", res[0].Path)
+	assert.Equal(t, 7, res[0].StartNode.Line)
+	assert.Equal(t, "schema has an `enum` of 6 values, but no `example` showing a typical value", res[1].Message)
+	assert.Equal(t, "$.components.schemas.Topping", res[1].Path)
+	assert.Equal(t, "schema `examples[1]` value `soggy` is not a member of its `enum`", res[2].Message)
+	assert.Equal(t, "schema `example` value `6` is not a member of its `enum`", res[3].Message)
+}
+
+func TestEnumExamples_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(enumExamplesTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["minEnumSize"] = 3
+
+	rule := buildOpenApiTestRuleAction(path, "enum_examples", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := EnumExamples{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+
+	opts["minEnumSize"] = 0
+	res = def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// EnumTypes checks every member of a schema's `enum` against the schema's `type` and `format`, and reports each
+// member that doesn't match. Unlike typed_enum, every type of a 3.1 type array is honored, `null` members are
+// valid when the type array contains `null` (or the schema is `nullable` in 3.0), and the members of schemas with
+// a `format` (int32, int64, date, date-time and uuid) are checked against it. Schemas without a type are ignored.
+type EnumTypes struct {
+}
+
+var enumUUID = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the EnumTypes rule.
+func (et EnumTypes) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "enum_types",
+	}
+}
+
+// enumMemberType returns the JSON type of an enum member, integers are `integer`, other numbers are `number`.
+func enumMemberType(member *yaml.Node) string {
+	switch member.Kind {
+	case yaml.SequenceNode:
+		return "array"
+	case yaml.MappingNode:
+		return "object"
+	case yaml.AliasNode:
+		if member.Alias != nil {
+			return enumMemberType(member.Alias)
+		}
+	}
+	switch member.Tag {
+	case "!!null":
+		return "null"
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		// a whole number like 1.0 is an integer, as far as JSON Schema is concerned.
+		if f, err := strconv.ParseFloat(member.Value, 64); err == nil && f == math.Trunc(f) && !math.IsInf(f, 0) {
+			return "integer"
+		}
+		return "number"
+	}
+	return "string"
+}
+
+// enumFormatValid returns false if an enum member doesn't match a format. Formats that aren't checked are valid.
+func enumFormatValid(member *yaml.Node, memberType, format string) bool {
+	switch {
+	case memberType == "integer" && (format == "int32" || format == "int64"):
+		bits := 32
+		if format == "int64" {
+			bits = 64
+		}
+		_, err := strconv.ParseInt(member.Value, 0, bits)
+		return err == nil
+	case memberType != "string":
+		return true
+	case format == "date":
+		_, err := time.Parse("2006-01-02", member.Value)
+		return err == nil
+	case format == "date-time":
+		_, err := time.Parse(time.RFC3339, member.Value)
+		return err == nil
+	case format == "uuid":
+		return enumUUID.MatchString(member.Value)
+	}
+	return true
+}
+
+// RunRule will execute the EnumTypes rule, based on supplied context and a supplied []*yaml.Node slice.
+func (et EnumTypes) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	walker.ForEachSchema(root, func(schema walker.Schema) bool {
+		_, enum := utils.FindKeyNodeTop("enum", schema.Node.Content)
+		_, typeNode := utils.FindKeyNodeTop("type", schema.Node.Content)
+		if enum == nil || typeNode == nil || !utils.IsNodeArray(enum) {
+			return !context.IsCancelled()
+		}
+
+		var types []string
+		if utils.IsNodeArray(typeNode) {
+			for _, t := range typeNode.Content {
+				types = append(types, t.Value)
+			}
+		} else {
+			types = append(types, typeNode.Value)
+		}
+		if _, nullable := utils.FindKeyNodeTop("nullable", schema.Node.Content); nullable != nil &&
+			nullable.Value == "true" {
+			types = append(types, "null")
+		}
+		var format string
+		if _, formatNode := utils.FindKeyNodeTop("format", schema.Node.Content); formatNode != nil {
+			format = formatNode.Value
+		}
+
+		for i, member := range enum.Content {
+			memberType := enumMemberType(member)
+			matches := false
+			for _, t := range types {
+				matches = matches || t == memberType || (t == "number" && memberType == "integer")
+			}
+			var msg string
+			switch {
+			case !matches:
+				msg = fmt.Sprintf("enum member `%s` is of type `%s`, but the schema type is %s", member.Value,
+					memberType, joinNames(types, "or"))
+			case !enumFormatValid(member, memberType, format):
+				msg = fmt.Sprintf("enum member `%s` is not a valid `%s`", member.Value, format)
+			default:
+				continue
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: member,
+				EndNode:   member,
+				Path:      fmt.Sprintf("%s.enum[%d]", schema.JSONPath, i),
+				Rule:      context.Rule,
+			})
+		}
+		return !context.IsCancelled()
+	})
+	return results
+}
@@ -0,0 +1,113 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnumTypes_GetSchema(t *testing.T) {
+	def := EnumTypes{}
+	assert.Equal(t, "enum_types", def.GetSchema().Name)
+}
+
+func TestEnumTypes_RunRule(t *testing.T) {
+	def := EnumTypes{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestEnumTypes_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      parameters:
+        - name: size
+          in: query
+          schema:
+            type: integer
+            enum: ["a", "b"]
+components:
+  schemas:
+    Topping:
+      type: [string, "null"]
+      enum: [cheese, null, 3]
+    Slices:
+      type: integer
+      format: int32
+      enum: [4, 8, 3000000000, 1.5]
+    Baked:
+      type: string
+      format: date
+      enum: ["2023-01-01", "yesterday"]
+    Size:
+      type: string
+      enum: [small, large]
+      nullable: false
+      properties:
+        inches:
+          type: number
+          enum: [10, 12.5, true]`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "enum_types", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := EnumTypes{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 7)
+	assert.Equal(t, "enum member `3` is of type `integer`, but the schema type is `string` or `null`", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Topping.enum[2]", res[0].Path)
+	assert.Equal(t, 15, res[0].StartNode.Line)
+	assert.Equal(t, "enum member `3000000000` is not a valid `int32`", res[1].Message)
+	assert.Equal(t, "enum member `1.5` is of type `number`, but the schema type is `integer`", res[2].Message)
+	assert.Equal(t, "$.components.schemas.Slices.enum[3]", res[2].Path)
+	assert.Equal(t, "enum member `yesterday` is not a valid `date`", res[3].Message)
+	assert.Equal(t, "enum member `true` is of type `boolean`, but the schema type is `number`", res[4].Message)
+	assert.Equal(t, "enum member `a` is of type `string`, but the schema type is `integer`", res[5].Message)
+	assert.Equal(t, "$.paths./pizza.get.parameters[0].schema.enum[0]", res[5].Path)
+	assert.Equal(t, "enum member `b` is of type `string`, but the schema type is `integer`", res[6].Message)
+}
+
+func TestEnumTypes_RunRule_Success(t *testing.T) {
+
+	yml := `openapi: 3.0.3
+components:
+  schemas:
+    Topping:
+      type: string
+      nullable: true
+      enum: [cheese, null]
+    Slices:
+      type: number
+      enum: [4, 8.5, 1.0]
+    Id:
+      type: string
+      format: uuid
+      enum: [6ba7b810-9dad-11d1-80b4-00c04fd430c8]
+    Untyped:
+      enum: [1, a, true]
+    Answer:
+      type: string
+      enum: [yes, no]`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "enum_types", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := EnumTypes{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ExampleKeys checks the names of media type `examples` say what each example is, documentation renders them as a
+// list to pick from, and `example1`, `example2` tells readers nothing. Names matching one of the regular expressions
+// in the `genericKeys` option (names like `example1`, `sample` or `ex_2` by default) are reported. Each example must
+// also have a `summary` or a `description`, referenced examples are resolved before they are checked.
+type ExampleKeys struct {
+}
+
+var defaultGenericExampleKeys = []string{`(?i)^(example|sample|ex|test)[-_ ]?\d*$`, `^\d+$`}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ExampleKeys rule.
+func (ek ExampleKeys) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "example_keys",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "genericKeys",
+				Description: "regular expressions matching example names that say nothing, like '^example\\d*$'",
+			},
+		},
+		ErrorMessage: "'example_keys' function has invalid options supplied. Example valid options are " +
+			"'genericKeys' = ['^example\\d*$', '^sample$']",
+	}
+}
+
+// RunRule will execute the ExampleKeys rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ek ExampleKeys) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	var genericKeys []*regexp.Regexp
+	for _, p := range getStringArrayOption("genericKeys", context.Options, defaultGenericExampleKeys) {
+		rx, err := regexp.Compile(p)
+		if err != nil {
+			return []model.RuleFunctionResult{
+				{
+					Message:   fmt.Sprintf("unable to compile `genericKeys` pattern '%s': %s", p, err.Error()),
+					StartNode: root,
+					EndNode:   root,
+					Path:      "$",
+					Rule:      context.Rule,
+				},
+			}
+		}
+		genericKeys = append(genericKeys, rx)
+	}
+
+	checkMediaType := func(name string, mediaType *yaml.Node, path string) {
+		if !utils.IsNodeMap(mediaType) {
+			return
+		}
+		_, examples := utils.FindKeyNodeTop("examples", mediaType.Content)
+		if examples == nil || !utils.IsNodeMap(examples) {
+			return
+		}
+		for i := 0; i < len(examples.Content)-1; i += 2 {
+			key, example := examples.Content[i], examples.Content[i+1]
+			examplePath := fmt.Sprintf("%s.examples.%s", path, key.Value)
+			add := func(msg string) {
+				results = append(results, model.RuleFunctionResult{
+					Message:   msg,
+					StartNode: key,
+					EndNode:   utils.FindLastChildNodeWithLevel(example, 0),
+					Path:      examplePath,
+					Rule:      context.Rule,
+				})
+			}
+			for _, rx := range genericKeys {
+				if rx.MatchString(key.Value) {
+					add(fmt.Sprintf("example `%s` of media type `%s` has a generic name, the name should say "+
+						"what the example is", key.Value, name))
+					break
+				}
+			}
+			// references to other documents can't be checked.
+			if example = resolveSchemaReference(root, example); example == nil {
+				continue
+			}
+			_, summary := utils.FindKeyNodeTop("summary", example.Content)
+			_, desc := utils.FindKeyNodeTop("description", example.Content)
+			if (summary == nil || summary.Value == "") && (desc == nil || desc.Value == "") {
+				add(fmt.Sprintf("example `%s` of media type `%s` has no `summary` or `description`", key.Value,
+					name))
+			}
+		}
+	}
+
+	// walk the document looking for 'content' maps, each value is a media type.
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				switch key.Value {
+				case "example", "examples", "schema":
+					continue
+				case "content":
+					if utils.IsNodeMap(value) {
+						for m := 0; m < len(value.Content)-1; m += 2 {
+							checkMediaType(value.Content[m].Value, value.Content[m+1],
+								fmt.Sprintf("%s.%s", childPath, value.Content[m].Value))
+						}
+						continue
+					}
+				}
+				walk(value, childPath)
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
@@ -0,0 +1,115 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var exampleKeysTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    post:
+      requestBody:
+        content:
+          application/json:
+            examples:
+              example1:
+                summary: a margherita
+                value:
+                  name: margherita
+              vegetarian:
+                value:
+                  name: veggie
+              outOfStock:
+                $ref: '#/components/examples/OutOfStock'
+              sample_2:
+                $ref: '#/components/examples/Undescribed'
+              remote:
+                $ref: 'examples.yaml#/Remote'
+components:
+  examples:
+    OutOfStock:
+      description: every topping is sold out
+      value:
+        name: none
+    Undescribed:
+      value:
+        name: plain`
+
+func TestExampleKeys_GetSchema(t *testing.T) {
+	def := ExampleKeys{}
+	assert.Equal(t, "example_keys", def.GetSchema().Name)
+}
+
+func TestExampleKeys_RunRule(t *testing.T) {
+	def := ExampleKeys{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestExampleKeys_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(exampleKeysTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "example_keys", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ExampleKeys{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "example `example1` of media type `application/json` has a generic name, the name should "+
+		"say what the example is", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.post.requestBody.content.application/json.examples.example1", res[0].Path)
+	assert.Equal(t, 9, res[0].StartNode.Line)
+	assert.Equal(t, "example `vegetarian` of media type `application/json` has no `summary` or `description`",
+		res[1].Message)
+	assert.Equal(t, "example `sample_2` of media type `application/json` has a generic name, the name should "+
+		"say what the example is", res[2].Message)
+	assert.Equal(t, "example `sample_2` of media type `application/json` has no `summary` or `description`",
+		res[3].Message)
+}
+
+func TestExampleKeys_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(exampleKeysTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "example_keys", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = map[string]interface{}{
+		"genericKeys": []interface{}{"^remote$"},
+	}
+
+	def := ExampleKeys{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "example `remote` of media type `application/json` has a generic name, the name should "+
+		"say what the example is", res[2].Message)
+}
+
+func TestExampleKeys_RunRule_BadPattern(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(exampleKeysTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "example_keys", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = map[string]interface{}{
+		"genericKeys": []interface{}{"^(example"},
+	}
+
+	def := ExampleKeys{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Contains(t, res[0].Message, "unable to compile `genericKeys` pattern '^(example'")
+}
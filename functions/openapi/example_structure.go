@@ -0,0 +1,155 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ExampleStructure checks string examples of `object` or `array` schemas, which are usually structured data
+// pasted in as a (multi-line) string. The string is parsed as YAML (which includes JSON), and parse failures are
+// reported. String schemas that declare a JSON or YAML `contentMediaType` really do expect a stringified payload,
+// their examples are parsed as that media type. Schema examples (`example` and `examples`) and media type examples
+// are checked, strings that parse fine, but into the wrong type, are left to the example validation rules.
+type ExampleStructure struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ExampleStructure rule.
+func (es ExampleStructure) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "example_structure",
+	}
+}
+
+// RunRule will execute the ExampleStructure rule, based on supplied context and a supplied []*yaml.Node slice.
+func (es ExampleStructure) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	// expected returns what the string examples of a schema should parse as: a media type (for stringified
+	// payloads), the structured type the schema expects, or nothing if the schema expects neither.
+	expected := func(schema *yaml.Node) (mediaType string, structured string) {
+		for _, t := range schemaTypesOf(schema) {
+			switch t {
+			case "object", "array":
+				structured = t
+			case "string":
+				if _, cmt := utils.FindKeyNodeTop("contentMediaType", schema.Content); cmt != nil {
+					if strings.Contains(cmt.Value, "json") || strings.Contains(cmt.Value, "yaml") {
+						mediaType = cmt.Value
+					}
+				}
+			}
+		}
+		return mediaType, structured
+	}
+
+	check := func(example *yaml.Node, path, mediaType, structured string) {
+		if example.Kind != yaml.ScalarNode || example.Tag != "!!str" {
+			return
+		}
+		var err error
+		var msg string
+		switch {
+		case mediaType != "" && strings.Contains(mediaType, "json"):
+			var v interface{}
+			err = json.Unmarshal([]byte(example.Value), &v)
+			msg = fmt.Sprintf("example does not parse as `%s`", mediaType)
+		case mediaType != "":
+			var v yaml.Node
+			err = yaml.Unmarshal([]byte(example.Value), &v)
+			msg = fmt.Sprintf("example does not parse as `%s`", mediaType)
+		case structured != "":
+			var v yaml.Node
+			err = yaml.Unmarshal([]byte(example.Value), &v)
+			msg = fmt.Sprintf("example is a string, but the schema is an `%s`, and it does not parse as JSON or YAML",
+				structured)
+		}
+		if err == nil {
+			return
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message:   fmt.Sprintf("%s: %s", msg, err.Error()),
+			StartNode: example,
+			EndNode:   example,
+			Path:      path,
+			Rule:      context.Rule,
+		})
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			_, example := utils.FindKeyNodeTop("example", node.Content)
+			_, examples := utils.FindKeyNodeTop("examples", node.Content)
+
+			// schemas have examples of their own.
+			if mediaType, structured := expected(node); mediaType != "" || structured != "" {
+				if example != nil {
+					check(example, fmt.Sprintf("%s.example", path), mediaType, structured)
+				}
+				if utils.IsNodeArray(examples) {
+					for i, ex := range examples.Content {
+						check(ex, fmt.Sprintf("%s.examples[%d]", path, i), mediaType, structured)
+					}
+				}
+			}
+
+			// media types (and parameters) have examples of their schema.
+			if _, schema := utils.FindKeyNodeTop("schema", node.Content); utils.IsNodeMap(schema) {
+				if resolved := resolveSchemaReference(root, schema); resolved != nil {
+					if mediaType, structured := expected(resolved); mediaType != "" || structured != "" {
+						if example != nil {
+							check(example, fmt.Sprintf("%s.example", path), mediaType, structured)
+						}
+						if utils.IsNodeMap(examples) {
+							for i := 0; i < len(examples.Content)-1; i += 2 {
+								if !utils.IsNodeMap(examples.Content[i+1]) {
+									continue
+								}
+								if _, value := utils.FindKeyNodeTop("value", examples.Content[i+1].Content); value != nil {
+									check(value, fmt.Sprintf("%s.examples.%s.value", path, examples.Content[i].Value),
+										mediaType, structured)
+								}
+							}
+						}
+					}
+				}
+			}
+
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if key.Value == "example" || key.Value == "examples" {
+					continue
+				}
+				walk(value, fmt.Sprintf("%s.%s", path, key.Value))
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
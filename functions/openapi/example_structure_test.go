@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var exampleStructureTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pizza'
+            examples:
+              good:
+                value: '{"name": "margherita"}'
+              bad:
+                value: '{"name": "margherita",'
+components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        name:
+          type: string
+      example: |
+        {"name": "margherita"
+    Toppings:
+      type: array
+      examples:
+        - "[cheese, tomato]"
+        - [cheese]
+    Payload:
+      type: string
+      contentMediaType: application/json
+      example: "{name: margherita}"
+    Name:
+      type: string
+      example: "{not: [structured"`
+
+func TestExampleStructure_GetSchema(t *testing.T) {
+	def := ExampleStructure{}
+	assert.Equal(t, "example_structure", def.GetSchema().Name)
+}
+
+func TestExampleStructure_RunRule(t *testing.T) {
+	def := ExampleStructure{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestExampleStructure_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(exampleStructureTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "example_structure", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ExampleStructure{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Contains(t, res[0].Message, "example is a string, but the schema is an `object`, and it does not parse "+
+		"as JSON or YAML: yaml:")
+	assert.Equal(t, "$.paths./pizza.post.requestBody.content.application/json.examples.bad.value", res[0].Path)
+	assert.Equal(t, 14, res[0].StartNode.Line)
+	assert.Equal(t, "$.components.schemas.Pizza.example", res[1].Path)
+	assert.Contains(t, res[2].Message, "example does not parse as `application/json`: invalid character")
+	assert.Equal(t, "$.components.schemas.Payload.example", res[2].Path)
+}
@@ -66,6 +66,9 @@ func (ex Examples) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext
 
 	if ops != nil {
 		for i, op := range ops.Content {
+			if context.IsCancelled() {
+				break
+			}
 			if i%2 == 0 {
 				opPath = op.Value
 				continue
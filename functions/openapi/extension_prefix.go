@@ -0,0 +1,130 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ExtensionPrefix checks specification extensions (`x-*` keys), anywhere in a document, are named with one of the
+// prefixes an organization has standardized on. The `prefixes` option holds glob patterns extensions must match
+// (like `x-acme-*`), nothing is checked until it is configured. Extensions defined by tools, like the `x-logo` and
+// `x-tagGroups` of ReDoc, can't be renamed, the `allowed` option holds the names (or glob patterns) of extensions
+// that don't need a prefix, and defaults to well known ReDoc extensions. Keys that are names rather than
+// extensions, like the names of properties and headers, and the values of examples, are not checked.
+type ExtensionPrefix struct {
+}
+
+// defaultAllowedExtensions are well known extensions, defined by ReDoc.
+var defaultAllowedExtensions = []string{"x-logo", "x-tagGroups", "x-codeSamples", "x-displayName", "x-traitTag",
+	"x-servers", "x-nullable", "x-additionalPropertiesName", "x-explicitMappingOnly", "x-enumDescriptions",
+	"x-ignoredHeaderParameters", "x-summary", "x-badges"}
+
+// extensionPrefixNamedKeys hold maps keyed by names, their keys are not extensions.
+var extensionPrefixNamedKeys = map[string]bool{
+	"properties": true, "patternProperties": true, "headers": true, "scopes": true, "variables": true,
+	"mapping": true, "content": true, "encoding": true, "examples": true, "security": true,
+}
+
+// extensionPrefixDataKeys hold values, not parts of the document, they are not walked.
+var extensionPrefixDataKeys = map[string]bool{
+	"example": true, "default": true, "enum": true, "const": true, "value": true,
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ExtensionPrefix rule.
+func (ep ExtensionPrefix) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "extension_prefix",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "prefixes",
+				Description: "glob patterns extensions must match, like 'x-acme-*'",
+			},
+			{
+				Name:        "allowed",
+				Description: "names (or glob patterns) of extensions that don't need to match, like 'x-logo'",
+			},
+		},
+		ErrorMessage: "'extension_prefix' function has invalid options supplied. Example valid options are " +
+			"'prefixes' = ['x-acme-*'] or 'allowed' = ['x-logo', 'x-amazon-apigateway-*']",
+	}
+}
+
+// RunRule will execute the ExtensionPrefix rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ep ExtensionPrefix) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	prefixes := getStringArrayOption("prefixes", context.Options, nil)
+	if len(prefixes) == 0 {
+		return nil
+	}
+	allowed := getStringArrayOption("allowed", context.Options, defaultAllowedExtensions)
+
+	matches := func(name string, patterns []string) bool {
+		for _, p := range patterns {
+			if ok, _ := path.Match(p, name); ok || p == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	// walk checks the extensions of a node, the keys of named maps are names, not extensions.
+	var walk func(node *yaml.Node, path string, named bool)
+	walk = func(node *yaml.Node, path string, named bool) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i), named)
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				if named {
+					walk(value, childPath, false)
+					continue
+				}
+				if walker.IsExtension(key.Value) {
+					if !matches(key.Value, prefixes) && !matches(key.Value, allowed) {
+						results = append(results, model.RuleFunctionResult{
+							Message: fmt.Sprintf("extension `%s` is not named with a prefix matching %s",
+								key.Value, joinNames(prefixes, "or")),
+							StartNode: key,
+							EndNode:   utils.FindLastChildNodeWithLevel(value, 0),
+							Path:      childPath,
+							Rule:      context.Rule,
+						})
+					}
+					continue
+				}
+				if extensionPrefixDataKeys[key.Value] || (key.Value == "examples" && utils.IsNodeArray(value)) {
+					continue
+				}
+				walk(value, childPath, extensionPrefixNamedKeys[key.Value])
+			}
+		}
+	}
+	walk(root, "$", false)
+	return results
+}
@@ -0,0 +1,123 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var extensionPrefixTestSpec = `openapi: 3.1.0
+info:
+  title: pizza
+  x-logo:
+    url: https://pizza.example.com/logo.png
+  x-acme-team: dough
+x-tagGroups:
+  - name: pizzas
+    tags:
+      - pizzas
+paths:
+  /pizzas:
+    x-internal: true
+    get:
+      x-acme-owner:
+        x-nested: ignored
+      responses:
+        '200':
+          description: pizzas
+          headers:
+            x-rate-limit:
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  x-crust:
+                    type: string
+                    x-cache: hot
+                example:
+                  x-crust: thin
+components:
+  schemas:
+    Pizza:
+      type: object
+      x-go-type: Pizza`
+
+func TestExtensionPrefix_GetSchema(t *testing.T) {
+	def := ExtensionPrefix{}
+	assert.Equal(t, "extension_prefix", def.GetSchema().Name)
+}
+
+func TestExtensionPrefix_RunRule(t *testing.T) {
+	def := ExtensionPrefix{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestExtensionPrefix_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(extensionPrefixTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["prefixes"] = []interface{}{"x-acme-*"}
+
+	rule := buildOpenApiTestRuleAction(path, "extension_prefix", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := ExtensionPrefix{}
+	res := def.RunRule(nodes, ctx)
+
+	// property and header names, example values, and the contents of extensions are not extensions.
+	assert.Len(t, res, 3)
+	assert.Equal(t, "extension `x-internal` is not named with a prefix matching `x-acme-*`", res[0].Message)
+	assert.Equal(t, "$.paths./pizzas.x-internal", res[0].Path)
+	assert.Equal(t, 13, res[0].StartNode.Line)
+	assert.Equal(t, "$.paths./pizzas.get.responses.200.content.application/json.schema.properties.x-crust.x-cache",
+		res[1].Path)
+	assert.Equal(t, "$.components.schemas.Pizza.x-go-type", res[2].Path)
+}
+
+func TestExtensionPrefix_RunRule_Allowed(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(extensionPrefixTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["prefixes"] = []interface{}{"x-acme-*"}
+	opts["allowed"] = []interface{}{"x-internal", "x-go-*", "x-cache"}
+
+	rule := buildOpenApiTestRuleAction(path, "extension_prefix", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := ExtensionPrefix{}
+	res := def.RunRule(nodes, ctx)
+
+	// replacing the allowed extensions replaces the ReDoc defaults.
+	assert.Len(t, res, 2)
+	assert.Equal(t, "$.info.x-logo", res[0].Path)
+	assert.Equal(t, "$.x-tagGroups", res[1].Path)
+}
+
+func TestExtensionPrefix_RunRule_NoPrefixes(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(extensionPrefixTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "extension_prefix", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ExtensionPrefix{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
@@ -35,6 +35,9 @@ func (fd FormDataConsumeCheck) RunRule(nodes []*yaml.Node, context model.RuleFun
 	opParams := context.Index.GetAllParametersFromOperations()
 
 	for path, methodMap := range paths {
+		if context.IsCancelled() {
+			break
+		}
 		var topParams map[string][]*index.Reference
 
 		// check for top params
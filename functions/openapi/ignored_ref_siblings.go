@@ -0,0 +1,92 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// IgnoredRefSiblings checks for properties placed next to a `$ref` in OpenAPI 3.0 documents, and names them. In
+// 3.0 a reference object can't have siblings, they are ignored, so a `description` next to a `$ref` does not apply
+// to anything. Documents that are not 3.0 are ignored, 3.1 allows some siblings. Examples are not checked.
+type IgnoredRefSiblings struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the IgnoredRefSiblings rule.
+func (irs IgnoredRefSiblings) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "ignored_ref_siblings",
+	}
+}
+
+// RunRule will execute the IgnoredRefSiblings rule, based on supplied context and a supplied []*yaml.Node slice.
+func (irs IgnoredRefSiblings) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if _, version := utils.FindKeyNodeTop("openapi", root.Content); version == nil ||
+		!strings.HasPrefix(version.Value, "3.0") {
+		return nil
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			refKey, ref := utils.FindKeyNodeTop("$ref", node.Content)
+			if ref != nil && utils.IsNodeStringValue(ref) {
+				var siblings []string
+				for i := 0; i < len(node.Content)-1; i += 2 {
+					if node.Content[i].Value != "$ref" {
+						siblings = append(siblings, node.Content[i].Value)
+					}
+				}
+				if len(siblings) > 0 {
+					msg := fmt.Sprintf("`$ref` has siblings %s, they are ignored in OpenAPI 3.0",
+						joinNames(siblings, "and"))
+					if len(siblings) == 1 {
+						msg = fmt.Sprintf("`$ref` has a sibling `%s`, it is ignored in OpenAPI 3.0", siblings[0])
+					}
+					results = append(results, model.RuleFunctionResult{
+						Message:   msg,
+						StartNode: refKey,
+						EndNode:   utils.FindLastChildNodeWithLevel(node, 0),
+						Path:      path,
+						Rule:      context.Rule,
+					})
+				}
+				return
+			}
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if key.Value == "example" || key.Value == "examples" {
+					continue
+				}
+				walk(value, fmt.Sprintf("%s.%s", path, key.Value))
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
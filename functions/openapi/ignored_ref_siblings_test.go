@@ -0,0 +1,90 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var ignoredRefSiblingsTestSpec = `openapi: 3.0.3
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pizza'
+                description: a pizza
+                nullable: true
+              example:
+                $ref: not a reference
+                name: margherita
+components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        $ref:
+          type: string
+        base:
+          $ref: '#/components/schemas/Base'
+          description: the base of the pizza
+    Base:
+      type: string`
+
+func TestIgnoredRefSiblings_GetSchema(t *testing.T) {
+	def := IgnoredRefSiblings{}
+	assert.Equal(t, "ignored_ref_siblings", def.GetSchema().Name)
+}
+
+func TestIgnoredRefSiblings_RunRule(t *testing.T) {
+	def := IgnoredRefSiblings{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestIgnoredRefSiblings_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(ignoredRefSiblingsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "ignored_ref_siblings", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := IgnoredRefSiblings{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "`$ref` has siblings `description` and `nullable`, they are ignored in OpenAPI 3.0",
+		res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get.responses.200.content.application/json.schema", res[0].Path)
+	assert.Equal(t, 11, res[0].StartNode.Line)
+	assert.Equal(t, "`$ref` has a sibling `description`, it is ignored in OpenAPI 3.0", res[1].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.base", res[1].Path)
+}
+
+func TestIgnoredRefSiblings_RunRule_OpenAPI31(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(`openapi: 3.1.0
+components:
+  schemas:
+    Pizza:
+      $ref: '#/components/schemas/Base'
+      description: a pizza`), path)
+
+	rule := buildOpenApiTestRuleAction(path, "ignored_ref_siblings", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := IgnoredRefSiblings{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// InfoMetadata checks the governance metadata of `info`, the `check` option selects what is checked, so each
+// check can be configured as its own rule. `contact` requires a contact with an `email` or a `url`. `license`
+// requires a license with a `name`. A license can be identified by an SPDX `identifier` (OpenAPI 3.1) or a `url`,
+// not both, and `identifier` is rejected in documents that are not OpenAPI 3.1.
+type InfoMetadata struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the InfoMetadata rule.
+func (im InfoMetadata) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name:     "info_metadata",
+		Required: []string{"check"},
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "check",
+				Description: "what to check, 'contact' or 'license'",
+			},
+		},
+		MinProperties: 1,
+		ErrorMessage: "'info_metadata' function has invalid options supplied. Example valid options are " +
+			"'check' = 'contact' or 'check' = 'license'",
+	}
+}
+
+// RunRule will execute the InfoMetadata rule, based on supplied context and a supplied []*yaml.Node slice.
+func (im InfoMetadata) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 || context.IsCancelled() {
+		return nil
+	}
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if !utils.IsNodeMap(root) {
+		return nil
+	}
+
+	check := strings.ToLower(getStringOption("check", context.Options, ""))
+	if check != "contact" && check != "license" {
+		return nil
+	}
+
+	infoKey, info := utils.FindKeyNodeTop("info", root.Content)
+	if !utils.IsNodeMap(info) {
+		// a missing info is reported by the schema rules.
+		return nil
+	}
+	result := func(msg string) []model.RuleFunctionResult {
+		return []model.RuleFunctionResult{{
+			Message:   msg,
+			StartNode: infoKey,
+			EndNode:   utils.FindLastChildNodeWithLevel(info, 0),
+			Path:      "$.info",
+			Rule:      context.Rule,
+		}}
+	}
+	has := func(node *yaml.Node, key string) bool {
+		_, value := utils.FindKeyNodeTop(key, node.Content)
+		return value != nil && strings.TrimSpace(value.Value) != ""
+	}
+
+	if check == "contact" {
+		_, contact := utils.FindKeyNodeTop("contact", info.Content)
+		switch {
+		case !utils.IsNodeMap(contact):
+			return result("`info` has no `contact`")
+		case !has(contact, "email") && !has(contact, "url"):
+			return result("`info.contact` has no `email` or `url`")
+		}
+		return nil
+	}
+
+	_, license := utils.FindKeyNodeTop("license", info.Content)
+	if !utils.IsNodeMap(license) {
+		return result("`info` has no `license`")
+	}
+	if !has(license, "name") {
+		return result("`info.license` has no `name`")
+	}
+	_, version := utils.FindKeyNodeTop("openapi", root.Content)
+	is31 := version != nil && strings.HasPrefix(version.Value, "3.1")
+	switch {
+	case has(license, "identifier") && !is31:
+		return result("`info.license` has an `identifier`, which is only supported by OpenAPI 3.1, use a `url`")
+	case has(license, "identifier") && has(license, "url"):
+		return result("`info.license` has an `identifier` and a `url`, only one of them is allowed")
+	}
+	return nil
+}
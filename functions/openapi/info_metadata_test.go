@@ -0,0 +1,85 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func runInfoMetadata(t *testing.T, spec, check string) []model.RuleFunctionResult {
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(spec), path)
+
+	opts := make(map[string]interface{})
+	opts["check"] = check
+
+	rule := buildOpenApiTestRuleAction(path, "info_metadata", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := InfoMetadata{}
+	return def.RunRule(nodes, ctx)
+}
+
+func TestInfoMetadata_GetSchema(t *testing.T) {
+	def := InfoMetadata{}
+	assert.Equal(t, "info_metadata", def.GetSchema().Name)
+}
+
+func TestInfoMetadata_RunRule(t *testing.T) {
+	def := InfoMetadata{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestInfoMetadata_RunRule_Contact(t *testing.T) {
+
+	specs := map[string]string{
+		"openapi: 3.1.0\ninfo:\n  title: pizza":                                           "`info` has no `contact`",
+		"openapi: 3.1.0\ninfo:\n  title: pizza\n  contact:\n    name: chef":               "`info.contact` has no `email` or `url`",
+		"openapi: 3.1.0\ninfo:\n  title: pizza\n  contact:\n    email: chef@pizza.com":    "",
+		"swagger: \"2.0\"\ninfo:\n  title: pizza\n  contact:\n    url: https://pizza.com": "",
+	}
+	for spec, msg := range specs {
+		res := runInfoMetadata(t, spec, "contact")
+		if msg == "" {
+			assert.Len(t, res, 0, spec)
+			continue
+		}
+		assert.Len(t, res, 1, spec)
+		assert.Equal(t, msg, res[0].Message)
+		assert.Equal(t, "$.info", res[0].Path)
+		assert.Equal(t, 2, res[0].StartNode.Line)
+	}
+}
+
+func TestInfoMetadata_RunRule_License(t *testing.T) {
+
+	specs := map[string]string{
+		"openapi: 3.1.0\ninfo:\n  title: pizza":                                                          "`info` has no `license`",
+		"openapi: 3.1.0\ninfo:\n  license:\n    url: https://opensource.org/licenses/MIT":                "`info.license` has no `name`",
+		"openapi: 3.1.0\ninfo:\n  license:\n    name: MIT\n    identifier: MIT":                          "",
+		"openapi: 3.0.3\ninfo:\n  license:\n    name: MIT\n    url: https://opensource.org/licenses/MIT": "",
+		"openapi: 3.0.3\ninfo:\n  license:\n    name: MIT\n    identifier: MIT": "`info.license` has an `identifier`, " +
+			"which is only supported by OpenAPI 3.1, use a `url`",
+		"openapi: 3.1.0\ninfo:\n  license:\n    name: MIT\n    identifier: MIT\n    url: https://mit.edu": "`info.license` " +
+			"has an `identifier` and a `url`, only one of them is allowed",
+	}
+	for spec, msg := range specs {
+		res := runInfoMetadata(t, spec, "license")
+		if msg == "" {
+			assert.Len(t, res, 0, spec)
+			continue
+		}
+		assert.Len(t, res, 1, spec)
+		assert.Equal(t, msg, res[0].Message)
+	}
+}
+
+func TestInfoMetadata_RunRule_NoCheck(t *testing.T) {
+	res := runInfoMetadata(t, "openapi: 3.1.0\ninfo:\n  title: pizza", "")
+	assert.Len(t, res, 0)
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// KnownFormats checks that every `format` used by a schema (or a swagger parameter, header or items object) is a
+// known format for the type it is used with. Unknown formats are ignored by most tools, so a typo like `datetime`
+// silently does nothing. Known formats come from JSON Schema and the OpenAPI format registry, and can be replaced
+// per type using the `formats` option. Vendor formats can be allowed for any type using the `allowed` option.
+type KnownFormats struct {
+}
+
+// defaultKnownFormats are the formats defined by JSON Schema and the OpenAPI format registry, per type.
+var defaultKnownFormats = map[string][]string{
+	"string": {"date", "date-time", "time", "duration", "email", "idn-email", "hostname", "idn-hostname",
+		"ipv4", "ipv6", "uri", "uri-reference", "iri", "iri-reference", "uri-template", "uuid", "json-pointer",
+		"relative-json-pointer", "regex", "byte", "binary", "password", "base64url", "char", "commonmark", "html",
+		"media-range", "sf-binary", "sf-boolean", "sf-decimal", "sf-integer", "sf-string", "sf-token"},
+	"integer": {"int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64", "double-int"},
+	"number":  {"float", "double", "decimal", "decimal128", "int32", "int64"},
+}
+
+var schemaTypes = map[string]bool{
+	"string": true, "integer": true, "number": true, "boolean": true, "array": true, "object": true, "null": true,
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the KnownFormats rule.
+func (kf KnownFormats) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "known_formats",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "formats",
+				Description: "known formats keyed by type, replacing the defaults for each type supplied",
+			},
+			{
+				Name:        "allowed",
+				Description: "custom (vendor) formats that are allowed for any type",
+			},
+		},
+		ErrorMessage: "'known_formats' function has invalid options supplied. Example valid options are " +
+			"'allowed' = ['x-currency'] or 'formats' = {'string': ['date', 'date-time']}",
+	}
+}
+
+// RunRule will execute the KnownFormats rule, based on supplied context and a supplied []*yaml.Node slice.
+func (kf KnownFormats) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	known := make(map[string]map[string]bool)
+	for t, formats := range defaultKnownFormats {
+		known[t] = make(map[string]bool)
+		for _, f := range formats {
+			known[t][f] = true
+		}
+	}
+	if custom, ok := utils.ExtractValueFromInterfaceMap("formats", context.Options).(map[string]interface{}); ok {
+		for t, formats := range custom {
+			known[t] = make(map[string]bool)
+			for _, f := range utils.ConvertInterfaceArrayToStringArray(formats) {
+				known[t][f] = true
+			}
+		}
+	}
+	allowed := make(map[string]bool)
+	for _, f := range getStringArrayOption("allowed", context.Options, nil) {
+		allowed[f] = true
+	}
+
+	checkFormat := func(node *yaml.Node, format *yaml.Node, path string) {
+		if allowed[format.Value] {
+			return
+		}
+		var types []string
+		if _, typeNode := utils.FindKeyNodeTop("type", node.Content); typeNode != nil {
+			if utils.IsNodeArray(typeNode) {
+				for _, t := range typeNode.Content {
+					if t.Value != "null" {
+						types = append(types, t.Value)
+					}
+				}
+			} else {
+				types = append(types, typeNode.Value)
+			}
+		}
+		if len(types) == 0 {
+			// untyped, the format can be known for any type.
+			for t := range known {
+				types = append(types, t)
+			}
+			sort.Strings(types)
+		}
+
+		candidates := make(map[string]bool)
+		for _, t := range types {
+			if known[t] == nil {
+				continue // formats are not checked for types without any known formats (like boolean).
+			}
+			if known[t][format.Value] {
+				return
+			}
+			for f := range known[t] {
+				candidates[f] = true
+			}
+		}
+		if len(candidates) == 0 {
+			return
+		}
+
+		msg := fmt.Sprintf("format `%s` is not a known format for type `%s`", format.Value,
+			strings.Join(types, "`, `"))
+		if schemaTypes[format.Value] {
+			msg = fmt.Sprintf("%s, `%s` is a type, not a format", msg, format.Value)
+		} else if suggestion := suggestFormat(format.Value, candidates); suggestion != "" {
+			msg = fmt.Sprintf("%s, did you mean `%s`?", msg, suggestion)
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message:   msg,
+			StartNode: format,
+			EndNode:   format,
+			Path:      fmt.Sprintf("%s.format", path),
+			Rule:      context.Rule,
+		})
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			if _, format := utils.FindKeyNodeTop("format", node.Content); format != nil &&
+				format.Kind == yaml.ScalarNode {
+				checkFormat(node, format, path)
+			}
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if key.Value == "example" || key.Value == "examples" || strings.HasPrefix(key.Value, "x-") {
+					continue
+				}
+				walk(value, fmt.Sprintf("%s.%s", path, key.Value))
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
+
+// suggestFormat looks for a known format that only differs by case or separators, like `datetime` and `date-time`.
+func suggestFormat(format string, candidates map[string]bool) string {
+	normalize := func(s string) string {
+		return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(s))
+	}
+	n := normalize(format)
+	var suggestions []string
+	for c := range candidates {
+		if normalize(c) == n {
+			suggestions = append(suggestions, c)
+		}
+	}
+	if len(suggestions) == 0 {
+		return ""
+	}
+	sort.Strings(suggestions)
+	return suggestions[0]
+}
@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var knownFormatsTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      parameters:
+        - in: query
+          name: format
+          schema:
+            type: integer
+            format: integer
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              example:
+                format: not-a-format
+              schema:
+                type: object
+                properties:
+                  format:
+                    type: string
+                    format: datetime
+                  baked:
+                    type: [string, "null"]
+                    format: date-time
+                  price:
+                    type: number
+                    format: x-currency
+                  slices:
+                    format: int8
+                  vegan:
+                    type: boolean
+                    format: yes-no
+                  size:
+                    type: string
+                    format: huge`
+
+func TestKnownFormats_GetSchema(t *testing.T) {
+	def := KnownFormats{}
+	assert.Equal(t, "known_formats", def.GetSchema().Name)
+}
+
+func TestKnownFormats_RunRule(t *testing.T) {
+	def := KnownFormats{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestKnownFormats_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(knownFormatsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "known_formats", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := KnownFormats{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "format `integer` is not a known format for type `integer`, `integer` is a type, "+
+		"not a format", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get.parameters[0].schema.format", res[0].Path)
+	assert.Equal(t, 10, res[0].StartNode.Line)
+	assert.Equal(t, "format `datetime` is not a known format for type `string`, did you mean `date-time`?",
+		res[1].Message)
+	assert.Equal(t, "$.paths./pizza.get.responses.200.content.application/json.schema.properties.format.format",
+		res[1].Path)
+	assert.Equal(t, "format `x-currency` is not a known format for type `number`", res[2].Message)
+	assert.Equal(t, "format `huge` is not a known format for type `string`", res[3].Message)
+}
+
+func TestKnownFormats_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(knownFormatsTestSpec), path)
+
+	opts := map[string]interface{}{
+		"allowed": []interface{}{"x-currency", "integer"},
+		"formats": map[string]interface{}{
+			"string": []interface{}{"huge", "date-time"},
+		},
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "known_formats", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := KnownFormats{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "format `datetime` is not a known format for type `string`, did you mean `date-time`?",
+		res[0].Message)
+}
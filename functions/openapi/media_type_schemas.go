@@ -0,0 +1,222 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// MediaTypeSchemas checks that the schema of every media type matches the media type, an `application/json` media
+// type with a binary string schema, or an `application/octet-stream` media type with an object schema is almost
+// certainly a mistake. Media types are mapped to the schema types they allow, `binary` is used for strings with a
+// `binary` format. Mappings can use wildcards (like `image/*` or `*/*+json`), the most specific match wins.
+//
+// The `mediaTypes` option replaces the default mapping of any media type supplied, an empty list switches the check
+// off for that media type. Schemas without a type (or that can't be resolved) are not checked.
+type MediaTypeSchemas struct {
+}
+
+// defaultMediaTypeSchemas are the schema types allowed for common media types.
+var defaultMediaTypeSchemas = map[string][]string{
+	"application/json":                  {"object", "array", "string", "number", "integer", "boolean", "null"},
+	"*/*+json":                          {"object", "array", "string", "number", "integer", "boolean", "null"},
+	"application/octet-stream":          {"binary", "string"},
+	"image/*":                           {"binary", "string"},
+	"audio/*":                           {"binary", "string"},
+	"video/*":                           {"binary", "string"},
+	"text/*":                            {"string"},
+	"multipart/*":                       {"object"},
+	"application/x-www-form-urlencoded": {"object"},
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the MediaTypeSchemas rule.
+func (mt MediaTypeSchemas) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "media_type_schemas",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "mediaTypes",
+				Description: "schema types allowed for each media type, replacing the defaults for each media type supplied",
+			},
+		},
+		ErrorMessage: "'media_type_schemas' function has invalid options supplied. Example valid options are " +
+			"'mediaTypes' = {'application/pdf': ['binary']}",
+	}
+}
+
+// RunRule will execute the MediaTypeSchemas rule, based on supplied context and a supplied []*yaml.Node slice.
+func (mt MediaTypeSchemas) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	mapping := make(map[string][]string)
+	for mediaType, types := range defaultMediaTypeSchemas {
+		mapping[mediaType] = types
+	}
+	switch custom := utils.ExtractValueFromInterfaceMap("mediaTypes", context.Options).(type) {
+	case map[string]interface{}:
+		for mediaType, types := range custom {
+			mapping[strings.ToLower(mediaType)] = utils.ConvertInterfaceArrayToStringArray(types)
+		}
+	case map[string][]string:
+		for mediaType, types := range custom {
+			mapping[strings.ToLower(mediaType)] = types
+		}
+	}
+
+	// exact media types are matched first, then patterns, the longest (most specific) first.
+	var patterns []string
+	for mediaType := range mapping {
+		if strings.Contains(mediaType, "*") {
+			patterns = append(patterns, mediaType)
+		}
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+	allowedTypes := func(mediaType string) []string {
+		if types, ok := mapping[mediaType]; ok {
+			return types
+		}
+		for _, p := range patterns {
+			if ok, _ := path.Match(p, mediaType); ok {
+				return mapping[p]
+			}
+		}
+		return nil
+	}
+
+	checkContent := func(content *yaml.Node, contentPath string) {
+		for i := 0; i < len(content.Content)-1; i += 2 {
+			mediaKey, media := content.Content[i], content.Content[i+1]
+			if !utils.IsNodeMap(media) {
+				continue
+			}
+			mediaType := strings.ToLower(strings.TrimSpace(strings.Split(mediaKey.Value, ";")[0]))
+			allowed := allowedTypes(mediaType)
+			if len(allowed) == 0 {
+				continue
+			}
+			_, schema := utils.FindKeyNodeTop("schema", media.Content)
+			if schema = resolveSchemaReference(root, schema); schema == nil {
+				continue
+			}
+			types := schemaTypesOf(schema)
+			if len(types) == 0 {
+				continue
+			}
+			match := false
+			for _, t := range types {
+				for _, a := range allowed {
+					if t == a {
+						match = true
+					}
+				}
+			}
+			if match {
+				continue
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("media type `%s` has a schema of type `%s`, which does not match "+
+					"(expected `%s`)", mediaKey.Value, strings.Join(types, "`, `"), strings.Join(allowed, "`, `")),
+				StartNode: mediaKey,
+				EndNode:   utils.FindLastChildNodeWithLevel(media, 0),
+				Path:      fmt.Sprintf("%s.%s", contentPath, mediaKey.Value),
+				Rule:      context.Rule,
+			})
+		}
+	}
+
+	// walk the document looking for `content` maps, schemas can't define media types, so they are skipped.
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				switch {
+				case key.Value == "example" || key.Value == "examples" || key.Value == "schema":
+					continue
+				case path == "$.components" && key.Value == "schemas":
+					continue
+				case key.Value == "content" && utils.IsNodeMap(value):
+					checkContent(value, childPath)
+					walk(value, childPath)
+				default:
+					walk(value, childPath)
+				}
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
+
+// resolveSchemaReference follows local references until a schema is found, nil is returned if the schema
+// can't be resolved, or the references loop.
+func resolveSchemaReference(root, schema *yaml.Node) *yaml.Node {
+	seen := make(map[*yaml.Node]bool)
+	for schema != nil && utils.IsNodeMap(schema) && !seen[schema] {
+		seen[schema] = true
+		_, ref := utils.FindKeyNodeTop("$ref", schema.Content)
+		if ref == nil {
+			return schema
+		}
+		schema = resolveLocalReference(root, ref.Value)
+	}
+	return nil
+}
+
+// schemaTypesOf returns the types of a schema, excluding `null`. Strings with a `binary` format are reported
+// as `binary`.
+func schemaTypesOf(schema *yaml.Node) []string {
+	_, typeNode := utils.FindKeyNodeTop("type", schema.Content)
+	if typeNode == nil {
+		return nil
+	}
+	var types []string
+	if utils.IsNodeArray(typeNode) {
+		for _, t := range typeNode.Content {
+			if t.Value != "null" {
+				types = append(types, t.Value)
+			}
+		}
+	} else if typeNode.Value != "null" {
+		types = append(types, typeNode.Value)
+	}
+	_, format := utils.FindKeyNodeTop("format", schema.Content)
+	for i, t := range types {
+		if t == "string" && format != nil && format.Value == "binary" {
+			types[i] = "binary"
+		}
+	}
+	return types
+}
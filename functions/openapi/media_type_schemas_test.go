@@ -0,0 +1,116 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var mediaTypeSchemasTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    post:
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                photo:
+                  type: string
+                  format: binary
+          application/json; charset=utf-8:
+            schema:
+              type: string
+              format: binary
+      responses:
+        "200":
+          description: ok
+          content:
+            application/octet-stream:
+              schema:
+                $ref: '#/components/schemas/Pizza'
+            application/vnd.pizza+json:
+              schema:
+                $ref: '#/components/schemas/Pizza'
+            image/png:
+              schema:
+                type: string
+                format: binary
+            text/csv:
+              schema:
+                type: array
+            application/xml:
+              schema:
+                type: string
+                format: binary
+components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        content:
+          type: object`
+
+func TestMediaTypeSchemas_GetSchema(t *testing.T) {
+	def := MediaTypeSchemas{}
+	assert.Equal(t, "media_type_schemas", def.GetSchema().Name)
+}
+
+func TestMediaTypeSchemas_RunRule(t *testing.T) {
+	def := MediaTypeSchemas{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestMediaTypeSchemas_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(mediaTypeSchemasTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "media_type_schemas", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := MediaTypeSchemas{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "media type `application/json; charset=utf-8` has a schema of type `binary`, which does not "+
+		"match (expected `object`, `array`, `string`, `number`, `integer`, `boolean`, `null`)", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.post.requestBody.content.application/json; charset=utf-8", res[0].Path)
+	assert.Equal(t, 14, res[0].StartNode.Line)
+	assert.Equal(t, "media type `application/octet-stream` has a schema of type `object`, which does not "+
+		"match (expected `binary`, `string`)", res[1].Message)
+	assert.Equal(t, "$.paths./pizza.post.responses.200.content.application/octet-stream", res[1].Path)
+	assert.Equal(t, "media type `text/csv` has a schema of type `array`, which does not match "+
+		"(expected `string`)", res[2].Message)
+}
+
+func TestMediaTypeSchemas_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(mediaTypeSchemasTestSpec), path)
+
+	opts := map[string]interface{}{
+		"mediaTypes": map[string]interface{}{
+			"text/csv":        []interface{}{"string", "array"},
+			"application/xml": []interface{}{"object"},
+			"*/*+json":        []interface{}{"array"},
+		},
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "media_type_schemas", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := MediaTypeSchemas{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "$.paths./pizza.post.responses.200.content.application/vnd.pizza+json", res[2].Path)
+	assert.Equal(t, "$.paths./pizza.post.responses.200.content.application/xml", res[3].Path)
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// MultipleOf checks every `multipleOf` in the document is a number greater than zero. JSON Schema does not allow
+// zero or negative values (nothing is a multiple of zero), but the error reported when validating a schema that uses
+// one is hard to understand. The whole schema tree is checked, including nested and composed schemas.
+type MultipleOf struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the MultipleOf rule.
+func (mo MultipleOf) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "multiple_of",
+	}
+}
+
+// RunRule will execute the MultipleOf rule, based on supplied context and a supplied []*yaml.Node slice.
+func (mo MultipleOf) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				if key.Value == "example" || key.Value == "examples" {
+					continue
+				}
+				// properties can be named `multipleOf`, they are schemas, not values.
+				if key.Value == "multipleOf" && value.Kind == yaml.ScalarNode {
+					var msg string
+					isNumber := utils.IsNodeIntValue(value) || utils.IsNodeFloatValue(value)
+					if n, err := strconv.ParseFloat(value.Value, 64); err != nil || !isNumber {
+						msg = fmt.Sprintf("`multipleOf` must be a number greater than zero, not `%s`", value.Value)
+					} else if n <= 0 {
+						msg = fmt.Sprintf("`multipleOf` is `%s`, it must be greater than zero", value.Value)
+					}
+					if msg != "" {
+						results = append(results, model.RuleFunctionResult{
+							Message:   msg,
+							StartNode: key,
+							EndNode:   value,
+							Path:      childPath,
+							Rule:      context.Rule,
+						})
+					}
+					continue
+				}
+				walk(value, childPath)
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
@@ -0,0 +1,70 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var multipleOfTestSpec = `openapi: 3.1.0
+components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        slices:
+          type: integer
+          multipleOf: 2
+        price:
+          type: number
+          multipleOf: 0.0
+        discount:
+          type: number
+          multipleOf: -0.5
+        weight:
+          type: number
+          multipleOf: 0.25
+        multipleOf:
+          type: string
+        toppings:
+          type: array
+          items:
+            allOf:
+              - type: integer
+                multipleOf: "three"
+          example:
+            multipleOf: 0`
+
+func TestMultipleOf_GetSchema(t *testing.T) {
+	def := MultipleOf{}
+	assert.Equal(t, "multiple_of", def.GetSchema().Name)
+}
+
+func TestMultipleOf_RunRule(t *testing.T) {
+	def := MultipleOf{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestMultipleOf_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(multipleOfTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "multiple_of", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := MultipleOf{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "`multipleOf` is `0.0`, it must be greater than zero", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.price.multipleOf", res[0].Path)
+	assert.Equal(t, 12, res[0].StartNode.Line)
+	assert.Equal(t, "`multipleOf` is `-0.5`, it must be greater than zero", res[1].Message)
+	assert.Equal(t, "`multipleOf` must be a number greater than zero, not `three`", res[2].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.toppings.items.allOf[0].multipleOf", res[2].Path)
+}
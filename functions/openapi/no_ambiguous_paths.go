@@ -36,6 +36,9 @@ func (ap AmbiguousPaths) RunRule(nodes []*yaml.Node, context model.RuleFunctionC
 
 	if ops != nil {
 		for i, op := range ops.Content {
+			if context.IsCancelled() {
+				break
+			}
 			if i%2 == 0 {
 				opPath = op.Value
 				continue
@@ -43,6 +43,9 @@ func (ne NoEvalInDescriptions) RunRule(nodes []*yaml.Node, context model.RuleFun
 	}
 
 	for _, desc := range descriptions {
+		if context.IsCancelled() {
+			break
+		}
 
 		if compiledRegex.MatchString(desc.Content) {
 
@@ -35,6 +35,9 @@ func (vp VerbsInPaths) RunRule(nodes []*yaml.Node, context model.RuleFunctionCon
 
 	if ops != nil {
 		for i, op := range ops.Content {
+			if context.IsCancelled() {
+				break
+			}
 			if i%2 == 0 {
 				opPath = op.Value
 				continue
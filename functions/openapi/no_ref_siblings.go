@@ -31,6 +31,9 @@ func (nrs NoRefSiblings) RunRule(nodes []*yaml.Node, context model.RuleFunctionC
 	var results []model.RuleFunctionResult
 	siblings := context.Index.GetReferencesWithSiblings()
 	for _, ref := range siblings {
+		if context.IsCancelled() {
+			break
+		}
 
 		key, val := utils.FindKeyNode("$ref", ref.Node.Content)
 		results = append(results, model.RuleFunctionResult{
@@ -0,0 +1,144 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// NumericBounds checks `number` and `integer` properties specify both a lower bound (`minimum`, or a numeric
+// `exclusiveMinimum`) and an upper bound (`maximum`, or a numeric `exclusiveMaximum`), so inputs are bounded.
+// Identifiers are often unbounded on purpose, properties with names matching the glob patterns in the `ignore`
+// option (`id`, `*Id` and `*_id` by default) are not checked, neither are properties with a format in the
+// `ignoreFormats` option. Referenced schemas are not followed.
+type NumericBounds struct {
+}
+
+var defaultNumericBoundsIgnore = []string{"id", "*Id", "*_id"}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the NumericBounds rule.
+func (nb NumericBounds) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "numeric_bounds",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "ignore",
+				Description: "glob patterns matching the names of properties that may be unbounded, like 'id' or '*Id'",
+			},
+			{
+				Name:        "ignoreFormats",
+				Description: "formats of properties that may be unbounded, like 'int64'",
+			},
+		},
+		ErrorMessage: "'numeric_bounds' function has invalid options supplied. Example valid options are " +
+			"'ignore' = ['id', '*Count'] or 'ignoreFormats' = ['int64']",
+	}
+}
+
+// hasBound returns true if a schema has a bound, either as the inclusive keyword, or as the exclusive keyword with a
+// number (OpenAPI 3.1). In OpenAPI 3.0 the exclusive keyword is a boolean, that needs the inclusive keyword too.
+func hasBound(schema *yaml.Node, inclusive, exclusive string) bool {
+	if _, bound := utils.FindKeyNodeTop(inclusive, schema.Content); bound != nil {
+		return true
+	}
+	_, bound := utils.FindKeyNodeTop(exclusive, schema.Content)
+	return bound != nil && bound.Kind == yaml.ScalarNode && bound.Tag != "!!bool"
+}
+
+// RunRule will execute the NumericBounds rule, based on supplied context and a supplied []*yaml.Node slice.
+func (nb NumericBounds) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	ignore := getStringArrayOption("ignore", context.Options, defaultNumericBoundsIgnore)
+	ignoreFormats := getStringArrayOption("ignoreFormats", context.Options, nil)
+
+	isIgnored := func(name string) bool {
+		for _, p := range ignore {
+			if ok, _ := path.Match(p, name); ok || p == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	checkProperties := func(properties *yaml.Node, propertiesPath string) {
+		for i := 0; i < len(properties.Content)-1; i += 2 {
+			name, schema := properties.Content[i], properties.Content[i+1]
+			if !utils.IsNodeMap(schema) || isIgnored(name.Value) {
+				continue
+			}
+			isNumeric := false
+			for _, t := range schemaTypesOf(schema) {
+				isNumeric = isNumeric || t == "number" || t == "integer"
+			}
+			if !isNumeric {
+				continue
+			}
+			if _, format := utils.FindKeyNodeTop("format", schema.Content); format != nil &&
+				containsString(ignoreFormats, format.Value) {
+				continue
+			}
+			var missing []string
+			if !hasBound(schema, "minimum", "exclusiveMinimum") {
+				missing = append(missing, "minimum")
+			}
+			if !hasBound(schema, "maximum", "exclusiveMaximum") {
+				missing = append(missing, "maximum")
+			}
+			if len(missing) == 0 {
+				continue
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("numeric property `%s` has no %s, inputs are unbounded", name.Value,
+					joinNames(missing, "or")),
+				StartNode: name,
+				EndNode:   utils.FindLastChildNodeWithLevel(schema, 0),
+				Path:      fmt.Sprintf("%s.%s", propertiesPath, name.Value),
+				Rule:      context.Rule,
+			})
+		}
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				if key.Value == "example" || key.Value == "examples" {
+					continue
+				}
+				if key.Value == "properties" && utils.IsNodeMap(value) {
+					checkProperties(value, childPath)
+				}
+				walk(value, childPath)
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
@@ -0,0 +1,96 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var numericBoundsTestSpec = `openapi: 3.1.0
+components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        id:
+          type: integer
+        toppingId:
+          type: integer
+        slices:
+          type: integer
+          minimum: 1
+          maximum: 16
+        price:
+          type: number
+          exclusiveMinimum: 0
+        diameter:
+          type: [number, "null"]
+          format: float
+        weight:
+          type: number
+          minimum: 0
+          exclusiveMaximum: true
+        size:
+          $ref: '#/components/schemas/Size'
+        name:
+          type: string
+      example:
+        properties:
+          calories:
+            type: integer`
+
+func TestNumericBounds_GetSchema(t *testing.T) {
+	def := NumericBounds{}
+	assert.Equal(t, "numeric_bounds", def.GetSchema().Name)
+}
+
+func TestNumericBounds_RunRule(t *testing.T) {
+	def := NumericBounds{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestNumericBounds_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(numericBoundsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "numeric_bounds", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := NumericBounds{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "numeric property `price` has no `maximum`, inputs are unbounded", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.price", res[0].Path)
+	assert.Equal(t, 15, res[0].StartNode.Line)
+	assert.Equal(t, "numeric property `diameter` has no `minimum` or `maximum`, inputs are unbounded", res[1].Message)
+	assert.Equal(t, "numeric property `weight` has no `maximum`, inputs are unbounded", res[2].Message)
+}
+
+func TestNumericBounds_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(numericBoundsTestSpec), path)
+
+	opts := map[string]interface{}{
+		"ignore":        []interface{}{"price", "weight"},
+		"ignoreFormats": []interface{}{"float"},
+	}
+	rule := buildOpenApiTestRuleAction(path, "numeric_bounds", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := NumericBounds{}
+	res := def.RunRule(nodes, ctx)
+
+	// the default ignored names are replaced.
+	assert.Len(t, res, 2)
+	assert.Equal(t, "numeric property `id` has no `minimum` or `maximum`, inputs are unbounded", res[0].Message)
+	assert.Equal(t, "numeric property `toppingId` has no `minimum` or `maximum`, inputs are unbounded", res[1].Message)
+}
@@ -32,6 +32,9 @@ func (od OAS2Discriminator) RunRule(nodes []*yaml.Node, context model.RuleFuncti
 	schemas := context.Index.GetAllComponentSchemas()
 
 	for id, schema := range schemas {
+		if context.IsCancelled() {
+			break
+		}
 
 		discriminator, dv := utils.FindKeyNode("discriminator", schema.Node.Content)
 
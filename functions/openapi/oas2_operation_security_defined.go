@@ -35,6 +35,9 @@ func (sd OAS2OperationSecurityDefined) RunRule(nodes []*yaml.Node, context model
 	securityDefinitions := context.Index.GetAllSecuritySchemes()
 
 	for path, methodMap := range paths {
+		if context.IsCancelled() {
+			break
+		}
 
 		for method, methodNode := range methodMap {
 
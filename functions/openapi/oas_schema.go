@@ -55,6 +55,9 @@ func (os OASSchema) RunRule(nodes []*yaml.Node, context model.RuleFunctionContex
 	// duplicates are possible, so we need to de-dupe them.
 	seen := make(map[string]*errors.SchemaValidationFailure)
 	for i := range validationErrors {
+		if context.IsCancelled() {
+			break
+		}
 		for y := range validationErrors[i].SchemaValidationErrors {
 			// skip, seen it.
 			if _, ok := seen[hashResult(validationErrors[i].SchemaValidationErrors[y])]; ok {
@@ -0,0 +1,143 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// OAuth2Scopes checks that security requirements only request scopes that have been declared by the oauth2
+// security scheme they reference. The scopes of every flow in a scheme are combined, so a scope can be declared by
+// any (or all) of the flows. Works with both OpenAPI 3 `securitySchemes` and Swagger `securityDefinitions`.
+// Requirements that reference schemes that don't exist, or are not oauth2 schemes are ignored.
+type OAuth2Scopes struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the OAuth2Scopes rule.
+func (oa OAuth2Scopes) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "oauth2_scopes",
+	}
+}
+
+// RunRule will execute the OAuth2Scopes rule, based on supplied context and a supplied []*yaml.Node slice.
+func (oa OAuth2Scopes) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	// collect the declared scopes of every oauth2 scheme.
+	var schemes *yaml.Node
+	if _, components := utils.FindKeyNodeTop("components", root.Content); utils.IsNodeMap(components) {
+		_, schemes = utils.FindKeyNodeTop("securitySchemes", components.Content)
+	}
+	if schemes == nil {
+		_, schemes = utils.FindKeyNodeTop("securityDefinitions", root.Content)
+	}
+	if !utils.IsNodeMap(schemes) {
+		return results
+	}
+
+	declared := make(map[string]map[string]bool)
+	for i := 0; i < len(schemes.Content)-1; i += 2 {
+		scheme := schemes.Content[i+1]
+		if _, ref := utils.FindKeyNodeTop("$ref", scheme.Content); ref != nil && strings.HasPrefix(ref.Value, "#") {
+			scheme = resolveLocalReference(root, ref.Value)
+		}
+		if !utils.IsNodeMap(scheme) {
+			continue
+		}
+		if _, t := utils.FindKeyNodeTop("type", scheme.Content); t == nil || t.Value != "oauth2" {
+			continue
+		}
+		scopes := make(map[string]bool)
+		addScopes := func(owner *yaml.Node) {
+			if _, s := utils.FindKeyNodeTop("scopes", owner.Content); utils.IsNodeMap(s) {
+				for x := 0; x < len(s.Content)-1; x += 2 {
+					scopes[s.Content[x].Value] = true
+				}
+			}
+		}
+		addScopes(scheme) // swagger
+		if _, flows := utils.FindKeyNodeTop("flows", scheme.Content); utils.IsNodeMap(flows) {
+			for f := 0; f < len(flows.Content)-1; f += 2 {
+				if utils.IsNodeMap(flows.Content[f+1]) {
+					addScopes(flows.Content[f+1])
+				}
+			}
+		}
+		declared[schemes.Content[i].Value] = scopes
+	}
+	if len(declared) == 0 {
+		return results
+	}
+
+	checkSecurity := func(security *yaml.Node, path, owner string) {
+		if !utils.IsNodeArray(security) {
+			return
+		}
+		for r, requirement := range security.Content {
+			if !utils.IsNodeMap(requirement) {
+				continue
+			}
+			for i := 0; i < len(requirement.Content)-1; i += 2 {
+				name, requested := requirement.Content[i].Value, requirement.Content[i+1]
+				scopes, ok := declared[name]
+				if !ok || !utils.IsNodeArray(requested) {
+					continue
+				}
+				for s, scope := range requested.Content {
+					if scopes[scope.Value] {
+						continue
+					}
+					results = append(results, model.RuleFunctionResult{
+						Message: fmt.Sprintf("%s requests scope `%s`, which is not declared by oauth2 "+
+							"scheme `%s`", owner, scope.Value, name),
+						StartNode: scope,
+						EndNode:   scope,
+						Path:      fmt.Sprintf("%s.security[%d].%s[%d]", path, r, name, s),
+						Rule:      context.Rule,
+					})
+				}
+			}
+		}
+	}
+
+	_, rootSecurity := utils.FindKeyNodeTop("security", root.Content)
+	checkSecurity(rootSecurity, "$", "the root security requirement")
+
+	_, paths := utils.FindKeyNodeTop("paths", root.Content)
+	if !utils.IsNodeMap(paths) {
+		return results
+	}
+	for i := 0; i < len(paths.Content)-1; i += 2 {
+		if context.IsCancelled() {
+			break
+		}
+		opPath, pathItem := paths.Content[i].Value, paths.Content[i+1]
+		for m := 0; m < len(pathItem.Content)-1; m += 2 {
+			opMethod := pathItem.Content[m].Value
+			if !isOperationMethod(opMethod) || !utils.IsNodeMap(pathItem.Content[m+1]) {
+				continue
+			}
+			_, security := utils.FindKeyNodeTop("security", pathItem.Content[m+1].Content)
+			checkSecurity(security, fmt.Sprintf("$.paths.%s.%s", opPath, opMethod),
+				fmt.Sprintf("operation `%s` at path `%s`", opMethod, opPath))
+		}
+	}
+	return results
+}
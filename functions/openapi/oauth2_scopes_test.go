@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOAuth2Scopes_GetSchema(t *testing.T) {
+	def := OAuth2Scopes{}
+	assert.Equal(t, "oauth2_scopes", def.GetSchema().Name)
+}
+
+func TestOAuth2Scopes_RunRule(t *testing.T) {
+	def := OAuth2Scopes{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestOAuth2Scopes_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+security:
+  - pizzaAuth: [read, admin]
+paths:
+  /pizza:
+    get:
+      security:
+        - pizzaAuth: [read]
+        - apiKey: []
+    post:
+      security:
+        - pizzaAuth: [write, bake]
+        - missing: [anything]
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+    pizzaAuth:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: https://pizza.com/auth
+          scopes:
+            read: read pizzas
+            write: write pizzas
+        clientCredentials:
+          tokenUrl: https://pizza.com/token
+          scopes:
+            read: read pizzas
+            delete: delete pizzas`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "oauth2_scopes", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := OAuth2Scopes{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "the root security requirement requests scope `admin`, which is not declared by "+
+		"oauth2 scheme `pizzaAuth`", res[0].Message)
+	assert.Equal(t, "$.security[0].pizzaAuth[1]", res[0].Path)
+	assert.Equal(t, 3, res[0].StartNode.Line)
+	assert.Equal(t, "operation `post` at path `/pizza` requests scope `bake`, which is not declared by "+
+		"oauth2 scheme `pizzaAuth`", res[1].Message)
+	assert.Equal(t, "$.paths./pizza.post.security[0].pizzaAuth[1]", res[1].Path)
+	assert.Equal(t, 12, res[1].StartNode.Line)
+}
+
+func TestOAuth2Scopes_RunRule_Swagger(t *testing.T) {
+
+	yml := `swagger: "2.0"
+paths:
+  /pizza:
+    get:
+      security:
+        - pizzaAuth: [read, write]
+securityDefinitions:
+  pizzaAuth:
+    type: oauth2
+    flow: implicit
+    authorizationUrl: https://pizza.com/auth
+    scopes:
+      read: read pizzas`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "oauth2_scopes", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := OAuth2Scopes{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.paths./pizza.get.security[0].pizzaAuth[1]", res[0].Path)
+}
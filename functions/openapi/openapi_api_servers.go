@@ -58,6 +58,9 @@ func (as APIServers) RunRule(nodes []*yaml.Node, context model.RuleFunctionConte
 
 	// check servers contains a URL and the URL is valid.
 	for i, serverRef := range rootServers {
+		if context.IsCancelled() {
+			break
+		}
 		urlLabelNode, urlNode := utils.FindKeyNode("url", serverRef.Node.Content)
 		if urlNode == nil {
 			results = append(results, model.RuleFunctionResult{
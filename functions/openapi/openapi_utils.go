@@ -4,9 +4,12 @@
 package openapi
 
 import (
+	"fmt"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
+	"strconv"
+	"strings"
 )
 
 // GetAllOperationsJSONPath wil return a string that can be used as a query for extracting all OpenAPI operations.
@@ -54,3 +57,167 @@ func createDescriptionResult(msg, path string, start *yaml.Node, end *yaml.Node)
 	res.Path = path
 	return res
 }
+
+// getStringArrayOption extracts a string slice from function options, returning the supplied default if the option
+// has not been set. Options can arrive as []string (built-in rules) or []interface{} (rulesets).
+func getStringArrayOption(name string, options interface{}, def []string) []string {
+	if vals := utils.ConvertInterfaceArrayToStringArray(utils.ExtractValueFromInterfaceMap(name, options)); vals != nil {
+		return vals
+	}
+	if props, ok := options.(map[string]string); ok && props[name] != "" {
+		return strings.Split(props[name], ",")
+	}
+	return def
+}
+
+// getStringOption extracts a string from function options, returning the supplied default if not set.
+func getStringOption(name string, options interface{}, def string) string {
+	if val, ok := utils.ExtractValueFromInterfaceMap(name, options).(string); ok && val != "" {
+		return val
+	}
+	if props, ok := options.(map[string]string); ok && props[name] != "" {
+		return props[name]
+	}
+	return def
+}
+
+// getIntOption extracts an integer from function options, returning the supplied default if not set. Numbers
+// decoded from JSON rulesets arrive as float64 and string maps carry text, so both are handled.
+func getIntOption(name string, options interface{}, def int) int {
+	switch val := utils.ExtractValueFromInterfaceMap(name, options).(type) {
+	case int:
+		return val
+	case float64:
+		return int(val)
+	case string:
+		if i, err := strconv.Atoi(val); err == nil {
+			return i
+		}
+	}
+	if props, ok := options.(map[string]string); ok {
+		if i, err := strconv.Atoi(props[name]); err == nil {
+			return i
+		}
+	}
+	return def
+}
+
+// getBoolOption extracts a boolean from function options, returning the supplied default if not set.
+func getBoolOption(name string, options interface{}, def bool) bool {
+	switch val := utils.ExtractValueFromInterfaceMap(name, options).(type) {
+	case bool:
+		return val
+	case string:
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b
+		}
+	}
+	if props, ok := options.(map[string]string); ok {
+		if b, err := strconv.ParseBool(props[name]); err == nil {
+			return b
+		}
+	}
+	return def
+}
+
+// isOperationMethod returns true if the supplied key is an HTTP method that can carry an operation.
+func isOperationMethod(method string) bool {
+	switch strings.ToLower(method) {
+	case "get", "put", "post", "delete", "options", "head", "patch", "trace":
+		return true
+	}
+	return false
+}
+
+// joinNames renders names as a quoted list, like "`get`, `list` or `find`", with the supplied conjunction.
+func joinNames(names []string, conjunction string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = fmt.Sprintf("`%s`", n)
+	}
+	if len(quoted) <= 1 {
+		return strings.Join(quoted, "")
+	}
+	return fmt.Sprintf("%s %s %s", strings.Join(quoted[:len(quoted)-1], ", "), conjunction, quoted[len(quoted)-1])
+}
+
+// forEachParameter calls fn for every parameter in a document, once each, until fn returns false. Component
+// parameters (and the top-level `parameters` of swagger documents) are visited first, then the parameters of path
+// items and operations. Referenced parameters are resolved (following references to references), a parameter used
+// through a reference is visited at the path of the parameter it references.
+func forEachParameter(root *yaml.Node, fn func(param *yaml.Node, path string) bool) {
+	if root == nil || root.Kind != yaml.MappingNode {
+		return
+	}
+	seen := make(map[*yaml.Node]bool)
+	stopped := false
+	visit := func(param *yaml.Node, path string) {
+		for depth := 0; utils.IsNodeMap(param) && depth < 10; depth++ {
+			_, ref := utils.FindKeyNodeTop("$ref", param.Content)
+			if ref == nil {
+				break
+			}
+			if !strings.HasPrefix(ref.Value, "#") {
+				return
+			}
+			param = resolveLocalReference(root, ref.Value)
+			_, path = utils.ConvertComponentIdIntoPath(ref.Value)
+		}
+		if stopped || !utils.IsNodeMap(param) || seen[param] {
+			return
+		}
+		if _, ref := utils.FindKeyNodeTop("$ref", param.Content); ref != nil {
+			return
+		}
+		seen[param] = true
+		stopped = !fn(param, path)
+	}
+	visitList := func(params *yaml.Node, path string) {
+		if !utils.IsNodeArray(params) {
+			return
+		}
+		for i, param := range params.Content {
+			visit(param, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+
+	visitMap := func(params *yaml.Node, path string) {
+		if !utils.IsNodeMap(params) {
+			return
+		}
+		for i := 0; i < len(params.Content)-1; i += 2 {
+			visit(params.Content[i+1], fmt.Sprintf("%s.%s", path, params.Content[i].Value))
+		}
+	}
+
+	if _, components := utils.FindKeyNodeTop("components", root.Content); utils.IsNodeMap(components) {
+		_, params := utils.FindKeyNodeTop("parameters", components.Content)
+		visitMap(params, "$.components.parameters")
+	}
+	_, params := utils.FindKeyNodeTop("parameters", root.Content)
+	visitMap(params, "$.parameters")
+
+	_, paths := utils.FindKeyNodeTop("paths", root.Content)
+	if !utils.IsNodeMap(paths) {
+		return
+	}
+	for i := 0; i < len(paths.Content)-1; i += 2 {
+		pathItem := paths.Content[i+1]
+		if !utils.IsNodeMap(pathItem) {
+			continue
+		}
+		itemPath := fmt.Sprintf("$.paths.%s", paths.Content[i].Value)
+		for m := 0; m < len(pathItem.Content)-1; m += 2 {
+			key := pathItem.Content[m].Value
+			if key == "parameters" {
+				visitList(pathItem.Content[m+1], itemPath+".parameters")
+				continue
+			}
+			if !isOperationMethod(key) || !utils.IsNodeMap(pathItem.Content[m+1]) {
+				continue
+			}
+			_, opParams := utils.FindKeyNodeTop("parameters", pathItem.Content[m+1].Content)
+			visitList(opParams, fmt.Sprintf("%s.%s.parameters", itemPath, key))
+		}
+	}
+}
@@ -6,12 +6,12 @@ package openapi
 import (
 	"fmt"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
-	"strconv"
 )
 
-// Operation4xResponse is a rule that checks if an operation returns a 4xx (user error) code.
+// Operation4xResponse is a rule that checks if an operation returns a 4xx (user error) code, a `4XX` range counts.
 type Operation4xResponse struct {
 }
 
@@ -36,6 +36,9 @@ func (or Operation4xResponse) RunRule(nodes []*yaml.Node, context model.RuleFunc
 
 	var opPath, opMethod string
 	for i, op := range ops {
+		if context.IsCancelled() {
+			break
+		}
 		if i%2 == 0 {
 			opPath = op.Value
 			continue
@@ -54,8 +57,7 @@ func (or Operation4xResponse) RunRule(nodes []*yaml.Node, context model.RuleFunc
 					if k%2 != 0 {
 						continue
 					}
-					responseCode, _ := strconv.Atoi(response.Value)
-					if responseCode >= 400 && responseCode <= 499 {
+					if class, _ := walker.StatusCodeClass(response.Value); class == 4 {
 						seen = true
 					}
 				}
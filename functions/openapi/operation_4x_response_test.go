@@ -40,6 +40,25 @@ func TestOperation4xResponse_RunRule_Success(t *testing.T) {
 	assert.Len(t, res, 0)
 }
 
+func TestOperation4xResponse_RunRule_Range(t *testing.T) {
+
+	sampleYaml, _ := os.ReadFile("../../model/test_files/status-code-ranges.openapi.yaml")
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal(sampleYaml, &rootNode)
+	assert.NoError(t, mErr)
+	nodes, _ := utils.FindNodes(sampleYaml, "$")
+	rule := buildOpenApiTestRuleAction(GetAllOperationsJSONPath(), "xor", "responses", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	config := index.CreateOpenAPIIndexConfig()
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, config)
+
+	def := Operation4xResponse{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
+
 func TestOperation4xResponse_RunRule_ExitEarly(t *testing.T) {
 
 	sampleYaml := []byte("hi: there")
@@ -44,6 +44,9 @@ func (od OperationDescription) RunRule(nodes []*yaml.Node, context model.RuleFun
 
 	var opPath, opMethod string
 	for i, op := range ops {
+		if context.IsCancelled() {
+			break
+		}
 		if i%2 == 0 {
 			opPath = op.Value
 			continue
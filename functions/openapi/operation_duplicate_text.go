@@ -0,0 +1,129 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// OperationDuplicateText checks that no two operations share a byte-identical `summary` or `description`, which
+// is usually a copy-paste error (like "Get a pet" on the delete endpoint). The `fields` option selects what
+// is compared: `summary`, `description` or `both` (the default). Every operation in a duplicate group is reported,
+// so all locations can be found. Empty values are not compared.
+type OperationDuplicateText struct {
+}
+
+// operationText is the summary or description of an operation.
+type operationText struct {
+	key    *yaml.Node
+	value  *yaml.Node
+	path   string
+	method string
+	opPath string
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the OperationDuplicateText rule.
+func (od OperationDuplicateText) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "operation_duplicate_text",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "fields",
+				Description: "what to compare, 'summary', 'description' or 'both' (defaults to 'both')",
+			},
+		},
+		ErrorMessage: "'operation_duplicate_text' function has invalid options supplied. Example valid options are " +
+			"'fields' = 'summary'",
+	}
+}
+
+// RunRule will execute the OperationDuplicateText rule, based on supplied context and a supplied []*yaml.Node slice.
+func (od OperationDuplicateText) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	if context.Index.GetPathsNode() == nil {
+		return results
+	}
+
+	var fields []string
+	switch getStringOption("fields", context.Options, "both") {
+	case "summary":
+		fields = []string{"summary"}
+	case "description":
+		fields = []string{"description"}
+	default:
+		fields = []string{"summary", "description"}
+	}
+
+	for _, field := range fields {
+		groups := make(map[string][]operationText)
+		var order []string
+
+		ops := context.Index.GetPathsNode().Content
+		var opPath string
+		for i, op := range ops {
+			if context.IsCancelled() {
+				break
+			}
+			if i%2 == 0 {
+				opPath = op.Value
+				continue
+			}
+			for m := 0; m < len(op.Content)-1; m += 2 {
+				method, operation := op.Content[m].Value, op.Content[m+1]
+				if !isOperationMethod(method) || !utils.IsNodeMap(operation) {
+					continue
+				}
+				key, value := utils.FindKeyNodeTop(field, operation.Content)
+				if value == nil || value.Kind != yaml.ScalarNode || strings.TrimSpace(value.Value) == "" {
+					continue
+				}
+				if groups[value.Value] == nil {
+					order = append(order, value.Value)
+				}
+				groups[value.Value] = append(groups[value.Value], operationText{
+					key:    key,
+					value:  value,
+					path:   fmt.Sprintf("$.paths.%s.%s.%s", opPath, method, field),
+					method: method,
+					opPath: opPath,
+				})
+			}
+		}
+
+		for _, text := range order {
+			group := groups[text]
+			if len(group) < 2 {
+				continue
+			}
+			for x, current := range group {
+				var others []string
+				for y, other := range group {
+					if x != y {
+						others = append(others, fmt.Sprintf("`%s %s`", other.method, other.opPath))
+					}
+				}
+				results = append(results, model.RuleFunctionResult{
+					Message: fmt.Sprintf("`%s` of the `%s` operation at path `%s` is identical to %s", field,
+						current.method, current.opPath, strings.Join(others, ", ")),
+					StartNode: current.key,
+					EndNode:   current.value,
+					Path:      current.path,
+					Rule:      context.Rule,
+				})
+			}
+		}
+	}
+	return results
+}
@@ -0,0 +1,96 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var operationDuplicateTextTestSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      summary: List pets
+      description: Returns a pet.
+    post:
+      summary: Create a pet
+      description: Returns a pet.
+  /pets/{id}:
+    get:
+      summary: Get a pet
+      description: Returns a pet.
+    delete:
+      summary: Get a pet
+      description: ""
+    put:
+      summary: Get a pet.`
+
+func TestOperationDuplicateText_GetSchema(t *testing.T) {
+	def := OperationDuplicateText{}
+	assert.Equal(t, "operation_duplicate_text", def.GetSchema().Name)
+}
+
+func TestOperationDuplicateText_RunRule(t *testing.T) {
+	def := OperationDuplicateText{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestOperationDuplicateText_RunRule_Fail(t *testing.T) {
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(operationDuplicateTextTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(operationDuplicateTextTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "operation_duplicate_text", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := OperationDuplicateText{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 5)
+	assert.Equal(t, "`summary` of the `get` operation at path `/pets/{id}` is identical to `delete /pets/{id}`",
+		res[0].Message)
+	assert.Equal(t, "$.paths./pets/{id}.get.summary", res[0].Path)
+	assert.Equal(t, 12, res[0].StartNode.Line)
+	assert.Equal(t, "$.paths./pets/{id}.delete.summary", res[1].Path)
+	assert.Equal(t, "`description` of the `get` operation at path `/pets` is identical to `post /pets`, "+
+		"`get /pets/{id}`", res[2].Message)
+	assert.Equal(t, "$.paths./pets.post.description", res[3].Path)
+	assert.Equal(t, "$.paths./pets/{id}.get.description", res[4].Path)
+}
+
+func TestOperationDuplicateText_RunRule_Summary(t *testing.T) {
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(operationDuplicateTextTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(operationDuplicateTextTestSpec), path)
+
+	opts := map[string]interface{}{
+		"fields": "summary",
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "operation_duplicate_text", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := OperationDuplicateText{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "$.paths./pets/{id}.delete.summary", res[1].Path)
+}
@@ -6,11 +6,16 @@ package openapi
 import (
 	"fmt"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
+	"regexp"
+	"strings"
 )
 
-// OperationId is a rule that will check if each operation provides an operationId
+// OperationId is a rule that will check if each operation provides an operationId. It can fix missing
+// operationIds, by generating one from the method and path (`get /pizzas/{id}/toppings` becomes
+// `getPizzasByIdToppings`), a number is added if the generated operationId is already used.
 type OperationId struct {
 }
 
@@ -33,6 +38,9 @@ func (oId OperationId) RunRule(nodes []*yaml.Node, context model.RuleFunctionCon
 	paths := context.Index.GetAllPaths()
 
 	for path, methodMap := range paths {
+		if context.IsCancelled() {
+			break
+		}
 
 		for method, methodNode := range methodMap {
 
@@ -53,3 +61,68 @@ func (oId OperationId) RunRule(nodes []*yaml.Node, context model.RuleFunctionCon
 	}
 	return results
 }
+
+var operationIdWords = regexp.MustCompile(`[A-Za-z0-9]+`)
+
+// Fix will propose adding a generated operationId to an operation that is missing one. Operations that generate
+// the same operationId (like `get /a-b` and `get /a_b`) are numbered in document order, so fixing all of them in
+// the same run doesn't create duplicates.
+func (oId OperationId) Fix(result model.RuleFunctionResult, context model.RuleFunctionContext) []model.NodeEdit {
+	if result.StartNode == nil || !utils.IsNodeMap(result.StartNode) || !strings.HasPrefix(result.Path, "$.paths.") {
+		return nil
+	}
+	opPath := strings.TrimPrefix(result.Path, "$.paths.")
+	split := strings.LastIndex(opPath, ".")
+	if split < 0 {
+		return nil
+	}
+	unique := generateOperationId(opPath[split+1:], opPath[:split])
+
+	if context.Index != nil && context.Index.GetRootNode() != nil {
+		root := context.Index.GetRootNode()
+		used := make(map[string]bool)
+		walker.ForEachOperation(root, func(op walker.Operation) bool {
+			if _, existing := utils.FindKeyNodeTop("operationId", op.Node.Content); existing != nil {
+				used[existing.Value] = true
+			}
+			return true
+		})
+
+		// every operation without an operationId is given one, the same way, until this one is reached.
+		walker.ForEachOperation(root, func(op walker.Operation) bool {
+			if _, existing := utils.FindKeyNodeTop("operationId", op.Node.Content); existing != nil {
+				return true
+			}
+			id := generateOperationId(op.Method, op.Path)
+			unique = id
+			for i := 2; used[unique]; i++ {
+				unique = fmt.Sprintf("%s%d", id, i)
+			}
+			used[unique] = true
+			return op.JSONPath != result.Path
+		})
+	}
+
+	return []model.NodeEdit{{
+		Line:   result.StartNode.Line,
+		Column: result.StartNode.Column,
+		Key:    "operationId",
+		Value:  unique,
+	}}
+}
+
+// generateOperationId builds an operationId from a method and a path, `get /pizzas/{id}` becomes `getPizzasById`.
+func generateOperationId(method, path string) string {
+	id := strings.ToLower(method)
+	for _, segment := range strings.Split(path, "/") {
+		prefix := ""
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			prefix = "By"
+		}
+		for _, word := range operationIdWords.FindAllString(segment, -1) {
+			id = fmt.Sprintf("%s%s%s%s", id, prefix, strings.ToUpper(word[:1]), word[1:])
+			prefix = ""
+		}
+	}
+	return id
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// OperationIdConvention checks operationIds follow a naming convention, they must match the `pattern` option
+// (camelCase, like `getPet`, by default). With `checkVerbs` set, the verb an operationId starts with (its first
+// word) must also agree with the method of the operation, `listPets` is fine for a `get`, not for a `post`. The
+// verbs of each method can be replaced with the `verbs` option (a map of method to verbs), and custom verbs (like
+// `search`) can be allowed on any method with the `allowedVerbs` option.
+type OperationIdConvention struct {
+}
+
+// defaultOperationVerbs are the verbs each method's operationIds can start with, unless configured.
+var defaultOperationVerbs = map[string][]string{
+	"get":     {"get", "list", "find", "search"},
+	"post":    {"create", "add"},
+	"put":     {"update", "replace", "set", "put"},
+	"patch":   {"update", "patch", "modify"},
+	"delete":  {"delete", "remove"},
+	"head":    {"head", "check", "exists"},
+	"options": {"options"},
+	"trace":   {"trace"},
+}
+
+// operationIdVerb is the first word of an operationId, in camelCase, PascalCase, snake_case or kebab-case.
+var operationIdVerb = regexp.MustCompile(`^[A-Za-z][a-z]*`)
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the OperationIdConvention rule.
+func (oc OperationIdConvention) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "operation_id_convention",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "pattern",
+				Description: "a regular expression operationIds must match (defaults to camelCase)",
+			},
+			{
+				Name:        "checkVerbs",
+				Description: "check the verb an operationId starts with agrees with its method (defaults to false)",
+			},
+			{
+				Name:        "verbs",
+				Description: "a map of method to the verbs its operationIds can start with",
+			},
+			{
+				Name:        "allowedVerbs",
+				Description: "custom verbs operationIds can start with, whatever their method",
+			},
+		},
+		ErrorMessage: "'operation_id_convention' function has invalid options supplied. Example valid options are " +
+			"'pattern' = '^[a-z][a-zA-Z0-9]*$', 'checkVerbs' = true or 'allowedVerbs' = ['search']",
+	}
+}
+
+// RunRule will execute the OperationIdConvention rule, based on supplied context and a supplied []*yaml.Node slice.
+func (oc OperationIdConvention) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	patternOption := getStringOption("pattern", context.Options, `^[a-z][a-zA-Z0-9]*$`)
+	pattern, err := regexp.Compile(patternOption)
+	if err != nil {
+		return []model.RuleFunctionResult{
+			{
+				Message:   fmt.Sprintf("unable to compile `pattern` '%s': %s", patternOption, err.Error()),
+				StartNode: root,
+				EndNode:   root,
+				Path:      "$",
+				Rule:      context.Rule,
+			},
+		}
+	}
+	checkVerbs := getBoolOption("checkVerbs", context.Options, false)
+	verbs := make(map[string][]string)
+	for method, v := range defaultOperationVerbs {
+		verbs[method] = v
+	}
+	if configured, ok := utils.ExtractValueFromInterfaceMap("verbs", context.Options).(map[string]interface{}); ok {
+		for method, v := range configured {
+			if methodVerbs := utils.ConvertInterfaceArrayToStringArray(v); methodVerbs != nil {
+				verbs[strings.ToLower(method)] = methodVerbs
+			}
+		}
+	}
+	allowedVerbs := make(map[string]bool)
+	for _, v := range getStringArrayOption("allowedVerbs", context.Options, nil) {
+		allowedVerbs[strings.ToLower(v)] = true
+	}
+
+	_, paths := utils.FindKeyNodeTop("paths", root.Content)
+	if !utils.IsNodeMap(paths) {
+		return results
+	}
+	for i := 0; i < len(paths.Content)-1; i += 2 {
+		if context.IsCancelled() {
+			break
+		}
+		opPath, pathItem := paths.Content[i].Value, paths.Content[i+1]
+		for m := 0; m < len(pathItem.Content)-1; m += 2 {
+			opKey, operation := pathItem.Content[m], pathItem.Content[m+1]
+			if !isOperationMethod(opKey.Value) || !utils.IsNodeMap(operation) {
+				continue
+			}
+			idKey, id := utils.FindKeyNodeTop("operationId", operation.Content)
+			if id == nil || id.Value == "" {
+				continue
+			}
+			var msg string
+			method := strings.ToLower(opKey.Value)
+			verb := strings.ToLower(operationIdVerb.FindString(id.Value))
+			switch {
+			case !pattern.MatchString(id.Value):
+				msg = fmt.Sprintf("operationId `%s` of operation `%s` at path `%s` does not match the pattern `%s`",
+					id.Value, opKey.Value, opPath, patternOption)
+			case checkVerbs && len(verbs[method]) > 0 && !allowedVerbs[verb] && !containsString(verbs[method], verb):
+				msg = fmt.Sprintf("operationId `%s` of operation `%s` at path `%s` should start with %s",
+					id.Value, opKey.Value, opPath, joinNames(verbs[method], "or"))
+			default:
+				continue
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: idKey,
+				EndNode:   id,
+				Path:      fmt.Sprintf("$.paths.%s.%s.operationId", opPath, opKey.Value),
+				Rule:      context.Rule,
+			})
+		}
+	}
+	return results
+}
+
+// containsString returns true if the supplied value is one of the supplied values.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if strings.ToLower(v) == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var operationIdConventionTestSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+    post:
+      operationId: searchPets
+    put:
+      operationId: create_pet
+  /pets/{id}:
+    get:
+      operationId: createPet
+    delete:
+      operationId: removePet
+    patch: {}`
+
+func TestOperationIdConvention_GetSchema(t *testing.T) {
+	def := OperationIdConvention{}
+	assert.Equal(t, "operation_id_convention", def.GetSchema().Name)
+}
+
+func TestOperationIdConvention_RunRule(t *testing.T) {
+	def := OperationIdConvention{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestOperationIdConvention_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(operationIdConventionTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "operation_id_convention", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := OperationIdConvention{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "operationId `create_pet` of operation `put` at path `/pets` does not match the pattern "+
+		"`^[a-z][a-zA-Z0-9]*$`", res[0].Message)
+	assert.Equal(t, "$.paths./pets.put.operationId", res[0].Path)
+	assert.Equal(t, 9, res[0].StartNode.Line)
+}
+
+func TestOperationIdConvention_RunRule_CheckVerbs(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(operationIdConventionTestSpec), path)
+
+	opts := map[string]interface{}{
+		"checkVerbs": true,
+		"pattern":    "^[a-z][a-zA-Z0-9_]*$",
+	}
+	rule := buildOpenApiTestRuleAction(path, "operation_id_convention", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := OperationIdConvention{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "operationId `searchPets` of operation `post` at path `/pets` should start with `create` "+
+		"or `add`", res[0].Message)
+	assert.Equal(t, "operationId `create_pet` of operation `put` at path `/pets` should start with `update`, "+
+		"`replace`, `set` or `put`", res[1].Message)
+	assert.Equal(t, "$.paths./pets/{id}.get.operationId", res[2].Path)
+}
+
+func TestOperationIdConvention_RunRule_AllowedVerbs(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(operationIdConventionTestSpec), path)
+
+	opts := map[string]interface{}{
+		"checkVerbs":   true,
+		"allowedVerbs": []interface{}{"search"},
+		"verbs": map[string]interface{}{
+			"put": []interface{}{"create"},
+		},
+		"pattern": ".*",
+	}
+	rule := buildOpenApiTestRuleAction(path, "operation_id_convention", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := OperationIdConvention{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.paths./pets/{id}.get.operationId", res[0].Path)
+}
+
+func TestOperationIdConvention_RunRule_BadPattern(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(operationIdConventionTestSpec), path)
+
+	opts := map[string]interface{}{
+		"pattern": "([",
+	}
+	rule := buildOpenApiTestRuleAction(path, "operation_id_convention", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := OperationIdConvention{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Contains(t, res[0].Message, "unable to compile `pattern` '(['")
+}
@@ -80,3 +80,85 @@ func TestOperationId_RunRule_Success(t *testing.T) {
 	assert.Len(t, res, 0)
 
 }
+
+func TestOperationId_Fix(t *testing.T) {
+
+	yml := `paths:
+  /pizzas/{pizzaId}/toppings:
+    get:
+      summary: list toppings
+    post:
+      summary: add a topping
+  /v1.0/pizzas:
+    get:
+      operationId: getV10Pizzas
+  /v1-0/pizzas:
+    get:
+      summary: list pizzas`
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	rule := buildOpenApiTestRuleAction(path, "operation_id", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	config := index.CreateOpenAPIIndexConfig()
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, config)
+
+	def := OperationId{}
+	res := def.RunRule(rootNode.Content, ctx)
+	assert.Len(t, res, 3)
+
+	ids := make(map[string]string)
+	for _, r := range res {
+		fixes := def.Fix(r, ctx)
+		assert.Len(t, fixes, 1)
+		assert.Equal(t, "operationId", fixes[0].Key)
+		assert.Equal(t, r.StartNode.Line, fixes[0].Line)
+		ids[r.Path] = fixes[0].Value
+	}
+	assert.Equal(t, "getPizzasByPizzaIdToppings", ids["$.paths./pizzas/{pizzaId}/toppings.get"])
+	assert.Equal(t, "postPizzasByPizzaIdToppings", ids["$.paths./pizzas/{pizzaId}/toppings.post"])
+	assert.Equal(t, "getV10Pizzas2", ids["$.paths./v1-0/pizzas.get"])
+}
+
+func TestOperationId_Fix_Colliding(t *testing.T) {
+
+	yml := `paths:
+  /a-b:
+    get:
+      summary: a-b
+  /a_b:
+    get:
+      summary: a_b
+  /ab:
+    get:
+      operationId: getAB2`
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	rule := buildOpenApiTestRuleAction(path, "operation_id", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	config := index.CreateOpenAPIIndexConfig()
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, config)
+
+	def := OperationId{}
+	res := def.RunRule(rootNode.Content, ctx)
+	assert.Len(t, res, 2)
+
+	// both paths generate `getAB`, the second one (in document order) skips the `getAB2` already used.
+	ids := make(map[string]string)
+	for _, r := range res {
+		fixes := def.Fix(r, ctx)
+		assert.Len(t, fixes, 1)
+		ids[r.Path] = fixes[0].Value
+	}
+	assert.Equal(t, "getAB", ids["$.paths./a-b.get"])
+	assert.Equal(t, "getAB3", ids["$.paths./a_b.get"])
+}
@@ -34,6 +34,9 @@ func (op OperationParameters) RunRule(nodes []*yaml.Node, context model.RuleFunc
 	// add any param indexing errors already found.
 	errs := context.Index.GetOperationParametersIndexErrors()
 	for n := range errs {
+		if context.IsCancelled() {
+			break
+		}
 		er := errs[n].(*index.IndexingError)
 		results = append(results, model.RuleFunctionResult{
 			Message:   er.Error(),
@@ -46,6 +49,9 @@ func (op OperationParameters) RunRule(nodes []*yaml.Node, context model.RuleFunc
 
 	// look in the index for all operations params.
 	for path, methods := range context.Index.GetOperationParameterReferences() {
+		if context.IsCancelled() {
+			break
+		}
 		for method, methodNode := range methods {
 
 			seenParamInLocations := make(map[string]bool)
@@ -0,0 +1,110 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// OperationProduces checks the media types the responses of swagger operations use are produced by the operation.
+// Swagger responses name their media types with their `examples` (keyed by media type), an operation produces the
+// media types of its own `produces`, which replaces (not extends) the `produces` of the document, even when it is
+// empty. Parameters of media types are ignored, ranges (like `application/*`) in `produces` match the media types
+// they cover. Referenced responses are followed.
+type OperationProduces struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the OperationProduces rule.
+func (op OperationProduces) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "operation_produces",
+	}
+}
+
+// RunRule will execute the OperationProduces rule, based on supplied context and a supplied []*yaml.Node slice.
+func (op OperationProduces) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	// mediaType returns a media type without its parameters, in lower case.
+	mediaType := func(value string) string {
+		base, _, _ := strings.Cut(value, ";")
+		return strings.ToLower(strings.TrimSpace(base))
+	}
+	_, rootProduces := utils.FindKeyNodeTop("produces", root.Content)
+
+	walker.ForEachOperation(root, func(operation walker.Operation) bool {
+		producesKey, produces := utils.FindKeyNodeTop("produces", operation.Node.Content)
+		if producesKey == nil {
+			produces = rootProduces
+		}
+		var produced []string
+		if produces != nil && utils.IsNodeArray(produces) {
+			for _, mt := range produces.Content {
+				produced = append(produced, mediaType(mt.Value))
+			}
+		}
+		isProduced := func(mt string) bool {
+			for _, p := range produced {
+				if ok, _ := path.Match(p, mt); ok || p == mt {
+					return true
+				}
+			}
+			return false
+		}
+
+		var missing []string
+		walker.ForEachResponse(operation, func(response walker.Response) bool {
+			node := resolveSchemaReference(root, response.Node)
+			if node == nil {
+				return true
+			}
+			_, examples := utils.FindKeyNodeTop("examples", node.Content)
+			if examples == nil || !utils.IsNodeMap(examples) {
+				return true
+			}
+			for i := 0; i < len(examples.Content)-1; i += 2 {
+				mt := examples.Content[i].Value
+				if !isProduced(mediaType(mt)) && !containsString(missing, mt) {
+					missing = append(missing, mt)
+				}
+			}
+			return true
+		})
+		if len(missing) == 0 {
+			return !context.IsCancelled()
+		}
+
+		declared := "it doesn't produce any media types"
+		if len(produced) > 0 {
+			declared = fmt.Sprintf("it produces %s", joinNames(produced, "and"))
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message: fmt.Sprintf("`%s` operation at path `%s` responds with %s, but %s", operation.Method,
+				operation.Path, joinNames(missing, "and"), declared),
+			StartNode: operation.KeyNode,
+			EndNode:   utils.FindLastChildNodeWithLevel(operation.Node, 0),
+			Path:      operation.JSONPath,
+			Rule:      context.Rule,
+		})
+		return !context.IsCancelled()
+	})
+	return results
+}
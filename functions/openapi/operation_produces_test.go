@@ -0,0 +1,84 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var operationProducesTestSpec = `swagger: '2.0'
+produces:
+  - application/json
+paths:
+  /pizzas:
+    get:
+      responses:
+        '200':
+          description: pizzas
+          examples:
+            application/json; charset=utf-8:
+              - name: margherita
+            text/csv: name
+    post:
+      produces:
+        - application/xml
+      responses:
+        '201':
+          $ref: '#/responses/Created'
+  /menus:
+    get:
+      produces:
+        - text/*
+      responses:
+        '200':
+          description: menus
+          examples:
+            text/html: <ul></ul>
+    delete:
+      produces: []
+      responses:
+        '204':
+          $ref: '#/responses/Created'
+responses:
+  Created:
+    description: created
+    examples:
+      application/json:
+        name: margherita`
+
+func TestOperationProduces_GetSchema(t *testing.T) {
+	def := OperationProduces{}
+	assert.Equal(t, "operation_produces", def.GetSchema().Name)
+}
+
+func TestOperationProduces_RunRule(t *testing.T) {
+	def := OperationProduces{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestOperationProduces_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(operationProducesTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "operation_produces", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := OperationProduces{}
+	res := def.RunRule(nodes, ctx)
+
+	// the operation `produces` of the post replaces the `produces` of the document.
+	assert.Len(t, res, 3)
+	assert.Equal(t, "`get` operation at path `/pizzas` responds with `text/csv`, but it produces `application/json`",
+		res[0].Message)
+	assert.Equal(t, "$.paths./pizzas.get", res[0].Path)
+	assert.Equal(t, 6, res[0].StartNode.Line)
+	assert.Equal(t, "`post` operation at path `/pizzas` responds with `application/json`, but it produces "+
+		"`application/xml`", res[1].Message)
+	assert.Equal(t, "`delete` operation at path `/menus` responds with `application/json`, but it doesn't produce "+
+		"any media types", res[2].Message)
+}
@@ -44,6 +44,9 @@ func (osd OperationSecurityDefined) RunRule(nodes []*yaml.Node, context model.Ru
 	securityDefinitions := context.Index.GetAllSecuritySchemes()
 
 	for path, methodMap := range paths {
+		if context.IsCancelled() {
+			break
+		}
 
 		for method, methodNode := range methodMap {
 
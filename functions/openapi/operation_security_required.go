@@ -0,0 +1,118 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// OperationSecurityRequired checks every operation is protected by a security requirement, its own or the root
+// `security` default. Operations that opt out of security (with `security: []`, or with only the anonymous `{}`
+// requirement) are only flagged if the `flagOptOut` option is set. Paths that are intentionally public (like
+// `/health` or `/login`) can be skipped with the `publicPaths` option, which accepts paths or glob patterns.
+type OperationSecurityRequired struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the OperationSecurityRequired rule.
+func (osr OperationSecurityRequired) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "operation_security_required",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "publicPaths",
+				Description: "paths (or glob patterns) that are intentionally public and don't need security",
+			},
+			{
+				Name:        "flagOptOut",
+				Description: "flag operations that explicitly opt out of security with `security: []` (defaults to false)",
+			},
+		},
+		ErrorMessage: "'operation_security_required' function has invalid options supplied. Example valid options are " +
+			"'publicPaths' = ['/health', '/login'] or 'flagOptOut' = true",
+	}
+}
+
+// RunRule will execute the OperationSecurityRequired rule, based on supplied context and a supplied []*yaml.Node slice.
+func (osr OperationSecurityRequired) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	publicPaths := getStringArrayOption("publicPaths", context.Options, nil)
+	flagOptOut := getBoolOption("flagOptOut", context.Options, false)
+
+	// a security requirement secures an operation if it requires at least one scheme.
+	secured := func(security *yaml.Node) bool {
+		if !utils.IsNodeArray(security) {
+			return false
+		}
+		for _, requirement := range security.Content {
+			if utils.IsNodeMap(requirement) && len(requirement.Content) > 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	_, rootSecurity := utils.FindKeyNodeTop("security", root.Content)
+	globallySecured := secured(rootSecurity)
+	_, paths := utils.FindKeyNodeTop("paths", root.Content)
+	if !utils.IsNodeMap(paths) {
+		return results
+	}
+	for i := 0; i < len(paths.Content)-1; i += 2 {
+		if context.IsCancelled() {
+			break
+		}
+		opPath, pathItem := paths.Content[i].Value, paths.Content[i+1]
+		isPublic := false
+		for _, p := range publicPaths {
+			if match, _ := path.Match(p, opPath); match || p == opPath {
+				isPublic = true
+				break
+			}
+		}
+		if isPublic {
+			continue
+		}
+		for m := 0; m < len(pathItem.Content)-1; m += 2 {
+			opKey, operation := pathItem.Content[m], pathItem.Content[m+1]
+			if !isOperationMethod(opKey.Value) || !utils.IsNodeMap(operation) {
+				continue
+			}
+			var msg string
+			_, opSecurity := utils.FindKeyNodeTop("security", operation.Content)
+			switch {
+			case opSecurity == nil && !globallySecured:
+				msg = fmt.Sprintf("operation `%s` at path `%s` has no `security` requirement, and there is no "+
+					"global `security` default", opKey.Value, opPath)
+			case opSecurity != nil && !secured(opSecurity) && flagOptOut:
+				msg = fmt.Sprintf("operation `%s` at path `%s` opts out of security", opKey.Value, opPath)
+			default:
+				continue
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: opKey,
+				EndNode:   utils.FindLastChildNodeWithLevel(operation, 0),
+				Path:      fmt.Sprintf("$.paths.%s.%s", opPath, opKey.Value),
+				Rule:      context.Rule,
+			})
+		}
+	}
+	return results
+}
@@ -0,0 +1,110 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var operationSecurityRequiredTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+    post:
+      security:
+        - apiKey: []
+      responses:
+        "201":
+          description: created
+    delete:
+      security: []
+      responses:
+        "204":
+          description: deleted
+    x-internal: true
+  /health:
+    get:
+      responses:
+        "200":
+          description: ok
+  /login/sso:
+    post:
+      security:
+        - {}
+      responses:
+        "200":
+          description: ok`
+
+func TestOperationSecurityRequired_GetSchema(t *testing.T) {
+	def := OperationSecurityRequired{}
+	assert.Equal(t, "operation_security_required", def.GetSchema().Name)
+}
+
+func TestOperationSecurityRequired_RunRule(t *testing.T) {
+	def := OperationSecurityRequired{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestOperationSecurityRequired_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(operationSecurityRequiredTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "operation_security_required", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := OperationSecurityRequired{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "operation `get` at path `/pizza` has no `security` requirement, and there is no "+
+		"global `security` default", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get", res[0].Path)
+	assert.Equal(t, 4, res[0].StartNode.Line)
+	assert.Equal(t, "$.paths./health.get", res[1].Path)
+}
+
+func TestOperationSecurityRequired_RunRule_GlobalSecurity(t *testing.T) {
+
+	path := "$"
+
+	spec := "security:\n  - apiKey: []\n" + operationSecurityRequiredTestSpec
+	nodes, _ := utils.FindNodes([]byte(spec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "operation_security_required", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := OperationSecurityRequired{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
+
+func TestOperationSecurityRequired_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(operationSecurityRequiredTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["publicPaths"] = []interface{}{"/health", "/login/*"}
+	opts["flagOptOut"] = true
+
+	rule := buildOpenApiTestRuleAction(path, "operation_security_required", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := OperationSecurityRequired{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "$.paths./pizza.get", res[0].Path)
+	assert.Equal(t, "operation `delete` at path `/pizza` opts out of security", res[1].Message)
+}
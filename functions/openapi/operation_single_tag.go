@@ -34,6 +34,9 @@ func (ost OperationSingleTag) RunRule(nodes []*yaml.Node, context model.RuleFunc
 	operationTags := context.Index.GetOperationTags()
 
 	for path, methodMap := range paths {
+		if context.IsCancelled() {
+			break
+		}
 
 		for method, methodNode := range methodMap {
 
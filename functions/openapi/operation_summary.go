@@ -0,0 +1,106 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// OperationSummary checks operation summaries are not too long, and do not contain line breaks. Summaries that
+// are present but empty are reported as missing, rather than too long.
+type OperationSummary struct {
+}
+
+const defaultSummaryMaxLength = 80
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the OperationSummary rule.
+func (os OperationSummary) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "operation_summary",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "maxLength",
+				Description: "the maximum number of characters allowed in a summary (defaults to 80)",
+			},
+		},
+		ErrorMessage: "'operation_summary' function has invalid options supplied.",
+	}
+}
+
+// RunRule will execute the OperationSummary rule, based on supplied context and a supplied []*yaml.Node slice.
+func (os OperationSummary) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	if context.Index.GetPathsNode() == nil {
+		return results
+	}
+
+	maxLength := getIntOption("maxLength", context.Options, defaultSummaryMaxLength)
+
+	ops := context.Index.GetPathsNode().Content
+	var opPath string
+	for i, op := range ops {
+		if context.IsCancelled() {
+			break
+		}
+		if i%2 == 0 {
+			opPath = op.Value
+			continue
+		}
+		for m := 0; m < len(op.Content)-1; m += 2 {
+			opMethod := op.Content[m].Value
+			if !isOperationMethod(opMethod) {
+				continue
+			}
+			summaryKey, summary := utils.FindKeyNodeTop("summary", op.Content[m+1].Content)
+			if summary == nil {
+				continue
+			}
+			path := fmt.Sprintf("$.paths.%s.%s.summary", opPath, opMethod)
+
+			if strings.TrimSpace(summary.Value) == "" {
+				results = append(results, model.RuleFunctionResult{
+					Message:   fmt.Sprintf("operation `%s` at path `%s` has an empty summary", opMethod, opPath),
+					StartNode: summaryKey,
+					EndNode:   summary,
+					Path:      path,
+					Rule:      context.Rule,
+				})
+				continue
+			}
+			if strings.ContainsAny(strings.TrimRight(summary.Value, "\r\n"), "\r\n") {
+				results = append(results, model.RuleFunctionResult{
+					Message: fmt.Sprintf("operation `%s` at path `%s` has a summary that contains line breaks",
+						opMethod, opPath),
+					StartNode: summaryKey,
+					EndNode:   summary,
+					Path:      path,
+					Rule:      context.Rule,
+				})
+			}
+			if l := utf8.RuneCountInString(strings.TrimSpace(summary.Value)); l > maxLength {
+				results = append(results, model.RuleFunctionResult{
+					Message: fmt.Sprintf("operation `%s` at path `%s` has a summary that is %d characters long, "+
+						"the maximum is %d", opMethod, opPath, l, maxLength),
+					StartNode: summaryKey,
+					EndNode:   summary,
+					Path:      path,
+					Rule:      context.Rule,
+				})
+			}
+		}
+	}
+	return results
+}
@@ -0,0 +1,87 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var operationSummaryTestSpec = `openapi: 3.0.1
+paths:
+  /pizza:
+    get:
+      summary: List all the pizza
+    post:
+      summary: Create a brand new pizza, with all the toppings you could ever want, plus extra cheese on top
+    put:
+      summary: ""
+    patch:
+      summary: |
+        Update a pizza
+        with new toppings
+    delete:
+      description: no summary, nothing to check.`
+
+func TestOperationSummary_GetSchema(t *testing.T) {
+	def := OperationSummary{}
+	assert.Equal(t, "operation_summary", def.GetSchema().Name)
+}
+
+func TestOperationSummary_RunRule(t *testing.T) {
+	def := OperationSummary{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestOperationSummary_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(operationSummaryTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(operationSummaryTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "operation_summary", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := OperationSummary{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "operation `post` at path `/pizza` has a summary that is 93 characters long, the maximum is 80",
+		res[0].Message)
+	assert.Equal(t, "$.paths./pizza.post.summary", res[0].Path)
+	assert.Equal(t, "operation `put` at path `/pizza` has an empty summary", res[1].Message)
+	assert.Equal(t, "operation `patch` at path `/pizza` has a summary that contains line breaks", res[2].Message)
+}
+
+func TestOperationSummary_RunRule_MaxLength(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(operationSummaryTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(operationSummaryTestSpec), path)
+
+	opts := map[string]string{
+		"maxLength": "10",
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "operation_summary", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), opts)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := OperationSummary{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 5)
+}
@@ -47,6 +47,9 @@ func (td TagDefined) RunRule(nodes []*yaml.Node, context model.RuleFunctionConte
 	}
 
 	for x, operationNode := range pathsNode.Content {
+		if context.IsCancelled() {
+			break
+		}
 		var currentPath string
 		var currentVerb string
 		if operationNode.Tag == "!!str" {
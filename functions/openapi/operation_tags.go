@@ -11,13 +11,22 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// OperationTags is a rule that checks operations are using tags and they are not empty.
+// OperationTags is a rule that checks operations are using tags and they are not empty. The `minTags` option sets
+// how many tags every operation needs (defaults to 1). Tags are counted whether they are defined at the root or not,
+// undefined tags are reported by the operation-tag-defined rule.
 type OperationTags struct{}
 
 // GetSchema returns a model.RuleFunctionSchema defining the schema of the TagDefined rule.
 func (ot OperationTags) GetSchema() model.RuleFunctionSchema {
 	return model.RuleFunctionSchema{
 		Name: "operation_tags",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "minTags",
+				Description: "the minimum number of tags every operation must have (defaults to 1)",
+			},
+		},
+		ErrorMessage: "'operation_tags' function has invalid options supplied. Example valid options are 'minTags' = 1",
 	}
 }
 
@@ -29,6 +38,7 @@ func (ot OperationTags) RunRule(nodes []*yaml.Node, context model.RuleFunctionCo
 	}
 
 	var results []model.RuleFunctionResult
+	minTags := getIntOption("minTags", context.Options, 1)
 	pathsNode := context.Index.GetPathsNode()
 
 	if pathsNode == nil {
@@ -36,6 +46,9 @@ func (ot OperationTags) RunRule(nodes []*yaml.Node, context model.RuleFunctionCo
 	}
 
 	for x, operationNode := range pathsNode.Content {
+		if context.IsCancelled() {
+			break
+		}
 		var currentPath string
 		var currentVerb string
 		if operationNode.Tag == "!!str" {
@@ -79,15 +92,22 @@ func (ot OperationTags) RunRule(nodes []*yaml.Node, context model.RuleFunctionCo
 					_, opTagsNode = utils.FindKeyNode("tags", verbDataNode.Content)
 				}
 
-				if opTagsNode == nil || len(opTagsNode.Content) <= 0 {
+				tagCount := 0
+				if opTagsNode != nil {
+					tagCount = len(opTagsNode.Content)
+				}
+				if tagCount < minTags {
 					endNode := utils.FindLastChildNodeWithLevel(verbNode, 0)
 					var msg string
 					if opTagsNode == nil {
 						msg = fmt.Sprintf("Tags for `%s` operation at path `%s` are missing",
 							currentVerb, currentPath)
-					} else {
+					} else if tagCount == 0 {
 						msg = fmt.Sprintf("Tags for `%s` operation at path `%s` are empty",
 							currentVerb, currentPath)
+					} else {
+						msg = fmt.Sprintf("Operation `%s` at path `%s` has %d tag(s), at least %d are required",
+							currentVerb, currentPath, tagCount, minTags)
 					}
 
 					results = append(results, model.RuleFunctionResult{
@@ -1,6 +1,7 @@
 package openapi
 
 import (
+	"context"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
@@ -242,3 +243,85 @@ func TestOperationTags_RunRule_HandleNoPaths(t *testing.T) {
 
 	assert.Len(t, res, 0)
 }
+
+func TestOperationTags_RunRule_MinTags(t *testing.T) {
+
+	yml := `tags:
+  - name: a
+paths:
+  /hello:
+    post:
+      tags:
+       - a
+       - b
+    get:
+      tags:
+       - undefined
+  /there/yeah:
+    post:
+      description: no tags`
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	opts := map[string]interface{}{
+		"minTags": 2,
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "operation_tags", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+	config := index.CreateOpenAPIIndexConfig()
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, config)
+
+	def := OperationTags{}
+	res := def.RunRule(nodes, ctx)
+
+	// tags that are not defined at the root still count.
+	assert.Len(t, res, 2)
+	assert.Equal(t, "Operation `get` at path `/hello` has 1 tag(s), at least 2 are required", res[0].Message)
+	assert.Equal(t, "$.paths./hello.get", res[0].Path)
+	assert.Equal(t, "Tags for `post` operation at path `/there/yeah` are missing", res[1].Message)
+
+	// no tags are required at all.
+	ctx.Options = map[string]interface{}{"minTags": 0}
+	assert.Len(t, def.RunRule(nodes, ctx), 0)
+}
+
+func TestOperationTags_RunRule_Cancelled(t *testing.T) {
+
+	yml := `paths:
+  /hello:
+    post:
+      description: hi
+  /there/yeah:
+    post:
+      description: there`
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "operation_tags", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	config := index.CreateOpenAPIIndexConfig()
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, config)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx.Context = cancelled
+
+	def := OperationTags{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
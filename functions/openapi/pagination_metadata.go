@@ -0,0 +1,208 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// PaginationMetadata checks paginated collections tell clients how to get the rest of the collection. Each
+// pagination style has the parameters that identify it and the metadata its responses must include, the `styles`
+// option (a map of style name to `parameters` and `properties`) replaces the defaults, which are `offset` (`offset`
+// or `page` parameters, needing a `total`) and `cursor` (`cursor`, `after` or `pageToken` parameters, needing a
+// `nextCursor`). A `get` operation with a parameter of a style is a collection using it, the schemas of its
+// successful responses must have every property of the style, as a property of the schema (or its `allOf` members),
+// or of one of its object properties (like `meta.total`). Collections returning a top level array can only carry
+// metadata in headers, which count as well. Names are compared without case.
+type PaginationMetadata struct {
+}
+
+// paginationStyle is a pagination style, the parameters identifying it and the metadata its responses need.
+type paginationStyle struct {
+	name       string
+	parameters []string
+	properties []string
+}
+
+var defaultPaginationStyles = []paginationStyle{
+	{name: "cursor", parameters: []string{"cursor", "after", "pageToken"}, properties: []string{"nextCursor"}},
+	{name: "offset", parameters: []string{"offset", "page"}, properties: []string{"total"}},
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the PaginationMetadata rule.
+func (pm PaginationMetadata) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "pagination_metadata",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name: "styles",
+				Description: "a map of pagination style to the 'parameters' that identify it, and the 'properties' " +
+					"its responses must include",
+			},
+		},
+		ErrorMessage: "'pagination_metadata' function has invalid options supplied. Example valid options are " +
+			"'styles' = {'cursor': {'parameters': ['cursor'], 'properties': ['nextCursor']}}",
+	}
+}
+
+// RunRule will execute the PaginationMetadata rule, based on supplied context and a supplied []*yaml.Node slice.
+func (pm PaginationMetadata) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	styles := defaultPaginationStyles
+	if configured, ok := utils.ExtractValueFromInterfaceMap("styles", context.Options).(map[string]interface{}); ok {
+		styles = nil
+		for name, s := range configured {
+			style := paginationStyle{name: name}
+			style.parameters = utils.ConvertInterfaceArrayToStringArray(utils.ExtractValueFromInterfaceMap("parameters", s))
+			style.properties = utils.ConvertInterfaceArrayToStringArray(utils.ExtractValueFromInterfaceMap("properties", s))
+			if len(style.parameters) > 0 && len(style.properties) > 0 {
+				styles = append(styles, style)
+			}
+		}
+		sort.Slice(styles, func(i, j int) bool { return styles[i].name < styles[j].name })
+	}
+
+	// metadata collects the names of the properties of a schema (and its allOf members), and of its object
+	// properties, in lower case.
+	var metadata func(schema *yaml.Node, names map[string]bool, depth int, seen map[*yaml.Node]bool)
+	metadata = func(schema *yaml.Node, names map[string]bool, depth int, seen map[*yaml.Node]bool) {
+		if schema = resolveSchemaReference(root, schema); schema == nil || seen[schema] {
+			return
+		}
+		seen[schema] = true
+		for _, p := range ownProperties(schema, "") {
+			names[strings.ToLower(p.key.Value)] = true
+			if depth == 0 {
+				if property := resolveSchemaReference(root, p.schema); property != nil &&
+					containsString(schemaTypesOf(property), "object") {
+					metadata(property, names, depth+1, seen)
+				}
+			}
+		}
+		if _, allOf := utils.FindKeyNodeTop("allOf", schema.Content); allOf != nil && utils.IsNodeArray(allOf) {
+			for _, member := range allOf.Content {
+				metadata(member, names, depth, seen)
+			}
+		}
+	}
+
+	walker.ForEachOperation(root, func(op walker.Operation) bool {
+		if op.Method != "get" {
+			return !context.IsCancelled()
+		}
+
+		params := make(map[string]bool)
+		for _, holder := range []*yaml.Node{op.PathItem, op.Node} {
+			_, list := utils.FindKeyNodeTop("parameters", holder.Content)
+			if list == nil || !utils.IsNodeArray(list) {
+				continue
+			}
+			for _, param := range list.Content {
+				if param = resolveSchemaReference(root, param); param == nil {
+					continue
+				}
+				if _, name := utils.FindKeyNodeTop("name", param.Content); name != nil {
+					params[strings.ToLower(name.Value)] = true
+				}
+			}
+		}
+		var used []paginationStyle
+		for _, style := range styles {
+			for _, p := range style.parameters {
+				if params[strings.ToLower(p)] {
+					used = append(used, style)
+					break
+				}
+			}
+		}
+		if len(used) == 0 {
+			return !context.IsCancelled()
+		}
+
+		_, responses := utils.FindKeyNodeTop("responses", op.Node.Content)
+		if responses == nil || !utils.IsNodeMap(responses) {
+			return !context.IsCancelled()
+		}
+		for i := 0; i < len(responses.Content)-1; i += 2 {
+			code := responses.Content[i].Value
+			response := resolveSchemaReference(root, responses.Content[i+1])
+			if !strings.HasPrefix(code, "2") || response == nil {
+				continue
+			}
+			responsePath := fmt.Sprintf("%s.responses.%s", op.JSONPath, code)
+
+			headers := make(map[string]bool)
+			if _, h := utils.FindKeyNodeTop("headers", response.Content); h != nil && utils.IsNodeMap(h) {
+				for x := 0; x < len(h.Content)-1; x += 2 {
+					headers[strings.ToLower(h.Content[x].Value)] = true
+				}
+			}
+
+			type responseSchema struct {
+				key, schema *yaml.Node
+				path        string
+			}
+			var schemas []responseSchema
+			if key, schema := utils.FindKeyNodeTop("schema", response.Content); schema != nil {
+				schemas = append(schemas, responseSchema{key, schema, responsePath + ".schema"})
+			}
+			if _, content := utils.FindKeyNodeTop("content", response.Content); content != nil &&
+				utils.IsNodeMap(content) {
+				for c := 0; c < len(content.Content)-1; c += 2 {
+					if key, schema := utils.FindKeyNodeTop("schema", content.Content[c+1].Content); schema != nil {
+						schemas = append(schemas, responseSchema{key, schema,
+							fmt.Sprintf("%s.content.%s.schema", responsePath, content.Content[c].Value)})
+					}
+				}
+			}
+
+			for _, rs := range schemas {
+				names := make(map[string]bool)
+				for name := range headers {
+					names[name] = true
+				}
+				metadata(rs.schema, names, 0, make(map[*yaml.Node]bool))
+				for _, style := range used {
+					var missing []string
+					for _, p := range style.properties {
+						if !names[strings.ToLower(p)] {
+							missing = append(missing, p)
+						}
+					}
+					if len(missing) == 0 {
+						continue
+					}
+					results = append(results, model.RuleFunctionResult{
+						Message: fmt.Sprintf("`get` operation at path `%s` uses `%s` pagination, but response "+
+							"`%s` has no %s", op.Path, style.name, code, joinNames(missing, "or")),
+						StartNode: rs.key,
+						EndNode:   utils.FindLastChildNodeWithLevel(rs.schema, 0),
+						Path:      rs.path,
+						Rule:      context.Rule,
+					})
+				}
+			}
+		}
+		return !context.IsCancelled()
+	})
+	return results
+}
@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var paginationMetadataTestSpec = `openapi: 3.1.0
+paths:
+  /pizzas:
+    get:
+      parameters:
+        - name: offset
+          in: query
+        - name: limit
+          in: query
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/PizzaPage'
+  /toppings:
+    get:
+      parameters:
+        - name: cursor
+          in: query
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  items:
+                    type: array
+                  meta:
+                    type: object
+                    properties:
+                      next:
+                        type: string
+  /sauces:
+    get:
+      parameters:
+        - name: page
+          in: query
+      responses:
+        "200":
+          headers:
+            X-Total:
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                type: array
+  /crusts:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: array
+components:
+  schemas:
+    PizzaPage:
+      allOf:
+        - $ref: '#/components/schemas/Page'
+        - type: object
+          properties:
+            items:
+              type: array
+    Page:
+      type: object
+      properties:
+        meta:
+          type: object
+          properties:
+            total:
+              type: integer`
+
+func TestPaginationMetadata_GetSchema(t *testing.T) {
+	def := PaginationMetadata{}
+	assert.Equal(t, "pagination_metadata", def.GetSchema().Name)
+}
+
+func TestPaginationMetadata_RunRule(t *testing.T) {
+	def := PaginationMetadata{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestPaginationMetadata_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(paginationMetadataTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "pagination_metadata", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := PaginationMetadata{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "`get` operation at path `/toppings` uses `cursor` pagination, but response `200` has no "+
+		"`nextCursor`", res[0].Message)
+	assert.Equal(t, "$.paths./toppings.get.responses.200.content.application/json.schema", res[0].Path)
+	assert.Equal(t, 25, res[0].StartNode.Line)
+	assert.Equal(t, "`get` operation at path `/sauces` uses `offset` pagination, but response `200` has no "+
+		"`total`", res[1].Message)
+}
+
+func TestPaginationMetadata_RunRule_Styles(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(paginationMetadataTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "pagination_metadata", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = map[string]interface{}{
+		"styles": map[string]interface{}{
+			"cursor": map[string]interface{}{
+				"parameters": []interface{}{"cursor"},
+				"properties": []interface{}{"next"},
+			},
+			"page": map[string]interface{}{
+				"parameters": []interface{}{"page"},
+				"properties": []interface{}{"x-total"},
+			},
+		},
+	}
+
+	def := PaginationMetadata{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ParameterBounds checks `integer` path and query parameters (like `limit` or `page`) specify a `minimum` and a
+// `maximum`, so clients can't ask for unbounded values. Identifiers rarely have useful bounds, parameters with names
+// matching the glob patterns in the `ignore` option (`id`, `*Id` and `*_id` by default) are not checked. Referenced
+// parameters and schemas are resolved, parameters are reported once, where they are defined. Swagger parameters
+// (that have no schema) are checked too.
+type ParameterBounds struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ParameterBounds rule.
+func (pb ParameterBounds) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "parameter_bounds",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "ignore",
+				Description: "glob patterns matching the names of parameters that may be unbounded, like 'id' or '*Id'",
+			},
+		},
+		ErrorMessage: "'parameter_bounds' function has invalid options supplied. Example valid options are " +
+			"'ignore' = ['id', '*Id', 'year']",
+	}
+}
+
+// RunRule will execute the ParameterBounds rule, based on supplied context and a supplied []*yaml.Node slice.
+func (pb ParameterBounds) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	ignore := getStringArrayOption("ignore", context.Options, defaultNumericBoundsIgnore)
+
+	forEachParameter(root, func(param *yaml.Node, paramPath string) bool {
+		nameKey, name := utils.FindKeyNodeTop("name", param.Content)
+		_, in := utils.FindKeyNodeTop("in", param.Content)
+		if name == nil || in == nil || (in.Value != "query" && in.Value != "path") {
+			return !context.IsCancelled()
+		}
+		for _, p := range ignore {
+			if ok, _ := path.Match(p, name.Value); ok || p == name.Value {
+				return !context.IsCancelled()
+			}
+		}
+
+		// swagger parameters are their own schema.
+		schema := param
+		if _, s := utils.FindKeyNodeTop("schema", param.Content); s != nil {
+			if schema = resolveSchemaReference(root, s); schema == nil {
+				return !context.IsCancelled()
+			}
+		}
+		isInteger := false
+		for _, t := range schemaTypesOf(schema) {
+			isInteger = isInteger || t == "integer"
+		}
+		if !isInteger {
+			return !context.IsCancelled()
+		}
+
+		var missing []string
+		if !hasBound(schema, "minimum", "exclusiveMinimum") {
+			missing = append(missing, "minimum")
+		}
+		if !hasBound(schema, "maximum", "exclusiveMaximum") {
+			missing = append(missing, "maximum")
+		}
+		if len(missing) > 0 {
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("integer `%s` parameter `%s` has no %s, values are unbounded", in.Value,
+					name.Value, joinNames(missing, "or")),
+				StartNode: nameKey,
+				EndNode:   utils.FindLastChildNodeWithLevel(param, 0),
+				Path:      paramPath,
+				Rule:      context.Rule,
+			})
+		}
+		return !context.IsCancelled()
+	})
+	return results
+}
@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var parameterBoundsTestSpec = `openapi: 3.1.0
+paths:
+  /pizza/{pizzaId}:
+    get:
+      parameters:
+        - name: pizzaId
+          in: path
+          required: true
+          schema:
+            type: integer
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            minimum: 1
+        - name: page
+          in: query
+          schema:
+            $ref: '#/components/schemas/Page'
+        - name: offset
+          in: query
+          schema:
+            type: [integer, "null"]
+            exclusiveMinimum: 0
+            maximum: 1000
+        - name: X-Count
+          in: header
+          schema:
+            type: integer
+        - $ref: '#/components/parameters/Year'
+components:
+  schemas:
+    Page:
+      type: integer
+  parameters:
+    Year:
+      name: year
+      in: query
+      schema:
+        type: integer`
+
+func TestParameterBounds_GetSchema(t *testing.T) {
+	def := ParameterBounds{}
+	assert.Equal(t, "parameter_bounds", def.GetSchema().Name)
+}
+
+func TestParameterBounds_RunRule(t *testing.T) {
+	def := ParameterBounds{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestParameterBounds_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(parameterBoundsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "parameter_bounds", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ParameterBounds{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "integer `query` parameter `year` has no `minimum` or `maximum`, values are unbounded",
+		res[0].Message)
+	assert.Equal(t, "$.components.parameters.Year", res[0].Path)
+	assert.Equal(t, "integer `query` parameter `limit` has no `maximum`, values are unbounded", res[1].Message)
+	assert.Equal(t, "$.paths./pizza/{pizzaId}.get.parameters[1]", res[1].Path)
+	assert.Equal(t, 11, res[1].StartNode.Line)
+	assert.Equal(t, "integer `query` parameter `page` has no `minimum` or `maximum`, values are unbounded",
+		res[2].Message)
+}
+
+func TestParameterBounds_RunRule_Ignore(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(parameterBoundsTestSpec), path)
+
+	opts := map[string]interface{}{"ignore": []interface{}{"year", "page"}}
+	rule := buildOpenApiTestRuleAction(path, "parameter_bounds", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := ParameterBounds{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "integer `path` parameter `pizzaId` has no `minimum` or `maximum`, values are unbounded",
+		res[0].Message)
+	assert.Equal(t, "$.paths./pizza/{pizzaId}.get.parameters[1]", res[1].Path)
+}
+
+func TestParameterBounds_RunRule_Swagger(t *testing.T) {
+
+	yml := `swagger: "2.0"
+paths:
+  /pizza:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          type: integer
+          maximum: 100`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "parameter_bounds", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ParameterBounds{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "integer `query` parameter `limit` has no `minimum`, values are unbounded", res[0].Message)
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ParameterCount checks operations don't have too many parameters, an operation with dozens of parameters is hard to
+// use (and to test). The `path`, `query`, `header` and `cookie` parameters of an operation are counted, including
+// the ones inherited from its path item (an operation parameter with the same name and location replaces an
+// inherited one), references are followed. Operations can have at most `maxParameters` (defaults to 10). Some
+// operations, like search or filter endpoints, legitimately take more, the `overrides` option maps paths (or glob
+// patterns matching paths, like `/search/*`) to their own maximum.
+type ParameterCount struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ParameterCount rule.
+func (pc ParameterCount) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "parameter_count",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "maxParameters",
+				Description: "the maximum number of parameters an operation can have (defaults to 10)",
+			},
+			{
+				Name:        "overrides",
+				Description: "a map of paths (or glob patterns matching paths) to the maximum for their operations",
+			},
+		},
+		ErrorMessage: "'parameter_count' function has invalid options supplied. Example valid options are " +
+			"'maxParameters' = 8 or 'overrides' = {'/pizzas/search': 20}",
+	}
+}
+
+// RunRule will execute the ParameterCount rule, based on supplied context and a supplied []*yaml.Node slice.
+func (pc ParameterCount) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	maxParameters := getIntOption("maxParameters", context.Options, 10)
+	overrides := make(map[string]int)
+	var patterns []string
+	if configured, ok := utils.ExtractValueFromInterfaceMap("overrides", context.Options).(map[string]interface{}); ok {
+		for pattern := range configured {
+			overrides[pattern] = getIntOption(pattern, configured, maxParameters)
+			patterns = append(patterns, pattern)
+		}
+		sort.Strings(patterns)
+	}
+
+	// limit returns the maximum for the operations of a path, an exact match beats a glob pattern.
+	limit := func(opPath string) int {
+		if max, ok := overrides[opPath]; ok {
+			return max
+		}
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, opPath); ok {
+				return overrides[pattern]
+			}
+		}
+		return maxParameters
+	}
+
+	walker.ForEachOperation(root, func(op walker.Operation) bool {
+		params := make(map[string]bool)
+		for _, holder := range []*yaml.Node{op.PathItem, op.Node} {
+			_, list := utils.FindKeyNodeTop("parameters", holder.Content)
+			if list == nil || !utils.IsNodeArray(list) {
+				continue
+			}
+			for _, param := range list.Content {
+				if param = resolveSchemaReference(root, param); param == nil {
+					continue
+				}
+				_, name := utils.FindKeyNodeTop("name", param.Content)
+				_, in := utils.FindKeyNodeTop("in", param.Content)
+				if name == nil || in == nil {
+					continue
+				}
+				switch in.Value {
+				case "path", "query", "header", "cookie":
+					params[in.Value+":"+name.Value] = true
+				}
+			}
+		}
+		if max := limit(op.Path); len(params) > max {
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("`%s` operation at path `%s` has %d parameters, more than the maximum of %d",
+					op.Method, op.Path, len(params), max),
+				StartNode: op.KeyNode,
+				EndNode:   utils.FindLastChildNodeWithLevel(op.Node, 0),
+				Path:      op.JSONPath,
+				Rule:      context.Rule,
+			})
+		}
+		return !context.IsCancelled()
+	})
+	return results
+}
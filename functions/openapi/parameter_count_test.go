@@ -0,0 +1,113 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var parameterCountTestSpec = `openapi: 3.1.0
+paths:
+  /pizzas/{pizzaId}:
+    parameters:
+      - $ref: '#/components/parameters/PizzaId'
+      - name: X-Tenant
+        in: header
+    get:
+      parameters:
+        - $ref: '#/components/parameters/PizzaId'
+        - name: fields
+          in: query
+        - name: session
+          in: cookie
+  /pizzas/search:
+    get:
+      parameters:
+        - name: crust
+          in: query
+        - name: size
+          in: query
+        - name: topping
+          in: query
+        - name: sort
+          in: query
+components:
+  parameters:
+    PizzaId:
+      name: pizzaId
+      in: path
+      required: true`
+
+func TestParameterCount_GetSchema(t *testing.T) {
+	def := ParameterCount{}
+	assert.Equal(t, "parameter_count", def.GetSchema().Name)
+}
+
+func TestParameterCount_RunRule(t *testing.T) {
+	def := ParameterCount{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestParameterCount_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(parameterCountTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["maxParameters"] = 3
+
+	rule := buildOpenApiTestRuleAction(path, "parameter_count", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := ParameterCount{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "`get` operation at path `/pizzas/{pizzaId}` has 4 parameters, more than the maximum of 3",
+		res[0].Message)
+	assert.Equal(t, "$.paths./pizzas/{pizzaId}.get", res[0].Path)
+	assert.Equal(t, 8, res[0].StartNode.Line)
+	assert.Equal(t, "$.paths./pizzas/search.get", res[1].Path)
+}
+
+func TestParameterCount_RunRule_Overrides(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(parameterCountTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["maxParameters"] = 3
+	opts["overrides"] = map[string]interface{}{"/pizzas/search": 10, "/pizzas/*": 1}
+
+	rule := buildOpenApiTestRuleAction(path, "parameter_count", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := ParameterCount{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "`get` operation at path `/pizzas/{pizzaId}` has 4 parameters, more than the maximum of 1",
+		res[0].Message)
+}
+
+func TestParameterCount_RunRule_Default(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(parameterCountTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "parameter_count", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ParameterCount{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
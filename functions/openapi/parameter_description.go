@@ -37,6 +37,9 @@ func (pd ParameterDescription) RunRule(nodes []*yaml.Node, context model.RuleFun
 
 	// look through top level params first.
 	for id, param := range params {
+		if context.IsCancelled() {
+			break
+		}
 		// only check if the param has an 'in' property.
 		_, in := utils.FindKeyNodeTop("in", param.Node.Content)
 		_, desc := utils.FindKeyNodeTop("description", param.Node.Content)
@@ -58,6 +61,9 @@ func (pd ParameterDescription) RunRule(nodes []*yaml.Node, context model.RuleFun
 
 	// look through all parameters from operations.
 	for path, methodMap := range opParams {
+		if context.IsCancelled() {
+			break
+		}
 		for method, paramMap := range methodMap {
 			for pName, opParam := range paramMap {
 				for _, param := range opParam {
@@ -0,0 +1,172 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ParameterNaming checks parameter names follow a consistent casing style, for each parameter location. Each
+// location (`query`, `header`, `path` and `cookie`) can be given its own casing type, locations without one are not
+// checked. Referenced parameters are resolved first, and each parameter is only checked once, no matter how many
+// operations use it. Names in the `allowedNames` option (like `X-Request-ID`) are never reported, header names
+// are matched without case, as they are case-insensitive.
+type ParameterNaming struct {
+}
+
+var parameterLocations = []string{"query", "header", "path", "cookie"}
+
+var parameterCasing = map[string]*regexp.Regexp{
+	"flat":   regexp.MustCompile(`^[a-z][a-z0-9]*$`),
+	"camel":  regexp.MustCompile(`^[a-z][a-z0-9]*(?:[A-Z0-9](?:[a-z0-9]+|$))*$`),
+	"pascal": regexp.MustCompile(`^[A-Z][a-z0-9]*(?:[A-Z0-9](?:[a-z0-9]+|$))*$`),
+	"kebab":  regexp.MustCompile(`^[a-z0-9-]+$`),
+	"cobol":  regexp.MustCompile(`^[A-Z0-9-]+$`),
+	"snake":  regexp.MustCompile(`^[a-z0-9_]+$`),
+	"macro":  regexp.MustCompile(`^[A-Z0-9_]+$`),
+	"train":  regexp.MustCompile(`^[A-Z][a-z0-9]*(?:-[A-Z][a-z0-9]*)*$`),
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ParameterNaming rule.
+func (pn ParameterNaming) GetSchema() model.RuleFunctionSchema {
+	casing := "the casing type for %s parameters, one of 'flat', 'camel', 'pascal', 'kebab', 'cobol', " +
+		"'snake', 'macro' or 'train'"
+	return model.RuleFunctionSchema{
+		Name: "parameter_naming",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "query",
+				Description: fmt.Sprintf(casing, "query"),
+			},
+			{
+				Name:        "header",
+				Description: fmt.Sprintf(casing, "header"),
+			},
+			{
+				Name:        "path",
+				Description: fmt.Sprintf(casing, "path"),
+			},
+			{
+				Name:        "cookie",
+				Description: fmt.Sprintf(casing, "cookie"),
+			},
+			{
+				Name:        "allowedNames",
+				Description: "parameter names that are exempt from checking, like standard headers",
+			},
+		},
+		ErrorMessage: "'parameter_naming' function has invalid options supplied. Example valid options are " +
+			"'query' = 'camel' or 'header' = 'train'",
+	}
+}
+
+// RunRule will execute the ParameterNaming rule, based on supplied context and a supplied []*yaml.Node slice.
+func (pn ParameterNaming) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	if context.Index.GetPathsNode() == nil {
+		return results
+	}
+
+	casings := make(map[string]string)
+	for _, in := range parameterLocations {
+		if c := getStringOption(in, context.Options, ""); parameterCasing[c] != nil {
+			casings[in] = c
+		}
+	}
+	if len(casings) == 0 {
+		return results
+	}
+
+	allowed := make(map[string]bool)
+	for _, name := range getStringArrayOption("allowedNames", context.Options, nil) {
+		allowed[name] = true
+		allowed["header:"+strings.ToLower(name)] = true
+	}
+
+	root := context.Index.GetRootNode()
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	seen := make(map[*yaml.Node]bool)
+	checkParams := func(params *yaml.Node, path string) {
+		if !utils.IsNodeArray(params) {
+			return
+		}
+		for x, param := range params.Content {
+			paramPath := fmt.Sprintf("%s[%d]", path, x)
+
+			// resolve referenced parameters first.
+			if _, ref := utils.FindKeyNodeTop("$ref", param.Content); ref != nil {
+				if root == nil || !strings.HasPrefix(ref.Value, "#") {
+					continue
+				}
+				if param = resolveLocalReference(root, ref.Value); param == nil {
+					continue
+				}
+				_, paramPath = utils.ConvertComponentIdIntoPath(ref.Value)
+			}
+			if !utils.IsNodeMap(param) || seen[param] {
+				continue
+			}
+			seen[param] = true
+
+			_, name := utils.FindKeyNodeTop("name", param.Content)
+			_, in := utils.FindKeyNodeTop("in", param.Content)
+			if name == nil || in == nil || casings[in.Value] == "" {
+				continue
+			}
+			if allowed[name.Value] || (in.Value == "header" && allowed["header:"+strings.ToLower(name.Value)]) {
+				continue
+			}
+			if !parameterCasing[casings[in.Value]].MatchString(name.Value) {
+				results = append(results, model.RuleFunctionResult{
+					Message: fmt.Sprintf("%s parameter `%s` is not %s case", in.Value, name.Value,
+						casings[in.Value]),
+					StartNode: name,
+					EndNode:   name,
+					Path:      fmt.Sprintf("%s.name", paramPath),
+					Rule:      context.Rule,
+				})
+			}
+		}
+	}
+
+	ops := context.Index.GetPathsNode().Content
+	var opPath string
+	for i, op := range ops {
+		if context.IsCancelled() {
+			break
+		}
+		if i%2 == 0 {
+			opPath = op.Value
+			continue
+		}
+		for m := 0; m < len(op.Content)-1; m += 2 {
+			key := op.Content[m].Value
+			if key == "parameters" {
+				checkParams(op.Content[m+1], fmt.Sprintf("$.paths.%s.parameters", opPath))
+				continue
+			}
+			if !isOperationMethod(key) {
+				continue
+			}
+			_, params := utils.FindKeyNodeTop("parameters", op.Content[m+1].Content)
+			checkParams(params, fmt.Sprintf("$.paths.%s.%s.parameters", opPath, key))
+		}
+	}
+	return results
+}
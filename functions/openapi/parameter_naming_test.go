@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var parameterNamingTestSpec = `openapi: 3.1.0
+paths:
+  /pizza/{pizza_id}:
+    parameters:
+      - in: path
+        name: pizza_id
+    get:
+      parameters:
+        - in: query
+          name: crustType
+        - in: query
+          name: topping_count
+        - in: header
+          name: x-request-id
+        - in: header
+          name: X-Request-ID
+        - in: header
+          name: Accept-Language
+        - $ref: '#/components/parameters/PageSize'
+  /pasta:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/PageSize'
+        - in: cookie
+          name: Session_Id
+components:
+  parameters:
+    PageSize:
+      in: query
+      name: page-size`
+
+func TestParameterNaming_GetSchema(t *testing.T) {
+	def := ParameterNaming{}
+	assert.Equal(t, "parameter_naming", def.GetSchema().Name)
+}
+
+func TestParameterNaming_RunRule(t *testing.T) {
+	def := ParameterNaming{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestParameterNaming_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(parameterNamingTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(parameterNamingTestSpec), path)
+
+	opts := map[string]interface{}{
+		"query":        "camel",
+		"path":         "camel",
+		"header":       "train",
+		"allowedNames": []interface{}{"X-Request-ID"},
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "parameter_naming", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := ParameterNaming{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "path parameter `pizza_id` is not camel case", res[0].Message)
+	assert.Equal(t, "$.paths./pizza/{pizza_id}.parameters[0].name", res[0].Path)
+	assert.Equal(t, 6, res[0].StartNode.Line)
+	assert.Equal(t, "query parameter `topping_count` is not camel case", res[1].Message)
+	assert.Equal(t, "$.paths./pizza/{pizza_id}.get.parameters[1].name", res[1].Path)
+
+	// the referenced parameter is only reported once, at its definition.
+	assert.Equal(t, "query parameter `page-size` is not camel case", res[2].Message)
+	assert.Equal(t, "$.components.parameters.PageSize.name", res[2].Path)
+	assert.Equal(t, 30, res[2].StartNode.Line)
+}
+
+func TestParameterNaming_RunRule_Cookie(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(parameterNamingTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(parameterNamingTestSpec), path)
+
+	opts := map[string]string{
+		"cookie": "snake",
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "parameter_naming", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), opts)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := ParameterNaming{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "cookie parameter `Session_Id` is not snake case", res[0].Message)
+}
+
+func TestParameterNaming_RunRule_NoOptions(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(parameterNamingTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(parameterNamingTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "parameter_naming", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := ParameterNaming{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
@@ -0,0 +1,176 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ParameterRedefinition checks that operations overriding a path-level parameter (same `name` and `in`) stay
+// compatible with it. Changing whether the parameter is `required`, or its `type` or `format` is almost certainly
+// a bug. Everything else (like the `description`) can be overridden. Referenced parameters are resolved before they
+// are compared. Both parameters are reported.
+type ParameterRedefinition struct {
+}
+
+// parameterDefinition is a path or operation parameter, resolved and ready to compare.
+type parameterDefinition struct {
+	node     *yaml.Node
+	path     string
+	name     string
+	in       string
+	required string
+	typ      string
+	format   string
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ParameterRedefinition rule.
+func (pr ParameterRedefinition) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "parameter_redefinition",
+	}
+}
+
+// RunRule will execute the ParameterRedefinition rule, based on supplied context and a supplied []*yaml.Node slice.
+func (pr ParameterRedefinition) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	if context.Index.GetPathsNode() == nil {
+		return results
+	}
+
+	root := context.Index.GetRootNode()
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	extractParams := func(params *yaml.Node, path string) []parameterDefinition {
+		var defs []parameterDefinition
+		if !utils.IsNodeArray(params) {
+			return defs
+		}
+		for x, node := range params.Content {
+			param := node
+			if _, ref := utils.FindKeyNodeTop("$ref", param.Content); ref != nil {
+				if root == nil {
+					continue
+				}
+				if param = resolveLocalReference(root, ref.Value); param == nil {
+					continue // unresolvable references are reported by other rules.
+				}
+			}
+			if !utils.IsNodeMap(param) {
+				continue
+			}
+			_, name := utils.FindKeyNodeTop("name", param.Content)
+			_, in := utils.FindKeyNodeTop("in", param.Content)
+			if name == nil || in == nil {
+				continue
+			}
+			def := parameterDefinition{
+				node:     node,
+				path:     fmt.Sprintf("%s[%d]", path, x),
+				name:     name.Value,
+				in:       in.Value,
+				required: "false",
+			}
+			if _, required := utils.FindKeyNodeTop("required", param.Content); required != nil {
+				def.required = required.Value
+			}
+			// swagger parameters define types directly, openapi 3 parameters use a schema.
+			typed := param
+			if _, schema := utils.FindKeyNodeTop("schema", param.Content); schema != nil && root != nil {
+				typed = resolveSchemaReference(root, schema)
+			}
+			if typed != nil {
+				if _, t := utils.FindKeyNodeTop("type", typed.Content); t != nil && t.Kind == yaml.ScalarNode {
+					def.typ = t.Value
+				}
+				if _, f := utils.FindKeyNodeTop("format", typed.Content); f != nil {
+					def.format = f.Value
+				}
+			}
+			defs = append(defs, def)
+		}
+		return defs
+	}
+
+	ops := context.Index.GetPathsNode().Content
+	var opPath string
+	for i, op := range ops {
+		if context.IsCancelled() {
+			break
+		}
+		if i%2 == 0 {
+			opPath = op.Value
+			continue
+		}
+		if !utils.IsNodeMap(op) {
+			continue
+		}
+		_, pathParams := utils.FindKeyNodeTop("parameters", op.Content)
+		shared := extractParams(pathParams, fmt.Sprintf("$.paths.%s.parameters", opPath))
+		if len(shared) == 0 {
+			continue
+		}
+		for m := 0; m < len(op.Content)-1; m += 2 {
+			opMethod := op.Content[m].Value
+			if !isOperationMethod(opMethod) || !utils.IsNodeMap(op.Content[m+1]) {
+				continue
+			}
+			_, opParams := utils.FindKeyNodeTop("parameters", op.Content[m+1].Content)
+			opParamsPath := fmt.Sprintf("$.paths.%s.%s.parameters", opPath, opMethod)
+			for _, override := range extractParams(opParams, opParamsPath) {
+				for _, original := range shared {
+					if original.name != override.name || original.in != override.in {
+						continue
+					}
+					var diffs []string
+					if original.required != override.required {
+						diffs = append(diffs, fmt.Sprintf("`required` (`%s` vs `%s`)", original.required,
+							override.required))
+					}
+					if original.typ != override.typ {
+						diffs = append(diffs, fmt.Sprintf("`type` (`%s` vs `%s`)", original.typ, override.typ))
+					}
+					if original.format != override.format {
+						diffs = append(diffs, fmt.Sprintf("`format` (`%s` vs `%s`)", original.format,
+							override.format))
+					}
+					if len(diffs) == 0 {
+						continue
+					}
+					changes := strings.Join(diffs, ", ")
+					results = append(results, model.RuleFunctionResult{
+						Message: fmt.Sprintf("path-level %s parameter `%s` is redefined by the `%s` operation at "+
+							"path `%s` with a different %s", original.in, original.name, opMethod, opPath, changes),
+						StartNode: original.node,
+						EndNode:   utils.FindLastChildNodeWithLevel(original.node, 0),
+						Path:      original.path,
+						Rule:      context.Rule,
+					}, model.RuleFunctionResult{
+						Message: fmt.Sprintf("%s parameter `%s` of the `%s` operation at path `%s` redefines "+
+							"the path-level parameter with a different %s", override.in, override.name, opMethod,
+							opPath, changes),
+						StartNode: override.node,
+						EndNode:   utils.FindLastChildNodeWithLevel(override.node, 0),
+						Path:      override.path,
+						Rule:      context.Rule,
+					})
+				}
+			}
+		}
+	}
+	return results
+}
@@ -0,0 +1,139 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParameterRedefinition_GetSchema(t *testing.T) {
+	def := ParameterRedefinition{}
+	assert.Equal(t, "parameter_redefinition", def.GetSchema().Name)
+}
+
+func TestParameterRedefinition_RunRule(t *testing.T) {
+	def := ParameterRedefinition{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestParameterRedefinition_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+paths:
+  /pizza/{id}:
+    parameters:
+      - in: path
+        name: id
+        required: true
+        schema:
+          type: string
+      - $ref: '#/components/parameters/Size'
+    get:
+      parameters:
+        - in: path
+          name: id
+          required: true
+          description: a better description is allowed.
+          schema:
+            type: string
+        - in: query
+          name: size
+          schema:
+            type: integer
+    put:
+      parameters:
+        - in: path
+          name: id
+          required: true
+          schema:
+            type: integer
+            format: int64
+        - in: header
+          name: size
+          schema:
+            type: integer
+components:
+  parameters:
+    Size:
+      in: query
+      name: size
+      required: true
+      schema:
+        type: integer`
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "parameter_redefinition", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := ParameterRedefinition{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "path-level query parameter `size` is redefined by the `get` operation at path `/pizza/{id}` "+
+		"with a different `required` (`true` vs `false`)", res[0].Message)
+	assert.Equal(t, "$.paths./pizza/{id}.parameters[1]", res[0].Path)
+	assert.Equal(t, 10, res[0].StartNode.Line)
+	assert.Equal(t, "query parameter `size` of the `get` operation at path `/pizza/{id}` redefines the "+
+		"path-level parameter with a different `required` (`true` vs `false`)", res[1].Message)
+	assert.Equal(t, "$.paths./pizza/{id}.get.parameters[1]", res[1].Path)
+	assert.Equal(t, "path-level path parameter `id` is redefined by the `put` operation at path `/pizza/{id}` "+
+		"with a different `type` (`string` vs `integer`), `format` (`` vs `int64`)", res[2].Message)
+	assert.Equal(t, "$.paths./pizza/{id}.parameters[0]", res[2].Path)
+	assert.Equal(t, "$.paths./pizza/{id}.put.parameters[0]", res[3].Path)
+}
+
+func TestParameterRedefinition_RunRule_Swagger(t *testing.T) {
+
+	yml := `swagger: 2.0
+paths:
+  /pizza/{id}:
+    parameters:
+      - in: path
+        name: id
+        required: true
+        type: string
+    get:
+      parameters:
+        - in: path
+          name: id
+          required: true
+          type: string
+          description: fine.
+    delete:
+      parameters:
+        - in: path
+          name: id
+          required: true
+          type: number`
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "parameter_redefinition", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := ParameterRedefinition{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "$.paths./pizza/{id}.delete.parameters[0]", res[1].Path)
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ParameterStyles checks the `style` of each parameter is defined for its location (`in`), and that the style and
+// `explode` are a combination the specification defines. When `style` is omitted, the default for the location is
+// used, and when `explode` is omitted, the default for the style (`true` for `form`, `false` otherwise) is used. So
+// a `deepObject` parameter must set `explode: true`, and `spaceDelimited` or `pipeDelimited` parameters can't.
+// Referenced parameters are resolved, and reported once, where they are defined.
+type ParameterStyles struct {
+}
+
+// parameterStyles are the styles each parameter location allows, the first is the default.
+var parameterStyles = map[string][]string{
+	"path":   {"simple", "matrix", "label"},
+	"query":  {"form", "spaceDelimited", "pipeDelimited", "deepObject"},
+	"header": {"simple"},
+	"cookie": {"form"},
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ParameterStyles rule.
+func (ps ParameterStyles) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "parameter_styles",
+	}
+}
+
+// RunRule will execute the ParameterStyles rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ps ParameterStyles) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	forEachParameter(root, func(param *yaml.Node, path string) bool {
+		_, nameNode := utils.FindKeyNodeTop("name", param.Content)
+		_, inNode := utils.FindKeyNodeTop("in", param.Content)
+		if nameNode == nil || inNode == nil || parameterStyles[inNode.Value] == nil {
+			return !context.IsCancelled()
+		}
+		name, in := nameNode.Value, inNode.Value
+		allowed := parameterStyles[in]
+
+		styleKey, styleNode := utils.FindKeyNodeTop("style", param.Content)
+		explodeKey, explodeNode := utils.FindKeyNodeTop("explode", param.Content)
+
+		style := allowed[0]
+		if styleNode != nil {
+			style = styleNode.Value
+			valid := false
+			for _, s := range allowed {
+				valid = valid || s == style
+			}
+			if !valid {
+				results = append(results, model.RuleFunctionResult{
+					Message: fmt.Sprintf("`%s` parameter `%s` has style `%s`, `%s` parameters may use %s", in, name,
+						style, in, joinNames(allowed, "or")),
+					StartNode: styleKey,
+					EndNode:   styleNode,
+					Path:      path,
+					Rule:      context.Rule,
+				})
+				return !context.IsCancelled()
+			}
+		}
+
+		explode := style == "form"
+		if explodeNode != nil {
+			explode = explodeNode.Value == "true"
+		}
+		var msg string
+		switch {
+		case style == "deepObject" && !explode:
+			msg = fmt.Sprintf("`%s` parameter `%s` has style `deepObject`, which is only defined with "+
+				"`explode: true`", in, name)
+		case (style == "spaceDelimited" || style == "pipeDelimited") && explode:
+			msg = fmt.Sprintf("`%s` parameter `%s` has style `%s`, which is only defined with `explode: false`",
+				in, name, style)
+		default:
+			return !context.IsCancelled()
+		}
+		if explodeNode == nil {
+			msg += ", `explode` defaults to `false`"
+		}
+		start, end := styleKey, styleNode
+		if explodeNode != nil {
+			start, end = explodeKey, explodeNode
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message:   msg,
+			StartNode: start,
+			EndNode:   end,
+			Path:      path,
+			Rule:      context.Rule,
+		})
+		return !context.IsCancelled()
+	})
+	return results
+}
@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParameterStyles_GetSchema(t *testing.T) {
+	def := ParameterStyles{}
+	assert.Equal(t, "parameter_styles", def.GetSchema().Name)
+}
+
+func TestParameterStyles_RunRule(t *testing.T) {
+	def := ParameterStyles{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestParameterStyles_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+paths:
+  /pizza/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        style: form
+    get:
+      parameters:
+        - name: filter
+          in: query
+          style: deepObject
+        - name: toppings
+          in: query
+          style: pipeDelimited
+          explode: true
+        - $ref: '#/components/parameters/Trace'
+components:
+  parameters:
+    Trace:
+      name: X-Trace
+      in: header
+      style: label`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "parameter_styles", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ParameterStyles{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "`header` parameter `X-Trace` has style `label`, `header` parameters may use `simple`",
+		res[0].Message)
+	assert.Equal(t, "$.components.parameters.Trace", res[0].Path)
+	assert.Equal(t, "`path` parameter `id` has style `form`, `path` parameters may use `simple`, `matrix` or "+
+		"`label`", res[1].Message)
+	assert.Equal(t, "$.paths./pizza/{id}.parameters[0]", res[1].Path)
+	assert.Equal(t, "`query` parameter `filter` has style `deepObject`, which is only defined with `explode: true`, "+
+		"`explode` defaults to `false`", res[2].Message)
+	assert.Equal(t, 13, res[2].StartNode.Line)
+	assert.Equal(t, "`query` parameter `toppings` has style `pipeDelimited`, which is only defined with "+
+		"`explode: false`", res[3].Message)
+	assert.Equal(t, "$.paths./pizza/{id}.get.parameters[1]", res[3].Path)
+	assert.Equal(t, 17, res[3].StartNode.Line)
+}
+
+func TestParameterStyles_RunRule_Success(t *testing.T) {
+
+	yml := `openapi: 3.0.3
+paths:
+  /pizza/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          style: matrix
+          explode: true
+        - name: filter
+          in: query
+          style: deepObject
+          explode: true
+        - name: toppings
+          in: query
+          style: spaceDelimited
+        - name: sort
+          in: query
+          explode: false
+        - name: X-Trace
+          in: header
+          explode: true
+        - name: session
+          in: cookie`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "parameter_styles", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ParameterStyles{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
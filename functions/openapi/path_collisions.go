@@ -0,0 +1,128 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"gopkg.in/yaml.v3"
+)
+
+// PathCollisions normalizes path templates, by replacing every parameter with a placeholder and removing trailing
+// slashes, then flags any templates that collide. `/users/{id}` and `/users/{userId}/` are duplicates for example.
+// When `checkShadowing` is enabled (the default), templates where a literal segment in one path is matched by a
+// parameter in another (`/users/{id}` and `/users/me`) are also flagged, as they depend on router ordering.
+type PathCollisions struct {
+}
+
+var pathParamPlaceholder = regexp.MustCompile(`{[^}]*}`)
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the PathCollisions rule.
+func (pc PathCollisions) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "path_collisions",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "checkShadowing",
+				Description: "flag literal segments that are shadowed by a parameter in another path (defaults to true)",
+			},
+		},
+		ErrorMessage: "'path_collisions' function has invalid options supplied.",
+	}
+}
+
+// RunRule will execute the PathCollisions rule, based on supplied context and a supplied []*yaml.Node slice.
+func (pc PathCollisions) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	if context.Index.GetPathsNode() == nil {
+		return results
+	}
+
+	checkShadowing := getBoolOption("checkShadowing", context.Options, true)
+
+	type seenPath struct {
+		path       string
+		normalized string
+		node       *yaml.Node
+	}
+	var seen []seenPath
+
+	addResults := func(a, b seenPath, reason string) {
+		results = append(results,
+			model.RuleFunctionResult{
+				Message: fmt.Sprintf("path `%s` %s path `%s` (line %d)", a.path, reason, b.path,
+					b.node.Line),
+				StartNode: a.node,
+				EndNode:   a.node,
+				Path:      fmt.Sprintf("$.paths.%s", a.path),
+				Rule:      context.Rule,
+			},
+			model.RuleFunctionResult{
+				Message: fmt.Sprintf("path `%s` %s path `%s` (line %d)", b.path, reason, a.path,
+					a.node.Line),
+				StartNode: b.node,
+				EndNode:   b.node,
+				Path:      fmt.Sprintf("$.paths.%s", b.path),
+				Rule:      context.Rule,
+			})
+	}
+
+	ops := context.Index.GetPathsNode().Content
+	for i := 0; i < len(ops); i += 2 {
+		if context.IsCancelled() {
+			return results
+		}
+		current := seenPath{
+			path:       ops[i].Value,
+			normalized: normalizePathTemplate(ops[i].Value),
+			node:       ops[i],
+		}
+		for _, s := range seen {
+			if s.normalized == current.normalized {
+				addResults(s, current, "collides with")
+				continue
+			}
+			if checkShadowing && pathShadows(s.normalized, current.normalized) {
+				addResults(s, current, "is ambiguous with")
+			}
+		}
+		seen = append(seen, current)
+	}
+	return results
+}
+
+// normalizePathTemplate replaces all path parameters with an empty placeholder and strips any trailing slash.
+func normalizePathTemplate(path string) string {
+	normalized := pathParamPlaceholder.ReplaceAllString(path, "{}")
+	if len(normalized) > 1 {
+		normalized = strings.TrimRight(normalized, "/")
+	}
+	return normalized
+}
+
+// pathShadows returns true if two normalized paths have the same number of segments, and every segment either
+// matches, or one of them is a placeholder.
+func pathShadows(a, b string) bool {
+	segsA := strings.Split(a, "/")
+	segsB := strings.Split(b, "/")
+	if len(segsA) != len(segsB) {
+		return false
+	}
+	for i := range segsA {
+		if segsA[i] == segsB[i] || segsA[i] == "{}" || segsB[i] == "{}" {
+			continue
+		}
+		return false
+	}
+	return true
+}
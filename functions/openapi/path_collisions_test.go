@@ -0,0 +1,119 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var pathCollisionsTestSpec = `openapi: 3.0.1
+paths:
+  /users/{id}:
+    get:
+      summary: get a user
+  /users/{userId}:
+    get:
+      summary: get a user again
+  /users/me:
+    get:
+      summary: get me
+  /pizza:
+    get:
+      summary: list pizza
+  /pizza/:
+    get:
+      summary: list pizza with a slash
+  /pizza/{id}/toppings:
+    get:
+      summary: list toppings`
+
+func TestPathCollisions_GetSchema(t *testing.T) {
+	def := PathCollisions{}
+	assert.Equal(t, "path_collisions", def.GetSchema().Name)
+}
+
+func TestPathCollisions_RunRule(t *testing.T) {
+	def := PathCollisions{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestPathCollisions_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(pathCollisionsTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(pathCollisionsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "path_collisions", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := PathCollisions{}
+	res := def.RunRule(nodes, ctx)
+
+	// two collisions and two ambiguous paths, each reported at both locations.
+	assert.Len(t, res, 8)
+	assert.Equal(t, "path `/users/{id}` collides with path `/users/{userId}` (line 6)", res[0].Message)
+	assert.Equal(t, "$.paths./users/{id}", res[0].Path)
+	assert.Equal(t, "path `/users/{userId}` collides with path `/users/{id}` (line 3)", res[1].Message)
+	assert.Equal(t, "$.paths./users/{userId}", res[1].Path)
+	assert.Equal(t, "path `/users/{id}` is ambiguous with path `/users/me` (line 9)", res[2].Message)
+	assert.Equal(t, "path `/pizza` collides with path `/pizza/` (line 15)", res[6].Message)
+}
+
+func TestPathCollisions_RunRule_NoShadowing(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(pathCollisionsTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(pathCollisionsTestSpec), path)
+
+	opts := map[string]interface{}{
+		"checkShadowing": false,
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "path_collisions", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := PathCollisions{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+}
+
+func TestPathCollisions_RunRule_Cancelled(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(pathCollisionsTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(pathCollisionsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "path_collisions", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx.Context = cancelled
+
+	def := PathCollisions{}
+	res := def.RunRule(nodes, ctx)
+	assert.Len(t, res, 0)
+}
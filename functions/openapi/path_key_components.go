@@ -0,0 +1,86 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// PathKeyComponents checks path keys are only a path, and don't include a query string (`?`), a fragment (`#`),
+// or the scheme and host of a server (`https://api.example.com/pizza`). Percent-encoded characters (like `%3F`)
+// are legal in paths, and are not checked.
+type PathKeyComponents struct {
+}
+
+// schemeAndHost matches the scheme and host at the start of a URL, or the host of a scheme-relative URL.
+var schemeAndHost = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9+.-]*:)?//[^/?#]*`)
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the PathKeyComponents rule.
+func (pk PathKeyComponents) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "path_key_components",
+	}
+}
+
+// RunRule will execute the PathKeyComponents rule, based on supplied context and a supplied []*yaml.Node slice.
+func (pk PathKeyComponents) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	_, paths := utils.FindKeyNodeTop("paths", root.Content)
+	if !utils.IsNodeMap(paths) {
+		return nil
+	}
+
+	for i := 0; i < len(paths.Content)-1; i += 2 {
+		if context.IsCancelled() {
+			break
+		}
+		pathKey := paths.Content[i]
+		opPath := pathKey.Value
+
+		var messages []string
+		rest := opPath
+		if host := schemeAndHost.FindString(rest); host != "" {
+			messages = append(messages, fmt.Sprintf("path `%s` includes the server `%s`, servers belong in `servers` "+
+				"(or `host` and `basePath`), paths start with `/`", opPath, host))
+			rest = rest[len(host):]
+		}
+		rest, fragment, hasFragment := strings.Cut(rest, "#")
+		if _, query, hasQuery := strings.Cut(rest, "?"); hasQuery {
+			messages = append(messages, fmt.Sprintf("path `%s` includes the query string `?%s`, use query "+
+				"parameters instead", opPath, query))
+		}
+		if hasFragment {
+			messages = append(messages, fmt.Sprintf("path `%s` includes the fragment `#%s`, fragments are never "+
+				"sent to the server", opPath, fragment))
+		}
+
+		for _, msg := range messages {
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: pathKey,
+				EndNode:   pathKey,
+				Path:      fmt.Sprintf("$.paths.%s", opPath),
+				Rule:      context.Rule,
+			})
+		}
+	}
+	return results
+}
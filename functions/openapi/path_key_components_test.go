@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathKeyComponents_GetSchema(t *testing.T) {
+	def := PathKeyComponents{}
+	assert.Equal(t, "path_key_components", def.GetSchema().Name)
+}
+
+func TestPathKeyComponents_RunRule(t *testing.T) {
+	def := PathKeyComponents{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestPathKeyComponents_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+paths:
+  /pizza/{name}:
+    get: {}
+  /pizza/half%3Fhalf:
+    get: {}
+  /pizza?size=large:
+    get: {}
+  /pizza#toppings:
+    get: {}
+  https://api.example.com/pizza?size=large#toppings:
+    get: {}
+  //api.example.com/pizza:
+    get: {}`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "path_key_components", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := PathKeyComponents{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 6)
+	assert.Equal(t, "path `/pizza?size=large` includes the query string `?size=large`, use query parameters instead",
+		res[0].Message)
+	assert.Equal(t, "$.paths./pizza?size=large", res[0].Path)
+	assert.Equal(t, 7, res[0].StartNode.Line)
+	assert.Equal(t, "path `/pizza#toppings` includes the fragment `#toppings`, fragments are never sent to the server",
+		res[1].Message)
+	assert.Equal(t, "path `https://api.example.com/pizza?size=large#toppings` includes the server "+
+		"`https://api.example.com`, servers belong in `servers` (or `host` and `basePath`), paths start with `/`",
+		res[2].Message)
+	assert.Equal(t, "path `https://api.example.com/pizza?size=large#toppings` includes the query string "+
+		"`?size=large`, use query parameters instead", res[3].Message)
+	assert.Equal(t, "path `https://api.example.com/pizza?size=large#toppings` includes the fragment `#toppings`, "+
+		"fragments are never sent to the server", res[4].Message)
+	assert.Equal(t, "path `//api.example.com/pizza` includes the server `//api.example.com`, servers belong in "+
+		"`servers` (or `host` and `basePath`), paths start with `/`", res[5].Message)
+}
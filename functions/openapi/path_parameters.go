@@ -60,6 +60,9 @@ func (pp PathParameters) RunRule(nodes []*yaml.Node, context model.RuleFunctionC
 		return results
 	}
 	for j, pathNode := range pathNodes.Content {
+		if context.IsCancelled() {
+			break
+		}
 
 		if utils.IsNodeStringValue(pathNode) {
 			// replace any params with an invalid char (%) so we can perform a path
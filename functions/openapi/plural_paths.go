@@ -0,0 +1,191 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// PluralPaths checks path segments naming collections are plural (`/pizzas/{id}`, not `/pizza/{id}`). A segment
+// names a collection when it is followed by a path parameter, or when it ends a path, and another path adds a
+// parameter to it (like `/pizzas` and `/pizzas/{id}`). The last word of the segment is checked, `/order-items` is
+// plural. Words ending in `s` (but not `ss`, `us` or `is`) are plural, as are irregular plurals (like `people`),
+// which can be added to with the `irregular` option (a map of singular to plural). Uncountable nouns (like `info`
+// or `status`) are neither, they are allowed by the `uncountable` option. Each collection is reported once, at the
+// first path using it.
+type PluralPaths struct {
+}
+
+var defaultUncountableNouns = []string{"info", "information", "status", "health", "metadata", "data", "media",
+	"news", "series", "species", "equipment", "feedback", "search", "auth", "me", "config", "configuration",
+	"software", "hardware", "firmware", "staff", "inventory", "weather", "traffic", "billing"}
+
+var defaultIrregularPlurals = map[string]string{
+	"person": "people", "child": "children", "man": "men", "woman": "women", "mouse": "mice", "goose": "geese",
+	"foot": "feet", "tooth": "teeth", "criterion": "criteria", "phenomenon": "phenomena", "datum": "data",
+}
+
+var pathVersionSegment = regexp.MustCompile(`^[vV]\d+([._]\d+)*$`)
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the PluralPaths rule.
+func (pp PluralPaths) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "plural_paths",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "uncountable",
+				Description: "nouns that are neither singular nor plural, like 'info' or 'status'",
+			},
+			{
+				Name:        "irregular",
+				Description: "a map of singular nouns to their irregular plurals, like 'person' = 'people'",
+			},
+		},
+		ErrorMessage: "'plural_paths' function has invalid options supplied. Example valid options are " +
+			"'uncountable' = ['info', 'status'] or 'irregular' = {'person': 'people'}",
+	}
+}
+
+// lastWord returns the index the last word of a path segment starts at, words are separated by `-`, `_`, `.` or
+// a change to upper case.
+func lastWord(segment string) int {
+	runes := []rune(segment)
+	for i := len(runes) - 1; i > 0; i-- {
+		if runes[i-1] == '-' || runes[i-1] == '_' || runes[i-1] == '.' {
+			return len(string(runes[:i]))
+		}
+		if unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i-1]) {
+			return len(string(runes[:i]))
+		}
+	}
+	return 0
+}
+
+// RunRule will execute the PluralPaths rule, based on supplied context and a supplied []*yaml.Node slice.
+func (pp PluralPaths) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	_, paths := utils.FindKeyNodeTop("paths", root.Content)
+	if paths == nil || !utils.IsNodeMap(paths) {
+		return nil
+	}
+
+	uncountable := make(map[string]bool)
+	for _, u := range getStringArrayOption("uncountable", context.Options, defaultUncountableNouns) {
+		uncountable[strings.ToLower(u)] = true
+	}
+	irregular := make(map[string]string)
+	plurals := make(map[string]bool)
+	for singular, plural := range defaultIrregularPlurals {
+		irregular[singular] = plural
+	}
+	if configured, ok := utils.ExtractValueFromInterfaceMap("irregular", context.Options).(map[string]interface{}); ok {
+		for singular, plural := range configured {
+			if p, isString := plural.(string); isString {
+				irregular[strings.ToLower(singular)] = strings.ToLower(p)
+			}
+		}
+	}
+	for _, plural := range irregular {
+		plurals[plural] = true
+	}
+
+	// pluralize returns the plural of a word, or an empty string if the word is plural (or uncountable) already.
+	pluralize := func(word string) string {
+		lower := strings.ToLower(word)
+		if uncountable[lower] || plurals[lower] {
+			return ""
+		}
+		if p, ok := irregular[lower]; ok {
+			if unicode.IsUpper([]rune(word)[0]) {
+				return strings.ToUpper(p[:1]) + p[1:]
+			}
+			return p
+		}
+		switch {
+		case strings.HasSuffix(lower, "s") && !strings.HasSuffix(lower, "ss") && !strings.HasSuffix(lower, "us") &&
+			!strings.HasSuffix(lower, "is"):
+			return ""
+		case len(lower) > 1 && strings.HasSuffix(lower, "y") &&
+			!strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+			return word[:len(word)-1] + "ies"
+		case strings.HasSuffix(lower, "s") || strings.HasSuffix(lower, "x") || strings.HasSuffix(lower, "z") ||
+			strings.HasSuffix(lower, "ch") || strings.HasSuffix(lower, "sh"):
+			return word + "es"
+		}
+		return word + "s"
+	}
+
+	isParameter := func(segment string) bool {
+		return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+	}
+	isWord := func(segment string) bool {
+		if segment == "" || strings.ContainsAny(segment, "{}") || pathVersionSegment.MatchString(segment) {
+			return false
+		}
+		return strings.IndexFunc(segment, unicode.IsLetter) >= 0
+	}
+
+	// collections that end a path, are the paths other paths add a parameter to.
+	extended := make(map[string]bool)
+	for i := 0; i < len(paths.Content)-1; i += 2 {
+		segments := strings.Split(strings.TrimSuffix(paths.Content[i].Value, "/"), "/")
+		for s := 1; s < len(segments); s++ {
+			if isParameter(segments[s]) {
+				extended[strings.Join(segments[:s], "/")] = true
+			}
+		}
+	}
+
+	reported := make(map[string]bool)
+	for i := 0; i < len(paths.Content)-1; i += 2 {
+		if context.IsCancelled() {
+			break
+		}
+		pathKey := paths.Content[i]
+		segments := strings.Split(strings.TrimSuffix(pathKey.Value, "/"), "/")
+		for s := 1; s < len(segments); s++ {
+			segment := segments[s]
+			collection := strings.Join(segments[:s+1], "/")
+			if !isWord(segment) || reported[collection] {
+				continue
+			}
+			if !extended[collection] && (s+1 >= len(segments) || !isParameter(segments[s+1])) {
+				continue
+			}
+			start := lastWord(segment)
+			plural := pluralize(segment[start:])
+			if plural == "" {
+				continue
+			}
+			reported[collection] = true
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("path `%s` names the collection `%s` with a singular noun, use `%s`",
+					pathKey.Value, segment, segment[:start]+plural),
+				StartNode: pathKey,
+				EndNode:   pathKey,
+				Path:      fmt.Sprintf("$.paths.%s", pathKey.Value),
+				Rule:      context.Rule,
+			})
+		}
+	}
+	return results
+}
@@ -0,0 +1,93 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var pluralPathsTestSpec = `openapi: 3.1.0
+paths:
+  /v1/pizza/{pizzaId}:
+    get: {}
+  /v1/pizza/{pizzaId}/topping:
+    get: {}
+  /v1/pizza/{pizzaId}/topping/{toppingId}:
+    get: {}
+  /v1/orders/{orderId}/orderItem/{itemId}:
+    get: {}
+  /v1/person/{personId}:
+    get: {}
+  /v1/category/{categoryId}/boxes/{boxId}:
+    get: {}
+  /v1/status/{statusId}:
+    get: {}
+  /v1/pizzas/{pizzaId}/bake:
+    post: {}
+  /v1/menu:
+    get: {}`
+
+func TestPluralPaths_GetSchema(t *testing.T) {
+	def := PluralPaths{}
+	assert.Equal(t, "plural_paths", def.GetSchema().Name)
+}
+
+func TestPluralPaths_RunRule(t *testing.T) {
+	def := PluralPaths{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestPluralPaths_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(pluralPathsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "plural_paths", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := PluralPaths{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 5)
+	assert.Equal(t, "path `/v1/pizza/{pizzaId}` names the collection `pizza` with a singular noun, use `pizzas`",
+		res[0].Message)
+	assert.Equal(t, "$.paths./v1/pizza/{pizzaId}", res[0].Path)
+	assert.Equal(t, 3, res[0].StartNode.Line)
+	assert.Equal(t, "path `/v1/pizza/{pizzaId}/topping` names the collection `topping` with a singular noun, use "+
+		"`toppings`", res[1].Message)
+	assert.Equal(t, "path `/v1/orders/{orderId}/orderItem/{itemId}` names the collection `orderItem` with a "+
+		"singular noun, use `orderItems`", res[2].Message)
+	assert.Equal(t, "path `/v1/person/{personId}` names the collection `person` with a singular noun, use "+
+		"`people`", res[3].Message)
+	assert.Equal(t, "path `/v1/category/{categoryId}/boxes/{boxId}` names the collection `category` with a "+
+		"singular noun, use `categories`", res[4].Message)
+}
+
+func TestPluralPaths_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(pluralPathsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "plural_paths", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = map[string]interface{}{
+		"uncountable": []interface{}{"pizza", "topping", "status"},
+		"irregular": map[string]interface{}{
+			"item":   "item",
+			"person": "persons",
+		},
+	}
+
+	def := PluralPaths{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "path `/v1/person/{personId}` names the collection `person` with a singular noun, use "+
+		"`persons`", res[0].Message)
+	assert.Equal(t, "$.paths./v1/category/{categoryId}/boxes/{boxId}", res[1].Path)
+}
@@ -33,6 +33,9 @@ func (pm PolymorphicAnyOf) RunRule(nodes []*yaml.Node, context model.RuleFunctio
 	refs := context.Index.GetPolyAnyOfReferences()
 
 	for _, ref := range refs {
+		if context.IsCancelled() {
+			break
+		}
 		results = append(results, model.RuleFunctionResult{
 			Message:   fmt.Sprintf("`anyOf` polymorphic reference: %s", context.Rule.Description),
 			StartNode: ref.Node,
@@ -30,6 +30,9 @@ func (pm PolymorphicOneOf) RunRule(nodes []*yaml.Node, context model.RuleFunctio
 	refs := context.Index.GetPolyOneOfReferences()
 
 	for _, ref := range refs {
+		if context.IsCancelled() {
+			break
+		}
 		results = append(results, model.RuleFunctionResult{
 			Message:   fmt.Sprintf("`oneOf` polymorphic reference: %s", context.Rule.Description),
 			StartNode: ref.Node,
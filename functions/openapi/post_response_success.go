@@ -33,6 +33,9 @@ func (prs PostResponseSuccess) RunRule(nodes []*yaml.Node, context model.RuleFun
 	var results []model.RuleFunctionResult
 
 	for j, node := range nodes {
+		if context.IsCancelled() {
+			break
+		}
 
 		var startNode, endNode *yaml.Node
 
@@ -0,0 +1,164 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// PropertyIdentifiers checks schema property names are valid identifiers in the language code is generated for,
+// so generators don't have to mangle them. Names must start with a letter or an underscore, and only contain
+// letters, digits and underscores (`$` is allowed for `typescript`). The `language` option selects a profile, the
+// `generic` profile (the default) has no reserved words, the others (`go`, `java`, `typescript`, `python` and
+// `csharp`) reject the reserved words of the language. Intentionally unusual names (like `@odata.id`) can be
+// allowed with the `allowed` option, which accepts names or glob patterns (like `@odata.*`).
+type PropertyIdentifiers struct {
+}
+
+// identifierProfile is how a language defines identifiers.
+type identifierProfile struct {
+	pattern  *regexp.Regexp
+	reserved []string
+}
+
+var plainIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var identifierProfiles = map[string]identifierProfile{
+	"generic": {pattern: plainIdentifier},
+	"go": {pattern: plainIdentifier, reserved: []string{"break", "case", "chan", "const", "continue", "default",
+		"defer", "else", "fallthrough", "for", "func", "go", "goto", "if", "import", "interface", "map", "package",
+		"range", "return", "select", "struct", "switch", "type", "var"}},
+	"java": {pattern: plainIdentifier, reserved: []string{"abstract", "assert", "boolean", "break", "byte", "case",
+		"catch", "char", "class", "const", "continue", "default", "do", "double", "else", "enum", "extends", "false",
+		"final", "finally", "float", "for", "goto", "if", "implements", "import", "instanceof", "int", "interface",
+		"long", "native", "new", "null", "package", "private", "protected", "public", "return", "short", "static",
+		"strictfp", "super", "switch", "synchronized", "this", "throw", "throws", "transient", "true", "try", "void",
+		"volatile", "while"}},
+	// reserved words work as property names in typescript, they only need to be valid identifiers.
+	"typescript": {pattern: regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)},
+	"python": {pattern: plainIdentifier, reserved: []string{"False", "None", "True", "and", "as", "assert", "async",
+		"await", "break", "class", "continue", "def", "del", "elif", "else", "except", "finally", "for", "from",
+		"global", "if", "import", "in", "is", "lambda", "nonlocal", "not", "or", "pass", "raise", "return", "try",
+		"while", "with", "yield"}},
+	"csharp": {pattern: plainIdentifier, reserved: []string{"abstract", "as", "base", "bool", "break", "byte", "case",
+		"catch", "char", "checked", "class", "const", "continue", "decimal", "default", "delegate", "do", "double",
+		"else", "enum", "event", "explicit", "extern", "false", "finally", "fixed", "float", "for", "foreach", "goto",
+		"if", "implicit", "in", "int", "interface", "internal", "is", "lock", "long", "namespace", "new", "null",
+		"object", "operator", "out", "override", "params", "private", "protected", "public", "readonly", "ref",
+		"return", "sbyte", "sealed", "short", "sizeof", "stackalloc", "static", "string", "struct", "switch", "this",
+		"throw", "true", "try", "typeof", "uint", "ulong", "unchecked", "unsafe", "ushort", "using", "virtual", "void",
+		"volatile", "while"}},
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the PropertyIdentifiers rule.
+func (pi PropertyIdentifiers) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "property_identifiers",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name: "language",
+				Description: "the language profile, one of 'generic', 'go', 'java', 'typescript', 'python' or " +
+					"'csharp' (defaults to 'generic')",
+			},
+			{
+				Name:        "allowed",
+				Description: "property names (or glob patterns) that are allowed, even if they are not identifiers",
+			},
+		},
+		ErrorMessage: "'property_identifiers' function has invalid options supplied. Example valid options are " +
+			"'language' = 'java' or 'allowed' = ['@odata.*']",
+	}
+}
+
+// RunRule will execute the PropertyIdentifiers rule, based on supplied context and a supplied []*yaml.Node slice.
+func (pi PropertyIdentifiers) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	language := strings.ToLower(getStringOption("language", context.Options, "generic"))
+	profile, ok := identifierProfiles[language]
+	if !ok {
+		language, profile = "generic", identifierProfiles["generic"]
+	}
+	reserved := make(map[string]bool)
+	for _, word := range profile.reserved {
+		reserved[word] = true
+	}
+	allowed := getStringArrayOption("allowed", context.Options, nil)
+
+	checkProperties := func(properties *yaml.Node, propertiesPath string) {
+		for i := 0; i < len(properties.Content)-1; i += 2 {
+			name := properties.Content[i]
+			isAllowed := false
+			for _, a := range allowed {
+				if match, _ := path.Match(a, name.Value); match || a == name.Value {
+					isAllowed = true
+					break
+				}
+			}
+			if isAllowed {
+				continue
+			}
+			var msg string
+			switch {
+			case !profile.pattern.MatchString(name.Value):
+				msg = fmt.Sprintf("property `%s` is not a valid identifier", name.Value)
+			case reserved[name.Value]:
+				msg = fmt.Sprintf("property `%s` is a reserved word in %s", name.Value, language)
+			default:
+				continue
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: name,
+				EndNode:   utils.FindLastChildNodeWithLevel(properties.Content[i+1], 0),
+				Path:      fmt.Sprintf("%s.%s", propertiesPath, name.Value),
+				Rule:      context.Rule,
+			})
+		}
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				if key.Value == "example" || key.Value == "examples" {
+					continue
+				}
+				if key.Value == "properties" && utils.IsNodeMap(value) {
+					checkProperties(value, childPath)
+				}
+				walk(value, childPath)
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
@@ -0,0 +1,110 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var propertyIdentifiersTestSpec = `openapi: 3.1.0
+components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        name:
+          type: string
+        first name:
+          type: string
+        2ndTopping:
+          type: string
+        class:
+          type: string
+        $price:
+          type: number
+        "@odata.id":
+          type: string
+        base:
+          type: object
+          properties:
+            thin-crust:
+              type: boolean`
+
+func TestPropertyIdentifiers_GetSchema(t *testing.T) {
+	def := PropertyIdentifiers{}
+	assert.Equal(t, "property_identifiers", def.GetSchema().Name)
+}
+
+func TestPropertyIdentifiers_RunRule(t *testing.T) {
+	def := PropertyIdentifiers{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestPropertyIdentifiers_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(propertyIdentifiersTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "property_identifiers", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := PropertyIdentifiers{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 5)
+	assert.Equal(t, "property `first name` is not a valid identifier", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.first name", res[0].Path)
+	assert.Equal(t, 9, res[0].StartNode.Line)
+	assert.Equal(t, "property `2ndTopping` is not a valid identifier", res[1].Message)
+	assert.Equal(t, "property `$price` is not a valid identifier", res[2].Message)
+	assert.Equal(t, "property `@odata.id` is not a valid identifier", res[3].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.base.properties.thin-crust", res[4].Path)
+}
+
+func TestPropertyIdentifiers_RunRule_Language(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(propertyIdentifiersTestSpec), path)
+
+	opts := map[string]interface{}{
+		"language": "java",
+		"allowed":  []interface{}{"@odata.*", "first name", "thin-crust"},
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "property_identifiers", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := PropertyIdentifiers{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "property `2ndTopping` is not a valid identifier", res[0].Message)
+	assert.Equal(t, "property `class` is a reserved word in java", res[1].Message)
+	assert.Equal(t, "property `$price` is not a valid identifier", res[2].Message)
+}
+
+func TestPropertyIdentifiers_RunRule_TypeScript(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(propertyIdentifiersTestSpec), path)
+
+	opts := map[string]interface{}{
+		"language": "typescript",
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "property_identifiers", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := PropertyIdentifiers{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4) // `$price` and `class` are fine in typescript.
+}
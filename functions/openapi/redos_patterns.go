@@ -0,0 +1,204 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// RedosPatterns checks `pattern` regular expressions (and the keys of `patternProperties`) for nested quantifiers,
+// like `(a+)+` or `(\w*,?)*`. vacuum (and Go) use RE2, which is safe, but many consumers validate with backtracking
+// engines (like PCRE, or JavaScript), where a nested quantifier can backtrack catastrophically on input that almost
+// matches, a denial of service. Detection is a heuristic, a group is reported when it repeats without a bound (`*`,
+// `+` or `{n,}`) and contains something that repeats as well (a quantifier with a maximum above one). It doesn't look
+// at what is repeated, so `(\d+-)+` is reported even though it can't backtrack much, and `(a|a)*` is not, even though
+// it can. Atomic groups (`(?>...)`) and possessive quantifiers (`a++`) don't backtrack, they are not reported.
+type RedosPatterns struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the RedosPatterns rule.
+func (rp RedosPatterns) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "redos_patterns",
+	}
+}
+
+// nestedQuantifier returns the first group of a regular expression that repeats without a bound and contains a
+// repetition, or an empty string if there isn't one.
+func nestedQuantifier(pattern string) string {
+	type group struct {
+		start   int
+		atomic  bool
+		repeats bool
+	}
+	var stack []*group
+	current := &group{}
+
+	// quantifier reads the quantifier at a position, returning its end, whether it repeats (its maximum is above one)
+	// and whether its maximum is unbounded. Possessive quantifiers never backtrack, they don't repeat.
+	quantifier := func(i int) (end int, repeats, unbounded bool) {
+		if i >= len(pattern) {
+			return i, false, false
+		}
+		switch pattern[i] {
+		case '*', '+':
+			repeats, unbounded, end = true, true, i+1
+		case '?':
+			end = i + 1
+		case '{':
+			closing := strings.IndexByte(pattern[i:], '}')
+			if closing < 0 {
+				return i, false, false
+			}
+			min, max, ranged := strings.Cut(pattern[i+1:i+closing], ",")
+			if _, err := strconv.Atoi(min); err != nil {
+				return i, false, false
+			}
+			end = i + closing + 1
+			switch {
+			case !ranged:
+				n, _ := strconv.Atoi(min)
+				repeats = n > 1
+			case max == "":
+				repeats, unbounded = true, true
+			default:
+				n, err := strconv.Atoi(max)
+				if err != nil {
+					return i, false, false
+				}
+				repeats = n > 1
+			}
+		default:
+			return i, false, false
+		}
+		if end < len(pattern) && pattern[end] == '+' {
+			return end + 1, false, false
+		}
+		if end < len(pattern) && pattern[end] == '?' {
+			end++
+		}
+		return end, repeats, unbounded
+	}
+
+	for i := 0; i < len(pattern); {
+		switch pattern[i] {
+		case '\\':
+			i += 2
+		case '[':
+			// skip the character class, a `]` first in the class is a literal.
+			j := i + 1
+			if j < len(pattern) && pattern[j] == '^' {
+				j++
+			}
+			if j < len(pattern) && pattern[j] == ']' {
+				j++
+			}
+			for j < len(pattern) && pattern[j] != ']' {
+				if pattern[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			i = j + 1
+		case '(':
+			stack = append(stack, current)
+			current = &group{start: i, atomic: strings.HasPrefix(pattern[i:], "(?>")}
+			i++
+			continue
+		case ')':
+			if len(stack) == 0 {
+				i++
+				continue
+			}
+			closed := current
+			current = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			end, repeats, unbounded := quantifier(i + 1)
+			if unbounded && closed.repeats && !closed.atomic {
+				return pattern[closed.start:end]
+			}
+			current.repeats = current.repeats || repeats || (closed.repeats && !closed.atomic)
+			i = end
+			continue
+		default:
+			i++
+		}
+		end, repeats, _ := quantifier(i)
+		current.repeats = current.repeats || repeats
+		i = end
+	}
+	return ""
+}
+
+// RunRule will execute the RedosPatterns rule, based on supplied context and a supplied []*yaml.Node slice.
+func (rp RedosPatterns) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	check := func(key, pattern *yaml.Node, path, kind string) {
+		if nested := nestedQuantifier(pattern.Value); nested != "" {
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("%s `%s` has a nested quantifier `%s`, it can backtrack catastrophically in "+
+					"backtracking regex engines (like PCRE)", kind, pattern.Value, nested),
+				StartNode: key,
+				EndNode:   pattern,
+				Path:      path,
+				Rule:      context.Rule,
+			})
+		}
+	}
+
+	// walk the document looking for patterns, examples are skipped.
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				switch key.Value {
+				case "example", "examples":
+					continue
+				case "pattern":
+					if value.Kind == yaml.ScalarNode {
+						check(key, value, childPath, "`pattern`")
+						continue
+					}
+				case "patternProperties":
+					if utils.IsNodeMap(value) {
+						for p := 0; p < len(value.Content)-1; p += 2 {
+							check(value.Content[p], value.Content[p], fmt.Sprintf("%s.%s", childPath,
+								value.Content[p].Value), "`patternProperties` key")
+						}
+					}
+				}
+				walk(value, childPath)
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedosPatterns_GetSchema(t *testing.T) {
+	def := RedosPatterns{}
+	assert.Equal(t, "redos_patterns", def.GetSchema().Name)
+}
+
+func TestRedosPatterns_RunRule(t *testing.T) {
+	def := RedosPatterns{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestNestedQuantifier(t *testing.T) {
+	tests := map[string]string{
+		`^(a+)+$`:        `(a+)+`,
+		`^(\w*,?)*$`:     `(\w*,?)*`,
+		`^(a{1,5}){2,}$`: `(a{1,5}){2,}`,
+		`^((a)+)+$`:      `((a)+)+`,
+		`^(x(ab)*y)*?$`:  `(x(ab)*y)*?`,
+		`^[a-z]+$`:       ``,
+		`^(ab)+$`:        ``,
+		`^(a+){3}$`:      ``,
+		`^(?>a+)+$`:      ``,
+		`^(a++)+$`:       ``,
+		`^[(a+)]+$`:      ``,
+		`^\(a+\)+$`:      ``,
+		`^(a|b)?$`:       ``,
+	}
+	for pattern, nested := range tests {
+		assert.Equal(t, nested, nestedQuantifier(pattern), pattern)
+	}
+}
+
+func TestRedosPatterns_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        name:
+          type: string
+          pattern: '^([a-z]+ ?)+$'
+        code:
+          type: string
+          pattern: '^[A-Z]{3}-\d+$'
+        pattern:
+          type: string
+      patternProperties:
+        '^x-(\w+-?)*$':
+          type: string
+      example:
+        pattern: '(a+)+'`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "redos_patterns", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := RedosPatterns{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "`pattern` `^([a-z]+ ?)+$` has a nested quantifier `([a-z]+ ?)+`, it can backtrack "+
+		"catastrophically in backtracking regex engines (like PCRE)", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.name.pattern", res[0].Path)
+	assert.Equal(t, 9, res[0].StartNode.Line)
+	assert.Equal(t, "$.components.schemas.Pizza.patternProperties.^x-(\\w+-?)*$", res[1].Path)
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	_ "embed"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// The IANA media types registry (https://www.iana.org/assignments/media-types), with one media type per line.
+// Vendor (`vnd.`) and personal (`prs.`) types are left out, every type in those trees is accepted.
+//
+//go:embed iana/media-types.txt
+var ianaMediaTypeList string
+
+var ianaMediaTypes = spdxSet(ianaMediaTypeList)
+
+// ianaTopLevelTypes are the registered top-level types, `*` is a wildcard.
+var ianaTopLevelTypes = map[string]bool{
+	"application": true, "audio": true, "example": true, "font": true, "haptics": true, "image": true,
+	"message": true, "model": true, "multipart": true, "text": true, "video": true, "*": true,
+}
+
+// ianaStructuredSuffixes are the registered structured syntax suffixes, like the `json` in `application/hal+json`.
+var ianaStructuredSuffixes = map[string]bool{
+	"ber": true, "cbor": true, "cbor-seq": true, "der": true, "fastinfoset": true, "gzip": true, "json": true,
+	"json-seq": true, "jwt": true, "sqlite3": true, "tlv": true, "wbxml": true, "xml": true, "yaml": true,
+	"zip": true, "zstd": true,
+}
+
+// RegisteredMediaTypes checks request and response media types (`content` keys in OpenAPI 3, `consumes` and
+// `produces` in Swagger) are registered with IANA, to catch typos like `application/jsonn`. Types with a registered
+// structured suffix (like `application/hal+json`), vendor types (`application/vnd.*`) and wildcards (like
+// `image/*`) are accepted. Parameters (like `; charset=utf-8`) are parsed, and only the type is checked.
+// Unregistered types that are used on purpose can be accepted with the `allowed` option.
+type RegisteredMediaTypes struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the RegisteredMediaTypes rule.
+func (rm RegisteredMediaTypes) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "registered_media_types",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "allowed",
+				Description: "media types that are accepted, even though they are not registered",
+			},
+		},
+		ErrorMessage: "'registered_media_types' function has invalid options supplied. Example valid options are " +
+			"'allowed' = ['application/x-ndjson']",
+	}
+}
+
+// RunRule will execute the RegisteredMediaTypes rule, based on supplied context and a supplied []*yaml.Node slice.
+func (rm RegisteredMediaTypes) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	allowed := make(map[string]bool)
+	for _, a := range getStringArrayOption("allowed", context.Options, nil) {
+		allowed[strings.ToLower(a)] = true
+	}
+
+	check := func(node *yaml.Node, path string) {
+		if msg := checkMediaType(node.Value, allowed); msg != "" {
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: node,
+				EndNode:   node,
+				Path:      path,
+				Rule:      context.Rule,
+			})
+		}
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				switch {
+				case key.Value == "example" || key.Value == "examples" || key.Value == "properties" ||
+					key.Value == "patternProperties" || strings.HasPrefix(key.Value, "x-"):
+					continue
+				case key.Value == "content" && utils.IsNodeMap(value):
+					for j := 0; j < len(value.Content)-1; j += 2 {
+						check(value.Content[j], fmt.Sprintf("%s.%s", childPath, value.Content[j].Value))
+					}
+				case (key.Value == "consumes" || key.Value == "produces") && utils.IsNodeArray(value):
+					for j, mediaType := range value.Content {
+						if mediaType.Kind == yaml.ScalarNode {
+							check(mediaType, fmt.Sprintf("%s[%d]", childPath, j))
+						}
+					}
+					continue
+				}
+				walk(value, childPath)
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
+
+// checkMediaType returns why a media type is not registered, or an empty string.
+func checkMediaType(value string, allowed map[string]bool) string {
+	mediaType, _, err := mime.ParseMediaType(value)
+	if err != nil {
+		return fmt.Sprintf("media type `%s` can't be parsed: %s", value, err.Error())
+	}
+	if allowed[mediaType] {
+		return ""
+	}
+	topLevel, subtype, ok := strings.Cut(mediaType, "/")
+	if !ok {
+		return fmt.Sprintf("media type `%s` has no subtype, media types look like `type/subtype`", value)
+	}
+	if !ianaTopLevelTypes[topLevel] {
+		return fmt.Sprintf("media type `%s` has the unknown type `%s`", value, topLevel)
+	}
+	if i := strings.LastIndex(subtype, "+"); i >= 0 && ianaStructuredSuffixes[subtype[i+1:]] {
+		return ""
+	}
+	if subtype == "*" || ianaMediaTypes[mediaType] || strings.HasPrefix(subtype, "vnd.") ||
+		strings.HasPrefix(subtype, "prs.") {
+		return ""
+	}
+	return fmt.Sprintf("media type `%s` is not registered with IANA", mediaType)
+}
@@ -0,0 +1,126 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var registeredMediaTypesTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    post:
+      requestBody:
+        content:
+          application/json; charset=utf-8: {}
+          application/x-www-form-urlencoded: {}
+          multipart/form-data: {}
+          application/jsonn: {}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/hal+json: {}
+            application/vnd.pizza.v2+json: {}
+            application/vnd.pizza: {}
+            image/*: {}
+            "*/*": {}
+            text/jsom: {}
+            application/x-ndjson: {}
+            pizza/json: {}
+            "application/json; charset": {}
+          x-content:
+            content:
+              not/checked: {}
+components:
+  schemas:
+    Pizza:
+      properties:
+        content:
+          type: string`
+
+func TestRegisteredMediaTypes_GetSchema(t *testing.T) {
+	def := RegisteredMediaTypes{}
+	assert.Equal(t, "registered_media_types", def.GetSchema().Name)
+}
+
+func TestRegisteredMediaTypes_RunRule(t *testing.T) {
+	def := RegisteredMediaTypes{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestRegisteredMediaTypes_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(registeredMediaTypesTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "registered_media_types", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := RegisteredMediaTypes{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 5)
+	assert.Equal(t, "media type `application/jsonn` is not registered with IANA", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.post.requestBody.content.application/jsonn", res[0].Path)
+	assert.Equal(t, 10, res[0].StartNode.Line)
+	assert.Equal(t, "media type `text/jsom` is not registered with IANA", res[1].Message)
+	assert.Equal(t, "media type `application/x-ndjson` is not registered with IANA", res[2].Message)
+	assert.Equal(t, "media type `pizza/json` has the unknown type `pizza`", res[3].Message)
+	assert.Equal(t, "media type `application/json; charset` can't be parsed: mime: invalid media parameter",
+		res[4].Message)
+}
+
+func TestRegisteredMediaTypes_RunRule_Swagger(t *testing.T) {
+
+	yml := `swagger: 2.0
+consumes:
+  - application/json
+  - applicaton/json
+paths:
+  /pizza:
+    get:
+      produces:
+        - application/xml
+        - application/jsno`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "registered_media_types", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := RegisteredMediaTypes{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "media type `applicaton/json` has the unknown type `applicaton`", res[0].Message)
+	assert.Equal(t, "$.consumes[1]", res[0].Path)
+	assert.Equal(t, "media type `application/jsno` is not registered with IANA", res[1].Message)
+	assert.Equal(t, "$.paths./pizza.get.produces[1]", res[1].Path)
+}
+
+func TestRegisteredMediaTypes_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(registeredMediaTypesTestSpec), path)
+
+	opts := map[string]interface{}{
+		"allowed": []interface{}{"application/X-NDJSON", "text/jsom"},
+	}
+	rule := buildOpenApiTestRuleAction(path, "registered_media_types", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := RegisteredMediaTypes{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "media type `application/jsonn` is not registered with IANA", res[0].Message)
+}
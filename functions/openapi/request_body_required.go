@@ -0,0 +1,105 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// RequestBodyRequired checks that operations accepting a request body mark it as `required: true`, request bodies
+// are optional by default, which is rarely what was intended. Only the methods listed in the `methods` option are
+// checked (POST, PUT and PATCH by default), so PATCH operations, where an optional body may be intentional, can be
+// left out. Referenced request bodies are resolved before they are checked.
+type RequestBodyRequired struct {
+}
+
+var defaultRequestBodyMethods = []string{"post", "put", "patch"}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the RequestBodyRequired rule.
+func (rb RequestBodyRequired) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "request_body_required",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "methods",
+				Description: "the operation methods that must mark request bodies as required (defaults to post, put and patch)",
+			},
+		},
+		ErrorMessage: "'request_body_required' function has invalid options supplied. Example valid options are " +
+			"'methods' = ['post', 'put']",
+	}
+}
+
+// RunRule will execute the RequestBodyRequired rule, based on supplied context and a supplied []*yaml.Node slice.
+func (rb RequestBodyRequired) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	if context.Index.GetPathsNode() == nil {
+		return results
+	}
+
+	methods := make(map[string]bool)
+	for _, m := range getStringArrayOption("methods", context.Options, defaultRequestBodyMethods) {
+		methods[strings.ToLower(strings.TrimSpace(m))] = true
+	}
+
+	root := context.Index.GetRootNode()
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	ops := context.Index.GetPathsNode().Content
+	var opPath string
+	for i, op := range ops {
+		if context.IsCancelled() {
+			break
+		}
+		if i%2 == 0 {
+			opPath = op.Value
+			continue
+		}
+		for m := 0; m < len(op.Content)-1; m += 2 {
+			opMethod := op.Content[m].Value
+			if !methods[strings.ToLower(opMethod)] || !utils.IsNodeMap(op.Content[m+1]) {
+				continue
+			}
+			bodyKey, body := utils.FindKeyNodeTop("requestBody", op.Content[m+1].Content)
+			if body == nil {
+				continue
+			}
+			resolved := body
+			if _, ref := utils.FindKeyNodeTop("$ref", body.Content); ref != nil && root != nil {
+				if resolved = resolveLocalReference(root, ref.Value); resolved == nil {
+					continue // unresolvable references are reported by other rules.
+				}
+			}
+			if !utils.IsNodeMap(resolved) {
+				continue
+			}
+			if _, required := utils.FindKeyNodeTop("required", resolved.Content); required != nil &&
+				required.Value == "true" {
+				continue
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("operation `%s` at path `%s` accepts a request body that is not "+
+					"marked `required: true`", opMethod, opPath),
+				StartNode: bodyKey,
+				EndNode:   utils.FindLastChildNodeWithLevel(body, 0),
+				Path:      fmt.Sprintf("$.paths.%s.%s.requestBody", opPath, opMethod),
+				Rule:      context.Rule,
+			})
+		}
+	}
+	return results
+}
@@ -0,0 +1,123 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var requestBodyRequiredTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+    put:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+    patch:
+      requestBody:
+        required: false
+        content:
+          application/json:
+            schema:
+              type: object
+  /pasta:
+    post:
+      requestBody:
+        $ref: '#/components/requestBodies/Pasta'
+    put:
+      requestBody:
+        $ref: '#/components/requestBodies/RequiredPasta'
+    get:
+      responses:
+        "200":
+          description: ok
+components:
+  requestBodies:
+    Pasta:
+      content:
+        application/json:
+          schema:
+            type: object
+    RequiredPasta:
+      required: true
+      content:
+        application/json:
+          schema:
+            type: object`
+
+func TestRequestBodyRequired_GetSchema(t *testing.T) {
+	def := RequestBodyRequired{}
+	assert.Equal(t, "request_body_required", def.GetSchema().Name)
+}
+
+func TestRequestBodyRequired_RunRule(t *testing.T) {
+	def := RequestBodyRequired{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestRequestBodyRequired_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(requestBodyRequiredTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(requestBodyRequiredTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "request_body_required", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := RequestBodyRequired{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "operation `post` at path `/pizza` accepts a request body that is not marked "+
+		"`required: true`", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.post.requestBody", res[0].Path)
+	assert.Equal(t, 5, res[0].StartNode.Line)
+	assert.Equal(t, "$.paths./pizza.patch.requestBody", res[1].Path)
+	assert.Equal(t, "$.paths./pasta.post.requestBody", res[2].Path)
+}
+
+func TestRequestBodyRequired_RunRule_NoPatch(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(requestBodyRequiredTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(requestBodyRequiredTestSpec), path)
+
+	opts := map[string]interface{}{
+		"methods": []interface{}{"POST", "PUT"},
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "request_body_required", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := RequestBodyRequired{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "$.paths./pizza.post.requestBody", res[0].Path)
+	assert.Equal(t, "$.paths./pasta.post.requestBody", res[1].Path)
+}
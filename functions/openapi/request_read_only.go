@@ -0,0 +1,176 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// RequestReadOnly checks the schemas of request bodies (and swagger `body` parameters) have no properties that are
+// both `readOnly` and `required`, clients can't send them, so they can't send a valid request. Schemas are only
+// checked where they are used by a request body, references are followed, so a schema shared with responses (where
+// a required read only property is correct) is only reported because of the request bodies using it. Properties
+// required by one `allOf` member and declared by another are checked too. Each property is reported once, where it
+// is declared, naming the first operation that sends it.
+type RequestReadOnly struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the RequestReadOnly rule.
+func (rr RequestReadOnly) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "request_read_only",
+	}
+}
+
+// RunRule will execute the RequestReadOnly rule, based on supplied context and a supplied []*yaml.Node slice.
+func (rr RequestReadOnly) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	reported := make(map[*yaml.Node]bool)
+
+	// traverse checks a schema used by a request body, and the schemas nested in it.
+	var traverse func(schema *yaml.Node, path, operation string, seen map[*yaml.Node]bool)
+	traverse = func(schema *yaml.Node, path, operation string, seen map[*yaml.Node]bool) {
+		if schema == nil || !utils.IsNodeMap(schema) {
+			return
+		}
+		if _, ref := utils.FindKeyNodeTop("$ref", schema.Content); ref != nil {
+			_, path = utils.ConvertComponentIdIntoPath(ref.Value)
+		}
+		if schema = resolveSchemaReference(root, schema); schema == nil || seen[schema] {
+			return
+		}
+		seen[schema] = true
+
+		// required properties and their schemas can come from the schema, or its allOf members.
+		properties := ownProperties(schema, path)
+		required := make(map[string]bool)
+		collectRequired := func(s *yaml.Node) {
+			if _, req := utils.FindKeyNodeTop("required", s.Content); req != nil && utils.IsNodeArray(req) {
+				for _, r := range req.Content {
+					required[r.Value] = true
+				}
+			}
+		}
+		collectRequired(schema)
+		_, allOf := utils.FindKeyNodeTop("allOf", schema.Content)
+		if allOf != nil && utils.IsNodeArray(allOf) {
+			for i, member := range allOf.Content {
+				memberPath := fmt.Sprintf("%s.allOf[%d]", path, i)
+				if _, ref := utils.FindKeyNodeTop("$ref", member.Content); ref != nil {
+					_, memberPath = utils.ConvertComponentIdIntoPath(ref.Value)
+				}
+				if resolved := resolveSchemaReference(root, member); resolved != nil {
+					collectRequired(resolved)
+					for name, p := range ownProperties(resolved, memberPath) {
+						if _, ok := properties[name]; !ok {
+							properties[name] = p
+						}
+					}
+				}
+			}
+		}
+
+		var readOnly []declaredProperty
+		for name := range required {
+			p, ok := properties[name]
+			if !ok || reported[p.key] {
+				continue
+			}
+			if property := resolveSchemaReference(root, p.schema); property != nil {
+				if _, ro := utils.FindKeyNodeTop("readOnly", property.Content); ro != nil && ro.Value == "true" {
+					readOnly = append(readOnly, p)
+				}
+			}
+		}
+		sortDeclaredProperties(readOnly)
+		for _, p := range readOnly {
+			reported[p.key] = true
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("property `%s` is `readOnly` and `required`, but is part of the request body "+
+					"of `%s`", p.key.Value, operation),
+				StartNode: p.key,
+				EndNode:   utils.FindLastChildNodeWithLevel(p.schema, 0),
+				Path:      p.path,
+				Rule:      context.Rule,
+			})
+		}
+
+		for i := 0; i < len(schema.Content)-1; i += 2 {
+			key, value := schema.Content[i].Value, schema.Content[i+1]
+			switch key {
+			case "properties":
+				if utils.IsNodeMap(value) {
+					for p := 0; p < len(value.Content)-1; p += 2 {
+						traverse(value.Content[p+1], fmt.Sprintf("%s.properties.%s", path, value.Content[p].Value),
+							operation, seen)
+					}
+				}
+			case "allOf", "oneOf", "anyOf":
+				if utils.IsNodeArray(value) {
+					for m, member := range value.Content {
+						traverse(member, fmt.Sprintf("%s.%s[%d]", path, key, m), operation, seen)
+					}
+				}
+			case "items", "additionalProperties":
+				traverse(value, fmt.Sprintf("%s.%s", path, key), operation, seen)
+			}
+		}
+	}
+
+	walker.ForEachOperation(root, func(op walker.Operation) bool {
+		operation := fmt.Sprintf("%s %s", op.Method, op.Path)
+		seen := make(map[*yaml.Node]bool)
+
+		if _, rb := utils.FindKeyNodeTop("requestBody", op.Node.Content); rb != nil {
+			if resolved := resolveSchemaReference(root, rb); resolved != nil {
+				_, content := utils.FindKeyNodeTop("content", resolved.Content)
+				if content != nil && utils.IsNodeMap(content) {
+					for i := 0; i < len(content.Content)-1; i += 2 {
+						_, schema := utils.FindKeyNodeTop("schema", content.Content[i+1].Content)
+						traverse(schema, fmt.Sprintf("%s.requestBody.content.%s.schema", op.JSONPath,
+							content.Content[i].Value), operation, seen)
+					}
+				}
+			}
+		}
+
+		// swagger sends the request body as a `body` parameter.
+		bodyParams := func(params *yaml.Node, path string) {
+			if params == nil || !utils.IsNodeArray(params) {
+				return
+			}
+			for i, param := range params.Content {
+				if param = resolveSchemaReference(root, param); param == nil {
+					continue
+				}
+				if _, in := utils.FindKeyNodeTop("in", param.Content); in != nil && in.Value == "body" {
+					_, schema := utils.FindKeyNodeTop("schema", param.Content)
+					traverse(schema, fmt.Sprintf("%s[%d].schema", path, i), operation, seen)
+				}
+			}
+		}
+		_, pathParams := utils.FindKeyNodeTop("parameters", op.PathItem.Content)
+		bodyParams(pathParams, fmt.Sprintf("$.paths.%s.parameters", op.Path))
+		_, opParams := utils.FindKeyNodeTop("parameters", op.Node.Content)
+		bodyParams(opParams, op.JSONPath+".parameters")
+		return !context.IsCancelled()
+	})
+	return results
+}
@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestReadOnly_GetSchema(t *testing.T) {
+	def := RequestReadOnly{}
+	assert.Equal(t, "request_read_only", def.GetSchema().Name)
+}
+
+func TestRequestReadOnly_RunRule(t *testing.T) {
+	def := RequestReadOnly{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestRequestReadOnly_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Order'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pizza'
+    put:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pizza'
+components:
+  schemas:
+    Order:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+          readOnly: true
+    Pizza:
+      allOf:
+        - $ref: '#/components/schemas/Entity'
+        - type: object
+          required: [createdAt, name]
+          properties:
+            name:
+              type: string
+            toppings:
+              type: array
+              items:
+                type: object
+                required: [toppingId]
+                properties:
+                  toppingId:
+                    $ref: '#/components/schemas/Id'
+    Entity:
+      type: object
+      properties:
+        createdAt:
+          type: string
+          readOnly: true
+    Id:
+      type: integer
+      readOnly: true`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "request_read_only", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := RequestReadOnly{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "property `createdAt` is `readOnly` and `required`, but is part of the request body of "+
+		"`post /pizza`", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Entity.properties.createdAt", res[0].Path)
+	assert.Equal(t, "property `toppingId` is `readOnly` and `required`, but is part of the request body of "+
+		"`post /pizza`", res[1].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.allOf[1].properties.toppings.items.properties.toppingId", res[1].Path)
+}
+
+func TestRequestReadOnly_RunRule_Swagger(t *testing.T) {
+
+	yml := `swagger: "2.0"
+paths:
+  /pizza:
+    post:
+      parameters:
+        - in: body
+          name: pizza
+          schema:
+            type: object
+            required: [id, name]
+            properties:
+              id:
+                type: string
+                readOnly: true
+              name:
+                type: string
+      responses:
+        "201":
+          description: created
+          schema:
+            type: object
+            required: [id]
+            properties:
+              id:
+                type: string
+                readOnly: true`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "request_read_only", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := RequestReadOnly{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.paths./pizza.post.parameters[0].schema.properties.id", res[0].Path)
+	assert.Equal(t, 12, res[0].StartNode.Line)
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// RequiredNullable checks for OpenAPI 3.0 properties that are both `required` and `nullable: true`. A nullable
+// property can be `null`, but it still has to be present, which is often not what the author meant. With the
+// `policy` option set to `forbid` (the default) every such property is flagged, with `explain` only properties
+// whose description doesn't mention `null` are flagged. OpenAPI 3.1 has no `nullable`, so it's out of scope.
+type RequiredNullable struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the RequiredNullable rule.
+func (rn RequiredNullable) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "required_nullable",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name: "policy",
+				Description: "'forbid' flags every required nullable property, 'explain' only flags those that don't " +
+					"describe what null means (defaults to 'forbid')",
+			},
+		},
+		ErrorMessage: "'required_nullable' function has invalid options supplied. Example valid options are " +
+			"'policy' = 'forbid' or 'policy' = 'explain'",
+	}
+}
+
+// RunRule will execute the RequiredNullable rule, based on supplied context and a supplied []*yaml.Node slice.
+func (rn RequiredNullable) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	explain := strings.ToLower(getStringOption("policy", context.Options, "forbid")) == "explain"
+
+	checkSchema := func(schema *yaml.Node, path string) {
+		_, required := utils.FindKeyNodeTop("required", schema.Content)
+		_, properties := utils.FindKeyNodeTop("properties", schema.Content)
+		if !utils.IsNodeArray(required) || !utils.IsNodeMap(properties) {
+			return
+		}
+		for _, name := range required.Content {
+			nameKey, property := utils.FindKeyNodeTop(name.Value, properties.Content)
+			if property == nil {
+				continue
+			}
+			if _, ref := utils.FindKeyNodeTop("$ref", property.Content); ref != nil {
+				if resolved := resolveLocalReference(root, ref.Value); resolved != nil {
+					property = resolved
+				}
+			}
+			if !utils.IsNodeMap(property) {
+				continue
+			}
+			if _, nullable := utils.FindKeyNodeTop("nullable", property.Content); nullable == nil ||
+				nullable.Value != "true" {
+				continue
+			}
+			if explain {
+				if _, desc := utils.FindKeyNodeTop("description", property.Content); desc != nil &&
+					strings.Contains(strings.ToLower(desc.Value), "null") {
+					continue
+				}
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("property `%s` is `required` and `nullable`, it must always be present but "+
+					"can be `null` (`nullable` does not make it optional)", name.Value),
+				StartNode: nameKey,
+				EndNode:   utils.FindLastChildNodeWithLevel(property, 0),
+				Path:      fmt.Sprintf("%s.properties.%s", path, name.Value),
+				Rule:      context.Rule,
+			})
+		}
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			checkSchema(node, path)
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if key.Value == "example" || key.Value == "examples" {
+					continue
+				}
+				walk(value, fmt.Sprintf("%s.%s", path, key.Value))
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
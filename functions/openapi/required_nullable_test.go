@@ -0,0 +1,93 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var requiredNullableTestSpec = `openapi: 3.0.3
+components:
+  schemas:
+    Pizza:
+      type: object
+      required:
+        - name
+        - topping
+        - deliveredAt
+        - size
+      properties:
+        name:
+          type: string
+          nullable: true
+        topping:
+          $ref: '#/components/schemas/Topping'
+        deliveredAt:
+          type: string
+          format: date-time
+          nullable: true
+          description: when the pizza was delivered, null until it is.
+        size:
+          type: integer
+          nullable: false
+        crust:
+          type: string
+          nullable: true
+    Topping:
+      type: string
+      nullable: true`
+
+func TestRequiredNullable_GetSchema(t *testing.T) {
+	def := RequiredNullable{}
+	assert.Equal(t, "required_nullable", def.GetSchema().Name)
+}
+
+func TestRequiredNullable_RunRule(t *testing.T) {
+	def := RequiredNullable{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestRequiredNullable_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(requiredNullableTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "required_nullable", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := RequiredNullable{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "property `name` is `required` and `nullable`, it must always be present but can be `null` "+
+		"(`nullable` does not make it optional)", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.name", res[0].Path)
+	assert.Equal(t, 12, res[0].StartNode.Line)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.topping", res[1].Path)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.deliveredAt", res[2].Path)
+}
+
+func TestRequiredNullable_RunRule_Explain(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(requiredNullableTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["policy"] = "explain"
+
+	rule := buildOpenApiTestRuleAction(path, "required_nullable", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := RequiredNullable{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.name", res[0].Path)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.topping", res[1].Path)
+}
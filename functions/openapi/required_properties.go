@@ -0,0 +1,138 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// RequiredProperties checks that every name in a `required` list is a property the schema defines. Properties
+// merged in via `allOf` (including referenced schemas) count, as do properties of the schema a composition
+// (`allOf`, `oneOf` or `anyOf`) member belongs to. Names matching one of the `patternProperties` patterns count
+// as well. Schemas that define no properties at all, like constraint-only `anyOf` members, are not checked.
+type RequiredProperties struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the RequiredProperties rule.
+func (rp RequiredProperties) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "required_properties",
+	}
+}
+
+// RunRule will execute the RequiredProperties rule, based on supplied context and a supplied []*yaml.Node slice.
+func (rp RequiredProperties) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	// collect adds the properties and pattern properties defined by a schema (and everything it merges via allOf).
+	var collect func(schema *yaml.Node, names map[string]bool, patterns *[]*regexp.Regexp, seen map[*yaml.Node]bool)
+	collect = func(schema *yaml.Node, names map[string]bool, patterns *[]*regexp.Regexp, seen map[*yaml.Node]bool) {
+		schema = resolveSchemaReference(root, schema)
+		if schema == nil || seen[schema] {
+			return
+		}
+		seen[schema] = true
+		if _, props := utils.FindKeyNodeTop("properties", schema.Content); utils.IsNodeMap(props) {
+			for i := 0; i < len(props.Content)-1; i += 2 {
+				names[props.Content[i].Value] = true
+			}
+		}
+		if _, props := utils.FindKeyNodeTop("patternProperties", schema.Content); utils.IsNodeMap(props) {
+			for i := 0; i < len(props.Content)-1; i += 2 {
+				if rx, err := regexp.Compile(props.Content[i].Value); err == nil {
+					*patterns = append(*patterns, rx)
+				}
+			}
+		}
+		if _, allOf := utils.FindKeyNodeTop("allOf", schema.Content); utils.IsNodeArray(allOf) {
+			for _, member := range allOf.Content {
+				collect(member, names, patterns, seen)
+			}
+		}
+	}
+
+	defined := func(name string, names map[string]bool, patterns []*regexp.Regexp) bool {
+		if names[name] {
+			return true
+		}
+		for _, rx := range patterns {
+			if rx.MatchString(name) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// walk the document looking for schemas, composition members inherit the properties of their parent schema.
+	var walk func(node *yaml.Node, path string, inheritedNames map[string]bool, inheritedPatterns []*regexp.Regexp)
+	walk = func(node *yaml.Node, path string, inheritedNames map[string]bool, inheritedPatterns []*regexp.Regexp) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i), nil, nil)
+			}
+		case yaml.MappingNode:
+			names := make(map[string]bool)
+			for name := range inheritedNames {
+				names[name] = true
+			}
+			patterns := append([]*regexp.Regexp{}, inheritedPatterns...)
+			if _, ref := utils.FindKeyNodeTop("$ref", node.Content); ref == nil {
+				collect(node, names, &patterns, make(map[*yaml.Node]bool))
+			}
+
+			_, required := utils.FindKeyNodeTop("required", node.Content)
+			if utils.IsNodeArray(required) && (len(names) > 0 || len(patterns) > 0) {
+				for i, entry := range required.Content {
+					if entry.Kind != yaml.ScalarNode || defined(entry.Value, names, patterns) {
+						continue
+					}
+					results = append(results, model.RuleFunctionResult{
+						Message: fmt.Sprintf("required property `%s` is not defined in the `properties` of the schema",
+							entry.Value),
+						StartNode: entry,
+						EndNode:   entry,
+						Path:      fmt.Sprintf("%s.required[%d]", path, i),
+						Rule:      context.Rule,
+					})
+				}
+			}
+
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if key.Value == "example" || key.Value == "examples" {
+					continue
+				}
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				if (key.Value == "allOf" || key.Value == "oneOf" || key.Value == "anyOf") && utils.IsNodeArray(value) {
+					for x, member := range value.Content {
+						walk(member, fmt.Sprintf("%s[%d]", childPath, x), names, patterns)
+					}
+					continue
+				}
+				walk(value, childPath, nil, nil)
+			}
+		}
+	}
+	walk(root, "$", nil, nil)
+	return results
+}
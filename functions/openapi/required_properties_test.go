@@ -0,0 +1,92 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var requiredPropertiesTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name, toppings]
+              properties:
+                name:
+                  type: string
+components:
+  schemas:
+    Base:
+      type: object
+      properties:
+        id:
+          type: string
+    Pizza:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          required: [id, size, crust]
+          properties:
+            size:
+              type: string
+    Labels:
+      type: object
+      required: [x-team, owner]
+      patternProperties:
+        '^x-':
+          type: string
+    Choice:
+      type: object
+      properties:
+        card:
+          type: string
+        cash:
+          type: string
+      anyOf:
+        - required: [card]
+        - required: [cheque]
+    Fragment:
+      required: [anything]`
+
+func TestRequiredProperties_GetSchema(t *testing.T) {
+	def := RequiredProperties{}
+	assert.Equal(t, "required_properties", def.GetSchema().Name)
+}
+
+func TestRequiredProperties_RunRule(t *testing.T) {
+	def := RequiredProperties{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestRequiredProperties_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(requiredPropertiesTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "required_properties", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := RequiredProperties{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "required property `toppings` is not defined in the `properties` of the schema", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.post.requestBody.content.application/json.schema.required[1]", res[0].Path)
+	assert.Equal(t, 11, res[0].StartNode.Line)
+	assert.Equal(t, "required property `crust` is not defined in the `properties` of the schema", res[1].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.allOf[1].required[2]", res[1].Path)
+	assert.Equal(t, "required property `owner` is not defined in the `properties` of the schema", res[2].Message)
+	assert.Equal(t, "$.components.schemas.Labels.required[1]", res[2].Path)
+	assert.Equal(t, "required property `cheque` is not defined in the `properties` of the schema", res[3].Message)
+	assert.Equal(t, "$.components.schemas.Choice.anyOf[1].required[0]", res[3].Path)
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"gopkg.in/yaml.v3"
+)
+
+// ResponseStatusCodes checks the keys of the `responses` of operations are well-formed. A key must be `default`, a
+// status code (`100` to `599`) or, in OpenAPI 3, a status code range. The only ranges are `1XX` to `5XX`, with an
+// upper case `X`, tools that don't recognise a key like `4xx` or `40X` as a range silently ignore the response.
+type ResponseStatusCodes struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ResponseStatusCodes rule.
+func (rs ResponseStatusCodes) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "response_status_codes",
+	}
+}
+
+// RunRule will execute the ResponseStatusCodes rule, based on supplied context and a supplied []*yaml.Node slice.
+func (rs ResponseStatusCodes) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	walker.ForEachOperation(root, func(op walker.Operation) bool {
+		walker.ForEachResponse(op, func(response walker.Response) bool {
+			if class, _ := walker.StatusCodeClass(response.Code); class > 0 || response.Code == "default" {
+				return true
+			}
+			message := fmt.Sprintf("response status code `%s` is not valid, it must be a status code (`100` to "+
+				"`599`), a range (`1XX` to `5XX`) or `default`", response.Code)
+			if class, isRange := walker.StatusCodeClass(strings.ToUpper(response.Code)); isRange {
+				message = fmt.Sprintf("response status code range `%s` is not valid, ranges are upper case, use "+
+					"`%dXX`", response.Code, class)
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message:   message,
+				StartNode: response.KeyNode,
+				EndNode:   response.KeyNode,
+				Path:      response.JSONPath,
+				Rule:      context.Rule,
+			})
+			return true
+		})
+		return !context.IsCancelled()
+	})
+	return results
+}
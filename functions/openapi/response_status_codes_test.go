@@ -0,0 +1,76 @@
+package openapi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseStatusCodes_GetSchema(t *testing.T) {
+	def := ResponseStatusCodes{}
+	assert.Equal(t, "response_status_codes", def.GetSchema().Name)
+}
+
+func TestResponseStatusCodes_RunRule(t *testing.T) {
+	def := ResponseStatusCodes{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestResponseStatusCodes_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+paths:
+  /pizzas:
+    get:
+      responses:
+        '200':
+          description: pizzas
+        4xx:
+          description: bad pizza
+        40X:
+          description: very bad pizza
+        '600':
+          description: burnt pizza
+        default:
+          description: something went wrong
+        x-oven: hot`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "response_status_codes", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ResponseStatusCodes{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "response status code range `4xx` is not valid, ranges are upper case, use `4XX`", res[0].Message)
+	assert.Equal(t, "$.paths./pizzas.get.responses.4xx", res[0].Path)
+	assert.Equal(t, 8, res[0].StartNode.Line)
+	assert.Equal(t, "response status code `40X` is not valid, it must be a status code (`100` to `599`), a range "+
+		"(`1XX` to `5XX`) or `default`", res[1].Message)
+	assert.Equal(t, "$.paths./pizzas.get.responses.600", res[2].Path)
+}
+
+func TestResponseStatusCodes_RunRule_Success(t *testing.T) {
+
+	sampleYaml, _ := os.ReadFile("../../model/test_files/status-code-ranges.openapi.yaml")
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes(sampleYaml, path)
+
+	rule := buildOpenApiTestRuleAction(path, "response_status_codes", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ResponseStatusCodes{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
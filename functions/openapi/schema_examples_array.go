@@ -0,0 +1,66 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaExamplesArray checks schemas in OpenAPI 3.1 documents use the `examples` array, rather than the deprecated
+// singular `example`. Only schemas are checked, media types, parameters and headers still have `example` and
+// `examples` (a map) of their own. Documents that are not 3.1 are ignored.
+type SchemaExamplesArray struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the SchemaExamplesArray rule.
+func (se SchemaExamplesArray) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "schema_examples_array",
+	}
+}
+
+// RunRule will execute the SchemaExamplesArray rule, based on supplied context and a supplied []*yaml.Node slice.
+func (se SchemaExamplesArray) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if _, version := utils.FindKeyNodeTop("openapi", root.Content); version == nil ||
+		!strings.HasPrefix(version.Value, "3.1") {
+		return nil
+	}
+
+	walker.ForEachSchema(root, func(schema walker.Schema) bool {
+		exampleKey, example := utils.FindKeyNodeTop("example", schema.Node.Content)
+		if example == nil {
+			return !context.IsCancelled()
+		}
+		msg := "schema uses `example`, which is deprecated in OpenAPI 3.1, use `examples` (an array) instead"
+		if _, examples := utils.FindKeyNodeTop("examples", schema.Node.Content); examples != nil {
+			msg = "schema declares both `example` and `examples`, `example` is deprecated in OpenAPI 3.1, " +
+				"move it into `examples`"
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message:   msg,
+			StartNode: exampleKey,
+			EndNode:   utils.FindLastChildNodeWithLevel(example, 0),
+			Path:      schema.JSONPath + ".example",
+			Rule:      context.Rule,
+		})
+		return !context.IsCancelled()
+	})
+	return results
+}
@@ -0,0 +1,96 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var schemaExamplesArrayTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      parameters:
+        - name: size
+          in: query
+          example: large
+          schema:
+            type: string
+            example: large
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              example:
+                name: margherita
+              schema:
+                $ref: '#/components/schemas/Pizza'
+components:
+  schemas:
+    Pizza:
+      type: object
+      examples:
+        - name: margherita
+      example:
+        name: margherita
+      properties:
+        name:
+          type: string
+          examples: [margherita]`
+
+func TestSchemaExamplesArray_GetSchema(t *testing.T) {
+	def := SchemaExamplesArray{}
+	assert.Equal(t, "schema_examples_array", def.GetSchema().Name)
+}
+
+func TestSchemaExamplesArray_RunRule(t *testing.T) {
+	def := SchemaExamplesArray{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestSchemaExamplesArray_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(schemaExamplesArrayTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "schema_examples_array", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := SchemaExamplesArray{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "schema declares both `example` and `examples`, `example` is deprecated in OpenAPI 3.1, "+
+		"move it into `examples`", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.example", res[0].Path)
+	assert.Equal(t, 27, res[0].StartNode.Line)
+	assert.Equal(t, "schema uses `example`, which is deprecated in OpenAPI 3.1, use `examples` (an array) instead",
+		res[1].Message)
+	assert.Equal(t, "$.paths./pizza.get.parameters[0].schema.example", res[1].Path)
+}
+
+func TestSchemaExamplesArray_RunRule_OpenAPI30(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(`openapi: 3.0.3
+components:
+  schemas:
+    Pizza:
+      type: object
+      example:
+        name: margherita`), path)
+
+	rule := buildOpenApiTestRuleAction(path, "schema_examples_array", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := SchemaExamplesArray{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
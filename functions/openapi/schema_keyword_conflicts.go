@@ -0,0 +1,179 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaKeywordConflicts checks schemas for keyword combinations that contradict one another, like `type: object`
+// with `items`, `type: array` with `properties` or a `const` value that is not part of the `enum`. The whole schema
+// tree is walked, including properties, items and polymorphic schemas. Schemas with no `type` that define both
+// `items` and `properties` are ambiguous (but common), they are reported separately so they can be ignored
+// using the `ignoreUntyped` option.
+type SchemaKeywordConflicts struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the SchemaKeywordConflicts rule.
+func (sk SchemaKeywordConflicts) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "schema_keyword_conflicts",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "ignoreUntyped",
+				Description: "ignore schemas without a type that define both items and properties (defaults to false)",
+			},
+		},
+		ErrorMessage: "'schema_keyword_conflicts' function has invalid options supplied.",
+	}
+}
+
+// RunRule will execute the SchemaKeywordConflicts rule, based on supplied context and a supplied []*yaml.Node slice.
+func (sk SchemaKeywordConflicts) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	ignoreUntyped := getBoolOption("ignoreUntyped", context.Options, false)
+	seen := make(map[*yaml.Node]bool)
+
+	var checkSchema func(schema *yaml.Node, path string)
+	checkSchema = func(schema *yaml.Node, path string) {
+		if schema == nil || !utils.IsNodeMap(schema) || seen[schema] {
+			return
+		}
+		seen[schema] = true
+
+		if _, ref := utils.FindKeyNodeTop("$ref", schema.Content); ref != nil {
+			return
+		}
+
+		_, typeNode := utils.FindKeyNodeTop("type", schema.Content)
+		itemsKey, items := utils.FindKeyNodeTop("items", schema.Content)
+		propsKey, props := utils.FindKeyNodeTop("properties", schema.Content)
+		constKey, constNode := utils.FindKeyNodeTop("const", schema.Content)
+		_, enumNode := utils.FindKeyNodeTop("enum", schema.Content)
+
+		types := make(map[string]bool)
+		if typeNode != nil {
+			if utils.IsNodeArray(typeNode) {
+				for _, t := range typeNode.Content {
+					types[t.Value] = true
+				}
+			} else {
+				types[typeNode.Value] = true
+			}
+		}
+
+		conflict := func(keyNode *yaml.Node, msg string) {
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: keyNode,
+				EndNode:   utils.FindLastChildNodeWithLevel(schema, 0),
+				Path:      path,
+				Rule:      context.Rule,
+			})
+		}
+
+		if types["object"] && !types["array"] && items != nil {
+			conflict(itemsKey, "schema has `type: object` but also defines `items`, these keywords conflict")
+		}
+		if types["array"] && !types["object"] && props != nil {
+			conflict(propsKey, "schema has `type: array` but also defines `properties`, these keywords conflict")
+		}
+		if typeNode == nil && items != nil && props != nil && !ignoreUntyped {
+			conflict(itemsKey, "schema has no `type` but defines both `items` and `properties`, which is ambiguous")
+		}
+		if constNode != nil && enumNode != nil && utils.IsNodeArray(enumNode) {
+			found := false
+			for _, e := range enumNode.Content {
+				if e.Value == constNode.Value {
+					found = true
+					break
+				}
+			}
+			if !found {
+				conflict(constKey, fmt.Sprintf("schema `const` value `%s` is excluded by its `enum`, "+
+					"these keywords conflict", constNode.Value))
+			}
+		}
+
+		// recurse into the schema tree.
+		for i := 0; i < len(schema.Content)-1; i += 2 {
+			key, value := schema.Content[i], schema.Content[i+1]
+			childPath := fmt.Sprintf("%s.%s", path, key.Value)
+			switch key.Value {
+			case "properties", "patternProperties":
+				if utils.IsNodeMap(value) {
+					for p := 0; p < len(value.Content)-1; p += 2 {
+						checkSchema(value.Content[p+1], fmt.Sprintf("%s.%s", childPath, value.Content[p].Value))
+					}
+				}
+			case "allOf", "oneOf", "anyOf", "prefixItems":
+				if utils.IsNodeArray(value) {
+					for x, s := range value.Content {
+						checkSchema(s, fmt.Sprintf("%s[%d]", childPath, x))
+					}
+				}
+			case "items":
+				if utils.IsNodeArray(value) {
+					for x, s := range value.Content {
+						checkSchema(s, fmt.Sprintf("%s[%d]", childPath, x))
+					}
+				} else {
+					checkSchema(value, childPath)
+				}
+			case "additionalProperties", "not", "contains", "if", "then", "else":
+				checkSchema(value, childPath)
+			}
+		}
+	}
+
+	// walk the document looking for schemas, component schemas, definitions and any 'schema' keys.
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				switch {
+				case key.Value == "example" || key.Value == "examples":
+					continue
+				case key.Value == "schema":
+					checkSchema(value, childPath)
+				case (path == "$.components" && key.Value == "schemas") || (path == "$" && key.Value == "definitions"):
+					if utils.IsNodeMap(value) {
+						for s := 0; s < len(value.Content)-1; s += 2 {
+							checkSchema(value.Content[s+1], fmt.Sprintf("%s.%s", childPath, value.Content[s].Value))
+						}
+					}
+				default:
+					walk(value, childPath)
+				}
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
@@ -0,0 +1,121 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var schemaKeywordConflictsTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                properties:
+                  name:
+                    type: string
+              example:
+                type: object
+                items: this is an example, not a schema.
+components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        toppings:
+          type: object
+          items:
+            type: string
+        size:
+          const: large
+          enum: [small, medium]
+        crust:
+          const: thin
+          enum: [thin, deep]
+    Untyped:
+      items:
+        type: string
+      properties:
+        name:
+          type: string
+    Good:
+      type: [array, object]
+      items:
+        type: string
+      properties:
+        name:
+          type: string`
+
+func TestSchemaKeywordConflicts_GetSchema(t *testing.T) {
+	def := SchemaKeywordConflicts{}
+	assert.Equal(t, "schema_keyword_conflicts", def.GetSchema().Name)
+}
+
+func TestSchemaKeywordConflicts_RunRule(t *testing.T) {
+	def := SchemaKeywordConflicts{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestSchemaKeywordConflicts_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(schemaKeywordConflictsTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(schemaKeywordConflictsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "schema_keyword_conflicts", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := SchemaKeywordConflicts{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "schema has `type: array` but also defines `properties`, these keywords conflict", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get.responses.200.content.application/json.schema", res[0].Path)
+	assert.Equal(t, "schema has `type: object` but also defines `items`, these keywords conflict", res[1].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.toppings", res[1].Path)
+	assert.Equal(t, "schema `const` value `large` is excluded by its `enum`, these keywords conflict", res[2].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.size", res[2].Path)
+	assert.Equal(t, "schema has no `type` but defines both `items` and `properties`, which is ambiguous", res[3].Message)
+	assert.Equal(t, "$.components.schemas.Untyped", res[3].Path)
+}
+
+func TestSchemaKeywordConflicts_RunRule_IgnoreUntyped(t *testing.T) {
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(schemaKeywordConflictsTestSpec), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(schemaKeywordConflictsTestSpec), path)
+
+	opts := map[string]interface{}{
+		"ignoreUntyped": true,
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "schema_keyword_conflicts", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := SchemaKeywordConflicts{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaNestingDepth checks inline schemas are not nested deeper than the `maxDepth` option (5 by default). Every
+// step into `properties`, `patternProperties`, `additionalProperties` or `items` is a level, compositions (like
+// `allOf`) are walked without adding one. References are not followed, a `$ref` is where inline nesting stops, so
+// recursive schemas are never counted as infinitely deep. Only the first schema past the maximum is reported.
+type SchemaNestingDepth struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the SchemaNestingDepth rule.
+func (sn SchemaNestingDepth) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "schema_nesting_depth",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "maxDepth",
+				Description: "the deepest schemas can be nested inline (defaults to 5)",
+			},
+		},
+		ErrorMessage: "'schema_nesting_depth' function has invalid options supplied. Example valid options are " +
+			"'maxDepth' = 3",
+	}
+}
+
+// RunRule will execute the SchemaNestingDepth rule, based on supplied context and a supplied []*yaml.Node slice.
+func (sn SchemaNestingDepth) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	maxDepth := getIntOption("maxDepth", context.Options, 5)
+
+	var checkSchema func(keyNode, schema *yaml.Node, path string, depth int)
+	checkSchema = func(keyNode, schema *yaml.Node, path string, depth int) {
+		if schema == nil || !utils.IsNodeMap(schema) {
+			return
+		}
+		if _, ref := utils.FindKeyNodeTop("$ref", schema.Content); ref != nil {
+			return
+		}
+		if depth > maxDepth {
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("schema is nested %d levels deep, the maximum is %d, move it into a "+
+					"component and reference it", depth, maxDepth),
+				StartNode: keyNode,
+				EndNode:   utils.FindLastChildNodeWithLevel(schema, 0),
+				Path:      path,
+				Rule:      context.Rule,
+			})
+			return
+		}
+
+		for i := 0; i < len(schema.Content)-1; i += 2 {
+			key, value := schema.Content[i], schema.Content[i+1]
+			childPath := fmt.Sprintf("%s.%s", path, key.Value)
+			switch key.Value {
+			case "properties", "patternProperties":
+				if utils.IsNodeMap(value) {
+					for p := 0; p < len(value.Content)-1; p += 2 {
+						checkSchema(value.Content[p], value.Content[p+1],
+							fmt.Sprintf("%s.%s", childPath, value.Content[p].Value), depth+1)
+					}
+				}
+			case "items", "prefixItems":
+				if utils.IsNodeArray(value) {
+					for x, s := range value.Content {
+						checkSchema(key, s, fmt.Sprintf("%s[%d]", childPath, x), depth+1)
+					}
+				} else {
+					checkSchema(key, value, childPath, depth+1)
+				}
+			case "additionalProperties":
+				checkSchema(key, value, childPath, depth+1)
+			case "allOf", "oneOf", "anyOf":
+				if utils.IsNodeArray(value) {
+					for x, s := range value.Content {
+						checkSchema(key, s, fmt.Sprintf("%s[%d]", childPath, x), depth)
+					}
+				}
+			case "not", "if", "then", "else":
+				checkSchema(key, value, childPath, depth)
+			}
+		}
+	}
+
+	// walk the document looking for schemas, component schemas, definitions and any 'schema' keys.
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				switch {
+				case key.Value == "example" || key.Value == "examples":
+					continue
+				case key.Value == "schema":
+					checkSchema(key, value, childPath, 0)
+				case (path == "$.components" && key.Value == "schemas") || (path == "$" && key.Value == "definitions"):
+					if utils.IsNodeMap(value) {
+						for s := 0; s < len(value.Content)-1; s += 2 {
+							checkSchema(value.Content[s], value.Content[s+1],
+								fmt.Sprintf("%s.%s", childPath, value.Content[s].Value), 0)
+						}
+					}
+				default:
+					walk(value, childPath)
+				}
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
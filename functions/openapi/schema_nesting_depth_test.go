@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var schemaNestingDepthTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    toppings:
+                      type: array
+                      items:
+                        allOf:
+                          - type: object
+                            properties:
+                              name:
+                                type: string
+components:
+  schemas:
+    Tree:
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Tree'
+    Order:
+      type: object
+      properties:
+        pizza:
+          type: object
+          properties:
+            crust:
+              type: object
+              additionalProperties:
+                type: object
+                properties:
+                  flour:
+                    type: string`
+
+func TestSchemaNestingDepth_GetSchema(t *testing.T) {
+	def := SchemaNestingDepth{}
+	assert.Equal(t, "schema_nesting_depth", def.GetSchema().Name)
+}
+
+func TestSchemaNestingDepth_RunRule(t *testing.T) {
+	def := SchemaNestingDepth{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestSchemaNestingDepth_RunRule_Success(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(schemaNestingDepthTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "schema_nesting_depth", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := SchemaNestingDepth{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
+
+func TestSchemaNestingDepth_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(schemaNestingDepthTestSpec), path)
+
+	opts := map[string]interface{}{
+		"maxDepth": 3,
+	}
+	rule := buildOpenApiTestRuleAction(path, "schema_nesting_depth", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := SchemaNestingDepth{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "schema is nested 4 levels deep, the maximum is 3, move it into a component and reference it",
+		res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get.responses.200.content.application/json.schema.items.properties.toppings."+
+		"items.allOf[0].properties.name", res[0].Path)
+	assert.Equal(t, 21, res[0].StartNode.Line)
+	assert.Equal(t, "$.components.schemas.Order.properties.pizza.properties.crust.additionalProperties."+
+		"properties.flour", res[1].Path)
+}
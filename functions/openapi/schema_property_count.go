@@ -0,0 +1,96 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaPropertyCount checks schemas don't have too many properties, a schema with hundreds of properties generates
+// client models nobody can use (or review). `allOf` composition is additive, so the properties of a schema are
+// counted together with those of its `allOf` members (references are followed, a property defined by more than one
+// member is counted once), a schema composed of small members can still be too large. Schemas can have at most
+// `maxProperties` (defaults to 50) properties.
+type SchemaPropertyCount struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the SchemaPropertyCount rule.
+func (sp SchemaPropertyCount) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "schema_property_count",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "maxProperties",
+				Description: "the maximum number of properties a schema can have, with its allOf members (defaults to 50)",
+			},
+		},
+		ErrorMessage: "'schema_property_count' function has invalid options supplied. Example valid options are " +
+			"'maxProperties' = 30",
+	}
+}
+
+// RunRule will execute the SchemaPropertyCount rule, based on supplied context and a supplied []*yaml.Node slice.
+func (sp SchemaPropertyCount) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	maxProperties := getIntOption("maxProperties", context.Options, 50)
+
+	// collect adds the property names of a schema, and of its allOf members, to a set.
+	var collect func(schema *yaml.Node, names map[string]bool, seen map[*yaml.Node]bool)
+	collect = func(schema *yaml.Node, names map[string]bool, seen map[*yaml.Node]bool) {
+		if schema = resolveSchemaReference(root, schema); schema == nil || seen[schema] {
+			return
+		}
+		seen[schema] = true
+		if _, properties := utils.FindKeyNodeTop("properties", schema.Content); utils.IsNodeMap(properties) {
+			for i := 0; i < len(properties.Content)-1; i += 2 {
+				names[properties.Content[i].Value] = true
+			}
+		}
+		if _, allOf := utils.FindKeyNodeTop("allOf", schema.Content); allOf != nil && utils.IsNodeArray(allOf) {
+			for _, member := range allOf.Content {
+				collect(member, names, seen)
+			}
+		}
+	}
+
+	walker.ForEachSchema(root, func(schema walker.Schema) bool {
+		if schema.Ref != "" {
+			return !context.IsCancelled()
+		}
+		names := make(map[string]bool)
+		collect(schema.Node, names, make(map[*yaml.Node]bool))
+		if len(names) > maxProperties {
+			startNode := schema.KeyNode
+			if startNode == nil {
+				startNode = schema.Node
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("schema has %d properties, more than the maximum of %d", len(names),
+					maxProperties),
+				StartNode: startNode,
+				EndNode:   utils.FindLastChildNodeWithLevel(schema.Node, 0),
+				Path:      schema.JSONPath,
+				Rule:      context.Rule,
+			})
+		}
+		return !context.IsCancelled()
+	})
+	return results
+}
@@ -0,0 +1,92 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var schemaPropertyCountTestSpec = `openapi: 3.1.0
+components:
+  schemas:
+    Base:
+      type: object
+      properties:
+        id:
+          type: string
+        created:
+          type: string
+    Pizza:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          properties:
+            id:
+              type: string
+            name:
+              type: string
+            crust:
+              type: string
+    Topping:
+      type: object
+      properties:
+        name:
+          type: string
+        vegan:
+          type: boolean
+        spicy:
+          type: boolean
+        price:
+          type: number`
+
+func TestSchemaPropertyCount_GetSchema(t *testing.T) {
+	def := SchemaPropertyCount{}
+	assert.Equal(t, "schema_property_count", def.GetSchema().Name)
+}
+
+func TestSchemaPropertyCount_RunRule(t *testing.T) {
+	def := SchemaPropertyCount{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestSchemaPropertyCount_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(schemaPropertyCountTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["maxProperties"] = 3
+
+	rule := buildOpenApiTestRuleAction(path, "schema_property_count", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := SchemaPropertyCount{}
+	res := def.RunRule(nodes, ctx)
+
+	// the members of Pizza are small enough, together they have 4 properties (`id` is counted once).
+	assert.Len(t, res, 2)
+	assert.Equal(t, "schema has 4 properties, more than the maximum of 3", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza", res[0].Path)
+	assert.Equal(t, 11, res[0].StartNode.Line)
+	assert.Equal(t, "$.components.schemas.Topping", res[1].Path)
+}
+
+func TestSchemaPropertyCount_RunRule_Success(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(schemaPropertyCountTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "schema_property_count", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := SchemaPropertyCount{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
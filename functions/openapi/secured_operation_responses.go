@@ -0,0 +1,118 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SecuredOperationResponses checks that operations protected by a security requirement document what happens when
+// the client is not authorized, with a `401` response (and a `403` response, if the `require403` option is set).
+// A `4XX` range response covers both. Operations inherit the root `security` requirement unless they define their
+// own, operations that opt out of security (`security: []`) are not checked.
+type SecuredOperationResponses struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the SecuredOperationResponses rule.
+func (so SecuredOperationResponses) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "secured_operation_responses",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "require403",
+				Description: "secured operations must also define a 403 response (defaults to false)",
+			},
+		},
+		ErrorMessage: "'secured_operation_responses' function has invalid options supplied. Example valid options " +
+			"are 'require403' = true",
+	}
+}
+
+// RunRule will execute the SecuredOperationResponses rule, based on supplied context and a supplied []*yaml.Node slice.
+func (so SecuredOperationResponses) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	required := []string{"401"}
+	if getBoolOption("require403", context.Options, false) {
+		required = append(required, "403")
+	}
+
+	// a security requirement secures an operation if it requires at least one scheme.
+	secured := func(security *yaml.Node) bool {
+		if !utils.IsNodeArray(security) {
+			return false
+		}
+		for _, requirement := range security.Content {
+			if utils.IsNodeMap(requirement) && len(requirement.Content) > 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	_, rootSecurity := utils.FindKeyNodeTop("security", root.Content)
+	_, paths := utils.FindKeyNodeTop("paths", root.Content)
+	if !utils.IsNodeMap(paths) {
+		return results
+	}
+	for i := 0; i < len(paths.Content)-1; i += 2 {
+		if context.IsCancelled() {
+			break
+		}
+		opPath, pathItem := paths.Content[i].Value, paths.Content[i+1]
+		for m := 0; m < len(pathItem.Content)-1; m += 2 {
+			opKey, operation := pathItem.Content[m], pathItem.Content[m+1]
+			if !isOperationMethod(opKey.Value) || !utils.IsNodeMap(operation) {
+				continue
+			}
+			security := rootSecurity
+			if _, opSecurity := utils.FindKeyNodeTop("security", operation.Content); opSecurity != nil {
+				security = opSecurity
+			}
+			if !secured(security) {
+				continue
+			}
+
+			codes := make(map[string]bool)
+			if _, responses := utils.FindKeyNodeTop("responses", operation.Content); utils.IsNodeMap(responses) {
+				for r := 0; r < len(responses.Content)-1; r += 2 {
+					codes[strings.ToUpper(responses.Content[r].Value)] = true
+				}
+			}
+			var missing []string
+			for _, code := range required {
+				if !codes[code] && !codes["4XX"] {
+					missing = append(missing, code)
+				}
+			}
+			if len(missing) == 0 {
+				continue
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("secured operation `%s` at path `%s` is missing a `%s` response", opKey.Value,
+					opPath, strings.Join(missing, "` and `")),
+				StartNode: opKey,
+				EndNode:   utils.FindLastChildNodeWithLevel(operation, 0),
+				Path:      fmt.Sprintf("$.paths.%s.%s", opPath, opKey.Value),
+				Rule:      context.Rule,
+			})
+		}
+	}
+	return results
+}
@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var securedOperationResponsesTestSpec = `openapi: 3.1.0
+security:
+  - apiKey: []
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+    post:
+      responses:
+        "401":
+          description: unauthorized
+    put:
+      responses:
+        4xx:
+          description: client error
+  /health:
+    get:
+      security: []
+      responses:
+        "200":
+          description: ok
+    post:
+      security:
+        - {}
+      responses:
+        "200":
+          description: ok
+    delete:
+      security:
+        - oauth: [admin]
+      responses:
+        "403":
+          description: forbidden`
+
+func TestSecuredOperationResponses_GetSchema(t *testing.T) {
+	def := SecuredOperationResponses{}
+	assert.Equal(t, "secured_operation_responses", def.GetSchema().Name)
+}
+
+func TestSecuredOperationResponses_RunRule(t *testing.T) {
+	def := SecuredOperationResponses{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestSecuredOperationResponses_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(securedOperationResponsesTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "secured_operation_responses", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := SecuredOperationResponses{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "secured operation `get` at path `/pizza` is missing a `401` response", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.get", res[0].Path)
+	assert.Equal(t, 6, res[0].StartNode.Line)
+	assert.Equal(t, "secured operation `delete` at path `/health` is missing a `401` response", res[1].Message)
+}
+
+func TestSecuredOperationResponses_RunRule_Require403(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(securedOperationResponsesTestSpec), path)
+
+	opts := map[string]interface{}{
+		"require403": true,
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "secured_operation_responses", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := SecuredOperationResponses{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "secured operation `get` at path `/pizza` is missing a `401` and `403` response", res[0].Message)
+	assert.Equal(t, "secured operation `post` at path `/pizza` is missing a `403` response", res[1].Message)
+	assert.Equal(t, "$.paths./health.delete", res[2].Path)
+}
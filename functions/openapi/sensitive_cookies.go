@@ -0,0 +1,99 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SensitiveCookies checks `cookie` parameters don't carry credentials, like tokens or session identifiers. Browsers
+// send cookies with every request, so a credential in a cookie parameter is open to cross-site request forgery, and
+// the parameter hides that the operation is secured. Credentials in a cookie should be described by an `apiKey`
+// security scheme (with `in: cookie`), cookies named by one of them are not reported. Parameter names are matched,
+// without case, against the glob patterns in the `patterns` option (like `*token*` or `*session*`).
+type SensitiveCookies struct {
+}
+
+var defaultSensitiveCookiePatterns = []string{"*token*", "*session*", "*sess_id*", "*sid", "*secret*", "*password*",
+	"*auth*", "*jwt*", "*apikey*", "*api_key*", "*api-key*", "*credential*"}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the SensitiveCookies rule.
+func (sc SensitiveCookies) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "sensitive_cookies",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "patterns",
+				Description: "glob patterns matching the names of cookies that carry sensitive data, like '*token*'",
+			},
+		},
+		ErrorMessage: "'sensitive_cookies' function has invalid options supplied. Example valid options are " +
+			"'patterns' = ['*token*', '*session*']",
+	}
+}
+
+// RunRule will execute the SensitiveCookies rule, based on supplied context and a supplied []*yaml.Node slice.
+func (sc SensitiveCookies) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	patterns := getStringArrayOption("patterns", context.Options, defaultSensitiveCookiePatterns)
+
+	// cookies described by an apiKey security scheme are exempt.
+	schemeCookies := make(map[string]bool)
+	if _, components := utils.FindKeyNodeTop("components", root.Content); utils.IsNodeMap(components) {
+		_, schemes := utils.FindKeyNodeTop("securitySchemes", components.Content)
+		if schemes != nil && utils.IsNodeMap(schemes) {
+			for i := 0; i < len(schemes.Content)-1; i += 2 {
+				scheme := resolveSchemaReference(root, schemes.Content[i+1])
+				if scheme == nil {
+					continue
+				}
+				_, in := utils.FindKeyNodeTop("in", scheme.Content)
+				_, name := utils.FindKeyNodeTop("name", scheme.Content)
+				if in != nil && in.Value == "cookie" && name != nil {
+					schemeCookies[strings.ToLower(name.Value)] = true
+				}
+			}
+		}
+	}
+
+	forEachParameter(root, func(param *yaml.Node, paramPath string) bool {
+		_, in := utils.FindKeyNodeTop("in", param.Content)
+		nameKey, name := utils.FindKeyNodeTop("name", param.Content)
+		if in == nil || in.Value != "cookie" || name == nil || schemeCookies[strings.ToLower(name.Value)] {
+			return !context.IsCancelled()
+		}
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name.Value)); ok {
+				results = append(results, model.RuleFunctionResult{
+					Message: fmt.Sprintf("cookie parameter `%s` looks like it carries sensitive data, describe it "+
+						"with an `apiKey` security scheme (`in: cookie`) instead", name.Value),
+					StartNode: nameKey,
+					EndNode:   name,
+					Path:      paramPath,
+					Rule:      context.Rule,
+				})
+				break
+			}
+		}
+		return !context.IsCancelled()
+	})
+	return results
+}
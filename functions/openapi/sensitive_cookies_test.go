@@ -0,0 +1,85 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var sensitiveCookiesTestSpec = `openapi: 3.1.0
+paths:
+  /pizzas:
+    get:
+      parameters:
+        - name: access_token
+          in: cookie
+        - name: theme
+          in: cookie
+        - name: SESSIONID
+          in: cookie
+        - name: token
+          in: query
+        - $ref: '#/components/parameters/Tracking'
+components:
+  parameters:
+    Tracking:
+      name: tracking-auth
+      in: cookie
+  securitySchemes:
+    session:
+      type: apiKey
+      in: cookie
+      name: sessionId`
+
+func TestSensitiveCookies_GetSchema(t *testing.T) {
+	def := SensitiveCookies{}
+	assert.Equal(t, "sensitive_cookies", def.GetSchema().Name)
+}
+
+func TestSensitiveCookies_RunRule(t *testing.T) {
+	def := SensitiveCookies{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestSensitiveCookies_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(sensitiveCookiesTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "sensitive_cookies", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := SensitiveCookies{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "$.components.parameters.Tracking", res[0].Path)
+	assert.Equal(t, "cookie parameter `access_token` looks like it carries sensitive data, describe it with an "+
+		"`apiKey` security scheme (`in: cookie`) instead", res[1].Message)
+	assert.Equal(t, "$.paths./pizzas.get.parameters[0]", res[1].Path)
+	assert.Equal(t, 6, res[1].StartNode.Line)
+}
+
+func TestSensitiveCookies_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(sensitiveCookiesTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["patterns"] = []interface{}{"theme"}
+
+	rule := buildOpenApiTestRuleAction(path, "sensitive_cookies", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := SensitiveCookies{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.paths./pizzas.get.parameters[1]", res[0].Path)
+}
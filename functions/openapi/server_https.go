@@ -0,0 +1,171 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ServerHTTPS checks server URLs use `https`, servers can be defined at the root, path and operation levels, all of
+// them are checked. When the scheme of a URL is a server variable (like `{scheme}://api.example.com`), the URL is
+// only reported if the `default` or a member of the `enum` of the variable is `http`. Servers for local development
+// are allowed to use `http`, hosts are matched against the glob patterns in the `allowedHosts` option, which are
+// `localhost`, `*.localhost`, `127.0.0.1` and `::1` by default. Relative URLs are not checked.
+type ServerHTTPS struct {
+}
+
+var defaultServerHTTPSAllowedHosts = []string{"localhost", "*.localhost", "127.0.0.1", "::1"}
+
+var serverVariable = regexp.MustCompile(`\{([^{}]+)}`)
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ServerHTTPS rule.
+func (sh ServerHTTPS) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "server_https",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "allowedHosts",
+				Description: "glob patterns matching hosts that may use 'http', like 'localhost' or '*.dev.example.com'",
+			},
+		},
+		ErrorMessage: "'server_https' function has invalid options supplied. Example valid options are " +
+			"'allowedHosts' = ['localhost', '*.internal']",
+	}
+}
+
+// serverVariableValues returns the values a server variable can have, its default and the members of its enum.
+func serverVariableValues(variables *yaml.Node, name string) []string {
+	if variables == nil || !utils.IsNodeMap(variables) {
+		return nil
+	}
+	_, variable := utils.FindKeyNodeTop(name, variables.Content)
+	if variable == nil || !utils.IsNodeMap(variable) {
+		return nil
+	}
+	var values []string
+	if _, def := utils.FindKeyNodeTop("default", variable.Content); def != nil {
+		values = append(values, def.Value)
+	}
+	if _, enum := utils.FindKeyNodeTop("enum", variable.Content); enum != nil && utils.IsNodeArray(enum) {
+		for _, e := range enum.Content {
+			values = append(values, e.Value)
+		}
+	}
+	return values
+}
+
+// RunRule will execute the ServerHTTPS rule, based on supplied context and a supplied []*yaml.Node slice.
+func (sh ServerHTTPS) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	allowedHosts := getStringArrayOption("allowedHosts", context.Options, defaultServerHTTPSAllowedHosts)
+
+	isAllowedHost := func(address string, variables *yaml.Node) bool {
+		// hosts are matched with the default of each variable.
+		address = serverVariable.ReplaceAllStringFunc(address, func(v string) string {
+			if values := serverVariableValues(variables, strings.Trim(v, "{}")); len(values) > 0 {
+				return values[0]
+			}
+			return v
+		})
+		u, err := url.Parse("https://" + address)
+		if err != nil {
+			return false
+		}
+		host := strings.ToLower(u.Hostname())
+		for _, allowed := range allowedHosts {
+			if ok, _ := path.Match(strings.ToLower(allowed), host); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	checkServers := func(servers *yaml.Node, basePath string) {
+		if servers == nil || !utils.IsNodeArray(servers) {
+			return
+		}
+		for s, server := range servers.Content {
+			urlKey, urlNode := utils.FindKeyNodeTop("url", server.Content)
+			if urlNode == nil {
+				continue
+			}
+			_, variables := utils.FindKeyNodeTop("variables", server.Content)
+			scheme, address, found := strings.Cut(urlNode.Value, "://")
+			if !found || isAllowedHost(address, variables) {
+				continue
+			}
+
+			var msg string
+			if v := serverVariable.FindStringSubmatch(scheme); v != nil {
+				for _, value := range serverVariableValues(variables, v[1]) {
+					if strings.EqualFold(serverVariable.ReplaceAllString(scheme, value), "http") {
+						msg = fmt.Sprintf("server URL `%s` can use `http`, through the server variable `%s`",
+							urlNode.Value, v[1])
+						break
+					}
+				}
+			} else if strings.EqualFold(scheme, "http") {
+				msg = fmt.Sprintf("server URL `%s` uses `http`, servers must use `https`", urlNode.Value)
+			}
+			if msg == "" {
+				continue
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: urlKey,
+				EndNode:   urlNode,
+				Path:      fmt.Sprintf("%s.servers[%d]", basePath, s),
+				Rule:      context.Rule,
+			})
+		}
+	}
+
+	_, servers := utils.FindKeyNodeTop("servers", root.Content)
+	checkServers(servers, "$")
+
+	_, paths := utils.FindKeyNodeTop("paths", root.Content)
+	if paths == nil || !utils.IsNodeMap(paths) {
+		return results
+	}
+	for i := 0; i < len(paths.Content)-1; i += 2 {
+		if context.IsCancelled() {
+			break
+		}
+		pathItem := paths.Content[i+1]
+		if !utils.IsNodeMap(pathItem) {
+			continue
+		}
+		pathBase := fmt.Sprintf("$.paths.%s", paths.Content[i].Value)
+		_, pathServers := utils.FindKeyNodeTop("servers", pathItem.Content)
+		checkServers(pathServers, pathBase)
+
+		for m := 0; m < len(pathItem.Content)-1; m += 2 {
+			if !isOperationMethod(pathItem.Content[m].Value) || !utils.IsNodeMap(pathItem.Content[m+1]) {
+				continue
+			}
+			_, opServers := utils.FindKeyNodeTop("servers", pathItem.Content[m+1].Content)
+			checkServers(opServers, fmt.Sprintf("%s.%s", pathBase, pathItem.Content[m].Value))
+		}
+	}
+	return results
+}
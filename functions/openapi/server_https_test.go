@@ -0,0 +1,91 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var serverHTTPSTestSpec = `openapi: 3.1.0
+servers:
+  - url: https://api.example.com
+  - url: http://api.example.com
+  - url: http://localhost:8080
+  - url: http://{env}.localhost
+    variables:
+      env:
+        default: pizza
+  - url: '{scheme}://api.example.com'
+    variables:
+      scheme:
+        default: https
+        enum: [https, http]
+  - url: '{scheme}://secure.example.com'
+    variables:
+      scheme:
+        default: https
+        enum: [https]
+  - url: /api
+paths:
+  /pizza:
+    servers:
+      - url: http://pizza.example.com
+    get:
+      servers:
+        - url: http://dev.example.com`
+
+func TestServerHTTPS_GetSchema(t *testing.T) {
+	def := ServerHTTPS{}
+	assert.Equal(t, "server_https", def.GetSchema().Name)
+}
+
+func TestServerHTTPS_RunRule(t *testing.T) {
+	def := ServerHTTPS{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestServerHTTPS_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(serverHTTPSTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "server_https", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := ServerHTTPS{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "server URL `http://api.example.com` uses `http`, servers must use `https`", res[0].Message)
+	assert.Equal(t, "$.servers[1]", res[0].Path)
+	assert.Equal(t, 4, res[0].StartNode.Line)
+	assert.Equal(t, "server URL `{scheme}://api.example.com` can use `http`, through the server variable `scheme`",
+		res[1].Message)
+	assert.Equal(t, "$.servers[4]", res[1].Path)
+	assert.Equal(t, "$.paths./pizza.servers[0]", res[2].Path)
+	assert.Equal(t, "$.paths./pizza.get.servers[0]", res[3].Path)
+}
+
+func TestServerHTTPS_RunRule_AllowedHosts(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(serverHTTPSTestSpec), path)
+
+	opts := map[string]interface{}{"allowedHosts": []interface{}{"*.example.com"}}
+	rule := buildOpenApiTestRuleAction(path, "server_https", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := ServerHTTPS{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "server URL `http://localhost:8080` uses `http`, servers must use `https`", res[0].Message)
+	assert.Equal(t, "$.servers[2]", res[0].Path)
+	assert.Equal(t, "$.servers[3]", res[1].Path)
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ServerVariables checks that server variables that declare an enum, also declare a default value that is a member
+// of that enum. Servers can be defined at the root, path and operation levels, all of them are checked.
+type ServerVariables struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ServerVariables rule.
+func (sv ServerVariables) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: "server_variables"}
+}
+
+// RunRule will execute the ServerVariables rule, based on supplied context and a supplied []*yaml.Node slice.
+func (sv ServerVariables) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	checkServers := func(serversNode *yaml.Node, basePath string) {
+		if serversNode == nil || !utils.IsNodeArray(serversNode) {
+			return
+		}
+		for s, server := range serversNode.Content {
+			_, varsNode := utils.FindKeyNodeTop("variables", server.Content)
+			if varsNode == nil || !utils.IsNodeMap(varsNode) {
+				continue
+			}
+			for v := 0; v < len(varsNode.Content); v += 2 {
+				if v+1 >= len(varsNode.Content) {
+					break
+				}
+				varKey := varsNode.Content[v]
+				variable := varsNode.Content[v+1]
+				path := fmt.Sprintf("%s.servers[%d].variables.%s", basePath, s, varKey.Value)
+
+				_, enumNode := utils.FindKeyNodeTop("enum", variable.Content)
+				if enumNode == nil || !utils.IsNodeArray(enumNode) {
+					continue
+				}
+				defKey, defNode := utils.FindKeyNodeTop("default", variable.Content)
+				if defNode == nil {
+					results = append(results, model.RuleFunctionResult{
+						Message: fmt.Sprintf("server variable `%s` declares an `enum` but has no `default` value",
+							varKey.Value),
+						StartNode: varKey,
+						EndNode:   utils.FindLastChildNodeWithLevel(variable, 0),
+						Path:      path,
+						Rule:      context.Rule,
+					})
+					continue
+				}
+				found := false
+				for _, e := range enumNode.Content {
+					if e.Value == defNode.Value {
+						found = true
+						break
+					}
+				}
+				if !found {
+					results = append(results, model.RuleFunctionResult{
+						Message: fmt.Sprintf("server variable `%s` default value `%s` is not a member of its `enum`",
+							varKey.Value, defNode.Value),
+						StartNode: defKey,
+						EndNode:   defNode,
+						Path:      fmt.Sprintf("%s.default", path),
+						Rule:      context.Rule,
+					})
+				}
+			}
+		}
+	}
+
+	// root servers
+	for _, node := range nodes {
+		root := node
+		if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+			root = root.Content[0]
+		}
+		_, servers := utils.FindKeyNodeTop("servers", root.Content)
+		checkServers(servers, "$")
+	}
+
+	// path and operation servers
+	if context.Index != nil && context.Index.GetPathsNode() != nil {
+		ops := context.Index.GetPathsNode().Content
+		var opPath string
+		for i, op := range ops {
+			if context.IsCancelled() {
+				break
+			}
+			if i%2 == 0 {
+				opPath = op.Value
+				continue
+			}
+			pathBase := fmt.Sprintf("$.paths.%s", opPath)
+			_, pathServers := utils.FindKeyNodeTop("servers", op.Content)
+			checkServers(pathServers, pathBase)
+
+			for m := 0; m < len(op.Content); m += 2 {
+				if m+1 >= len(op.Content) || !isOperationMethod(op.Content[m].Value) {
+					continue
+				}
+				_, opServers := utils.FindKeyNodeTop("servers", op.Content[m+1].Content)
+				checkServers(opServers, fmt.Sprintf("%s.%s", pathBase, op.Content[m].Value))
+			}
+		}
+	}
+	return results
+}
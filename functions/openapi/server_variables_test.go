@@ -0,0 +1,99 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestServerVariables_GetSchema(t *testing.T) {
+	def := ServerVariables{}
+	assert.Equal(t, "server_variables", def.GetSchema().Name)
+}
+
+func TestServerVariables_RunRule(t *testing.T) {
+	def := ServerVariables{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestServerVariables_RunRule_Success(t *testing.T) {
+
+	yml := `openapi: 3.0.1
+servers:
+  - url: https://{region}.quobix.com/{version}
+    variables:
+      region:
+        enum: [eu, us]
+        default: eu
+      version:
+        default: v1`
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "server_variables", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := ServerVariables{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
+
+func TestServerVariables_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.0.1
+servers:
+  - url: https://{region}.quobix.com
+    variables:
+      region:
+        enum: [eu, us]
+        default: asia
+paths:
+  /pizza:
+    servers:
+      - url: https://{size}.quobix.com
+        variables:
+          size:
+            enum: [small, large]
+    get:
+      servers:
+        - url: https://{crust}.quobix.com
+          variables:
+            crust:
+              enum: [thin, deep]
+              default: stuffed`
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "server_variables", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := ServerVariables{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "$.servers[0].variables.region.default", res[0].Path)
+	assert.Equal(t, "server variable `region` default value `asia` is not a member of its `enum`", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.servers[0].variables.size", res[1].Path)
+	assert.Equal(t, "server variable `size` declares an `enum` but has no `default` value", res[1].Message)
+	assert.Equal(t, "$.paths./pizza.get.servers[0].variables.crust.default", res[2].Path)
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SetArrays checks that array properties that represent sets (like `tags` or `roles`) declare `uniqueItems: true`.
+// Properties are sets if their name matches one of the `patterns` option (glob patterns, matched without case).
+// Arrays of objects are skipped, unless the `includeObjects` option is set, it's not clear when two objects are
+// the same. References to schemas are followed.
+type SetArrays struct {
+}
+
+// defaultSetPatterns are the property names that are sets, unless configured otherwise.
+var defaultSetPatterns = []string{"tags", "*tags", "roles", "*roles", "permissions", "*permissions", "scopes",
+	"*scopes", "labels", "*labels", "categories", "*categories"}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the SetArrays rule.
+func (sa SetArrays) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "set_arrays",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "patterns",
+				Description: "glob patterns of the names of properties that are sets",
+			},
+			{
+				Name:        "includeObjects",
+				Description: "check arrays of objects too (defaults to false)",
+			},
+		},
+		ErrorMessage: "'set_arrays' function has invalid options supplied. Example valid options are " +
+			"'patterns' = ['tags', '*Roles'] or 'includeObjects' = true",
+	}
+}
+
+// RunRule will execute the SetArrays rule, based on supplied context and a supplied []*yaml.Node slice.
+func (sa SetArrays) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	patterns := getStringArrayOption("patterns", context.Options, defaultSetPatterns)
+	includeObjects := getBoolOption("includeObjects", context.Options, false)
+
+	isSet := func(name string) bool {
+		name = strings.ToLower(name)
+		for _, p := range patterns {
+			if match, _ := path.Match(strings.ToLower(p), name); match {
+				return true
+			}
+		}
+		return false
+	}
+
+	checkProperties := func(properties *yaml.Node, propertiesPath string) {
+		for i := 0; i < len(properties.Content)-1; i += 2 {
+			name, property := properties.Content[i], properties.Content[i+1]
+			if !isSet(name.Value) {
+				continue
+			}
+			schema := resolveSchemaReference(root, property)
+			if schema == nil {
+				continue
+			}
+			isArray := false
+			for _, t := range schemaTypesOf(schema) {
+				isArray = isArray || t == "array"
+			}
+			if !isArray {
+				continue
+			}
+			if _, unique := utils.FindKeyNodeTop("uniqueItems", schema.Content); unique != nil &&
+				unique.Value == "true" {
+				continue
+			}
+			if !includeObjects {
+				_, items := utils.FindKeyNodeTop("items", schema.Content)
+				if items = resolveSchemaReference(root, items); items != nil {
+					_, itemProperties := utils.FindKeyNodeTop("properties", items.Content)
+					isObject := itemProperties != nil
+					for _, t := range schemaTypesOf(items) {
+						isObject = isObject || t == "object"
+					}
+					if isObject {
+						continue
+					}
+				}
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("property `%s` is a set, but the array does not declare `uniqueItems: true`",
+					name.Value),
+				StartNode: name,
+				EndNode:   utils.FindLastChildNodeWithLevel(property, 0),
+				Path:      fmt.Sprintf("%s.%s", propertiesPath, name.Value),
+				Rule:      context.Rule,
+			})
+		}
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				if key.Value == "example" || key.Value == "examples" {
+					continue
+				}
+				if key.Value == "properties" && utils.IsNodeMap(value) {
+					checkProperties(value, childPath)
+				}
+				walk(value, childPath)
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var setArraysTestSpec = `openapi: 3.1.0
+components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        tags:
+          type: array
+          items:
+            type: string
+        userRoles:
+          $ref: '#/components/schemas/Roles'
+        scopes:
+          type: array
+          uniqueItems: true
+          items:
+            type: string
+        labels:
+          type: array
+          items:
+            $ref: '#/components/schemas/Label'
+        toppings:
+          type: array
+          items:
+            type: string
+        categories:
+          type: string
+    Roles:
+      type: [array, "null"]
+      items:
+        type: string
+    Label:
+      type: object
+      properties:
+        name:
+          type: string`
+
+func TestSetArrays_GetSchema(t *testing.T) {
+	def := SetArrays{}
+	assert.Equal(t, "set_arrays", def.GetSchema().Name)
+}
+
+func TestSetArrays_RunRule(t *testing.T) {
+	def := SetArrays{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestSetArrays_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(setArraysTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "set_arrays", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := SetArrays{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "property `tags` is a set, but the array does not declare `uniqueItems: true`", res[0].Message)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.tags", res[0].Path)
+	assert.Equal(t, 7, res[0].StartNode.Line)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.userRoles", res[1].Path)
+}
+
+func TestSetArrays_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(setArraysTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["patterns"] = []interface{}{"toppings", "labels"}
+	opts["includeObjects"] = true
+
+	rule := buildOpenApiTestRuleAction(path, "set_arrays", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := SetArrays{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.labels", res[0].Path)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.toppings", res[1].Path)
+}
@@ -0,0 +1,182 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// The SPDX license list (https://spdx.org/licenses), its deprecated licenses and its license exceptions, with one
+// identifier per line.
+var (
+	//go:embed spdx/licenses.txt
+	spdxLicenseList string
+
+	//go:embed spdx/deprecated.txt
+	spdxDeprecatedList string
+
+	//go:embed spdx/exceptions.txt
+	spdxExceptionList string
+)
+
+var spdxLicenses, spdxDeprecated, spdxExceptions = spdxSet(spdxLicenseList), spdxSet(spdxDeprecatedList),
+	spdxSet(spdxExceptionList)
+
+// spdxSet builds a set of SPDX identifiers, from a list with one identifier per line. Identifiers are matched
+// without case.
+func spdxSet(list string) map[string]bool {
+	set := make(map[string]bool)
+	for _, id := range strings.Fields(list) {
+		set[strings.ToLower(id)] = true
+	}
+	return set
+}
+
+// SPDXLicense checks that the `identifier` of the license in `info` (OpenAPI 3.1) is a valid SPDX license
+// expression: a license from the SPDX license list (or a `LicenseRef-`), optionally followed by `+`, or licenses
+// combined with `AND`, `OR`, `WITH` (followed by a license exception) and parentheses. Deprecated licenses are
+// reported too, they have been replaced by newer identifiers.
+type SPDXLicense struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the SPDXLicense rule.
+func (sl SPDXLicense) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "spdx_license",
+	}
+}
+
+// RunRule will execute the SPDXLicense rule, based on supplied context and a supplied []*yaml.Node slice.
+func (sl SPDXLicense) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 || context.IsCancelled() {
+		return nil
+	}
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if !utils.IsNodeMap(root) {
+		return nil
+	}
+	if _, version := utils.FindKeyNodeTop("openapi", root.Content); version == nil ||
+		!strings.HasPrefix(version.Value, "3.1") {
+		return nil
+	}
+
+	_, info := utils.FindKeyNodeTop("info", root.Content)
+	if !utils.IsNodeMap(info) {
+		return nil
+	}
+	_, license := utils.FindKeyNodeTop("license", info.Content)
+	if !utils.IsNodeMap(license) {
+		return nil
+	}
+	identifierKey, identifier := utils.FindKeyNodeTop("identifier", license.Content)
+	if identifier == nil || identifier.Kind != yaml.ScalarNode {
+		return nil
+	}
+
+	msg := checkSPDXExpression(identifier.Value)
+	if msg == "" {
+		return nil
+	}
+	return []model.RuleFunctionResult{{
+		Message:   msg,
+		StartNode: identifierKey,
+		EndNode:   identifier,
+		Path:      "$.info.license.identifier",
+		Rule:      context.Rule,
+	}}
+}
+
+// checkSPDXExpression parses an SPDX license expression, and returns why it's not valid, or an empty string.
+func checkSPDXExpression(expression string) string {
+	invalid := fmt.Sprintf("license `identifier` `%s` is not a valid SPDX license expression", expression)
+
+	// parentheses are tokens of their own, even when they are not separated by spaces.
+	tokens := strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression))
+	if len(tokens) == 0 {
+		return invalid
+	}
+
+	pos := 0
+	var problem string
+	var compound func() bool
+
+	// simple is a license (or a license reference), optionally followed by `WITH` and an exception.
+	simple := func() bool {
+		if pos >= len(tokens) {
+			return false
+		}
+		if tokens[pos] == "(" {
+			pos++
+			if !compound() || pos >= len(tokens) || tokens[pos] != ")" {
+				return false
+			}
+			pos++
+			return true
+		}
+		id := tokens[pos]
+		if id == ")" || id == "AND" || id == "OR" || id == "WITH" {
+			return false
+		}
+		pos++
+		ref := id
+		if i := strings.Index(ref, ":"); strings.HasPrefix(ref, "DocumentRef-") && i > 0 {
+			ref = ref[i+1:]
+		}
+		switch {
+		case strings.HasPrefix(ref, "LicenseRef-") && len(ref) > len("LicenseRef-"):
+		case spdxLicenses[strings.ToLower(strings.TrimSuffix(id, "+"))]:
+		case spdxDeprecated[strings.ToLower(strings.TrimSuffix(id, "+"))]:
+			if problem == "" {
+				problem = fmt.Sprintf("SPDX license `%s` is deprecated", id)
+			}
+		default:
+			if problem == "" {
+				problem = fmt.Sprintf("license `identifier` uses `%s`, which is not an SPDX license", id)
+			}
+		}
+		if pos < len(tokens) && tokens[pos] == "WITH" {
+			pos++
+			if pos >= len(tokens) {
+				return false
+			}
+			exception := tokens[pos]
+			pos++
+			if !spdxExceptions[strings.ToLower(exception)] && problem == "" {
+				problem = fmt.Sprintf("license `identifier` uses `%s`, which is not an SPDX license exception",
+					exception)
+			}
+		}
+		return true
+	}
+
+	// compound is a list of expressions joined by `AND` or `OR`.
+	compound = func() bool {
+		if !simple() {
+			return false
+		}
+		for pos < len(tokens) && (tokens[pos] == "AND" || tokens[pos] == "OR") {
+			pos++
+			if !simple() {
+				return false
+			}
+		}
+		return true
+	}
+
+	if !compound() || pos != len(tokens) {
+		return invalid
+	}
+	return problem
+}
@@ -0,0 +1,91 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSPDXLicense_GetSchema(t *testing.T) {
+	def := SPDXLicense{}
+	assert.Equal(t, "spdx_license", def.GetSchema().Name)
+}
+
+func TestSPDXLicense_RunRule(t *testing.T) {
+	def := SPDXLicense{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestSPDXLicense_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+info:
+  license:
+    name: pizza
+    identifier: Pizza-1.0`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "spdx_license", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := SPDXLicense{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "license `identifier` uses `Pizza-1.0`, which is not an SPDX license", res[0].Message)
+	assert.Equal(t, "$.info.license.identifier", res[0].Path)
+	assert.Equal(t, 5, res[0].StartNode.Line)
+}
+
+func TestSPDXLicense_RunRule_Success(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+info:
+  license:
+    name: Apache 2.0
+    identifier: Apache-2.0`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "spdx_license", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := SPDXLicense{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
+
+func TestCheckSPDXExpression(t *testing.T) {
+	expressions := map[string]string{
+		"MIT": "",
+		"mit": "",
+		"GPL-2.0-or-later WITH Classpath-exception-2.0": "",
+		"Apache-2.0 OR MIT":                             "",
+		"(MIT AND BSD-3-Clause) OR Apache-2.0":          "",
+		"(MIT OR (BSD-2-Clause AND ISC))":               "",
+		"MPL-1.1+":                                      "",
+		"LicenseRef-Pizza":                              "",
+		"DocumentRef-spdx-tool:LicenseRef-Pizza":        "",
+		"GPL-2.0":                                       "SPDX license `GPL-2.0` is deprecated",
+		"MIT OR Pizza":                                  "license `identifier` uses `Pizza`, which is not an SPDX license",
+		"GPL-3.0-only WITH Pizza-exception": "license `identifier` uses `Pizza-exception`, which is not an SPDX " +
+			"license exception",
+		"MIT AND":        "license `identifier` `MIT AND` is not a valid SPDX license expression",
+		"(MIT":           "license `identifier` `(MIT` is not a valid SPDX license expression",
+		"MIT Apache-2.0": "license `identifier` `MIT Apache-2.0` is not a valid SPDX license expression",
+		"MIT WITH":       "license `identifier` `MIT WITH` is not a valid SPDX license expression",
+		"":               "license `identifier` `` is not a valid SPDX license expression",
+	}
+	for expression, msg := range expressions {
+		assert.Equal(t, msg, checkSPDXExpression(expression), expression)
+	}
+}
@@ -6,12 +6,13 @@ package openapi
 import (
 	"fmt"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"strconv"
 )
 
-// SuccessResponse is a rule that checks if an operation returns a code >= 200 and <= 400
+// SuccessResponse is a rule that checks if an operation returns a code >= 200 and <= 400, `2XX` and `3XX` ranges count.
 type SuccessResponse struct {
 }
 
@@ -42,6 +43,9 @@ func (sr SuccessResponse) RunRule(nodes []*yaml.Node, context model.RuleFunction
 		}
 
 		for j, operationNode := range pathNode.Content {
+			if context.IsCancelled() {
+				break
+			}
 
 			if utils.IsNodeStringValue(operationNode) {
 				currentPath = operationNode.Value
@@ -61,12 +65,10 @@ func (sr SuccessResponse) RunRule(nodes []*yaml.Node, context model.RuleFunction
 					if fieldNode != nil && valNode != nil {
 						var responseSeen bool
 						var responseInvalidType bool
-						var responseCode int
 						var invalidCodes []int
 						for _, response := range valNode.Content {
 							if utils.IsNodeStringValue(response) {
-								responseCode, _ = strconv.Atoi(response.Value)
-								if responseCode >= 200 && responseCode < 400 {
+								if class, _ := walker.StatusCodeClass(response.Value); class == 2 || class == 3 {
 									responseSeen = true
 								}
 							}
@@ -40,6 +40,24 @@ func TestSuccessResponse_RunRule_Success(t *testing.T) {
 	assert.Len(t, res, 0)
 }
 
+func TestSuccessResponse_RunRule_Range(t *testing.T) {
+
+	sampleYaml, _ := os.ReadFile("../../model/test_files/status-code-ranges.openapi.yaml")
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(sampleYaml))
+
+	nodes, _ := utils.FindNodes(sampleYaml, "$")
+
+	rule := buildOpenApiTestRuleAction(GetAllOperationsJSONPath(), "xor", "responses", nil)
+
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := SuccessResponse{}
+	ctx.SpecInfo = info
+	res := def.RunRule(nodes, ctx)
+	assert.Len(t, res, 0)
+}
+
 func TestSuccessResponse_TriggerFailure(t *testing.T) {
 
 	yml := `swagger: 2.0
@@ -0,0 +1,138 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SuccessStatusCodes checks the success (`2xx`) responses of operations use the status codes expected for their
+// method, like `200` for a `get`, `201` for a `post` and `204` for a `delete`. The codes of each method can be
+// replaced with the `expected` option (a map of method to codes). Asynchronous operations that accept work and
+// return `202` can be allowed with the `asyncMethods` option (methods), or the `asyncPaths` option (paths or glob
+// patterns, like `/jobs/*`). Ranges (like `2XX`) and methods without expected codes are not checked.
+type SuccessStatusCodes struct {
+}
+
+// defaultSuccessStatusCodes are the success codes expected for each method, unless configured.
+var defaultSuccessStatusCodes = map[string][]string{
+	"get":    {"200"},
+	"post":   {"201"},
+	"put":    {"200", "204"},
+	"patch":  {"200", "204"},
+	"delete": {"204"},
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the SuccessStatusCodes rule.
+func (sc SuccessStatusCodes) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "success_status_codes",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "expected",
+				Description: "a map of method to the success status codes its operations can return",
+			},
+			{
+				Name:        "asyncMethods",
+				Description: "methods whose operations can also return '202'",
+			},
+			{
+				Name:        "asyncPaths",
+				Description: "paths (or glob patterns) whose operations can also return '202'",
+			},
+		},
+		ErrorMessage: "'success_status_codes' function has invalid options supplied. Example valid options are " +
+			"'expected' = {'post': ['200', '201']}, 'asyncMethods' = ['post'] or 'asyncPaths' = ['/jobs/*']",
+	}
+}
+
+// RunRule will execute the SuccessStatusCodes rule, based on supplied context and a supplied []*yaml.Node slice.
+func (sc SuccessStatusCodes) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	expected := make(map[string][]string)
+	for method, codes := range defaultSuccessStatusCodes {
+		expected[method] = codes
+	}
+	if configured, ok := utils.ExtractValueFromInterfaceMap("expected", context.Options).(map[string]interface{}); ok {
+		for method, c := range configured {
+			if codes := utils.ConvertInterfaceArrayToStringArray(c); codes != nil {
+				expected[strings.ToLower(method)] = codes
+			}
+		}
+	}
+	asyncMethods := getStringArrayOption("asyncMethods", context.Options, nil)
+	asyncPaths := getStringArrayOption("asyncPaths", context.Options, nil)
+
+	isAsync := func(method, opPath string) bool {
+		if containsString(asyncMethods, method) {
+			return true
+		}
+		for _, p := range asyncPaths {
+			if ok, _ := path.Match(p, opPath); ok || p == opPath {
+				return true
+			}
+		}
+		return false
+	}
+
+	walker.ForEachOperation(root, func(op walker.Operation) bool {
+		codes := expected[op.Method]
+		if len(codes) == 0 {
+			return !context.IsCancelled()
+		}
+		if isAsync(op.Method, op.Path) && !containsString(codes, "202") {
+			codes = append(append([]string{}, codes...), "202")
+		}
+		_, responses := utils.FindKeyNodeTop("responses", op.Node.Content)
+		if !utils.IsNodeMap(responses) {
+			return !context.IsCancelled()
+		}
+		var unexpected []string
+		for i := 0; i < len(responses.Content)-1; i += 2 {
+			code := responses.Content[i].Value
+			if len(code) != 3 || code[0] != '2' || strings.EqualFold(code, "2XX") {
+				continue
+			}
+			if !containsString(codes, code) {
+				unexpected = append(unexpected, code)
+			}
+		}
+		if len(unexpected) == 0 {
+			return !context.IsCancelled()
+		}
+		noun := "status code"
+		if len(unexpected) > 1 {
+			noun = "status codes"
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message: fmt.Sprintf("operation `%s` at path `%s` returns the success %s %s, `%s` operations "+
+				"should return %s", op.Method, op.Path, noun, joinNames(unexpected, "and"), op.Method,
+				joinNames(codes, "or")),
+			StartNode: op.KeyNode,
+			EndNode:   utils.FindLastChildNodeWithLevel(op.Node, 0),
+			Path:      op.JSONPath,
+			Rule:      context.Rule,
+		})
+		return !context.IsCancelled()
+	})
+	return results
+}
@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var successStatusCodesTestSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+        "404":
+          description: not found
+    post:
+      responses:
+        "200":
+          description: ok
+        "203":
+          description: ok
+    delete:
+      responses:
+        2XX:
+          description: ok
+  /jobs/{id}:
+    post:
+      responses:
+        "202":
+          description: accepted
+    delete:
+      responses:
+        "204":
+          description: deleted
+    options:
+      responses:
+        "200":
+          description: ok`
+
+func TestSuccessStatusCodes_GetSchema(t *testing.T) {
+	def := SuccessStatusCodes{}
+	assert.Equal(t, "success_status_codes", def.GetSchema().Name)
+}
+
+func TestSuccessStatusCodes_RunRule(t *testing.T) {
+	def := SuccessStatusCodes{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestSuccessStatusCodes_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(successStatusCodesTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "success_status_codes", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := SuccessStatusCodes{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "operation `post` at path `/pizza` returns the success status codes `200` and `203`, `post` "+
+		"operations should return `201`", res[0].Message)
+	assert.Equal(t, "$.paths./pizza.post", res[0].Path)
+	assert.Equal(t, 10, res[0].StartNode.Line)
+	assert.Equal(t, "operation `post` at path `/jobs/{id}` returns the success status code `202`, `post` "+
+		"operations should return `201`", res[1].Message)
+}
+
+func TestSuccessStatusCodes_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(successStatusCodesTestSpec), path)
+
+	opts := map[string]interface{}{
+		"expected":   map[string]interface{}{"POST": []interface{}{201, 200}, "options": []interface{}{204}},
+		"asyncPaths": []interface{}{"/jobs/*"},
+	}
+	rule := buildOpenApiTestRuleAction(path, "success_status_codes", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := SuccessStatusCodes{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "operation `post` at path `/pizza` returns the success status code `203`, `post` "+
+		"operations should return `201` or `200`", res[0].Message)
+	assert.Equal(t, "operation `options` at path `/jobs/{id}` returns the success status code `200`, `options` "+
+		"operations should return `204` or `202`", res[1].Message)
+
+	opts = map[string]interface{}{
+		"asyncMethods": []interface{}{"post"},
+	}
+	ctx.Options = opts
+	res = def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "operation `post` at path `/pizza` returns the success status codes `200` and `203`, `post` "+
+		"operations should return `201` or `202`", res[0].Message)
+}
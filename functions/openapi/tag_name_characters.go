@@ -0,0 +1,133 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// TagNameCharacters checks tag names only use characters that are safe in URLs, documentation tools build anchors
+// and links from tag names, and spaces or special characters break them. The safe characters are a regular
+// expression character class, set by the `safeCharacters` option (`A-Za-z0-9._~-` by default, the unreserved
+// characters of RFC 3986). Tags that need a pretty name (with spaces) for display, can carry it in an
+// `x-displayName` extension, which is not checked. Tags are reported where they are defined, tags used by operations
+// that are not defined are reported where they are used.
+type TagNameCharacters struct {
+}
+
+const defaultTagSafeCharacters = `A-Za-z0-9._~-`
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the TagNameCharacters rule.
+func (tn TagNameCharacters) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "tag_name_characters",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "safeCharacters",
+				Description: "a regular expression character class of the characters tag names can use, like 'a-z0-9-'",
+			},
+		},
+		ErrorMessage: "'tag_name_characters' function has invalid options supplied. Example valid options are " +
+			"'safeCharacters' = 'a-z0-9-'",
+	}
+}
+
+// RunRule will execute the TagNameCharacters rule, based on supplied context and a supplied []*yaml.Node slice.
+func (tn TagNameCharacters) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	safeCharacters := getStringOption("safeCharacters", context.Options, defaultTagSafeCharacters)
+	unsafe, err := regexp.Compile(fmt.Sprintf("[^%s]", safeCharacters))
+	if err != nil {
+		return []model.RuleFunctionResult{
+			{
+				Message:   fmt.Sprintf("unable to compile `safeCharacters` '%s': %s", safeCharacters, err.Error()),
+				StartNode: root,
+				EndNode:   root,
+				Path:      "$",
+				Rule:      context.Rule,
+			},
+		}
+	}
+
+	// unsafeCharacters returns the characters of a tag name that are not safe, once each.
+	unsafeCharacters := func(name string) []string {
+		var found []string
+		for _, c := range unsafe.FindAllString(name, -1) {
+			if q := fmt.Sprintf("%q", c); !containsString(found, q) {
+				found = append(found, q)
+			}
+		}
+		return found
+	}
+
+	defined := make(map[string]bool)
+	_, tags := utils.FindKeyNodeTop("tags", root.Content)
+	if tags != nil && utils.IsNodeArray(tags) {
+		for i, tag := range tags.Content {
+			nameKey, name := utils.FindKeyNodeTop("name", tag.Content)
+			if name == nil {
+				continue
+			}
+			defined[name.Value] = true
+			chars := unsafeCharacters(name.Value)
+			if len(chars) == 0 {
+				continue
+			}
+			msg := fmt.Sprintf("tag `%s` has characters that are not safe in URLs (%s)", name.Value,
+				strings.Join(chars, ", "))
+			if _, display := utils.FindKeyNodeTop("x-displayName", tag.Content); display == nil {
+				msg += ", a name for display can be set with `x-displayName`"
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: nameKey,
+				EndNode:   name,
+				Path:      fmt.Sprintf("$.tags[%d].name", i),
+				Rule:      context.Rule,
+			})
+		}
+	}
+
+	walker.ForEachOperation(root, func(op walker.Operation) bool {
+		_, opTags := utils.FindKeyNodeTop("tags", op.Node.Content)
+		if opTags == nil || !utils.IsNodeArray(opTags) {
+			return !context.IsCancelled()
+		}
+		for i, tag := range opTags.Content {
+			if defined[tag.Value] {
+				continue
+			}
+			if chars := unsafeCharacters(tag.Value); len(chars) > 0 {
+				results = append(results, model.RuleFunctionResult{
+					Message: fmt.Sprintf("tag `%s` of `%s` operation at path `%s` has characters that are not "+
+						"safe in URLs (%s)", tag.Value, op.Method, op.Path, strings.Join(chars, ", ")),
+					StartNode: tag,
+					EndNode:   tag,
+					Path:      fmt.Sprintf("%s.tags[%d]", op.JSONPath, i),
+					Rule:      context.Rule,
+				})
+			}
+		}
+		return !context.IsCancelled()
+	})
+	return results
+}
@@ -0,0 +1,95 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var tagNameCharactersTestSpec = `openapi: 3.1.0
+tags:
+  - name: pizza-orders
+    x-displayName: Pizza Orders
+  - name: Pizza Toppings & Sauces
+  - name: Delivery Drivers
+    x-displayName: Delivery Drivers
+paths:
+  /pizza:
+    get:
+      tags:
+        - pizza-orders
+        - Pizza Toppings & Sauces
+        - free/stuff`
+
+func TestTagNameCharacters_GetSchema(t *testing.T) {
+	def := TagNameCharacters{}
+	assert.Equal(t, "tag_name_characters", def.GetSchema().Name)
+}
+
+func TestTagNameCharacters_RunRule(t *testing.T) {
+	def := TagNameCharacters{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestTagNameCharacters_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(tagNameCharactersTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "tag_name_characters", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := TagNameCharacters{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "tag `Pizza Toppings & Sauces` has characters that are not safe in URLs (\" \", \"&\"), a "+
+		"name for display can be set with `x-displayName`", res[0].Message)
+	assert.Equal(t, "$.tags[1].name", res[0].Path)
+	assert.Equal(t, 5, res[0].StartNode.Line)
+	assert.Equal(t, "tag `Delivery Drivers` has characters that are not safe in URLs (\" \")", res[1].Message)
+	assert.Equal(t, "tag `free/stuff` of `get` operation at path `/pizza` has characters that are not safe in "+
+		"URLs (\"/\")", res[2].Message)
+	assert.Equal(t, "$.paths./pizza.get.tags[2]", res[2].Path)
+}
+
+func TestTagNameCharacters_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(tagNameCharactersTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "tag_name_characters", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = map[string]interface{}{
+		"safeCharacters": `A-Za-z &/-`,
+	}
+
+	def := TagNameCharacters{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
+
+func TestTagNameCharacters_RunRule_BadOption(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(tagNameCharactersTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "tag_name_characters", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = map[string]interface{}{
+		"safeCharacters": `z-a`,
+	}
+
+	def := TagNameCharacters{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Contains(t, res[0].Message, "unable to compile `safeCharacters` 'z-a'")
+}
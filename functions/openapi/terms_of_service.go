@@ -0,0 +1,93 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// TermsOfService checks `info.termsOfService`, when present, is a URL. Absolute URLs must use `http` or `https` and
+// have a host, with the `requireHttps` option set they must use `https`. The specification allows a relative URL,
+// but many consumers (documentation tools, API catalogs) can't resolve one, so relative URLs are reported unless the
+// `allowRelative` option is set.
+type TermsOfService struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the TermsOfService rule.
+func (ts TermsOfService) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "terms_of_service",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "requireHttps",
+				Description: "the URL must use 'https' (defaults to false)",
+			},
+			{
+				Name:        "allowRelative",
+				Description: "allow relative URLs, like '/terms' (defaults to false)",
+			},
+		},
+		ErrorMessage: "'terms_of_service' function has invalid options supplied. Example valid options are " +
+			"'requireHttps' = true or 'allowRelative' = true",
+	}
+}
+
+// RunRule will execute the TermsOfService rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ts TermsOfService) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 || context.IsCancelled() {
+		return nil
+	}
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	_, info := utils.FindKeyNodeTop("info", root.Content)
+	if info == nil || !utils.IsNodeMap(info) {
+		return nil
+	}
+	tosKey, tos := utils.FindKeyNodeTop("termsOfService", info.Content)
+	if tos == nil {
+		return nil
+	}
+
+	requireHttps := getBoolOption("requireHttps", context.Options, false)
+	allowRelative := getBoolOption("allowRelative", context.Options, false)
+
+	value := strings.TrimSpace(tos.Value)
+	var msg string
+	u, err := url.Parse(value)
+	switch {
+	case value == "":
+		msg = "`termsOfService` is empty, it must be a URL"
+	case tos.Kind != yaml.ScalarNode || err != nil || strings.ContainsAny(value, " \t\n"):
+		msg = fmt.Sprintf("`termsOfService` value `%s` is not a URL", tos.Value)
+	case u.IsAbs() && ((u.Scheme != "http" && u.Scheme != "https") || u.Host == ""):
+		msg = fmt.Sprintf("`termsOfService` URL `%s` must use `http` or `https`, and have a host", tos.Value)
+	case u.IsAbs() && requireHttps && u.Scheme != "https":
+		msg = fmt.Sprintf("`termsOfService` URL `%s` uses `http`, it must use `https`", tos.Value)
+	case !u.IsAbs() && !allowRelative:
+		msg = fmt.Sprintf("`termsOfService` URL `%s` is relative, many consumers can't resolve relative URLs, "+
+			"use an absolute URL", tos.Value)
+	default:
+		return nil
+	}
+	return []model.RuleFunctionResult{
+		{
+			Message:   msg,
+			StartNode: tosKey,
+			EndNode:   tos,
+			Path:      "$.info.termsOfService",
+			Rule:      context.Rule,
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTermsOfService_GetSchema(t *testing.T) {
+	def := TermsOfService{}
+	assert.Equal(t, "terms_of_service", def.GetSchema().Name)
+}
+
+func TestTermsOfService_RunRule(t *testing.T) {
+	def := TermsOfService{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestTermsOfService_RunRule_Fail(t *testing.T) {
+
+	tests := map[string]string{
+		"https://pizza.example.com/terms": "",
+		"http://pizza.example.com/terms":  "",
+		"see the pizza shop website":      "`termsOfService` value `see the pizza shop website` is not a URL",
+		"ftp://pizza.example.com/terms": "`termsOfService` URL `ftp://pizza.example.com/terms` must use `http` or " +
+			"`https`, and have a host",
+		"/terms": "`termsOfService` URL `/terms` is relative, many consumers can't resolve relative URLs, use an " +
+			"absolute URL",
+	}
+
+	for tos, msg := range tests {
+		yml := "openapi: 3.1.0\ninfo:\n  title: Pizza\n  termsOfService: '" + tos + "'"
+
+		path := "$"
+
+		nodes, _ := utils.FindNodes([]byte(yml), path)
+
+		rule := buildOpenApiTestRuleAction(path, "terms_of_service", "", nil)
+		ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+		def := TermsOfService{}
+		res := def.RunRule(nodes, ctx)
+
+		if msg == "" {
+			assert.Len(t, res, 0, tos)
+			continue
+		}
+		assert.Len(t, res, 1, tos)
+		assert.Equal(t, msg, res[0].Message)
+		assert.Equal(t, "$.info.termsOfService", res[0].Path)
+		assert.Equal(t, 4, res[0].StartNode.Line)
+	}
+}
+
+func TestTermsOfService_RunRule_Options(t *testing.T) {
+
+	opts := make(map[string]interface{})
+	opts["requireHttps"] = true
+	opts["allowRelative"] = true
+
+	for tos, expected := range map[string]int{"http://pizza.example.com/terms": 1, "/terms": 0} {
+		yml := "swagger: '2.0'\ninfo:\n  title: Pizza\n  termsOfService: '" + tos + "'"
+
+		path := "$"
+
+		nodes, _ := utils.FindNodes([]byte(yml), path)
+
+		rule := buildOpenApiTestRuleAction(path, "terms_of_service", "", opts)
+		ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+		ctx.Options = opts
+
+		def := TermsOfService{}
+		res := def.RunRule(nodes, ctx)
+
+		assert.Len(t, res, expected, tos)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// TimestampFormats checks string properties named like timestamps (`createdAt`, `updated_at`, `startDate`,
+// `timestamp`) use `format: date-time` or `format: date`, rather than being free-form strings. Names are matched
+// against the glob patterns in the `patterns` option, and the accepted formats can be changed with the `formats`
+// option. Only the patterns supplied are matched, so names like `updatedBy` are left alone.
+type TimestampFormats struct {
+}
+
+var defaultTimestampPatterns = []string{"*_at", "*At", "*_date", "*Date", "date", "timestamp", "*_timestamp",
+	"*Timestamp"}
+
+var defaultTimestampFormats = []string{"date-time", "date"}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the TimestampFormats rule.
+func (tf TimestampFormats) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "timestamp_formats",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "patterns",
+				Description: "glob patterns matching the names of timestamp properties, like '*_at' or '*Date'",
+			},
+			{
+				Name:        "formats",
+				Description: "formats timestamp properties may use (defaults to 'date-time' and 'date')",
+			},
+		},
+		ErrorMessage: "'timestamp_formats' function has invalid options supplied. Example valid options are " +
+			"'patterns' = ['*_at', '*Date'] or 'formats' = ['date-time']",
+	}
+}
+
+// RunRule will execute the TimestampFormats rule, based on supplied context and a supplied []*yaml.Node slice.
+func (tf TimestampFormats) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	patterns := getStringArrayOption("patterns", context.Options, defaultTimestampPatterns)
+	formats := getStringArrayOption("formats", context.Options, defaultTimestampFormats)
+	allowed := make(map[string]bool)
+	for _, f := range formats {
+		allowed[f] = true
+	}
+
+	isTimestamp := func(name string) bool {
+		for _, p := range patterns {
+			if ok, _ := path.Match(p, name); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	checkProperties := func(properties *yaml.Node, propertiesPath string) {
+		for i := 0; i < len(properties.Content)-1; i += 2 {
+			name, schema := properties.Content[i], properties.Content[i+1]
+			if !utils.IsNodeMap(schema) || !isTimestamp(name.Value) {
+				continue
+			}
+			isString := false
+			for _, t := range schemaTypesOf(schema) {
+				isString = isString || t == "string"
+			}
+			if !isString {
+				continue // referenced schemas, objects and numeric (epoch) timestamps are not checked.
+			}
+			_, format := utils.FindKeyNodeTop("format", schema.Content)
+			if format != nil && allowed[format.Value] {
+				continue
+			}
+			msg := fmt.Sprintf("property `%s` looks like a timestamp, but is a string without a `%s` format",
+				name.Value, strings.Join(formats, "` or `"))
+			if format != nil {
+				msg = fmt.Sprintf("property `%s` looks like a timestamp, but uses the `%s` format instead of `%s`",
+					name.Value, format.Value, strings.Join(formats, "` or `"))
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: name,
+				EndNode:   utils.FindLastChildNodeWithLevel(schema, 0),
+				Path:      fmt.Sprintf("%s.%s", propertiesPath, name.Value),
+				Rule:      context.Rule,
+			})
+		}
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if context.IsCancelled() {
+			return
+		}
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				if key.Value == "example" || key.Value == "examples" {
+					continue
+				}
+				if key.Value == "properties" && utils.IsNodeMap(value) {
+					checkProperties(value, childPath)
+				}
+				walk(value, childPath)
+			}
+		}
+	}
+	walk(root, "$")
+	return results
+}
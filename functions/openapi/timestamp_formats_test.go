@@ -0,0 +1,96 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var timestampFormatsTestSpec = `openapi: 3.1.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        createdAt:
+          type: string
+          format: date-time
+        updated_at:
+          type: string
+        updatedBy:
+          type: string
+        deliveryDate:
+          type: string
+          format: date
+        timestamp:
+          type: [string, "null"]
+          format: uuid
+        expires_at:
+          type: integer
+        customer:
+          type: object
+          properties:
+            birthDate:
+              type: string
+      example:
+        created_at: now`
+
+func TestTimestampFormats_GetSchema(t *testing.T) {
+	def := TimestampFormats{}
+	assert.Equal(t, "timestamp_formats", def.GetSchema().Name)
+}
+
+func TestTimestampFormats_RunRule(t *testing.T) {
+	def := TimestampFormats{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestTimestampFormats_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(timestampFormatsTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "timestamp_formats", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := TimestampFormats{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "property `updated_at` looks like a timestamp, but is a string without a `date-time` or `date` format",
+		res[0].Message)
+	assert.Equal(t, "$.components.schemas.Order.properties.updated_at", res[0].Path)
+	assert.Equal(t, 10, res[0].StartNode.Line)
+	assert.Equal(t, "property `timestamp` looks like a timestamp, but uses the `uuid` format instead of `date-time` or `date`",
+		res[1].Message)
+	assert.Equal(t, "$.components.schemas.Order.properties.timestamp", res[1].Path)
+	assert.Equal(t, "$.components.schemas.Order.properties.customer.properties.birthDate", res[2].Path)
+}
+
+func TestTimestampFormats_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(timestampFormatsTestSpec), path)
+
+	opts := map[string]interface{}{
+		"patterns": []interface{}{"*By", "*_at"},
+		"formats":  []interface{}{"date-time"},
+	}
+
+	rule := buildOpenApiTestRuleAction(path, "timestamp_formats", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := TimestampFormats{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "property `updated_at` looks like a timestamp, but is a string without a `date-time` format",
+		res[0].Message)
+	assert.Equal(t, "$.components.schemas.Order.properties.updatedBy", res[1].Path)
+}
@@ -33,6 +33,9 @@ func (te TypedEnum) RunRule(nodes []*yaml.Node, context model.RuleFunctionContex
 	enums := context.Index.GetAllEnums()
 
 	for _, enum := range enums {
+		if context.IsCancelled() {
+			break
+		}
 		var enumType string
 		if enum.Type.Value != "" {
 			enumType = enum.Type.Value
@@ -35,6 +35,9 @@ func (oId UniqueOperationId) RunRule(nodes []*yaml.Node, context model.RuleFunct
 	seenIds := make(map[string]bool)
 
 	for path, methodMap := range paths {
+		if context.IsCancelled() {
+			break
+		}
 
 		for method, methodNode := range methodMap {
 
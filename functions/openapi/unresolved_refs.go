@@ -0,0 +1,170 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// UnresolvedRefs checks that every $ref in the document can be resolved. That includes references found in
+// composition keywords (allOf, oneOf, anyOf), discriminator mappings and link operationRef values.
+// Local references are resolved against the document, external references are only checked when the index has
+// been configured to look them up.
+type UnresolvedRefs struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the UnresolvedRefs rule.
+func (ur UnresolvedRefs) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: "unresolved_refs"}
+}
+
+// RunRule will execute the UnresolvedRefs rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ur UnresolvedRefs) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	// external references are only checked if the index was allowed to look them up, in which case any failure
+	// will have been recorded as an indexing error against the reference node.
+	externalErrors := make(map[*yaml.Node]bool)
+	checkExternal := false
+	if context.Index != nil {
+		if cfg := context.Index.GetConfig(); cfg != nil {
+			checkExternal = cfg.AllowFileLookup || cfg.AllowRemoteLookup
+		}
+		for _, e := range context.Index.GetReferenceIndexErrors() {
+			if iErr, ok := e.(*index.IndexingError); ok && iErr.Node != nil {
+				externalErrors[iErr.Node] = true
+			}
+		}
+	}
+
+	report := func(keyNode, valueNode *yaml.Node, ref, path string) {
+		results = append(results, model.RuleFunctionResult{
+			Message:   fmt.Sprintf("reference `%s` cannot be resolved", ref),
+			StartNode: keyNode,
+			EndNode:   valueNode,
+			Path:      path,
+			Rule:      context.Rule,
+		})
+	}
+
+	check := func(keyNode, valueNode, refNode *yaml.Node, ref, path string) {
+		if strings.HasPrefix(ref, "#") {
+			if resolveLocalReference(root, ref) == nil {
+				report(keyNode, valueNode, ref, path)
+			}
+			return
+		}
+		if checkExternal && (externalErrors[keyNode] || externalErrors[valueNode] || externalErrors[refNode]) {
+			report(keyNode, valueNode, ref, path)
+		}
+	}
+
+	var walk func(node *yaml.Node, path string, seen map[*yaml.Node]bool)
+	walk = func(node *yaml.Node, path string, seen map[*yaml.Node]bool) {
+		if node == nil || seen[node] || context.IsCancelled() {
+			return
+		}
+		seen[node] = true
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, fmt.Sprintf("%s[%d]", path, i), seen)
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				key := node.Content[i]
+				value := node.Content[i+1]
+				childPath := fmt.Sprintf("%s.%s", path, key.Value)
+				switch key.Value {
+				case "$ref":
+					if utils.IsNodeStringValue(value) {
+						check(key, value, node, value.Value, childPath)
+					}
+					continue
+				case "operationRef":
+					if utils.IsNodeStringValue(value) && strings.Contains(path, ".links.") {
+						check(key, value, node, value.Value, childPath)
+					}
+					continue
+				case "mapping":
+					if strings.HasSuffix(path, ".discriminator") && utils.IsNodeMap(value) {
+						for m := 0; m < len(value.Content)-1; m += 2 {
+							target := value.Content[m+1].Value
+							mappingPath := fmt.Sprintf("%s.%s", childPath, value.Content[m].Value)
+							if !strings.Contains(target, "#") && !strings.Contains(target, "/") {
+								// a bare schema name, which maps to a component schema.
+								target = fmt.Sprintf("#/components/schemas/%s", target)
+							}
+							check(value.Content[m], value.Content[m+1], value, target, mappingPath)
+						}
+						continue
+					}
+				}
+				walk(value, childPath, seen)
+			}
+		}
+	}
+	walk(root, "$", make(map[*yaml.Node]bool))
+	return results
+}
+
+// resolveLocalReference will locate the node pointed to by a local JSON pointer (e.g. #/components/schemas/Pet)
+// within the supplied root node. Returns nil if the pointer cannot be resolved.
+func resolveLocalReference(root *yaml.Node, ref string) *yaml.Node {
+	pointer := strings.TrimPrefix(ref, "#")
+	if pointer == "" {
+		return root
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil
+	}
+	current := root
+	for _, segment := range strings.Split(pointer[1:], "/") {
+		if s, err := url.PathUnescape(segment); err == nil {
+			segment = s
+		}
+		segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+		switch current.Kind {
+		case yaml.MappingNode:
+			var found *yaml.Node
+			for i := 0; i < len(current.Content)-1; i += 2 {
+				if current.Content[i].Value == segment {
+					found = current.Content[i+1]
+					break
+				}
+			}
+			if found == nil {
+				return nil
+			}
+			current = found
+		case yaml.SequenceNode:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= len(current.Content) {
+				return nil
+			}
+			current = current.Content[idx]
+		default:
+			return nil
+		}
+	}
+	return current
+}
@@ -0,0 +1,121 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnresolvedRefs_GetSchema(t *testing.T) {
+	def := UnresolvedRefs{}
+	assert.Equal(t, "unresolved_refs", def.GetSchema().Name)
+}
+
+func TestUnresolvedRefs_RunRule(t *testing.T) {
+	def := UnresolvedRefs{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestUnresolvedRefs_RunRule_Success(t *testing.T) {
+
+	yml := `openapi: 3.0.1
+paths:
+  /pizza/{id}:
+    get:
+      operationId: getPizza
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pizza'
+          links:
+            self:
+              operationRef: '#/paths/~1pizza~1{id}/get'
+components:
+  schemas:
+    Pizza:
+      allOf:
+        - $ref: '#/components/schemas/Food'
+      discriminator:
+        propertyName: type
+        mapping:
+          food: Food
+          pizza: '#/components/schemas/Pizza'
+    Food:
+      type: object`
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "unresolved_refs", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := UnresolvedRefs{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
+
+func TestUnresolvedRefs_RunRule_Fail(t *testing.T) {
+
+	yml := `openapi: 3.0.1
+paths:
+  /pizza/{id}:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Piza'
+          links:
+            self:
+              operationRef: '#/paths/~1calzone/get'
+components:
+  schemas:
+    Pizza:
+      allOf:
+        - $ref: '#/components/schemas/Fod'
+      discriminator:
+        propertyName: type
+        mapping:
+          food: Fud
+          pizza: '#/components/schemas/Pizza'`
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "unresolved_refs", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, index.CreateOpenAPIIndexConfig())
+
+	def := UnresolvedRefs{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 4)
+	assert.Equal(t, "reference `#/components/schemas/Piza` cannot be resolved", res[0].Message)
+	assert.Equal(t, "$.paths./pizza/{id}.get.responses.200.content.application/json.schema.$ref", res[0].Path)
+	assert.Equal(t, "$.paths./pizza/{id}.get.responses.200.links.self.operationRef", res[1].Path)
+	assert.Equal(t, "$.components.schemas.Pizza.allOf[0].$ref", res[2].Path)
+	assert.Equal(t, "$.components.schemas.Pizza.discriminator.mapping.food", res[3].Path)
+	assert.Equal(t, "reference `#/components/schemas/Fud` cannot be resolved", res[3].Message)
+}
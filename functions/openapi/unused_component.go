@@ -9,20 +9,61 @@ import (
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
+	"path"
 	"strings"
 )
 
 // UnusedComponent will check if a component or definition has been created, but it's not used anywhere by anything.
+// Every type of component is checked, unless the `componentTypes` option limits the types (like `schemas` or
+// `parameters`, Swagger `definitions` are `schemas` and `securityDefinitions` are `securitySchemes`). Components
+// that are shared with other documents on purpose can be exported with the `exported` option, which accepts names
+// or glob patterns, on their own (`Error`) or with their type (`schemas/Shared*`). Unknown `componentTypes` are
+// reported.
 type UnusedComponent struct {
 }
 
+// componentTypes are the types of component UnusedComponent checks, in the order of the index maps it searches.
+var componentTypes = []string{"schemas", "responses", "parameters", "examples", "requestBodies", "headers",
+	"securitySchemes", "links", "callbacks"}
+
 // GetSchema returns a model.RuleFunctionSchema defining the schema of the UnusedComponent rule.
 func (uc UnusedComponent) GetSchema() model.RuleFunctionSchema {
 	return model.RuleFunctionSchema{
 		Name: "unused_component",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "componentTypes",
+				Description: "the types of component to check, like 'schemas' or 'parameters' (defaults to all of them)",
+			},
+			{
+				Name:        "exported",
+				Description: "names (or glob patterns) of components that are exported, and can be unused",
+			},
+		},
+		ErrorMessage: "'unused_component' function has invalid options supplied. Example valid options are " +
+			"'componentTypes' = ['schemas', 'responses'] or 'exported' = ['Error', 'schemas/Shared*']",
 	}
 }
 
+// componentTypeAndName splits a component reference (like `#/components/schemas/Pizza` or `#/definitions/Pizza`)
+// into its type and name. Swagger types are returned as their OpenAPI 3 equivalent.
+func componentTypeAndName(definition string) (string, string) {
+	segs := strings.Split(strings.TrimPrefix(definition, "#/"), "/")
+	if len(segs) > 1 && segs[0] == "components" {
+		segs = segs[1:]
+	}
+	if len(segs) < 2 {
+		return "", definition
+	}
+	switch segs[0] {
+	case "definitions":
+		segs[0] = "schemas"
+	case "securityDefinitions":
+		segs[0] = "securitySchemes"
+	}
+	return segs[0], strings.Join(segs[1:], "/")
+}
+
 // RunRule will execute the UnusedComponent rule, based on supplied context and a supplied []*yaml.Node slice.
 func (uc UnusedComponent) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
 
@@ -32,6 +73,42 @@ func (uc UnusedComponent) RunRule(nodes []*yaml.Node, context model.RuleFunction
 
 	var results []model.RuleFunctionResult
 
+	checkTypes := getStringArrayOption("componentTypes", context.Options, componentTypes)
+	exported := getStringArrayOption("exported", context.Options, nil)
+
+	// a type that isn't known (like `schema`) would check nothing without anyone noticing, it's reported instead.
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	for _, checkType := range checkTypes {
+		if context.IsCancelled() {
+			break
+		}
+		if !containsString(componentTypes, strings.ToLower(checkType)) {
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("`componentTypes` option `%s` is not a type of component, it can be %s",
+					checkType, joinNames(componentTypes, "or")),
+				StartNode: root,
+				EndNode:   root,
+				Path:      "$",
+				Rule:      context.Rule,
+			})
+		}
+	}
+
+	isExported := func(definition string) bool {
+		componentType, name := componentTypeAndName(definition)
+		for _, e := range exported {
+			for _, candidate := range []string{name, componentType + "/" + name} {
+				if ok, _ := path.Match(e, candidate); ok || e == candidate {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
 	// extract all references, and every single component
 	allRefs := context.Index.GetAllReferences()
 	schemas := context.Index.GetAllComponentSchemas()
@@ -100,8 +177,17 @@ func (uc UnusedComponent) RunRule(nodes []*yaml.Node, context model.RuleFunction
 	}
 
 	// find everything that was never referenced.
-	for _, resultMap := range mapsToSearch {
+	for i, resultMap := range mapsToSearch {
+		if context.IsCancelled() {
+			break
+		}
+		if !containsString(checkTypes, strings.ToLower(componentTypes[i])) {
+			continue
+		}
 		for key, ref := range resultMap {
+			if isExported(key) {
+				continue
+			}
 
 			// check everything!
 			if allRefs[key] == nil {
@@ -125,6 +211,9 @@ func (uc UnusedComponent) RunRule(nodes []*yaml.Node, context model.RuleFunction
 
 	// for every orphan, build a result.
 	for key, ref := range notUsed {
+		if context.IsCancelled() {
+			break
+		}
 		_, path := utils.ConvertComponentIdIntoPath(ref.Definition)
 
 		// roll back node by one, so we have the actual start.
@@ -299,3 +299,81 @@ components:
 
 	assert.Len(t, res, 0)
 }
+
+func TestUnusedComponent_RunRule_Options(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Pizza:
+      type: object
+    SharedError:
+      type: object
+  parameters:
+    Size:
+      name: size
+      in: query
+    Legacy:
+      name: legacy
+      in: query
+  responses:
+    NotFound:
+      description: not found
+  examples:
+    Margherita:
+      value: margherita`
+
+	path := "$"
+
+	var rootNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	opts := map[string]interface{}{
+		"componentTypes": []interface{}{"schemas", "parameters"},
+		"exported":       []interface{}{"Shared*", "parameters/Legacy"},
+	}
+	rule := buildOpenApiTestRuleAction(path, "unused_component", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+	config := index.CreateOpenAPIIndexConfig()
+	ctx.Index = index.NewSpecIndexWithConfig(&rootNode, config)
+
+	def := UnusedComponent{}
+	res := def.RunRule(nodes, ctx)
+
+	var messages []string
+	for _, r := range res {
+		messages = append(messages, r.Message)
+	}
+	assert.ElementsMatch(t, []string{
+		"`#/components/schemas/Pizza` is potentially unused or has been orphaned",
+		"`#/components/parameters/Size` is potentially unused or has been orphaned",
+	}, messages)
+
+	// every type is checked by default.
+	ctx.Options = nil
+	res = def.RunRule(nodes, ctx)
+	assert.Len(t, res, 6)
+
+	// types that are not known are reported, the known types are still checked.
+	ctx.Options = map[string]interface{}{
+		"componentTypes": []interface{}{"schema", "Parameters"},
+		"exported":       []interface{}{"Shared*", "parameters/Legacy"},
+	}
+	res = def.RunRule(nodes, ctx)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "`componentTypes` option `schema` is not a type of component, it can be `schemas`, "+
+		"`responses`, `parameters`, `examples`, `requestBodies`, `headers`, `securitySchemes`, `links` or "+
+		"`callbacks`", res[0].Message)
+	assert.Equal(t, "$", res[0].Path)
+	assert.Equal(t, "`#/components/parameters/Size` is potentially unused or has been orphaned", res[1].Message)
+}
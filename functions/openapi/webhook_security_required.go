@@ -0,0 +1,119 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// WebhookSecurityRequired checks every webhook operation (OpenAPI 3.1) is protected by a security requirement, its
+// own or the root `security` default, like OperationSecurityRequired does for paths. Webhooks that opt out of
+// security (with `security: []`, or with only the anonymous `{}` requirement) are only flagged if the `flagOptOut`
+// option is set. Webhooks are often secured by verifying a signature of the payload instead, which can't be
+// described with a security scheme, operations (or path items) with the extension named by the `exemptExtension`
+// option (`x-webhook-signature` by default) set to anything but `false` are not checked. Referenced path items are
+// resolved.
+type WebhookSecurityRequired struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the WebhookSecurityRequired rule.
+func (ws WebhookSecurityRequired) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "webhook_security_required",
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name: "exemptExtension",
+				Description: "the extension marking webhooks secured another way, like a signature (defaults to " +
+					"'x-webhook-signature')",
+			},
+			{
+				Name:        "flagOptOut",
+				Description: "flag webhooks that explicitly opt out of security with `security: []` (defaults to false)",
+			},
+		},
+		ErrorMessage: "'webhook_security_required' function has invalid options supplied. Example valid options are " +
+			"'exemptExtension' = 'x-signed' or 'flagOptOut' = true",
+	}
+}
+
+// RunRule will execute the WebhookSecurityRequired rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ws WebhookSecurityRequired) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	var results []model.RuleFunctionResult
+
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	exemptExtension := getStringOption("exemptExtension", context.Options, "x-webhook-signature")
+	flagOptOut := getBoolOption("flagOptOut", context.Options, false)
+
+	// a security requirement secures an operation if it requires at least one scheme.
+	secured := func(security *yaml.Node) bool {
+		if !utils.IsNodeArray(security) {
+			return false
+		}
+		for _, requirement := range security.Content {
+			if utils.IsNodeMap(requirement) && len(requirement.Content) > 0 {
+				return true
+			}
+		}
+		return false
+	}
+	isExempt := func(node *yaml.Node) bool {
+		_, ext := utils.FindKeyNodeTop(exemptExtension, node.Content)
+		return ext != nil && ext.Value != "false"
+	}
+
+	_, rootSecurity := utils.FindKeyNodeTop("security", root.Content)
+	globallySecured := secured(rootSecurity)
+	_, webhooks := utils.FindKeyNodeTop("webhooks", root.Content)
+	if !utils.IsNodeMap(webhooks) {
+		return results
+	}
+	for i := 0; i < len(webhooks.Content)-1; i += 2 {
+		if context.IsCancelled() {
+			break
+		}
+		name := webhooks.Content[i].Value
+		pathItem := resolveSchemaReference(root, webhooks.Content[i+1])
+		if pathItem == nil || isExempt(pathItem) {
+			continue
+		}
+		for m := 0; m < len(pathItem.Content)-1; m += 2 {
+			opKey, operation := pathItem.Content[m], pathItem.Content[m+1]
+			if !isOperationMethod(opKey.Value) || !utils.IsNodeMap(operation) || isExempt(operation) {
+				continue
+			}
+			var msg string
+			_, opSecurity := utils.FindKeyNodeTop("security", operation.Content)
+			switch {
+			case opSecurity == nil && !globallySecured:
+				msg = fmt.Sprintf("operation `%s` of webhook `%s` has no `security` requirement, and there is no "+
+					"global `security` default", opKey.Value, name)
+			case opSecurity != nil && !secured(opSecurity) && flagOptOut:
+				msg = fmt.Sprintf("operation `%s` of webhook `%s` opts out of security", opKey.Value, name)
+			default:
+				continue
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message:   msg,
+				StartNode: opKey,
+				EndNode:   utils.FindLastChildNodeWithLevel(operation, 0),
+				Path:      fmt.Sprintf("$.webhooks.%s.%s", name, opKey.Value),
+				Rule:      context.Rule,
+			})
+		}
+	}
+	return results
+}
@@ -0,0 +1,135 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var webhookSecurityRequiredTestSpec = `openapi: 3.1.0
+webhooks:
+  newPizza:
+    post:
+      responses:
+        "200":
+          description: ok
+  pizzaDelivered:
+    post:
+      security:
+        - webhookKey: []
+      responses:
+        "200":
+          description: ok
+  pizzaCancelled:
+    post:
+      x-webhook-signature: true
+      description: signed with the X-Pizza-Signature header
+      responses:
+        "200":
+          description: ok
+  pizzaEaten:
+    $ref: '#/components/pathItems/pizzaEaten'
+  pizzaBurned:
+    post:
+      security: []
+      responses:
+        "200":
+          description: ok
+components:
+  pathItems:
+    pizzaEaten:
+      put:
+        responses:
+          "200":
+            description: ok`
+
+func TestWebhookSecurityRequired_GetSchema(t *testing.T) {
+	def := WebhookSecurityRequired{}
+	assert.Equal(t, "webhook_security_required", def.GetSchema().Name)
+}
+
+func TestWebhookSecurityRequired_RunRule(t *testing.T) {
+	def := WebhookSecurityRequired{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestWebhookSecurityRequired_RunRule_Fail(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(webhookSecurityRequiredTestSpec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "webhook_security_required", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := WebhookSecurityRequired{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "operation `post` of webhook `newPizza` has no `security` requirement, and there is no "+
+		"global `security` default", res[0].Message)
+	assert.Equal(t, "$.webhooks.newPizza.post", res[0].Path)
+	assert.Equal(t, 4, res[0].StartNode.Line)
+	assert.Equal(t, "$.webhooks.pizzaEaten.put", res[1].Path)
+}
+
+func TestWebhookSecurityRequired_RunRule_GlobalSecurity(t *testing.T) {
+
+	path := "$"
+
+	spec := "security:\n  - webhookKey: []\n" + webhookSecurityRequiredTestSpec
+	nodes, _ := utils.FindNodes([]byte(spec), path)
+
+	rule := buildOpenApiTestRuleAction(path, "webhook_security_required", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	def := WebhookSecurityRequired{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
+
+func TestWebhookSecurityRequired_RunRule_Options(t *testing.T) {
+
+	path := "$"
+
+	spec := "security:\n  - webhookKey: []\n" + webhookSecurityRequiredTestSpec
+	nodes, _ := utils.FindNodes([]byte(spec), path)
+
+	opts := make(map[string]interface{})
+	opts["flagOptOut"] = true
+
+	rule := buildOpenApiTestRuleAction(path, "webhook_security_required", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := WebhookSecurityRequired{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "operation `post` of webhook `pizzaBurned` opts out of security", res[0].Message)
+	assert.Equal(t, "$.webhooks.pizzaBurned.post", res[0].Path)
+}
+
+func TestWebhookSecurityRequired_RunRule_ExemptExtension(t *testing.T) {
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(webhookSecurityRequiredTestSpec), path)
+
+	opts := make(map[string]interface{})
+	opts["exemptExtension"] = "x-signed"
+
+	rule := buildOpenApiTestRuleAction(path, "webhook_security_required", "", opts)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Options = opts
+
+	def := WebhookSecurityRequired{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "$.webhooks.pizzaCancelled.post", res[1].Path)
+}
@@ -45,6 +45,9 @@ func (cd CheckSecurity) RunRule(nodes []*yaml.Node, context model.RuleFunctionCo
 	}
 
 	for i := 1; i < len(valueOfPathNode.Content); i += 2 {
+		if context.IsCancelled() {
+			break
+		}
 		for j := 0; j < len(valueOfPathNode.Content[i].Content); j += 2 {
 			if slices.Contains(
 				[]string{"get", "head", "post", "put", "patch", "delete", "options", "trace"},
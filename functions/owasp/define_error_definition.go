@@ -26,6 +26,9 @@ func (cd DefineErrorDefinition) RunRule(nodes []*yaml.Node, context model.RuleFu
 
 	var responseCode string
 	for i, node := range nodes[0].Content {
+		if context.IsCancelled() {
+			return nil
+		}
 		if i%2 == 0 {
 			responseCode = node.Value
 		} else if responseCode == "400" || responseCode == "422" || strings.ToUpper(responseCode) == "4XX" {
@@ -1,6 +1,7 @@
 package owasp
 
 import (
+	"context"
 	"testing"
 
 	"github.com/daveshanley/vacuum/model"
@@ -44,3 +45,28 @@ paths:
 
 	assert.Len(t, res, 1)
 }
+
+func TestDefineErrorDefinition_Cancelled(t *testing.T) {
+
+	yml := `"200":
+  description: "ok"
+"422":
+  description: "classic validation fail"`
+
+	path := "$"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "define_error_definition", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+
+	// cancelled part way through the responses is not the same as the error response being missing.
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx.Context = cancelled
+
+	def := DefineErrorDefinition{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 0)
+}
@@ -0,0 +1,99 @@
+package owasp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DefineErrorResponse checks that the response for an error status code is defined, and has `content`. The status
+// code range covering the code (like `5XX` for `500`) is accepted in its place.
+type DefineErrorResponse struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the DefineErrorResponse rule.
+func (er DefineErrorResponse) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name:     "define_error_response",
+		Required: []string{"code"},
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "code",
+				Description: "the error status code that must be defined, like `500`",
+			},
+		},
+		MinProperties: 1,
+		MaxProperties: 1,
+		ErrorMessage:  "'define_error_response' needs a 'code' to check for",
+	}
+}
+
+// RunRule will execute the DefineErrorResponse rule, based on supplied context and a supplied []*yaml.Node slice.
+func (er DefineErrorResponse) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	code := fmt.Sprint(utils.ExtractValueFromInterfaceMap("code", context.Options))
+	class, isRange := walker.StatusCodeClass(code)
+	if class == 0 || isRange {
+		return nil
+	}
+	codeRange := fmt.Sprintf("%dXX", class)
+
+	// the exact code wins over the range covering it.
+	var keyNode, responseNode *yaml.Node
+	for i := 0; i < len(nodes[0].Content)-1; i += 2 {
+		if context.IsCancelled() {
+			return nil
+		}
+		key := nodes[0].Content[i]
+		if key.Value == code {
+			keyNode, responseNode = key, nodes[0].Content[i+1]
+			break
+		}
+		if keyNode == nil && strings.ToUpper(key.Value) == codeRange {
+			keyNode, responseNode = key, nodes[0].Content[i+1]
+		}
+	}
+
+	ruleMessage := context.Rule.Description
+	if context.Rule.Message != "" {
+		ruleMessage = context.Rule.Message
+	}
+
+	if keyNode == nil {
+		return []model.RuleFunctionResult{
+			{
+				Message:   fmt.Sprintf("%s: `%s` or `%s` must be defined", ruleMessage, code, codeRange),
+				StartNode: nodes[0],
+				EndNode:   utils.FindLastChildNodeWithLevel(nodes[0], 0),
+				Path:      fmt.Sprintf("%s", context.Given),
+				Rule:      context.Rule,
+			},
+		}
+	}
+
+	if responseNode.Kind == yaml.MappingNode {
+		for i := 0; i < len(responseNode.Content)-1; i += 2 {
+			if responseNode.Content[i].Value == "content" {
+				return nil
+			}
+		}
+	}
+
+	return []model.RuleFunctionResult{
+		{
+			Message:   fmt.Sprintf("%s: `%s` response must define `content`", ruleMessage, keyNode.Value),
+			StartNode: keyNode,
+			EndNode:   utils.FindLastChildNodeWithLevel(responseNode, 0),
+			Path:      fmt.Sprintf("%s.%s", context.Given, keyNode.Value),
+			Rule:      context.Rule,
+		},
+	}
+}
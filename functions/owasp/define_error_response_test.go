@@ -0,0 +1,94 @@
+package owasp
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefineErrorResponse_GetSchema(t *testing.T) {
+	def := DefineErrorResponse{}
+	assert.Equal(t, "define_error_response", def.GetSchema().Name)
+}
+
+func TestDefineErrorResponse_RunRule(t *testing.T) {
+	def := DefineErrorResponse{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestDefineErrorResponse_Responses(t *testing.T) {
+
+	tc := []struct {
+		name      string
+		responses string
+		code      string
+		n         int
+		message   string
+	}{
+		{
+			name: "code is defined",
+			responses: `"500":
+  content:
+    application/json: {}`,
+			code: "500",
+		},
+		{
+			name: "range covers the code",
+			responses: `5xx:
+  content:
+    application/json: {}`,
+			code: "500",
+		},
+		{
+			name: "code is checked before the range",
+			responses: `5XX:
+  content:
+    application/json: {}
+"500":
+  description: "no content"`,
+			code:    "500",
+			n:       1,
+			message: "error responses: `500` response must define `content`",
+		},
+		{
+			name: "another range does not cover the code",
+			responses: `4XX:
+  content:
+    application/json: {}`,
+			code:    "500",
+			n:       1,
+			message: "error responses: `500` or `5XX` must be defined",
+		},
+		{
+			name: "range is missing content",
+			responses: `4XX:
+  description: "no content"`,
+			code:    "429",
+			n:       1,
+			message: "error responses: `4XX` response must define `content`",
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, _ := utils.FindNodes([]byte(tt.responses), "$")
+
+			opts := map[string]interface{}{"code": tt.code}
+			rule := buildOpenApiTestRuleAction("$", "define_error_response", "", opts)
+			rule.Description = "error responses"
+			ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), opts)
+			ctx.Rule = &rule
+
+			def := DefineErrorResponse{}
+			res := def.RunRule(nodes, ctx)
+
+			assert.Len(t, res, tt.n)
+			if tt.n > 0 {
+				assert.Equal(t, tt.message, res[0].Message)
+			}
+		})
+	}
+}
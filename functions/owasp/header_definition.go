@@ -2,17 +2,17 @@ package owasp
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/walker"
 	"github.com/pb33f/libopenapi/utils"
 	"golang.org/x/exp/slices"
 	"gopkg.in/yaml.v3"
 )
 
 type message struct {
-	responseCode int
+	responseCode string
 	headersSets  [][]string
 }
 
@@ -25,7 +25,7 @@ func (m message) String() string {
 		oout += "{" + strings.Join(headerSet, ", ") + "} "
 	}
 
-	return fmt.Sprintf(`Response with code %d, must contain one of the defined 'headers': {%s}`, m.responseCode, oout)
+	return fmt.Sprintf(`Response with code %s, must contain one of the defined 'headers': {%s}`, m.responseCode, oout)
 }
 
 // GetSchema returns a model.RuleFunctionSchema defining the schema of the HeaderDefinition rule.
@@ -45,22 +45,23 @@ func (cd HeaderDefinition) RunRule(nodes []*yaml.Node, context model.RuleFunctio
 		headers = castedHeaders
 	}
 
-	var responseCode = -1
+	// `2XX` and `4XX` ranges are checked like the status codes they cover.
 	var results []model.RuleFunctionResult
-	for i, node := range nodes[0].Content {
-		if i%2 == 0 {
-			responseCode, _ = strconv.Atoi(node.Value)
-		} else if responseCode >= 200 && responseCode < 300 || responseCode >= 400 && responseCode < 500 {
-			result := cd.getResult(responseCode, node, context, headers)
+	for i := 0; i < len(nodes[0].Content)-1; i += 2 {
+		if context.IsCancelled() {
+			break
+		}
+		responseCode := nodes[0].Content[i].Value
+		if class, _ := walker.StatusCodeClass(responseCode); class == 2 || class == 4 {
+			result := cd.getResult(responseCode, nodes[0].Content[i+1], context, headers)
 			results = append(results, result...)
-			responseCode = 0
 		}
 	}
 
 	return results
 }
 
-func (cd HeaderDefinition) getResult(responseCode int, node *yaml.Node, context model.RuleFunctionContext, headersSets [][]string) []model.RuleFunctionResult {
+func (cd HeaderDefinition) getResult(responseCode string, node *yaml.Node, context model.RuleFunctionContext, headersSets [][]string) []model.RuleFunctionResult {
 	var results []model.RuleFunctionResult
 	numberOfHeaders := 0
 
@@ -72,7 +73,7 @@ func (cd HeaderDefinition) getResult(responseCode int, node *yaml.Node, context
 					Message:   message{responseCode: responseCode, headersSets: headersSets}.String(),
 					StartNode: headersNode,
 					EndNode:   utils.FindLastChildNodeWithLevel(headersNode, 0),
-					Path:      fmt.Sprintf("$.paths.responses.%d.headers", responseCode),
+					Path:      fmt.Sprintf("$.paths.responses.%s.headers", responseCode),
 					Rule:      context.Rule,
 				})
 			}
@@ -85,7 +86,7 @@ func (cd HeaderDefinition) getResult(responseCode int, node *yaml.Node, context
 			Message:   message{responseCode: responseCode, headersSets: headersSets}.String(),
 			StartNode: node,
 			EndNode:   utils.FindLastChildNodeWithLevel(node, 0),
-			Path:      fmt.Sprintf("$.paths.responses.%d", responseCode),
+			Path:      fmt.Sprintf("$.paths.responses.%s", responseCode),
 			Rule:      context.Rule,
 		})
 	}
@@ -63,3 +63,35 @@ func TestHeaderDefinition_HeaderDefinitionMissing(t *testing.T) {
 	assert.Len(t, res, 4)
 
 }
+
+func TestHeaderDefinition_HeaderDefinitionRange(t *testing.T) {
+
+	yml := `paths:
+  /pizza/:
+    responses:
+      2XX:
+        headers:
+          "Content-Type":
+            schema:
+              type: string
+      4XX:
+        description: error
+      5XX:
+        description: error
+`
+
+	path := "$.paths..responses"
+
+	nodes, _ := utils.FindNodes([]byte(yml), path)
+
+	rule := buildOpenApiTestRuleAction(path, "header_definition", "", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), map[string]interface{}{
+		"headers": [][]string{{"Accept", "Cache-Control"}, {"Content-Type"}},
+	})
+
+	def := HeaderDefinition{}
+	res := def.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.paths.responses.4XX", res[0].Path)
+}
@@ -40,10 +40,20 @@ var hydrateJS string
 //go:embed ui/src/css/report.css
 var reportCSS string
 
+//go:embed templates/filter.gohtml
+var filter string
+
+//go:embed templates/report-filter.js
+var filterJS string
+
 type HTMLReport interface {
 	GenerateReport(testMode bool) []byte
+	SetFileName(fileName string) // The name of the linted file, used to filter results by file.
 }
 
+// DefaultFileName is the file results are reported against, when the name of the linted file is not set.
+const DefaultFileName = "specification"
+
 // MaxViolations the maximum number of violations the report will render per broken rule.
 // TODO: make this configurable
 const MaxViolations = 100
@@ -61,6 +71,21 @@ type ReportData struct {
 	Generated        time.Time                 `json:"generated"`
 	DisableTimestamp bool                      `json:"-"`
 	SpecString       []string                  `json:"-"`
+	FilterJS         string                    `json:"-"`
+	FilterResults    []*FilterResult           `json:"-"`
+}
+
+// FilterResult is a result, as it is embedded in the report (as JSON) so results can be filtered and sorted by
+// the page itself, without a server. Every result is embedded, not only the ones rendered per rule.
+type FilterResult struct {
+	RuleId   string `json:"ruleId"`
+	Severity string `json:"severity"`
+	Category string `json:"category"`
+	Message  string `json:"message"`
+	Path     string `json:"path"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
 }
 
 func NewHTMLReport(
@@ -69,7 +94,7 @@ func NewHTMLReport(
 	results *model.RuleResultSet,
 	stats *reports.ReportStatistics,
 	disableTimestamp bool) HTMLReport {
-	return &htmlReport{index, info, results, stats, disableTimestamp}
+	return &htmlReport{index: index, info: info, results: results, stats: stats, disableTimestamp: disableTimestamp}
 }
 
 type htmlReport struct {
@@ -78,6 +103,49 @@ type htmlReport struct {
 	results          *model.RuleResultSet
 	stats            *reports.ReportStatistics
 	disableTimestamp bool
+	fileName         string
+}
+
+// SetFileName sets the name of the linted file, results are reported against DefaultFileName if it is not set.
+func (html *htmlReport) SetFileName(fileName string) {
+	html.fileName = fileName
+}
+
+// buildFilterResults converts the results of the report into the results embedded for filtering.
+func (html htmlReport) buildFilterResults() []*FilterResult {
+	fileName := html.fileName
+	if fileName == "" {
+		fileName = DefaultFileName
+	}
+	filterResults := []*FilterResult{}
+	if html.results == nil {
+		return filterResults
+	}
+	for _, result := range html.results.Results {
+		fr := &FilterResult{
+			RuleId:   result.RuleId,
+			Severity: result.RuleSeverity,
+			Message:  result.Message,
+			Path:     result.Path,
+			File:     fileName,
+			Line:     result.Range.Start.Line,
+			Column:   result.Range.Start.Char,
+		}
+		if result.StartNode != nil {
+			fr.Line, fr.Column = result.StartNode.Line, result.StartNode.Column
+		}
+		if result.Rule != nil {
+			fr.RuleId = result.Rule.Id
+			if fr.Severity == "" {
+				fr.Severity = result.Rule.Severity
+			}
+			if result.Rule.RuleCategory != nil {
+				fr.Category = result.Rule.RuleCategory.Id
+			}
+		}
+		filterResults = append(filterResults, fr)
+	}
+	return filterResults
 }
 
 func (html htmlReport) GenerateReport(test bool) []byte {
@@ -185,6 +253,10 @@ func (html htmlReport) GenerateReport(test bool) []byte {
 	if err != nil {
 		return nil
 	}
+	_, err = t.New("filter").Parse(filter)
+	if err != nil {
+		return nil
+	}
 	_, err = t.New("report").Parse(reportTemplate)
 	if err != nil {
 		return nil
@@ -194,6 +266,9 @@ func (html htmlReport) GenerateReport(test bool) []byte {
 
 	// we need a new category here 'all'
 	cats := model.RuleCategoriesOrdered
+	if html.results != nil {
+		cats = html.results.GetRuleCategories()
+	}
 	n := []*model.RuleCategory{model.RuleCategories[model.CategoryAll]}
 	cats = append(n, cats...)
 
@@ -213,6 +288,8 @@ func (html htmlReport) GenerateReport(test bool) []byte {
 		RuleResults:    html.results,
 		MaxViolations:  MaxViolations,
 		SpecString:     specStringData,
+		FilterJS:       filterJS,
+		FilterResults:  html.buildFilterResults(),
 	}
 	if html.info != nil {
 		reportData.Generated = html.info.Generated
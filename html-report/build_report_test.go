@@ -1,12 +1,16 @@
 package html_report
 
 import (
+	"encoding/json"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
 	"github.com/daveshanley/vacuum/motor"
 	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/daveshanley/vacuum/statistics"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -55,3 +59,62 @@ func TestNewHTMLReport_FullRender_Stripe(t *testing.T) {
 	assert.True(t, len(generated) > 0)
 
 }
+
+func TestNewHTMLReport_FilterResults(t *testing.T) {
+
+	specBytes := []byte(`openapi: 3.1.0
+info:
+  title: pizza
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok`)
+	defaultRuleSets := rulesets.BuildDefaultRuleSets()
+	selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
+
+	ruleset := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet: selectedRS,
+		Spec:    specBytes,
+	})
+
+	resultSet := model.NewRuleResultSet(ruleset.Results)
+	stats := statistics.CreateReportStatistics(ruleset.Index, ruleset.SpecInfo, resultSet)
+
+	report := NewHTMLReport(ruleset.Index, ruleset.SpecInfo, resultSet, stats, true)
+	report.SetFileName("pizza.yaml")
+	generated := string(report.GenerateReport(false))
+
+	// the report is self-contained, nothing is loaded from anywhere else.
+	assert.NotContains(t, generated, "<script src=")
+	assert.NotContains(t, generated, "<link ")
+	assert.Contains(t, generated, `id="report-filter"`)
+
+	embedded := regexp.MustCompile(`(?s)<script type="application/json" id="vacuum-results">(.*?)</script>`).
+		FindStringSubmatch(generated)
+	assert.Len(t, embedded, 2)
+
+	var filterResults []*FilterResult
+	assert.NoError(t, json.Unmarshal([]byte(embedded[1]), &filterResults))
+	assert.Len(t, filterResults, len(resultSet.Results))
+	assert.NotEmpty(t, filterResults)
+	for _, r := range filterResults {
+		assert.Equal(t, "pizza.yaml", r.File)
+		assert.NotEmpty(t, r.RuleId)
+		assert.NotEmpty(t, r.Severity)
+		assert.Greater(t, r.Line, 0)
+	}
+}
+
+func TestNewHTMLReport_FilterResults_Escaped(t *testing.T) {
+
+	resultSet := model.NewRuleResultSet([]model.RuleFunctionResult{
+		{Message: "</script><script>alert(1)</script>", RuleId: "nasty", RuleSeverity: model.SeverityWarn},
+	})
+	report := NewHTMLReport(nil, nil, resultSet, &reports.ReportStatistics{}, true)
+	generated := string(report.GenerateReport(false))
+
+	assert.False(t, strings.Contains(generated, "</script><script>alert(1)"))
+	assert.Contains(t, generated, `"file":"`+DefaultFileName+`"`)
+}
@@ -0,0 +1,83 @@
+// filters and sorts the results embedded in the report, no server is needed.
+(function () {
+    var data = document.getElementById('vacuum-results');
+    var panel = document.getElementById('report-filter');
+    if (!data || !panel) {
+        return;
+    }
+    var results = JSON.parse(data.textContent) || [];
+    var maxRows = 1000;
+    var icons = {error: '❌', warn: '⚠️', info: '🔵', hint: '💠'};
+
+    var severity = panel.querySelector('select[name=severity]');
+    var rule = panel.querySelector('select[name=rule]');
+    var file = panel.querySelector('select[name=file]');
+    var sort = panel.querySelector('select[name=sort]');
+    var count = panel.querySelector('.report-filter-count');
+    var body = panel.querySelector('tbody');
+
+    function unique(key) {
+        var seen = {};
+        results.forEach(function (r) {
+            seen[r[key]] = true;
+        });
+        return Object.keys(seen).sort();
+    }
+
+    function fill(select, values) {
+        values.forEach(function (v) {
+            var option = document.createElement('option');
+            option.value = v;
+            option.textContent = v;
+            select.appendChild(option);
+        });
+    }
+
+    function cell(row, text, className) {
+        var td = document.createElement('td');
+        td.textContent = text;
+        if (className) {
+            td.className = className;
+        }
+        row.appendChild(td);
+    }
+
+    function render() {
+        var filtered = results.filter(function (r) {
+            return (!severity.value || r.severity === severity.value) &&
+                (!rule.value || r.ruleId === rule.value) &&
+                (!file.value || r.file === file.value);
+        });
+        var direction = sort.value === 'desc' ? -1 : 1;
+        filtered.sort(function (a, b) {
+            if (a.file !== b.file) {
+                return a.file < b.file ? -1 : 1;
+            }
+            return direction * ((a.line - b.line) || (a.column - b.column));
+        });
+
+        var rows = document.createDocumentFragment();
+        filtered.slice(0, maxRows).forEach(function (r) {
+            var row = document.createElement('tr');
+            cell(row, icons[r.severity] || '');
+            cell(row, r.ruleId);
+            cell(row, r.file + ':' + r.line + ':' + r.column, 'location');
+            cell(row, r.message);
+            cell(row, r.path, 'path');
+            rows.appendChild(row);
+        });
+        body.textContent = '';
+        body.appendChild(rows);
+
+        count.textContent = 'showing ' + Math.min(filtered.length, maxRows) + ' of ' + filtered.length +
+            ' matching results (' + results.length + ' in total)';
+    }
+
+    fill(severity, unique('severity'));
+    fill(rule, unique('ruleId'));
+    fill(file, unique('file'));
+    [severity, rule, file, sort].forEach(function (select) {
+        select.addEventListener('change', render);
+    });
+    render();
+})();
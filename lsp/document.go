@@ -0,0 +1,73 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// offset returns the byte offset of a position in a text. Positions count UTF-16 code units, a character offset
+// past the end of its line is the end of the line, and a line past the end of the text is the end of the text.
+func offset(text string, pos Position) int {
+	start := 0
+	for line := 0; line < pos.Line; line++ {
+		next := strings.IndexByte(text[start:], '\n')
+		if next < 0 {
+			return len(text)
+		}
+		start += next + 1
+	}
+	units := 0
+	for i, r := range text[start:] {
+		if r == '\n' || units >= pos.Character {
+			return start + i
+		}
+		units += utf16Len(r)
+	}
+	return len(text)
+}
+
+// utf16Len returns the number of UTF-16 code units that encode a rune.
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+// applyChanges applies content changes to a text, in order. Changes with a range replace the range, changes without
+// one replace the whole text.
+func applyChanges(text string, changes []textDocumentContentChangeEvent) string {
+	for _, change := range changes {
+		if change.Range == nil {
+			text = change.Text
+			continue
+		}
+		start, end := offset(text, change.Range.Start), offset(text, change.Range.End)
+		if end < start {
+			start, end = end, start
+		}
+		text = text[:start] + change.Text + text[end:]
+	}
+	return text
+}
+
+// position returns the LSP position of a one based line and column (counting runes), like those of a yaml.Node.
+func position(lines []string, line, column int) Position {
+	if line < 1 {
+		return Position{}
+	}
+	pos := Position{Line: line - 1}
+	if pos.Line >= len(lines) {
+		return pos
+	}
+	text := lines[pos.Line]
+	for runes := 0; runes < column-1 && len(text) > 0; runes++ {
+		r, size := utf8.DecodeRuneInString(text)
+		pos.Character += utf16Len(r)
+		text = text[size:]
+	}
+	return pos
+}
@@ -0,0 +1,394 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package lsp is a Language Server Protocol server, it lints the OpenAPI documents open in an editor as they are
+// edited, and publishes the results as diagnostics. Documents are synced incrementally, and linting is debounced,
+// a burst of edits is linted once, after the edits stop.
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+)
+
+// DefaultDebounce is how long the server waits after an edit before linting, when not configured.
+const DefaultDebounce = 300 * time.Millisecond
+
+// LintFunc lints the text of a document, identified by its URI. Linting must stop when the context is cancelled,
+// the results are no longer wanted.
+type LintFunc func(ctx context.Context, uri string, spec []byte) []model.RuleFunctionResult
+
+// MotorLinter returns a LintFunc that lints documents with the motor, execution is a template for each run, the
+// spec, context and base (the directory of documents that are files, so relative references resolve) are set for
+// every document.
+func MotorLinter(execution motor.RuleSetExecution) LintFunc {
+	return func(ctx context.Context, uri string, spec []byte) []model.RuleFunctionResult {
+		run := execution
+		run.Spec = spec
+		run.Context = ctx
+		if u, err := url.Parse(uri); err == nil && u.Scheme == "file" && run.Base == "" {
+			run.Base = filepath.Dir(filepath.FromSlash(u.Path))
+		}
+		return motor.ApplyRulesToRuleSet(&run).Results
+	}
+}
+
+// Server is a Language Server Protocol server, create one with NewServer.
+type Server struct {
+	Lint     LintFunc      // lints documents
+	Debounce time.Duration // how long to wait after an edit before linting
+	Version  string        // the version of vacuum, reported to the client
+	Logger   *slog.Logger  // logs problems, never to stdout (which belongs to the protocol)
+
+	writeLock sync.Mutex
+	out       io.Writer
+
+	lock        sync.Mutex
+	ctx         context.Context
+	documents   map[string]*document
+	initialized bool
+	shutdown    bool
+}
+
+// document is an open document, and the state of linting it.
+type document struct {
+	text    string
+	version int
+	timer   *time.Timer
+	cancel  context.CancelFunc
+}
+
+// NewServer creates a server that lints documents with the supplied LintFunc.
+func NewServer(lint LintFunc) *Server {
+	return &Server{
+		Lint:      lint,
+		Debounce:  DefaultDebounce,
+		Logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		documents: make(map[string]*document),
+	}
+}
+
+// Serve reads messages from in and writes messages to out, until the client sends `exit`, or in is closed. An error
+// is returned if the client exits without a shutdown request, or the connection fails.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s.out = out
+	s.lock.Lock()
+	s.ctx = ctx
+	s.lock.Unlock()
+	defer s.closeAll()
+
+	reader := bufio.NewReader(in)
+	for {
+		body, err := readMessage(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var msg message
+		if err = json.Unmarshal(body, &msg); err != nil {
+			s.respondError(nil, codeParseError, fmt.Sprintf("unable to parse message: %s", err.Error()))
+			continue
+		}
+		if msg.Method == "exit" {
+			s.lock.Lock()
+			clean := s.shutdown
+			s.lock.Unlock()
+			if !clean {
+				return errors.New("client exited without a shutdown request")
+			}
+			return nil
+		}
+		s.handle(&msg)
+	}
+}
+
+// handle handles a request or notification.
+func (s *Server) handle(msg *message) {
+	s.lock.Lock()
+	initialized, shutdown := s.initialized, s.shutdown
+	s.lock.Unlock()
+
+	switch {
+	case msg.Method == "":
+		// responses to requests the server never sends.
+		return
+	case msg.Method == "initialize":
+		s.lock.Lock()
+		s.initialized = true
+		s.lock.Unlock()
+		s.respond(msg.ID, initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync: textDocumentSyncOptions{OpenClose: true, Change: syncIncremental},
+			},
+			ServerInfo: serverInfo{Name: "vacuum", Version: s.Version},
+		})
+		return
+	case !initialized:
+		s.respondError(msg.ID, codeServerNotInitialized, "the server has not been initialized")
+		return
+	case shutdown && msg.ID != nil:
+		s.respondError(msg.ID, codeInvalidRequest, "the server is shutting down")
+		return
+	}
+
+	switch msg.Method {
+	case "shutdown":
+		s.lock.Lock()
+		s.shutdown = true
+		s.lock.Unlock()
+		s.closeAll()
+		s.respond(msg.ID, nil)
+	case "textDocument/didOpen":
+		var params didOpenTextDocumentParams
+		if s.decode(msg, &params) {
+			s.update(params.TextDocument.URI, params.TextDocument.Version, func(string) string {
+				return params.TextDocument.Text
+			}, 0)
+		}
+	case "textDocument/didChange":
+		var params didChangeTextDocumentParams
+		if s.decode(msg, &params) {
+			s.update(params.TextDocument.URI, params.TextDocument.Version, func(text string) string {
+				return applyChanges(text, params.ContentChanges)
+			}, s.Debounce)
+		}
+	case "textDocument/didClose":
+		var params didCloseTextDocumentParams
+		if s.decode(msg, &params) {
+			s.close(params.TextDocument.URI)
+			s.publish(params.TextDocument.URI, nil, []Diagnostic{})
+		}
+	default:
+		// notifications the server doesn't handle are ignored, requests must be answered.
+		if msg.ID != nil {
+			s.respondError(msg.ID, codeMethodNotFound, fmt.Sprintf("method '%s' is not supported", msg.Method))
+		}
+	}
+}
+
+// decode decodes the params of a message, responding with an error to requests with invalid params.
+func (s *Server) decode(msg *message, params any) bool {
+	if err := json.Unmarshal(msg.Params, params); err != nil {
+		s.Logger.Warn("invalid params", "method", msg.Method, "error", err.Error())
+		if msg.ID != nil {
+			s.respondError(msg.ID, codeInvalidParams, err.Error())
+		}
+		return false
+	}
+	return true
+}
+
+// update changes the text of a document, and schedules it to be linted after a delay. A pending lint of the
+// document is rescheduled, and a running one is cancelled, its results would be stale.
+func (s *Server) update(uri string, version int, change func(text string) string, delay time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	doc, ok := s.documents[uri]
+	if !ok {
+		doc = &document{}
+		s.documents[uri] = doc
+	}
+	doc.text = change(doc.text)
+	doc.version = version
+	if doc.timer != nil {
+		doc.timer.Stop()
+	}
+	if doc.cancel != nil {
+		doc.cancel()
+		doc.cancel = nil
+	}
+	doc.timer = time.AfterFunc(delay, func() { s.lint(uri, version) })
+}
+
+// lint lints a version of a document, and publishes the diagnostics, unless the document changed in the meantime.
+func (s *Server) lint(uri string, version int) {
+	s.lock.Lock()
+	doc, ok := s.documents[uri]
+	if !ok || doc.version != version || s.ctx == nil {
+		s.lock.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	doc.cancel = cancel
+	text := doc.text
+	s.lock.Unlock()
+	defer cancel()
+
+	results := s.Lint(ctx, uri, []byte(text))
+	if ctx.Err() != nil {
+		return
+	}
+
+	s.lock.Lock()
+	current := s.documents[uri] == doc && doc.version == version
+	s.lock.Unlock()
+	if current {
+		s.publish(uri, &version, Diagnostics(text, results))
+	}
+}
+
+// close forgets a document, stopping any linting of it.
+func (s *Server) close(uri string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if doc, ok := s.documents[uri]; ok {
+		if doc.timer != nil {
+			doc.timer.Stop()
+		}
+		if doc.cancel != nil {
+			doc.cancel()
+		}
+		delete(s.documents, uri)
+	}
+}
+
+// closeAll forgets every document.
+func (s *Server) closeAll() {
+	s.lock.Lock()
+	uris := make([]string, 0, len(s.documents))
+	for uri := range s.documents {
+		uris = append(uris, uri)
+	}
+	s.lock.Unlock()
+	for _, uri := range uris {
+		s.close(uri)
+	}
+}
+
+// Diagnostics converts the results of linting a text to diagnostics.
+func Diagnostics(text string, results []model.RuleFunctionResult) []Diagnostic {
+	lines := strings.Split(text, "\n")
+	diagnostics := make([]Diagnostic, 0, len(results))
+	for _, result := range results {
+		d := Diagnostic{Message: result.Message, Source: "vacuum", Severity: SeverityWarning}
+		if result.Rule != nil {
+			d.Code = result.Rule.Id
+			switch result.Rule.Severity {
+			case model.SeverityError:
+				d.Severity = SeverityError
+			case model.SeverityInfo:
+				d.Severity = SeverityInformation
+			case model.SeverityHint:
+				d.Severity = SeverityHint
+			}
+		}
+		switch {
+		case result.StartNode != nil:
+			d.Range.Start = position(lines, result.StartNode.Line, result.StartNode.Column)
+			end := result.StartNode
+			if result.EndNode != nil && result.EndNode.Line >= end.Line {
+				end = result.EndNode
+			}
+			// the range ends at the end of the value of the end node, if it is on one line.
+			column := end.Column
+			if !strings.Contains(end.Value, "\n") {
+				column += len([]rune(end.Value))
+			}
+			d.Range.End = position(lines, end.Line, column)
+		default:
+			d.Range.Start = position(lines, result.Range.Start.Line, result.Range.Start.Char)
+			d.Range.End = position(lines, result.Range.End.Line, result.Range.End.Char)
+		}
+		if d.Range.End.Line < d.Range.Start.Line ||
+			(d.Range.End.Line == d.Range.Start.Line && d.Range.End.Character < d.Range.Start.Character) {
+			d.Range.End = d.Range.Start
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
+
+// publish sends the diagnostics of a document to the client.
+func (s *Server) publish(uri string, version *int, diagnostics []Diagnostic) {
+	params, _ := json.Marshal(publishDiagnosticsParams{URI: uri, Version: version, Diagnostics: diagnostics})
+	s.write(&message{Method: "textDocument/publishDiagnostics", Params: params})
+}
+
+// respond sends the result of a request.
+func (s *Server) respond(id *json.RawMessage, result any) {
+	if result == nil {
+		// a null result must still be sent, the field can't be omitted.
+		result = json.RawMessage("null")
+	}
+	s.write(&message{ID: id, Result: result})
+}
+
+// respondError sends the error of a request.
+func (s *Server) respondError(id *json.RawMessage, code int, msg string) {
+	if id == nil {
+		null := json.RawMessage("null")
+		id = &null
+	}
+	s.write(&message{ID: id, Error: &responseError{Code: code, Message: msg}})
+}
+
+// write sends a message to the client, messages are written one at a time.
+func (s *Server) write(msg *message) {
+	msg.JSONRPC = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		s.Logger.Error("unable to encode message", "error", err.Error())
+		return
+	}
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	if _, err = fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		s.Logger.Error("unable to write message", "error", err.Error())
+	}
+}
+
+// readMessage reads the body of a message, messages have headers (only `Content-Length` is used), a blank line,
+// then the body.
+func readMessage(reader *bufio.Reader) ([]byte, error) {
+	length, headers := -1, 0
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) && line == "" && headers == 0 {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("unable to read message header: %w", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if headers == 0 {
+				// blank lines between messages are skipped.
+				continue
+			}
+			if length < 0 {
+				return nil, errors.New("message has no Content-Length header")
+			}
+			break
+		}
+		headers++
+		name, value, found := strings.Cut(line, ":")
+		if found && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			if length, err = strconv.Atoi(strings.TrimSpace(value)); err != nil || length < 0 {
+				return nil, fmt.Errorf("invalid Content-Length header '%s'", strings.TrimSpace(value))
+			}
+		}
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return nil, fmt.Errorf("unable to read message body: %w", err)
+	}
+	return body, nil
+}
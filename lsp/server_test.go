@@ -0,0 +1,194 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// client is the editor end of a connection to a server.
+type client struct {
+	t      *testing.T
+	in     io.WriteCloser
+	reader *bufio.Reader
+	done   chan error
+}
+
+func newClient(t *testing.T, s *Server) *client {
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+	c := &client{t: t, in: clientOut, reader: bufio.NewReader(clientIn), done: make(chan error, 1)}
+	go func() {
+		c.done <- s.Serve(context.Background(), serverIn, serverOut)
+		_ = serverOut.Close()
+	}()
+	return c
+}
+
+func (c *client) send(id int, method string, params any) {
+	msg := map[string]any{"jsonrpc": "2.0", "method": method, "params": params}
+	if id > 0 {
+		msg["id"] = id
+	}
+	body, _ := json.Marshal(msg)
+	_, err := fmt.Fprintf(c.in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	assert.NoError(c.t, err)
+}
+
+func (c *client) receive() message {
+	body, err := readMessage(c.reader)
+	assert.NoError(c.t, err)
+	var msg message
+	assert.NoError(c.t, json.Unmarshal(body, &msg))
+	return msg
+}
+
+func (c *client) diagnostics() publishDiagnosticsParams {
+	for {
+		msg := c.receive()
+		if msg.Method == "textDocument/publishDiagnostics" {
+			var params publishDiagnosticsParams
+			assert.NoError(c.t, json.Unmarshal(msg.Params, &params))
+			return params
+		}
+	}
+}
+
+func TestApplyChanges(t *testing.T) {
+	text := "openapi: 3.1.0\ninfo:\n  title: pizza\n"
+
+	// replace `pizza` with `burger`, then insert a line.
+	text = applyChanges(text, []textDocumentContentChangeEvent{
+		{Range: &Range{Start: Position{Line: 2, Character: 9}, End: Position{Line: 2, Character: 14}}, Text: "burger"},
+		{Range: &Range{Start: Position{Line: 3}, End: Position{Line: 3}}, Text: "paths: {}\n"},
+	})
+	assert.Equal(t, "openapi: 3.1.0\ninfo:\n  title: burger\npaths: {}\n", text)
+
+	// characters are UTF-16 code units, the pizza emoji is two.
+	text = applyChanges("title: 🍕 pizza", []textDocumentContentChangeEvent{
+		{Range: &Range{Start: Position{Character: 10}, End: Position{Character: 15}}, Text: "slice"},
+	})
+	assert.Equal(t, "title: 🍕 slice", text)
+
+	// a change without a range replaces the document.
+	assert.Equal(t, "swagger: '2.0'", applyChanges(text, []textDocumentContentChangeEvent{{Text: "swagger: '2.0'"}}))
+}
+
+func TestDiagnostics(t *testing.T) {
+	text := "openapi: 3.1.0\ninfo:\n  title: 🍕 pizza\n"
+	key := &yaml.Node{Line: 3, Column: 3, Value: "title"}
+	value := &yaml.Node{Line: 3, Column: 10, Value: "🍕 pizza"}
+	results := []model.RuleFunctionResult{
+		{
+			Message:   "title is too tasty",
+			StartNode: key,
+			EndNode:   value,
+			Rule:      &model.Rule{Id: "tasty-title", Severity: model.SeverityError},
+		},
+	}
+
+	diagnostics := Diagnostics(text, results)
+
+	assert.Len(t, diagnostics, 1)
+	assert.Equal(t, Diagnostic{
+		Range:    Range{Start: Position{Line: 2, Character: 2}, End: Position{Line: 2, Character: 17}},
+		Severity: SeverityError,
+		Code:     "tasty-title",
+		Source:   "vacuum",
+		Message:  "title is too tasty",
+	}, diagnostics[0])
+}
+
+func TestServer_DebouncesChanges(t *testing.T) {
+	var lock sync.Mutex
+	var linted []string
+	s := NewServer(func(ctx context.Context, uri string, spec []byte) []model.RuleFunctionResult {
+		lock.Lock()
+		linted = append(linted, string(spec))
+		lock.Unlock()
+		return []model.RuleFunctionResult{{Message: "pizza", StartNode: &yaml.Node{Line: 1, Column: 1}}}
+	})
+	s.Debounce = 100 * time.Millisecond
+	c := newClient(t, s)
+
+	c.send(1, "initialize", map[string]any{})
+	result, _ := json.Marshal(c.receive().Result)
+	assert.Contains(t, string(result), `"change":2`)
+	c.send(0, "initialized", map[string]any{})
+
+	uri := "file:///specs/pizza.yaml"
+	c.send(0, "textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "version": 1, "text": "openapi: 3.1.0\n"},
+	})
+	assert.Equal(t, 1, *c.diagnostics().Version)
+
+	// a burst of edits is linted once.
+	for version := 2; version <= 5; version++ {
+		c.send(0, "textDocument/didChange", map[string]any{
+			"textDocument": map[string]any{"uri": uri, "version": version},
+			"contentChanges": []map[string]any{{
+				"range": Range{Start: Position{Line: 1}, End: Position{Line: 1}},
+				"text":  fmt.Sprintf("# %d\n", version),
+			}},
+		})
+	}
+	published := c.diagnostics()
+	assert.Equal(t, 5, *published.Version)
+	assert.Len(t, published.Diagnostics, 1)
+	lock.Lock()
+	assert.Equal(t, []string{"openapi: 3.1.0\n", "openapi: 3.1.0\n# 5\n# 4\n# 3\n# 2\n"}, linted)
+	lock.Unlock()
+
+	c.send(0, "textDocument/didClose", map[string]any{"textDocument": map[string]any{"uri": uri}})
+	assert.Len(t, c.diagnostics().Diagnostics, 0)
+
+	c.send(2, "shutdown", nil)
+	assert.Nil(t, c.receive().Error)
+	c.send(0, "exit", nil)
+	assert.NoError(t, <-c.done)
+}
+
+func TestServer_Errors(t *testing.T) {
+	c := newClient(t, NewServer(func(context.Context, string, []byte) []model.RuleFunctionResult { return nil }))
+
+	c.send(1, "textDocument/hover", map[string]any{})
+	assert.Equal(t, codeServerNotInitialized, c.receive().Error.Code)
+
+	c.send(2, "initialize", map[string]any{})
+	c.receive()
+	c.send(3, "textDocument/hover", map[string]any{})
+	assert.Equal(t, codeMethodNotFound, c.receive().Error.Code)
+
+	c.send(0, "exit", nil)
+	assert.Error(t, <-c.done)
+}
+
+func TestMotorLinter(t *testing.T) {
+	defaultRuleSets := rulesets.BuildDefaultRuleSets()
+	lint := MotorLinter(motor.RuleSetExecution{
+		RuleSet:     defaultRuleSets.GenerateOpenAPIRecommendedRuleSet(),
+		SilenceLogs: true,
+	})
+
+	spec := "openapi: 3.1.0\ninfo:\n  title: pizza\n  version: 1.0.0\npaths:\n  /pizza:\n    get:\n      responses:\n" +
+		"        '200':\n          description: ok\n"
+	results := lint(context.Background(), "file:///specs/pizza.yaml", []byte(spec))
+	diagnostics := Diagnostics(spec, results)
+
+	assert.NotEmpty(t, diagnostics)
+	for _, d := range diagnostics {
+		assert.NotEmpty(t, d.Code)
+		assert.Equal(t, "vacuum", d.Source)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// NodeEdit is a change to a document, proposed to fix a result. The node to change is identified by its position
+// (Line and Column) rather than by pointer, so an edit can be applied to any parse of the same document, resolved
+// or not. If a Key is set, the key is added to the mapping at that position with Value as its value, otherwise
+// Value replaces the value of the scalar at that position.
+type NodeEdit struct {
+	Line   int    `json:"line" yaml:"line"`                   // The line of the node to change
+	Column int    `json:"column" yaml:"column"`               // The column of the node to change
+	Key    string `json:"key,omitempty" yaml:"key,omitempty"` // The key to add to a mapping
+	Value  string `json:"value" yaml:"value"`                 // The new value of the scalar, or of the added key
+}
+
+// FixableRuleFunction is a RuleFunction that can also propose fixes for the results it produces. Fixes are only
+// proposed for rules that opt in to them, by setting AutoFix.
+type FixableRuleFunction interface {
+	RuleFunction
+	Fix(result RuleFunctionResult, context RuleFunctionContext) []NodeEdit // Propose edits that fix a result.
+}
+
+// ApplyNodeEdits applies edits to a parsed document and returns the number of edits applied. Edits are skipped if
+// there is no node of the right kind at their position, or if the key they add already exists.
+func ApplyNodeEdits(root *yaml.Node, edits []NodeEdit) int {
+	if root == nil || len(edits) == 0 {
+		return 0
+	}
+
+	// mappings share their position with their first key, so nodes are indexed by position and kind.
+	type position struct {
+		line, column int
+		kind         yaml.Kind
+	}
+	nodes := make(map[position]*yaml.Node)
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		p := position{node.Line, node.Column, node.Kind}
+		if nodes[p] == nil {
+			nodes[p] = node
+		}
+		for _, n := range node.Content {
+			walk(n)
+		}
+	}
+	walk(root)
+
+	applied := 0
+	for _, edit := range edits {
+		if edit.Key == "" {
+			node := nodes[position{edit.Line, edit.Column, yaml.ScalarNode}]
+			if node == nil || node.Value == edit.Value {
+				continue
+			}
+			node.Value = edit.Value
+			applied++
+			continue
+		}
+		node := nodes[position{edit.Line, edit.Column, yaml.MappingNode}]
+		if node == nil {
+			continue
+		}
+		exists := false
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			exists = exists || node.Content[i].Value == edit.Key
+		}
+		if exists {
+			continue
+		}
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: edit.Key},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: edit.Value})
+		applied++
+	}
+	return applied
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// NDJSONWriter writes results as newline delimited JSON (JSON-Lines), one Spectral compatible report item per line.
+// Every line is a complete JSON object, so the output can be piped into a log pipeline and read one line at a time,
+// without ever holding the whole report in memory. Results are written (and flushed, if the underlying writer
+// supports it) as soon as they are supplied. It is safe to write results from multiple goroutines.
+type NDJSONWriter struct {
+	writer io.Writer
+	lock   sync.Mutex
+	count  int
+}
+
+// NewNDJSONWriter creates a new NDJSONWriter that writes results to the supplied writer.
+func NewNDJSONWriter(writer io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{writer: writer}
+}
+
+// Write will serialize a single result into a line, using the supplied source (usually the file name of the
+// specification) and write it. Results without a rule or without a location are skipped.
+func (nw *NDJSONWriter) Write(result *RuleFunctionResult, source string) error {
+	if result == nil || result.Rule == nil || result.StartNode == nil || result.EndNode == nil {
+		return nil
+	}
+	// sources like `<stdin>` and messages quoting markup are written as they are, not as `\u003cstdin\u003e`.
+	var line bytes.Buffer
+	encoder := json.NewEncoder(&line)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(generateSpectralReportItem(result, source)); err != nil {
+		return err
+	}
+
+	nw.lock.Lock()
+	defer nw.lock.Unlock()
+	if _, err := nw.writer.Write(line.Bytes()); err != nil {
+		return err
+	}
+	nw.count++
+	return nw.flush()
+}
+
+// WriteResults will write every result in the supplied slice, stopping at the first error.
+func (nw *NDJSONWriter) WriteResults(results []*RuleFunctionResult, source string) error {
+	for _, result := range results {
+		if err := nw.Write(result, source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Count returns the number of results that have been written.
+func (nw *NDJSONWriter) Count() int {
+	nw.lock.Lock()
+	defer nw.lock.Unlock()
+	return nw.count
+}
+
+// flush will flush the underlying writer, if it buffers output (like a bufio.Writer or an http.ResponseWriter).
+func (nw *NDJSONWriter) flush() error {
+	switch w := nw.writer.(type) {
+	case interface{ Flush() error }:
+		return w.Flush()
+	case interface{ Flush() }:
+		w.Flush()
+	}
+	return nil
+}
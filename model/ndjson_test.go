@@ -0,0 +1,97 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNDJSONWriter_Write(t *testing.T) {
+
+	r1 := &RuleFunctionResult{Rule: &Rule{Id: "one", Severity: SeverityError},
+		Message: "multi\nline \"message\"", Path: "$.paths./pizza.get",
+		StartNode: &yaml.Node{Line: 1, Column: 10}, EndNode: &yaml.Node{Line: 20, Column: 20}}
+	r2 := &RuleFunctionResult{Rule: &Rule{Id: "two", Severity: SeverityInfo}, Message: "two",
+		StartNode: &yaml.Node{Line: 2, Column: 4}, EndNode: &yaml.Node{Line: 2, Column: 8}}
+	noLocation := &RuleFunctionResult{Rule: &Rule{Id: "three"}, Message: "three"}
+
+	var buf bytes.Buffer
+	writer := NewNDJSONWriter(&buf)
+	assert.NoError(t, writer.WriteResults([]*RuleFunctionResult{r1, noLocation, r2}, "pizza.yaml"))
+	assert.Equal(t, 2, writer.Count())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 2)
+
+	var first, second reports.SpectralReport
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+	assert.Equal(t, "one", first.Code)
+	assert.Equal(t, "multi\nline \"message\"", first.Message)
+	assert.Equal(t, []string{"paths", "/pizza", "get"}, first.Path)
+	assert.Equal(t, 0, first.Severity)
+	assert.Equal(t, 20, first.Range.End.Line)
+	assert.Equal(t, "pizza.yaml", first.Source)
+	assert.Equal(t, "two", second.Code)
+	assert.Equal(t, 2, second.Severity)
+}
+
+func TestNDJSONWriter_Write_NoHTMLEscaping(t *testing.T) {
+
+	r := &RuleFunctionResult{Rule: &Rule{Id: "one"}, Message: "`<b>` & `<i>` are not allowed",
+		StartNode: &yaml.Node{Line: 1, Column: 1}, EndNode: &yaml.Node{Line: 1, Column: 2}}
+
+	var buf bytes.Buffer
+	writer := NewNDJSONWriter(&buf)
+	assert.NoError(t, writer.Write(r, "<stdin>"))
+
+	assert.Contains(t, buf.String(), `"source":"<stdin>"`)
+	assert.Contains(t, buf.String(), "\"message\":\"`<b>` & `<i>` are not allowed\"")
+	assert.True(t, strings.HasSuffix(buf.String(), "}\n"))
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+}
+
+func TestNDJSONWriter_Write_Flush(t *testing.T) {
+
+	var buf bytes.Buffer
+	buffered := bufio.NewWriterSize(&buf, 4096)
+	writer := NewNDJSONWriter(buffered)
+
+	r := &RuleFunctionResult{Rule: &Rule{Id: "one", Severity: SeverityWarn}, Message: "one",
+		StartNode: &yaml.Node{Line: 1, Column: 1}, EndNode: &yaml.Node{Line: 1, Column: 1}}
+	assert.NoError(t, writer.Write(r, "pizza.yaml"))
+
+	// the line must be available straight away, not when the buffer fills up.
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+}
+
+func TestNDJSONWriter_Write_Concurrent(t *testing.T) {
+
+	var buf bytes.Buffer
+	writer := NewNDJSONWriter(&buf)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := &RuleFunctionResult{Rule: &Rule{Id: "one", Severity: SeverityWarn}, Message: "one",
+				StartNode: &yaml.Node{Line: 1, Column: 1}, EndNode: &yaml.Node{Line: 1, Column: 1}}
+			_ = writer.Write(r, "pizza.yaml")
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 50, writer.Count())
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		var report reports.SpectralReport
+		assert.NoError(t, json.Unmarshal([]byte(line), &report))
+	}
+}
@@ -77,50 +77,92 @@ func NewRuleResultSetPointer(results []*RuleFunctionResult) *RuleResultSet {
 	}
 }
 
+// SetOrigin records where the results of the set came from (like the file name of a specification), on every result
+// that doesn't have an origin yet. Set the origin of each set before merging them (see MergeResultSets).
+func (rr *RuleResultSet) SetOrigin(origin string) {
+	for _, res := range rr.Results {
+		if res != nil && res.Origin == "" {
+			res.Origin = origin
+		}
+	}
+}
+
+// MergeResultSets will combine multiple sets of results (like the results of linting several files, or of running
+// several rulesets) into a single set. Results are kept in the order of the sets they came from, and the results
+// themselves are not copied, so everything they carry (rule, range, path, nodes and origin) is preserved. A result
+// that is part of more than one set is only merged once, and nil sets are ignored. Counts are re-calculated from the
+// merged results, rather than added up from the sets.
+func MergeResultSets(sets ...*RuleResultSet) *RuleResultSet {
+	var results []*RuleFunctionResult
+	seen := make(map[*RuleFunctionResult]bool)
+	for _, set := range sets {
+		if set == nil {
+			continue
+		}
+		for _, res := range set.Results {
+			if res == nil || seen[res] {
+				continue
+			}
+			seen[res] = true
+			results = append(results, res)
+		}
+	}
+	rrs := NewRuleResultSetPointer(results)
+	rrs.ErrorCount = getCount(rrs, SeverityError)
+	rrs.WarnCount = getCount(rrs, SeverityWarn)
+	rrs.InfoCount = getCount(rrs, SeverityInfo)
+	return rrs
+}
+
 // GenerateSpectralReport will return a Spectral compatible report structure, easily serializable
 func (rr *RuleResultSet) GenerateSpectralReport(source string) []reports.SpectralReport {
 
 	var report []reports.SpectralReport
 	for _, result := range rr.Results {
+		report = append(report, generateSpectralReportItem(result, source))
+	}
+	return report
+}
 
-		sev := 1
-		switch result.Rule.Severity {
-		case SeverityError:
-			sev = 0
-		case SeverityInfo:
-			sev = 2
-		case SeverityHint:
-			sev = 3
-		}
+// generateSpectralReportItem converts a single result into a Spectral compatible report item.
+func generateSpectralReportItem(result *RuleFunctionResult, source string) reports.SpectralReport {
+
+	sev := 1
+	switch result.Rule.Severity {
+	case SeverityError:
+		sev = 0
+	case SeverityInfo:
+		sev = 2
+	case SeverityHint:
+		sev = 3
+	}
 
-		resultRange := reports.Range{
-			Start: reports.RangeItem{
-				Line: result.StartNode.Line,
-				Char: result.StartNode.Column,
-			},
-			End: reports.RangeItem{
-				Line: result.EndNode.Line,
-				Char: result.EndNode.Column,
-			},
-		}
-		var path []string
-		pathArr := strings.Split(result.Path, ".")
-		for _, pItem := range pathArr {
-			if pItem != "$" {
-				path = append(path, pItem)
-			}
+	resultRange := reports.Range{
+		Start: reports.RangeItem{
+			Line: result.StartNode.Line,
+			Char: result.StartNode.Column,
+		},
+		End: reports.RangeItem{
+			Line: result.EndNode.Line,
+			Char: result.EndNode.Column,
+		},
+	}
+	var path []string
+	pathArr := strings.Split(result.Path, ".")
+	for _, pItem := range pathArr {
+		if pItem != "$" {
+			path = append(path, pItem)
 		}
+	}
 
-		report = append(report, reports.SpectralReport{
-			Code:     result.Rule.Id,
-			Path:     path,
-			Message:  result.Message,
-			Severity: sev,
-			Range:    resultRange,
-			Source:   source,
-		})
+	return reports.SpectralReport{
+		Code:     result.Rule.Id,
+		Path:     path,
+		Message:  result.Message,
+		Severity: sev,
+		Range:    resultRange,
+		Source:   source,
 	}
-	return report
 }
 
 // GetErrorCount will return the number of errors returned by the rule results.
@@ -182,6 +224,48 @@ func (rr *RuleResultSet) GetResultsByRuleCategory(category string) []*RuleFuncti
 	return results
 }
 
+// AddRuleCategories adds the custom categories declared by a ruleset (in the order of their ids) to the set, so
+// they are returned by GetRuleCategories even when none of their rules returned results.
+func (rr *RuleResultSet) AddRuleCategories(categories map[string]*RuleCategory) {
+	var ids []string
+	for id := range categories {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if categories[id] == nil || RuleCategories[id] != nil {
+			continue
+		}
+		rr.categories = append(rr.categories, categories[id])
+	}
+}
+
+// GetRuleCategories returns all the built-in categories in order, followed by the custom categories added with
+// AddRuleCategories, and then any other custom categories used by rules that returned results, in the order they
+// were first seen.
+func (rr *RuleResultSet) GetRuleCategories() []*RuleCategory {
+	cats := append([]*RuleCategory{}, RuleCategoriesOrdered...)
+	seen := make(map[string]bool)
+	for _, cat := range rr.categories {
+		if !seen[cat.Id] {
+			seen[cat.Id] = true
+			cats = append(cats, cat)
+		}
+	}
+	for _, result := range rr.Results {
+		if result.Rule == nil || result.Rule.RuleCategory == nil {
+			continue
+		}
+		cat := result.Rule.RuleCategory
+		if RuleCategories[cat.Id] != nil || seen[cat.Id] {
+			continue
+		}
+		seen[cat.Id] = true
+		cats = append(cats, cat)
+	}
+	return cats
+}
+
 // GetErrorsByRuleCategory will return all results with an error level severity from rule category.
 func (rr *RuleResultSet) GetErrorsByRuleCategory(category string) []*RuleFunctionResult {
 	var filtered []*RuleFunctionResult
@@ -238,6 +322,15 @@ func (rr *RuleResultSet) GetHintByRuleCategory(category string) []*RuleFunctionR
 // compiled statistics for easy indexing.
 func (rr *RuleResultSet) GetRuleResultsForCategory(category string) *RuleResultsForCategory {
 	cat := RuleCategories[category]
+	if cat == nil {
+		// could be a custom category.
+		for _, c := range rr.GetRuleCategories() {
+			if c.Id == category {
+				cat = c
+				break
+			}
+		}
+	}
 	if cat == nil {
 		return nil
 	}
@@ -49,3 +49,59 @@ func TestRuleResultSet_PrepareForSerialization(t *testing.T) {
 	}
 
 }
+
+func TestMergeResultSets(t *testing.T) {
+
+	errRule := &Rule{Id: "one", Severity: SeverityError, RuleCategory: RuleCategories[CategoryInfo]}
+	warnRule := &Rule{Id: "two", Severity: SeverityWarn, RuleCategory: RuleCategories[CategoryOperations]}
+	infoRule := &Rule{Id: "three", Severity: SeverityInfo, RuleCategory: RuleCategories[CategorySchemas]}
+
+	a := NewRuleResultSet([]RuleFunctionResult{
+		{Message: "a1", Rule: errRule, Path: "$.info", StartNode: &yaml.Node{Line: 10}},
+		{Message: "a2", Rule: warnRule, Path: "$.paths", StartNode: &yaml.Node{Line: 2}},
+	})
+	b := NewRuleResultSet([]RuleFunctionResult{
+		{Message: "b1", Rule: infoRule, Path: "$.components", StartNode: &yaml.Node{Line: 1}},
+	})
+	c := NewRuleResultSetPointer([]*RuleFunctionResult{
+		{Message: "c1", Rule: errRule, Path: "$.servers", RuleId: "one", Given: "$.servers"},
+		a.Results[0], // already part of a, must not be counted twice.
+	})
+
+	a.SetOrigin("a.yaml")
+	b.SetOrigin("b.yaml")
+	c.SetOrigin("c.yaml") // the result already part of a keeps its origin.
+
+	merged := MergeResultSets(a, nil, b, c)
+
+	var messages []string
+	for _, res := range merged.Results {
+		messages = append(messages, res.Message)
+	}
+	assert.Equal(t, []string{"a1", "a2", "b1", "c1"}, messages)
+	assert.Equal(t, 2, merged.ErrorCount)
+	assert.Equal(t, 1, merged.WarnCount)
+	assert.Equal(t, 1, merged.InfoCount)
+	assert.Equal(t, 2, merged.GetErrorCount())
+
+	// results are not copied, and keep where they came from.
+	assert.Same(t, b.Results[0], merged.Results[2])
+	assert.Equal(t, "$.servers", merged.Results[3].Given)
+	assert.Len(t, merged.GetResultsByRuleCategory(CategoryOperations), 1)
+	var origins []string
+	for _, res := range merged.Results {
+		origins = append(origins, res.Origin)
+	}
+	assert.Equal(t, []string{"a.yaml", "a.yaml", "b.yaml", "c.yaml"}, origins)
+
+	// merging doesn't change the sets.
+	assert.Len(t, a.Results, 2)
+	assert.Equal(t, 1, a.GetErrorCount())
+	assert.Equal(t, merged.Results, MergeResultSets(a, b, c).Results)
+}
+
+func TestMergeResultSets_Empty(t *testing.T) {
+	merged := MergeResultSets()
+	assert.Empty(t, merged.Results)
+	assert.Equal(t, 0, merged.GetErrorCount())
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"context"
 	_ "embed" // embedding is not supported by golint,
 	"encoding/json"
 	"github.com/daveshanley/vacuum/model/reports"
@@ -46,6 +47,7 @@ type RuleFunctionContext struct {
 	SpecInfo   *datamodel.SpecInfo `json:"specInfo,omitempty" yaml:"specInfo,omitempty"`     // A reference to all specification information for the spec being parsed.
 	Document   libopenapi.Document `json:"-" yaml:"-"`                                       // A reference to the document being parsed
 	Logger     *slog.Logger        `json:"-" yaml:"-"`                                       // Custom logger
+	Context    context.Context     `json:"-" yaml:"-"`                                       // Cancelled when linting is aborted or times out, may be nil.
 }
 
 // RuleFunctionResult describes a failure with linting after being run through a rule
@@ -63,6 +65,20 @@ type RuleFunctionResult struct {
 	// ModelContext may or may nor be populated, depending on the rule used and the context of the rule. If it is
 	// populated, then this is a reference to the model that fired the rule. (not currently used yet)
 	ModelContext any `json:"-" yaml:"-"`
+
+	// Function, Given and Value describe what produced the result, for tooling (like IDE tooltips or fixes) that
+	// needs more than the message. They are populated when rules are applied.
+	Function string `json:"function,omitempty" yaml:"function,omitempty"` // The function that produced the result
+	Given    string `json:"given,omitempty" yaml:"given,omitempty"`       // The JSONPath `given` that matched the node
+	Value    string `json:"value,omitempty" yaml:"value,omitempty"`       // The matched value, truncated (see RenderNodeValue)
+
+	// Fixes are the edits proposed to fix the result, only rules with AutoFix set that use a FixableRuleFunction
+	// propose them.
+	Fixes []NodeEdit `json:"fixes,omitempty" yaml:"fixes,omitempty"`
+
+	// Origin is where the result came from (like the file name of a specification, or the name of a ruleset), so
+	// results merged from several sets (see MergeResultSets) can be told apart. It's set with SetOrigin.
+	Origin string `json:"origin,omitempty" yaml:"origin,omitempty"`
 }
 
 // RuleResultSet contains all the results found during a linting run, and all the methods required to
@@ -73,6 +89,7 @@ type RuleResultSet struct {
 	ErrorCount  int                                     `json:"errorCount" yaml:"errorCount"`               // Total errors
 	InfoCount   int                                     `json:"infoCount" yaml:"infoCount"`                 // Total info
 	categoryMap map[*RuleCategory][]*RuleFunctionResult `json:"-" yaml:"-"`
+	categories  []*RuleCategory                         `json:"-" yaml:"-"` // custom categories, see AddRuleCategories
 }
 
 // RuleFunction is any compatible structure that can be used to run vacuum rules.
@@ -104,6 +121,10 @@ type Rule struct {
 	RuleCategory       *RuleCategory  `json:"category,omitempty" yaml:"category,omitempty"`
 	Name               string         `json:"-" yaml:"-"`
 	HowToFix           string         `json:"howToFix,omitempty" yaml:"howToFix,omitempty"`
+	AutoFix            bool           `json:"autoFix,omitempty" yaml:"autoFix,omitempty"` // propose fixes, if the function can
+
+	// GivenByFormat replaces Given for documents of a format, it is set when Given uses an alias scoped by format.
+	GivenByFormat map[string][]string `json:"givenByFormat,omitempty" yaml:"givenByFormat,omitempty"`
 }
 
 // RuleFunctionProperty is used by RuleFunctionSchema to describe the functionOptions a Rule accepts
@@ -149,6 +170,13 @@ func (rfs RuleFunctionSchema) GetPropertyDescription(name string) string {
 	return ""
 }
 
+// IsCancelled returns true if linting has been cancelled, or has timed out. Functions should check this as they
+// iterate over nodes, and return whatever results they have once it returns true. Existing functions that never
+// check it keep working, they are just not interrupted. A context with no Context set is never cancelled.
+func (rfc RuleFunctionContext) IsCancelled() bool {
+	return rfc.Context != nil && rfc.Context.Err() != nil
+}
+
 // ToJSON render out a rule to JSON.
 func (r Rule) ToJSON() string {
 	d, _ := json.Marshal(r)
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/santhosh-tekuri/jsonschema/v5"
@@ -483,3 +484,14 @@ func TestRuleResultsForCategory_Sort(t *testing.T) {
 	assert.Equal(t, "three", catResults.RuleResults[0].Rule.Description) // first result should be lowest sev.
 
 }
+
+func TestRuleFunctionContext_IsCancelled(t *testing.T) {
+	assert.False(t, RuleFunctionContext{}.IsCancelled())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rfc := RuleFunctionContext{Context: ctx}
+	assert.False(t, rfc.IsCancelled())
+
+	cancel()
+	assert.True(t, rfc.IsCancelled())
+}
@@ -14,6 +14,9 @@ const (
 	OAS31 = "oas3_1"
 )
 
+// MaxResultValueLength is the maximum number of characters of a matched value, kept by a RuleFunctionResult.
+const MaxResultValueLength = 256
+
 var OAS3_1Format = []string{OAS31}
 var OAS3Format = []string{OAS3}
 var OAS3AllFormat = []string{OAS3, OAS31}
@@ -125,6 +128,9 @@ func countPropsInterface(options map[string]interface{}, numProps int) int {
 		if _, ok := v.(int); ok {
 			numProps++
 		}
+		if _, ok := v.(float64); ok {
+			numProps++
+		}
 		if _, ok := v.(bool); ok {
 			numProps++
 		}
@@ -186,3 +192,100 @@ func CompileRegex(context RuleFunctionContext, pattern string, results *[]RuleFu
 	}
 	return compiledRegex
 }
+
+var nodeValueEscaper = strings.NewReplacer("\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// RenderNodeValue renders a node as a compact, single line string that is no longer than maxLength characters
+// (truncated values end with `...`). Scalars render as their value, maps as `{key: value}` and sequences as
+// `[value]`. Scalars are cut down to the limit before they are escaped, and rendering stops as soon as the limit is
+// reached, so it is safe to use on very large nodes.
+func RenderNodeValue(node *yaml.Node, maxLength int) string {
+	return RenderExpandedNodeValue(node, maxLength, nil)
+}
+
+// RenderExpandedNodeValue renders a node like RenderNodeValue, but every node is passed to expand before it's
+// rendered, and the node expand returns is rendered instead. Nodes are only expanded until the limit is reached.
+func RenderExpandedNodeValue(node *yaml.Node, maxLength int, expand func(node *yaml.Node) *yaml.Node) string {
+	if node == nil || maxLength <= 0 {
+		return ""
+	}
+	w := &nodeValueWriter{remaining: maxLength}
+	var render func(n *yaml.Node)
+	render = func(n *yaml.Node) {
+		if w.truncated {
+			return
+		}
+		if expand != nil {
+			n = expand(n)
+		}
+		switch n.Kind {
+		case yaml.DocumentNode:
+			for _, c := range n.Content {
+				render(c)
+			}
+		case yaml.MappingNode:
+			w.write("{")
+			for i := 0; i < len(n.Content)-1 && !w.truncated; i += 2 {
+				if i > 0 {
+					w.write(", ")
+				}
+				render(n.Content[i])
+				w.write(": ")
+				render(n.Content[i+1])
+			}
+			w.write("}")
+		case yaml.SequenceNode:
+			w.write("[")
+			for i := 0; i < len(n.Content) && !w.truncated; i++ {
+				if i > 0 {
+					w.write(", ")
+				}
+				render(n.Content[i])
+			}
+			w.write("]")
+		case yaml.AliasNode:
+			w.write("*" + n.Value)
+		default:
+			// escaping never shortens a value, so anything past the limit can be dropped first.
+			w.write(nodeValueEscaper.Replace(firstRunes(n.Value, w.remaining+1)))
+		}
+	}
+	render(node)
+	if w.truncated {
+		return w.b.String() + "..."
+	}
+	return w.b.String()
+}
+
+// nodeValueWriter keeps the first characters written to it, until it has no characters remaining, everything
+// written after that is dropped and the value is marked as truncated.
+type nodeValueWriter struct {
+	b         strings.Builder
+	remaining int
+	truncated bool
+}
+
+func (w *nodeValueWriter) write(s string) {
+	if w.truncated {
+		return
+	}
+	for _, r := range s {
+		if w.remaining == 0 {
+			w.truncated = true
+			return
+		}
+		w.b.WriteRune(r)
+		w.remaining--
+	}
+}
+
+// firstRunes returns the first n characters of a string, or the whole string if it's shorter.
+func firstRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
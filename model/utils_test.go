@@ -3,6 +3,7 @@ package model
 import (
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
+	"strings"
 	"testing"
 )
 
@@ -139,6 +140,12 @@ func TestValidateRuleFunctionContextAgainstSchema_Success_SimulateYAML_Float(t *
 	assert.Len(t, errs, 0)
 }
 
+func TestCountPropsInterface_Numbers(t *testing.T) {
+	// options read from a YAML ruleset are converted to JSON first, so numbers arrive as float64.
+	opts := map[string]interface{}{"min": 1, "max": 3.0}
+	assert.Equal(t, 2, countPropsInterface(opts, 0))
+}
+
 func TestValidateRuleFunctionContextAgainstSchema_Success_SimulateYAML_InterfaceArray(t *testing.T) {
 
 	opts := make(map[string]interface{})
@@ -343,3 +350,47 @@ func (df dummyFuncMinMax) GetSchema() RuleFunctionSchema {
 func (df dummyFuncMinMax) RunRule(nodes []*yaml.Node, context RuleFunctionContext) []RuleFunctionResult {
 	return nil
 }
+
+func TestRenderNodeValue(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte("pizza:\n  toppings: [cheese, tomato]\n  name: \"multi\\nline\"\n  base: &b thin\n  crust: *b"), &node)
+
+	assert.Equal(t, "{pizza: {toppings: [cheese, tomato], name: multi\\nline, base: thin, crust: *b}}",
+		RenderNodeValue(&node, MaxResultValueLength))
+	assert.Equal(t, "{pizza: {...", RenderNodeValue(&node, 9))
+	assert.Equal(t, "", RenderNodeValue(nil, MaxResultValueLength))
+
+	// scalars are cut down before they are escaped, values that fit exactly are not truncated.
+	long := yaml.Node{Kind: yaml.ScalarNode, Value: strings.Repeat("pizza\n", 1<<20)}
+	assert.Equal(t, "pizza\\npi...", RenderNodeValue(&long, 9))
+	assert.Equal(t, "pizzã", RenderNodeValue(&yaml.Node{Kind: yaml.ScalarNode, Value: "pizzã"}, 5))
+	assert.Equal(t, "pizz...", RenderNodeValue(&yaml.Node{Kind: yaml.ScalarNode, Value: "pizzã"}, 4))
+
+	// rendering a large sequence stops at the limit.
+	items := &yaml.Node{Kind: yaml.SequenceNode}
+	for i := 0; i < 100000; i++ {
+		items.Content = append(items.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "cheese"})
+	}
+	assert.Equal(t, "[cheese, ch...", RenderNodeValue(items, 11))
+}
+
+func TestRenderExpandedNodeValue(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte("pizza: {}\nburger: {}"), &node)
+
+	expanded := 0
+	expand := func(n *yaml.Node) *yaml.Node {
+		if n.Kind == yaml.MappingNode && len(n.Content) == 0 {
+			expanded++
+			return &yaml.Node{Kind: yaml.ScalarNode, Value: "cheese"}
+		}
+		return n
+	}
+	assert.Equal(t, "{pizza: cheese, burger: cheese}", RenderExpandedNodeValue(&node, MaxResultValueLength, expand))
+	assert.Equal(t, 2, expanded)
+
+	// nothing is expanded past the limit.
+	expanded = 0
+	assert.Equal(t, "{pizza: ch...", RenderExpandedNodeValue(&node, 10, expand))
+	assert.Equal(t, 1, expanded)
+}
@@ -0,0 +1,400 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/daveshanley/vacuum/model"
+	"gopkg.in/yaml.v3"
+)
+
+// ApplyFixes applies the fixes proposed by results (see model.FixableRuleFunction) to a YAML specification, and
+// returns the fixed specification and the number of fixes applied. Each fix is spliced into the original bytes, at
+// the position of the node it changes, so everything a fix does not touch (comments, blank lines, quoting and
+// indentation) is kept byte for byte. If there is nothing to fix, the specification is returned untouched.
+//
+// The fixed specification is parsed again and checked against the original with the edits applied, if splicing
+// changed anything else, an error is returned. JSON specifications are rejected.
+func ApplyFixes(spec []byte, results []*model.RuleFunctionResult) ([]byte, int, error) {
+	var edits []model.NodeEdit
+	for _, result := range results {
+		edits = append(edits, result.Fixes...)
+	}
+	if len(edits) == 0 {
+		return spec, 0, nil
+	}
+	if trimmed := bytes.TrimSpace(spec); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return spec, 0, errors.New("fixes can only be applied to YAML specifications, not JSON")
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(spec, &root); err != nil {
+		return spec, 0, err
+	}
+
+	f := newFixSplicer(spec, &root)
+	var splices []fixSplice
+	for _, edit := range edits {
+		if s, ok := f.splice(edit); ok {
+			s.order = len(splices)
+			splices = append(splices, s)
+		}
+	}
+	if len(splices) == 0 {
+		return spec, 0, nil
+	}
+
+	// splices are applied from the end of the document, so the offsets of the others stay put. Additions at the
+	// same offset are applied in reverse, so they end up in the order they were proposed.
+	sort.Slice(splices, func(i, j int) bool {
+		if splices[i].start != splices[j].start {
+			return splices[i].start > splices[j].start
+		}
+		return splices[i].order > splices[j].order
+	})
+	fixed := append([]byte(nil), spec...)
+	for _, s := range splices {
+		fixed = append(fixed[:s.start], append([]byte(s.text), fixed[s.end:]...)...)
+	}
+
+	var expected, actual yaml.Node
+	_ = yaml.Unmarshal(spec, &expected)
+	model.ApplyNodeEdits(&expected, edits)
+	if err := yaml.Unmarshal(fixed, &actual); err != nil || !sameYAML(&expected, &actual) {
+		return spec, 0, errors.New("fixes could not be applied without changing the rest of the specification")
+	}
+	return fixed, len(splices), nil
+}
+
+// fixSplice replaces the bytes between start and end of a specification with text.
+type fixSplice struct {
+	start, end int
+	text       string
+	order      int
+}
+
+// fixPosition identifies a node by its position and kind, mappings share their position with their first key.
+type fixPosition struct {
+	line, column int
+	kind         yaml.Kind
+}
+
+// fixSplicer turns edits into splices of the bytes of a specification.
+type fixSplicer struct {
+	spec       []byte
+	lineStarts []int
+	nodes      map[fixPosition]*yaml.Node
+	flow       map[*yaml.Node]bool
+	owners     map[*yaml.Node]int
+	replaced   map[fixPosition]bool
+	added      map[fixPosition][]string
+	indent     int
+}
+
+func newFixSplicer(spec []byte, root *yaml.Node) *fixSplicer {
+	f := &fixSplicer{
+		spec:       spec,
+		lineStarts: []int{0},
+		nodes:      make(map[fixPosition]*yaml.Node),
+		flow:       make(map[*yaml.Node]bool),
+		owners:     make(map[*yaml.Node]int),
+		replaced:   make(map[fixPosition]bool),
+		added:      make(map[fixPosition][]string),
+		indent:     detectIndent(spec),
+	}
+	for i, b := range spec {
+		if b == '\n' {
+			f.lineStarts = append(f.lineStarts, i+1)
+		}
+	}
+	// the owner of a node is the column its key (or sequence entry) is indented by, the lines of a scalar have to
+	// be indented more than that.
+	var walk func(node *yaml.Node, flow bool, owner int)
+	walk = func(node *yaml.Node, flow bool, owner int) {
+		p := fixPosition{node.Line, node.Column, node.Kind}
+		if f.nodes[p] == nil {
+			f.nodes[p] = node
+		}
+		flow = flow || node.Style&yaml.FlowStyle != 0
+		f.flow[node] = flow
+		f.owners[node] = owner
+		for i, n := range node.Content {
+			switch {
+			case node.Kind == yaml.MappingNode && i%2 == 1:
+				walk(n, flow, node.Content[i-1].Column-1)
+			case node.Kind == yaml.SequenceNode:
+				walk(n, flow, node.Column-1)
+			default:
+				walk(n, flow, owner)
+			}
+		}
+	}
+	walk(root, false, -1)
+	return f
+}
+
+// splice returns the splice for an edit, edits are skipped (like model.ApplyNodeEdits skips them) if there is no
+// node of the right kind at their position, if they change nothing, or if the key they add already exists.
+func (f *fixSplicer) splice(edit model.NodeEdit) (fixSplice, bool) {
+	if edit.Key == "" {
+		p := fixPosition{edit.Line, edit.Column, yaml.ScalarNode}
+		node := f.nodes[p]
+		if node == nil || node.Value == edit.Value || f.replaced[p] {
+			return fixSplice{}, false
+		}
+		start := f.offset(node.Line, node.Column)
+		end, contentIndent := f.scalarEnd(node, start)
+		if contentIndent < 0 {
+			contentIndent = f.owners[node] + f.indent
+		}
+		value := &yaml.Node{Kind: yaml.ScalarNode, Tag: node.Tag, Style: node.Style, Value: edit.Value}
+		if f.flow[node] && value.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+			value.Style = yaml.DoubleQuotedStyle
+		}
+		text, ok := encodeFixNode(value, contentIndent)
+		if !ok {
+			return fixSplice{}, false
+		}
+		if f.flow[node] && strings.Contains(text, "\n") {
+			value.Style = yaml.DoubleQuotedStyle
+			text, _ = encodeFixNode(value, contentIndent)
+		}
+		f.replaced[p] = true
+		return fixSplice{start: start, end: end, text: text}, true
+	}
+
+	p := fixPosition{edit.Line, edit.Column, yaml.MappingNode}
+	node := f.nodes[p]
+	if node == nil || f.flow[node] {
+		return fixSplice{}, false
+	}
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == edit.Key {
+			return fixSplice{}, false
+		}
+	}
+	for _, key := range f.added[p] {
+		if key == edit.Key {
+			return fixSplice{}, false
+		}
+	}
+	indent := node.Column - 1
+	entry := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: edit.Key},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: edit.Value},
+	}}
+	text, ok := encodeFixNode(entry, indent)
+	if !ok {
+		return fixSplice{}, false
+	}
+	f.added[p] = append(f.added[p], edit.Key)
+
+	// the entry is added after the last line of the mapping, before any blank or comment lines that follow it.
+	at := f.mappingEnd(node)
+	text = strings.Repeat(" ", indent) + text
+	if at == len(f.spec) && len(f.spec) > 0 && f.spec[len(f.spec)-1] != '\n' {
+		return fixSplice{start: at, end: at, text: "\n" + text}, true
+	}
+	return fixSplice{start: at, end: at, text: text + "\n"}, true
+}
+
+// offset returns the byte offset of a line and (character) column.
+func (f *fixSplicer) offset(line, column int) int {
+	if line < 1 || line > len(f.lineStarts) {
+		return len(f.spec)
+	}
+	offset := f.lineStarts[line-1]
+	for i := 1; i < column && offset < len(f.spec) && f.spec[offset] != '\n'; i++ {
+		_, size := utf8.DecodeRune(f.spec[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// line returns the text of a line, without its line break.
+func (f *fixSplicer) line(line int) string {
+	if line < 1 || line > len(f.lineStarts) {
+		return ""
+	}
+	start, end := f.lineStarts[line-1], len(f.spec)
+	if line < len(f.lineStarts) {
+		end = f.lineStarts[line] - 1
+	}
+	return strings.TrimSuffix(string(f.spec[start:end]), "\r")
+}
+
+// lineIndent returns the number of spaces a line is indented by.
+func (f *fixSplicer) lineIndent(line int) int {
+	text := f.line(line)
+	return len(text) - len(strings.TrimLeft(text, " "))
+}
+
+// isBlankOrComment returns true if a line holds nothing, or only a comment.
+func (f *fixSplicer) isBlankOrComment(line int) bool {
+	trimmed := strings.TrimSpace(f.line(line))
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
+
+// lineEnd returns the offset of the end of a line, before its line break.
+func (f *fixSplicer) lineEnd(line int) int {
+	return f.lineStarts[line-1] + len(f.line(line))
+}
+
+// scalarEnd returns the offset of the end of the scalar starting at start, and the indentation of the content of
+// block scalars (or -1 for other scalars).
+func (f *fixSplicer) scalarEnd(node *yaml.Node, start int) (int, int) {
+	spec := f.spec
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		for i := start + 1; i < len(spec); i++ {
+			if spec[i] == '\\' {
+				i++
+			} else if spec[i] == '"' {
+				return i + 1, -1
+			}
+		}
+		return len(spec), -1
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		for i := start + 1; i < len(spec); i++ {
+			if spec[i] == '\'' {
+				if i+1 < len(spec) && spec[i+1] == '\'' {
+					i++
+					continue
+				}
+				return i + 1, -1
+			}
+		}
+		return len(spec), -1
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		// the header (like `|-`) ends at the first space, the content is every following line indented at least as
+		// much as the first, up to the last line that isn't blank.
+		end := start
+		for end < len(spec) && spec[end] != ' ' && spec[end] != '\n' && spec[end] != '\r' {
+			end++
+		}
+		contentIndent := -1
+		for l := node.Line + 1; l <= len(f.lineStarts); l++ {
+			if strings.TrimSpace(f.line(l)) == "" {
+				continue
+			}
+			if contentIndent < 0 {
+				contentIndent = f.lineIndent(l)
+				if contentIndent <= f.owners[node] {
+					return end, f.owners[node] + f.indent
+				}
+			}
+			if f.lineIndent(l) < contentIndent {
+				break
+			}
+			end = f.lineEnd(l)
+		}
+		if contentIndent < 0 {
+			contentIndent = f.owners[node] + f.indent
+		}
+		return end, contentIndent
+	}
+
+	// plain scalars end at a comment or the end of the line (or at flow indicators, in flow collections), and carry
+	// on over lines that are indented more than their owner.
+	text := f.line(node.Line)
+	lineStart := f.lineStarts[node.Line-1]
+	end := lineStart + len(text)
+	for i := start - lineStart; i < len(text); i++ {
+		if text[i] == '#' && i > 0 && (text[i-1] == ' ' || text[i-1] == '\t') {
+			end = lineStart + i
+			break
+		}
+		if f.flow[node] && strings.IndexByte(",[]{}", text[i]) >= 0 {
+			end = lineStart + i
+			break
+		}
+	}
+	end = start + len(strings.TrimRight(string(spec[start:end]), " \t"))
+	if f.flow[node] {
+		return end, -1
+	}
+	for l := node.Line + 1; l <= len(f.lineStarts); l++ {
+		trimmed := strings.TrimSpace(f.line(l))
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") || f.lineIndent(l) <= f.owners[node] {
+			break
+		}
+		end = f.lineEnd(l)
+	}
+	return end, -1
+}
+
+// mappingEnd returns the offset of the start of the line after the last line of a block mapping.
+func (f *fixSplicer) mappingEnd(node *yaml.Node) int {
+	indent := node.Column - 1
+	last := node.Line
+	for l := node.Line + 1; l <= len(f.lineStarts); l++ {
+		if f.isBlankOrComment(l) {
+			continue
+		}
+		text := f.line(l)
+		if f.lineIndent(l) < indent || (indent == 0 && (strings.HasPrefix(text, "---") ||
+			strings.HasPrefix(text, "..."))) {
+			break
+		}
+		last = l
+	}
+	if last == len(f.lineStarts) {
+		return len(f.spec)
+	}
+	return f.lineStarts[last]
+}
+
+// encodeFixNode encodes a node as YAML, with the lines after the first indented by indent.
+func encodeFixNode(node *yaml.Node, indent int) (string, bool) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(4)
+	if err := encoder.Encode(node); err != nil {
+		return "", false
+	}
+	if err := encoder.Close(); err != nil {
+		return "", false
+	}
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) != "" {
+			lines[i] = strings.Repeat(" ", indent) + strings.TrimPrefix(lines[i], "    ")
+		}
+	}
+	return strings.Join(lines, "\n"), true
+}
+
+// sameYAML returns true if two documents hold the same nodes, with the same values, ignoring styles, comments
+// and positions.
+func sameYAML(a, b *yaml.Node) bool {
+	if a.Kind != b.Kind || a.Value != b.Value || a.ShortTag() != b.ShortTag() || len(a.Content) != len(b.Content) {
+		return false
+	}
+	for i := range a.Content {
+		if !sameYAML(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// detectIndent returns the indentation used by the first indented mapping key of a YAML document, or 2.
+func detectIndent(spec []byte) int {
+	for _, line := range strings.Split(string(spec), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if indent := len(line) - len(trimmed); indent > 0 && trimmed != "" && !strings.HasPrefix(trimmed, "-") &&
+			!strings.HasPrefix(trimmed, "#") {
+			return indent
+		}
+	}
+	return 2
+}
@@ -0,0 +1,186 @@
+package motor
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+)
+
+var fixesTestSpec = `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+  description: "pizza api  "
+paths:
+  # all about pizza.
+  /pizzas/{id}:
+    get:
+      summary: get a pizza
+      responses:
+        "200":
+          description: |
+            a pizza, with toppings.   
+    delete:
+      operationId: deletePizza
+      responses:
+        "204":
+          description: deleted
+`
+
+func fixesTestRuleSet(autoFix bool) *rulesets.RuleSet {
+	opId := rulesets.GetOperationIdRule()
+	whitespace := rulesets.GetDescriptionTrailingWhitespaceRule()
+	opId.AutoFix, whitespace.AutoFix = autoFix, autoFix
+	return &rulesets.RuleSet{
+		Rules: map[string]*model.Rule{
+			opId.Id:       opId,
+			whitespace.Id: whitespace,
+		},
+	}
+}
+
+func TestApplyFixes(t *testing.T) {
+
+	results := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet: fixesTestRuleSet(true),
+		Spec:    []byte(fixesTestSpec),
+	})
+	assert.Len(t, results.Errors, 0)
+	assert.Len(t, results.Results, 3)
+
+	resultSet := model.NewRuleResultSet(results.Results)
+	fixed, applied, err := ApplyFixes([]byte(fixesTestSpec), resultSet.Results)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, applied)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+  description: "pizza api"
+paths:
+  # all about pizza.
+  /pizzas/{id}:
+    get:
+      summary: get a pizza
+      responses:
+        "200":
+          description: |
+            a pizza, with toppings.
+      operationId: getPizzasById
+    delete:
+      operationId: deletePizza
+      responses:
+        "204":
+          description: deleted
+`, string(fixed))
+
+	// once fixed, there is nothing left to fix.
+	results = ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet: fixesTestRuleSet(true),
+		Spec:    fixed,
+	})
+	assert.Len(t, results.Results, 0)
+}
+
+func TestApplyFixes_NotOptedIn(t *testing.T) {
+
+	results := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet: fixesTestRuleSet(false),
+		Spec:    []byte(fixesTestSpec),
+	})
+	assert.Len(t, results.Results, 3)
+
+	resultSet := model.NewRuleResultSet(results.Results)
+	fixed, applied, err := ApplyFixes([]byte(fixesTestSpec), resultSet.Results)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, applied)
+	assert.Equal(t, fixesTestSpec, string(fixed))
+}
+
+func TestApplyFixes_JSON(t *testing.T) {
+
+	spec := `{"openapi": "3.1.0", "paths": {"/pizza": {"get": {"summary": "pizza"}}}}`
+	results := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet: fixesTestRuleSet(true),
+		Spec:    []byte(spec),
+	})
+	assert.Len(t, results.Results, 1)
+
+	resultSet := model.NewRuleResultSet(results.Results)
+	_, applied, err := ApplyFixes([]byte(spec), resultSet.Results)
+	assert.Error(t, err)
+	assert.Equal(t, 0, applied)
+}
+
+func TestApplyFixes_KeepsFormatting(t *testing.T) {
+
+	// blank lines, flow collections, comments and the missing line break at the end are not touched.
+	spec := `openapi: 3.1.0
+info:
+  title: pizza
+
+
+  version: 1.0.0
+  description: 'pizza api  ' # trailing comment
+tags: [ pizza,   dough ]
+paths:
+  /pizzas:
+
+    get:
+      summary: get pizzas
+      responses:
+        "200":
+          description: pizzas
+
+  # pizza by id.
+  /pizzas/{id}:
+    get:
+      summary: get a pizza
+      operationId: getPizza
+      responses:
+        "200":
+          description: >
+            a pizza,
+            with toppings.  `
+
+	results := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet: fixesTestRuleSet(true),
+		Spec:    []byte(spec),
+	})
+	assert.Len(t, results.Errors, 0)
+	assert.Len(t, results.Results, 3)
+
+	resultSet := model.NewRuleResultSet(results.Results)
+	fixed, applied, err := ApplyFixes([]byte(spec), resultSet.Results)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, applied)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+  title: pizza
+
+
+  version: 1.0.0
+  description: 'pizza api' # trailing comment
+tags: [ pizza,   dough ]
+paths:
+  /pizzas:
+
+    get:
+      summary: get pizzas
+      responses:
+        "200":
+          description: pizzas
+      operationId: getPizzas
+
+  # pizza by id.
+  /pizzas/{id}:
+    get:
+      summary: get a pizza
+      operationId: getPizza
+      responses:
+        "200":
+          description: >-
+            a pizza, with toppings.`, string(fixed))
+}
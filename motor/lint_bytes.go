@@ -0,0 +1,37 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"errors"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+)
+
+// LintBytes lints a specification (JSON or YAML) that is held in memory, against a ruleset, and returns the
+// results sorted by line number, exactly as the lint command would for the same file. Nothing is read from the
+// filesystem, so references to local files can't be resolved, remote references are looked up (like the lint
+// command does). If no ruleset is supplied, the recommended OpenAPI ruleset is used.
+//
+// An error is returned if the specification could not be parsed, or a rule failed to run. Use ApplyRulesToRuleSet
+// for anything more involved, like custom functions or timeouts.
+func LintBytes(spec []byte, rs *rulesets.RuleSet) (*model.RuleResultSet, error) {
+	if rs == nil {
+		rs = rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+	}
+	result := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet:     rs,
+		Spec:        spec,
+		AllowLookup: true,
+		SilenceLogs: true,
+	})
+	if len(result.Errors) > 0 {
+		return nil, errors.Join(result.Errors...)
+	}
+	resultSet := model.NewRuleResultSet(result.Results)
+	resultSet.AddRuleCategories(rs.Categories)
+	resultSet.SortResultsByLineNumber()
+	return resultSet, nil
+}
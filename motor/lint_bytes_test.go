@@ -0,0 +1,18 @@
+package motor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintBytes_DefaultRuleSet(t *testing.T) {
+	results, err := LintBytes([]byte(`{"openapi": "3.1.0", "info": {"title": "pizza", "version": "1.0"}, "paths": {}}`), nil)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, results.Results)
+}
+
+func TestLintBytes_Invalid(t *testing.T) {
+	_, err := LintBytes([]byte("not: [an openapi spec"), nil)
+	assert.Error(t, err)
+}
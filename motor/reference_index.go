@@ -0,0 +1,105 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ReferenceLocation is a single place in the specification where a component is referenced.
+type ReferenceLocation struct {
+	Path   string // The path to the object containing the $ref, for example $.paths./pizza.get.responses.200
+	Line   int    // The line of the $ref.
+	Column int    // The column of the $ref.
+}
+
+// ReferenceIndex maps every component referenced by a specification (using the $ref value, for example
+// #/components/schemas/Pizza) to the locations it is referenced from, in document order. It's built from the index
+// of the unresolved specification, once linting is complete, and can't be changed.
+type ReferenceIndex struct {
+	references map[string][]ReferenceLocation
+}
+
+// Components returns every component that is referenced at least once, sorted.
+func (ri *ReferenceIndex) Components() []string {
+	if ri == nil {
+		return nil
+	}
+	components := make([]string, 0, len(ri.references))
+	for c := range ri.references {
+		components = append(components, c)
+	}
+	sort.Strings(components)
+	return components
+}
+
+// Locations returns every location a component is referenced from, or nil if it's never referenced.
+func (ri *ReferenceIndex) Locations(component string) []ReferenceLocation {
+	if ri == nil || len(ri.references[component]) == 0 {
+		return nil
+	}
+	return append([]ReferenceLocation{}, ri.references[component]...)
+}
+
+// newReferenceIndex collects the references the index found in the unresolved specification (the same references
+// every rule using the index sees), keyed by their $ref value. The index only knows the component a reference points
+// to, so the path of the object holding each $ref is looked up in the specification.
+func newReferenceIndex(idx *index.SpecIndex) *ReferenceIndex {
+	ri := &ReferenceIndex{references: make(map[string][]ReferenceLocation)}
+	if idx == nil {
+		return ri
+	}
+	refs := idx.GetAllSequencedReferences()
+	holders := make(map[*yaml.Node]string, len(refs))
+	for _, ref := range refs {
+		if ref.Node != nil {
+			holders[ref.Node] = ""
+		}
+	}
+	findPaths(idx.GetRootNode(), "$", holders)
+	for _, ref := range refs {
+		if ref.Node == nil {
+			continue
+		}
+		key, value := utils.FindKeyNodeTop("$ref", ref.Node.Content)
+		if key == nil || value == nil {
+			continue
+		}
+		ri.references[value.Value] = append(ri.references[value.Value], ReferenceLocation{
+			Path:   holders[ref.Node],
+			Line:   key.Line,
+			Column: key.Column,
+		})
+	}
+	return ri
+}
+
+// findPaths sets the path of every node in the paths map, walking down from a node at the supplied path.
+func findPaths(node *yaml.Node, path string, paths map[*yaml.Node]string) {
+	if node == nil {
+		return
+	}
+	if _, ok := paths[node]; ok {
+		paths[node] = path
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, n := range node.Content {
+			findPaths(n, path, paths)
+		}
+	case yaml.SequenceNode:
+		for i, n := range node.Content {
+			findPaths(n, fmt.Sprintf("%s[%d]", path, i), paths)
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			findPaths(node.Content[i+1], fmt.Sprintf("%s.%s", path, node.Content[i].Value), paths)
+		}
+	}
+}
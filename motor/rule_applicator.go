@@ -4,11 +4,15 @@
 package motor
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/url"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/daveshanley/vacuum/functions"
 	"github.com/daveshanley/vacuum/model"
@@ -37,6 +41,13 @@ type ruleContext struct {
 	document          libopenapi.Document
 	skipDocumentCheck bool
 	logger            *slog.Logger
+	context           context.Context
+	resultHandler     func(results []model.RuleFunctionResult)
+
+	// streaming mode only lints some of the nodes a rule matches in each part of the specification, and renders
+	// the values of the nodes it didn't keep in memory itself.
+	keepNode    func(node *yaml.Node) bool
+	renderValue func(node *yaml.Node) string
 }
 
 // RuleSetExecution is an instruction set for executing a ruleset. It's a convenience structure to allow the signature
@@ -53,6 +64,12 @@ type RuleSetExecution struct {
 	Document          libopenapi.Document           // a ready to render model.
 	SkipDocumentCheck bool                          // Skip the document check, useful for fragments and non openapi specs.
 	Logger            *slog.Logger                  // A custom logger.
+	Context           context.Context               // Cancel the context to abort linting, partial results are returned.
+	Timeout           time.Duration                 // Abort linting after this duration, partial results are returned.
+
+	// ResultHandler is called with results as they are produced, before linting has finished. It's called from
+	// multiple goroutines, and it may be handed duplicates that are removed from the final results.
+	ResultHandler func(results []model.RuleFunctionResult)
 }
 
 // RuleSetExecutionResult returns the results of running the ruleset against the supplied spec.
@@ -62,7 +79,36 @@ type RuleSetExecutionResult struct {
 	Index            *index.SpecIndex           // The index that was created from the specification, used by the rules.
 	SpecInfo         *datamodel.SpecInfo        // A reference to the SpecInfo object, used by all the rules.
 	Errors           []error                    // Any errors that were returned.
+	DeferredRules    []*model.Rule              // Rules that were not run in streaming mode, because they need a resolved spec or index.
+	TimedOut         bool                       // The execution was cancelled, or timed out. Results are partial.
+
+	referenceSource *index.SpecIndex
+	referenceSpec   []byte
+	referencesOnce  sync.Once
+	references      *ReferenceIndex
+}
 
+// ReferenceIndex returns every component referenced by the specification, and the locations referencing it.
+// The index is built the first time it's asked for, it's read-only, and empty if the specification could not be
+// parsed.
+func (r *RuleSetExecutionResult) ReferenceIndex() *ReferenceIndex {
+	r.referencesOnce.Do(func() {
+		source := r.referenceSource
+		if source == nil && r.SpecInfo != nil && r.SpecInfo.RootNode != nil {
+			// streaming mode doesn't index the specification, so it's indexed now, without looking anything up.
+			// the root node may not hold the whole specification, so it's parsed again if it was supplied.
+			root := r.SpecInfo.RootNode
+			if r.referenceSpec != nil {
+				var node yaml.Node
+				if err := yaml.Unmarshal(r.referenceSpec, &node); err == nil {
+					root = &node
+				}
+			}
+			source = index.NewSpecIndexWithConfig(root, index.CreateClosedAPIIndexConfig())
+		}
+		r.references = newReferenceIndex(source)
+	})
+	return r.references
 }
 
 // todo: move copy into virtual file system or some kind of map.
@@ -74,7 +120,62 @@ const CircularReferencesFix string = "Circular references are created by schemas
 // ApplyRulesToRuleSet is a replacement for ApplyRules. This function was created before trying to use
 // vacuum as an API. The signature is not sufficient, but is embedded everywhere. This new method
 // uses a message structure, to allow the signature to grow, without breaking anything.
+//
+// If the execution has a Timeout or a Context that is cancelled, linting is aborted and whatever results have been
+// collected so far are returned, with TimedOut set on the result.
 func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
+	return applyWithTimeout(execution, applyRulesToRuleSet)
+}
+
+// applyWithTimeout runs apply with a context that is cancelled when the execution's Context is, or when its
+// Timeout passes, and returns a TimedOut result as soon as that happens.
+func applyWithTimeout(execution *RuleSetExecution, apply func(ctx context.Context, execution *RuleSetExecution,
+	rulesRunning *atomic.Bool) *RuleSetExecutionResult) *RuleSetExecutionResult {
+
+	ctx := execution.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// nothing can cancel this execution, so there is nothing to watch.
+	if ctx.Done() == nil && execution.Timeout <= 0 {
+		return apply(ctx, execution, nil)
+	}
+
+	// rules run with a context of their own, cancelled once this returns, so rules that are still running stop at
+	// their next check, however the execution ended.
+	var cancel context.CancelFunc
+	if execution.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, execution.Timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	// if the context is done before rules start running (the spec is still being parsed, indexed or resolved)
+	// there are no partial results to return. Once rules are running, they stop and return what they have.
+	var rulesRunning atomic.Bool
+	resultChan := make(chan *RuleSetExecutionResult, 1)
+	go func() {
+		resultChan <- apply(ctx, execution, &rulesRunning)
+	}()
+
+	select {
+	case result := <-resultChan:
+		return result
+	case <-ctx.Done():
+		if rulesRunning.Load() {
+			return <-resultChan
+		}
+		return &RuleSetExecutionResult{
+			RuleSetExecution: execution,
+			TimedOut:         true,
+		}
+	}
+}
+
+func applyRulesToRuleSet(ctx context.Context, execution *RuleSetExecution,
+	rulesRunning *atomic.Bool) *RuleSetExecutionResult {
 
 	builtinFunctions := functions.MapBuiltinFunctions()
 	var ruleResults []model.RuleFunctionResult
@@ -326,8 +427,15 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 		HowToFix: "Ensure that all $ref values are resolvable and locatable within a local or remote document. " + CircularReferencesFix,
 	}
 
+	// when the ruleset has its own rule for unresolved references, it reports them, only circular references are
+	// left to be reported here.
+	reportsUnresolved := execution.RuleSet != nil && reportsUnresolvedRefs(execution.RuleSet)
+
 	// add all resolving errors to the results.
 	for _, er := range resolvingErrors {
+		if reportsUnresolved && er.CircularReference == nil {
+			continue
+		}
 		res := model.RuleFunctionResult{
 			RuleId:    "resolving-references",
 			Rule:      resolvingRule,
@@ -340,6 +448,9 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 	}
 
 	for _, er := range indexResolved.GetReferenceIndexErrors() {
+		if reportsUnresolved {
+			break
+		}
 		var idxError *index.IndexingError
 		errors.As(er, &idxError)
 		res := model.RuleFunctionResult{
@@ -353,6 +464,21 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 		ruleResults = append(ruleResults, res)
 	}
 
+	// once rules are running, a timeout waits for this to return, so nothing is handled after it's been reported.
+	if rulesRunning != nil {
+		rulesRunning.Store(true)
+	}
+	if ctx.Err() != nil {
+		return &RuleSetExecutionResult{
+			RuleSetExecution: execution,
+			TimedOut:         true,
+		}
+	}
+
+	if execution.ResultHandler != nil && len(ruleResults) > 0 {
+		execution.ResultHandler(ruleResults)
+	}
+
 	// run all rules.
 	var errs []error
 
@@ -368,7 +494,7 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 			}
 
 			// this list of things is most likely going to grow a bit, so we use a nice clean message design.
-			ctx := ruleContext{
+			rc := ruleContext{
 				rule:              rule,
 				specNode:          ruleSpec,
 				builtinFunctions:  builtinFunctions,
@@ -382,14 +508,36 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 				silenceLogs:       execution.SilenceLogs,
 				skipDocumentCheck: execution.SkipDocumentCheck,
 				logger:            docConfig.Logger,
+				context:           ctx,
+				resultHandler:     execution.ResultHandler,
 			}
 			if execution.PanicFunction != nil {
-				ctx.panicFunc = execution.PanicFunction
+				rc.panicFunc = execution.PanicFunction
 			}
-			go runRule(ctx)
+			go runRule(rc)
 		}
 
-		ruleWaitGroup.Wait()
+		rulesDone := make(chan bool)
+		go func() {
+			ruleWaitGroup.Wait()
+			close(rulesDone)
+		}()
+
+		select {
+		case <-rulesDone:
+		case <-ctx.Done():
+			// rules that are still running will stop at the next node, take what we have right now.
+			partial, partialErrs := collected(&ruleResults, &errs)
+			return &RuleSetExecutionResult{
+				RuleSetExecution: execution,
+				Results:          *removeDuplicates(&partial),
+				Index:            indexResolved,
+				SpecInfo:         specInfo,
+				Errors:           partialErrs,
+				TimedOut:         true,
+				referenceSource:  indexUnresolved,
+			}
+		}
 	}
 
 	ruleResults = *removeDuplicates(&ruleResults)
@@ -400,6 +548,7 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 		Index:            indexResolved,
 		SpecInfo:         specInfo,
 		Errors:           errs,
+		referenceSource:  indexUnresolved,
 	}
 }
 
@@ -414,24 +563,11 @@ func runRule(ctx ruleContext) {
 	}
 	defer ctx.wg.Done()
 
-	var givenPaths []string
-	if x, ok := ctx.rule.Given.(string); ok {
-		givenPaths = append(givenPaths, x)
-	}
+	for _, givenPath := range rulePaths(ctx.rule, ctx.specInfo) {
 
-	if x, ok := ctx.rule.Given.([]string); ok {
-		givenPaths = x
-	}
-
-	if x, ok := ctx.rule.Given.([]interface{}); ok {
-		for _, gpI := range x {
-			if gp, ok := gpI.(string); ok {
-				givenPaths = append(givenPaths, gp)
-			}
+		if ctx.context != nil && ctx.context.Err() != nil {
+			return
 		}
-	}
-
-	for _, givenPath := range givenPaths {
 
 		var nodes []*yaml.Node
 		var err error
@@ -444,9 +580,20 @@ func runRule(ctx ruleContext) {
 		}
 
 		if err != nil {
+			lock.Lock()
 			*ctx.errors = append(*ctx.errors, err)
+			lock.Unlock()
 			return
 		}
+		if ctx.keepNode != nil {
+			var kept []*yaml.Node
+			for _, node := range nodes {
+				if ctx.keepNode(node) {
+					kept = append(kept, node)
+				}
+			}
+			nodes = kept
+		}
 		if len(nodes) <= 0 {
 			continue
 		}
@@ -457,7 +604,7 @@ func runRule(ctx ruleContext) {
 
 		if err == nil {
 
-			ctx.ruleResults = buildResults(ctx, ruleAction, nodes)
+			ctx.ruleResults = buildResults(ctx, ruleAction, givenPath, nodes)
 
 		} else {
 
@@ -467,16 +614,67 @@ func runRule(ctx ruleContext) {
 
 			if err == nil {
 				for _, rAction := range ruleActions {
-					ctx.ruleResults = buildResults(ctx, rAction, nodes)
+					ctx.ruleResults = buildResults(ctx, rAction, givenPath, nodes)
 				}
 			}
 		}
 	}
 }
 
+// rulePaths returns the JSONPaths of the nodes a rule is given, aliases scoped by format expand to different paths
+// for each format.
+func rulePaths(rule *model.Rule, specInfo *datamodel.SpecInfo) []string {
+	var givenPaths []string
+	if x, ok := rule.Given.(string); ok {
+		givenPaths = append(givenPaths, x)
+	}
+
+	if x, ok := rule.Given.([]string); ok {
+		givenPaths = x
+	}
+
+	if x, ok := rule.Given.([]interface{}); ok {
+		for _, gpI := range x {
+			if gp, ok := gpI.(string); ok {
+				givenPaths = append(givenPaths, gp)
+			}
+		}
+	}
+
+	if specInfo != nil {
+		if x, ok := rule.GivenByFormat[aliasFormat(specInfo)]; ok {
+			givenPaths = x
+		}
+	}
+	return givenPaths
+}
+
+// reportsUnresolvedRefs returns true if a rule in the ruleset uses the oasUnresolvedRefs function, which reports
+// every $ref that can't be resolved.
+func reportsUnresolvedRefs(ruleSet *rulesets.RuleSet) bool {
+	for _, rule := range ruleSet.Rules {
+		var ruleAction model.RuleAction
+		if err := mapstructure.Decode(rule.Then, &ruleAction); err == nil {
+			if ruleAction.Function == "oasUnresolvedRefs" {
+				return true
+			}
+			continue
+		}
+		var ruleActions []model.RuleAction
+		if err := mapstructure.Decode(rule.Then, &ruleActions); err == nil {
+			for _, rAction := range ruleActions {
+				if rAction.Function == "oasUnresolvedRefs" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 var lock sync.Mutex
 
-func buildResults(ctx ruleContext, ruleAction model.RuleAction, nodes []*yaml.Node) *[]model.RuleFunctionResult {
+func buildResults(ctx ruleContext, ruleAction model.RuleAction, givenPath string, nodes []*yaml.Node) *[]model.RuleFunctionResult {
 
 	ruleFunction := ctx.builtinFunctions.FindFunction(ruleAction.Function)
 	// not found, check if it's been registered as a custom function
@@ -499,6 +697,7 @@ func buildResults(ctx ruleContext, ruleAction model.RuleAction, nodes []*yaml.No
 			SpecInfo:   ctx.specInfo,
 			Document:   ctx.document,
 			Logger:     ctx.logger,
+			Context:    ctx.context,
 		}
 
 		if !ctx.skipDocumentCheck && ctx.specInfo.SpecFormat == "" && ctx.specInfo.Version == "" {
@@ -520,6 +719,11 @@ func buildResults(ctx ruleContext, ruleAction model.RuleAction, nodes []*yaml.No
 			// iterate through nodes and supply them one at a time so we don't pollute each run
 			for _, node := range nodes {
 
+				// stop if linting has been cancelled, or has timed out.
+				if ctx.context != nil && ctx.context.Err() != nil {
+					break
+				}
+
 				// if this rule is designed for a different version, skip it.
 				if len(ctx.rule.Formats) > 0 {
 					match := false
@@ -534,11 +738,26 @@ func buildResults(ctx ruleContext, ruleAction model.RuleAction, nodes []*yaml.No
 				}
 
 				runRuleResults := ruleFunction.RunRule([]*yaml.Node{node}, rfc)
+				fixer, fixable := ruleFunction.(model.FixableRuleFunction)
+				for i := range runRuleResults {
+					addResultMetadata(&runRuleResults[i], ruleAction, givenPath, ctx.renderValue)
+					if fixable && ctx.rule.AutoFix {
+						runRuleResults[i].Fixes = fixer.Fix(runRuleResults[i], rfc)
+					}
+				}
 
 				// because this function is running in multiple threads, we need to sync access to the final result
-				// list, otherwise things can get a bit random.
+				// list, otherwise things can get a bit random. Once linting has been cancelled (or has timed out) the
+				// results collected so far have been returned, so anything found after that is dropped.
 				lock.Lock()
+				if ctx.context != nil && ctx.context.Err() != nil {
+					lock.Unlock()
+					break
+				}
 				*ctx.ruleResults = append(*ctx.ruleResults, runRuleResults...)
+				if ctx.resultHandler != nil && len(runRuleResults) > 0 {
+					ctx.resultHandler(runRuleResults)
+				}
 				lock.Unlock()
 			}
 
@@ -547,6 +766,47 @@ func buildResults(ctx ruleContext, ruleAction model.RuleAction, nodes []*yaml.No
 	return ctx.ruleResults
 }
 
+// addResultMetadata records the function, the given path and the matched value that produced a result, unless
+// the function already set them. If the action checks a field, the value of the field is the matched value.
+func addResultMetadata(result *model.RuleFunctionResult, ruleAction model.RuleAction, givenPath string,
+	renderValue func(node *yaml.Node) string) {
+	if result.Function == "" {
+		result.Function = ruleAction.Function
+	}
+	if result.Given == "" {
+		result.Given = givenPath
+	}
+	if result.Value == "" && result.StartNode != nil {
+		matched := result.StartNode
+		if ruleAction.Field != "" && utils.IsNodeMap(matched) {
+			if _, field := utils.FindKeyNodeTop(ruleAction.Field, matched.Content); field != nil {
+				matched = field
+			}
+		}
+		if renderValue != nil {
+			result.Value = renderValue(matched)
+		} else {
+			result.Value = model.RenderNodeValue(matched, model.MaxResultValueLength)
+		}
+	}
+}
+
+// aliasFormat returns the format used to pick the paths of an alias for a document. The spec format is `oas3` for
+// every 3.x document, so 3.1 documents are told apart by their version.
+func aliasFormat(specInfo *datamodel.SpecInfo) string {
+	if specInfo.SpecFormat == model.OAS3 && strings.HasPrefix(specInfo.Version, "3.1") {
+		return model.OAS31
+	}
+	return specInfo.SpecFormat
+}
+
+// collected returns a copy of the results and errors that rules have collected so far, while they are still running.
+func collected(results *[]model.RuleFunctionResult, errs *[]error) ([]model.RuleFunctionResult, []error) {
+	lock.Lock()
+	defer lock.Unlock()
+	return append([]model.RuleFunctionResult{}, *results...), append([]error(nil), *errs...)
+}
+
 type seenResult struct {
 	location string
 	message  string
@@ -569,7 +829,7 @@ func removeDuplicates(results *[]model.RuleFunctionResult) *[]model.RuleFunction
 				}
 				newResults = append(newResults, result)
 			}
-		} else {
+		} else if result.StartNode != nil {
 		stopNowPlease:
 			for _, line := range r {
 				if line.location == fmt.Sprintf("%d:%d", result.StartNode.Line, result.StartNode.Column) &&
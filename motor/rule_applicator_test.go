@@ -1,9 +1,12 @@
 package motor
 
 import (
+	"context"
 	"fmt"
 	"github.com/daveshanley/vacuum/plugin"
 	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -214,6 +217,159 @@ func TestApplyRules_LengthTestFail(t *testing.T) {
 
 }
 
+func TestApplyRules_AliasScopedByFormat(t *testing.T) {
+
+	yaml := `aliases:
+  Schemas:
+    targets:
+      - formats: [oas2]
+        given: $.definitions[*]
+      - formats: [oas3]
+        given: $.components.schemas[*]
+rules:
+  schema-description:
+    description: schemas need a description
+    given: '#Schemas'
+    resolved: false
+    severity: warn
+    then:
+      field: description
+      function: truthy`
+
+	rc := CreateRuleComposer()
+	rs, err := rc.ComposeRuleSet([]byte(yaml))
+	assert.NoError(t, err)
+
+	specs := map[string]string{
+		"$.definitions[*]": `swagger: "2.0"
+info:
+  title: pizza
+  version: 1.0.0
+paths: {}
+definitions:
+  Pizza:
+    type: object
+components:
+  schemas:
+    Topping:
+      type: object`,
+		"$.components.schemas[*]": `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+definitions:
+  Pizza:
+    type: object
+components:
+  schemas:
+    Topping:
+      type: object`,
+	}
+	for given, spec := range specs {
+		results := ApplyRulesToRuleSet(&RuleSetExecution{
+			RuleSet: rs,
+			Spec:    []byte(spec),
+		})
+		assert.Len(t, results.Results, 1)
+		assert.Equal(t, given, results.Results[0].Given)
+	}
+}
+
+func TestApplyRules_AliasScopedByVersion(t *testing.T) {
+
+	yaml := `aliases:
+  Described:
+    targets:
+      - formats: [oas3.0]
+        given: $.info
+      - formats: [oas3.1]
+        given: $.components.schemas[*]
+rules:
+  described:
+    description: things need a description
+    given: '#Described'
+    resolved: false
+    severity: warn
+    then:
+      field: description
+      function: truthy`
+
+	rc := CreateRuleComposer()
+	rs, err := rc.ComposeRuleSet([]byte(yaml))
+	assert.NoError(t, err)
+
+	specs := map[string]string{
+		"$.info": `openapi: 3.0.3
+info:
+  title: pizza
+  version: 1.0.0
+components:
+  schemas:
+    Topping:
+      type: object`,
+		"$.components.schemas[*]": `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+components:
+  schemas:
+    Topping:
+      type: object`,
+	}
+	for given, spec := range specs {
+		results := ApplyRulesToRuleSet(&RuleSetExecution{
+			RuleSet: rs,
+			Spec:    []byte(spec),
+		})
+		assert.Len(t, results.Results, 1)
+		assert.Equal(t, given, results.Results[0].Given)
+	}
+}
+
+func TestApplyRules_LengthTestFail_Metadata(t *testing.T) {
+
+	json := fmt.Sprintf(`{
+  "documentationUrl": "quobix.com",
+  "rules": {
+    "length-test": {
+      "description": "this is a test for checking the length function",
+      "recommended": true,
+      "type": "style",
+      "given": "$.info",
+      "severity": "%s",
+      "then": {
+        "function": "length",
+        "field": "title",
+        "functionOptions" : {
+          "max" : "5"
+        }
+      }
+    }
+  }
+}
+`, model.SeverityError)
+	rc := CreateRuleComposer()
+	rs, err := rc.ComposeRuleSet([]byte(json))
+	assert.NoError(t, err)
+
+	spec := fmt.Sprintf(`openapi: 3.1.0
+info:
+  title: %s
+  version: 1.0.0`, strings.Repeat("burger", 100))
+
+	rse := &RuleSetExecution{
+		RuleSet: rs,
+		Spec:    []byte(spec),
+	}
+	results := ApplyRulesToRuleSet(rse)
+
+	assert.Len(t, results.Errors, 0)
+	assert.Len(t, results.Results, 1)
+	assert.Equal(t, "length", results.Results[0].Function)
+	assert.Equal(t, "$.info", results.Results[0].Given)
+	assert.Equal(t, strings.Repeat("burger", 100)[:model.MaxResultValueLength]+"...", results.Results[0].Value)
+}
+
 func TestApplyRules_LengthTestSuccess(t *testing.T) {
 
 	json := fmt.Sprintf(`{
@@ -1564,6 +1720,143 @@ servers:
 
 }
 
+func TestRuleInfoLicenseSPDXRule_OAS31(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+info:
+  license:
+    name: Foo
+    identifier: Foo-1.0
+`
+
+	rules := make(map[string]*model.Rule)
+	rules[rulesets.InfoLicenseSPDX] = rulesets.GetInfoLicenseSPDXRule()
+
+	rs := &rulesets.RuleSet{
+		Rules: rules,
+	}
+
+	rse := &RuleSetExecution{
+		RuleSet: rs,
+		Spec:    []byte(yml),
+	}
+	results := ApplyRulesToRuleSet(rse)
+	assert.Len(t, results.Errors, 0)
+	assert.Len(t, results.Results, 1)
+	assert.Equal(t, "$.info.license.identifier", results.Results[0].Path)
+
+}
+
+func TestRuleInfoLicenseSPDXRule_OAS30(t *testing.T) {
+
+	yml := `openapi: 3.0.3
+info:
+  license:
+    name: Foo
+    identifier: Foo-1.0
+`
+
+	rules := make(map[string]*model.Rule)
+	rules[rulesets.InfoLicenseSPDX] = rulesets.GetInfoLicenseSPDXRule()
+
+	rs := &rulesets.RuleSet{
+		Rules: rules,
+	}
+
+	rse := &RuleSetExecution{
+		RuleSet: rs,
+		Spec:    []byte(yml),
+	}
+	results := ApplyRulesToRuleSet(rse)
+	assert.Len(t, results.Errors, 0)
+	assert.Len(t, results.Results, 0)
+
+}
+
+func TestRuleSchemaExamplesArrayRule_OAS31(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pizza:
+      type: string
+      example: foo
+`
+
+	rules := make(map[string]*model.Rule)
+	rules[rulesets.SchemaExamplesArray] = rulesets.GetSchemaExamplesArrayRule()
+
+	rs := &rulesets.RuleSet{
+		Rules: rules,
+	}
+
+	rse := &RuleSetExecution{
+		RuleSet: rs,
+		Spec:    []byte(yml),
+	}
+	results := ApplyRulesToRuleSet(rse)
+	assert.Len(t, results.Errors, 0)
+	assert.Len(t, results.Results, 1)
+	assert.Equal(t, "$.components.schemas.Pizza.example", results.Results[0].Path)
+
+}
+
+func TestRuleSchemaExamplesArrayRule_OAS30(t *testing.T) {
+
+	yml := `openapi: 3.0.3
+components:
+  schemas:
+    Pizza:
+      type: string
+      example: foo
+`
+
+	rules := make(map[string]*model.Rule)
+	rules[rulesets.SchemaExamplesArray] = rulesets.GetSchemaExamplesArrayRule()
+
+	rs := &rulesets.RuleSet{
+		Rules: rules,
+	}
+
+	rse := &RuleSetExecution{
+		RuleSet: rs,
+		Spec:    []byte(yml),
+	}
+	results := ApplyRulesToRuleSet(rse)
+	assert.Len(t, results.Errors, 0)
+	assert.Len(t, results.Results, 0)
+
+}
+
+func TestRuleWebhookSecurityRequiredRule(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+webhooks:
+  newPizza:
+    post:
+      responses:
+        "200":
+          description: ok
+`
+
+	rules := make(map[string]*model.Rule)
+	rules[rulesets.WebhookSecurityRequired] = rulesets.GetWebhookSecurityRequiredRule()
+
+	rs := &rulesets.RuleSet{
+		Rules: rules,
+	}
+
+	rse := &RuleSetExecution{
+		RuleSet: rs,
+		Spec:    []byte(yml),
+	}
+	results := ApplyRulesToRuleSet(rse)
+	assert.Len(t, results.Errors, 0)
+	assert.Len(t, results.Results, 1)
+	assert.Equal(t, "$.webhooks.newPizza.post", results.Results[0].Path)
+
+}
+
 type testRule struct{}
 
 func (t *testRule) GetSchema() model.RuleFunctionSchema {
@@ -1693,6 +1986,43 @@ components:
 	assert.Equal(t, "resolving-references", results.Results[0].RuleId)
 }
 
+func TestRuleSet_TestBadRef_NoUnresolvedRefs(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+paths:
+  /one:
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/one'
+components:
+  schemas:
+    none:
+      type: string`
+
+	rules := make(map[string]*model.Rule)
+	rules[rulesets.NoUnresolvedRefs] = rulesets.GetNoUnresolvedRefsRule()
+
+	rs := &rulesets.RuleSet{
+		Rules: rules,
+	}
+
+	rse := &RuleSetExecution{
+		RuleSet: rs,
+		Spec:    []byte(yml),
+	}
+	results := ApplyRulesToRuleSet(rse)
+	assert.Len(t, results.Errors, 0)
+
+	// the broken reference is only reported by the rule, not again as a resolving error.
+	assert.Len(t, results.Results, 1)
+	assert.Equal(t, "reference `#/components/schemas/one` cannot be resolved", results.Results[0].Message)
+	assert.Equal(t, rulesets.NoUnresolvedRefs, results.Results[0].Rule.Id)
+}
+
 type testRuleNotResolved struct{}
 
 func (r *testRuleNotResolved) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
@@ -2185,3 +2515,234 @@ func Benchmark_StripeSpecAgainstDefaultRuleSet(b *testing.B) {
 		assert.NotNil(b, results)
 	}
 }
+
+type slowRuleFunction struct{}
+
+func (s slowRuleFunction) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: "slow"}
+}
+
+// RunRule blocks until linting is cancelled, like a runaway rule would.
+func (s slowRuleFunction) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	<-context.Context.Done()
+	return nil
+}
+
+func timeoutTestRuleSet() *rulesets.RuleSet {
+	yml := `extends: [[spectral:oas, off]]
+rules:
+  slow-rule:
+    given: $.paths[*]
+    then:
+      function: slow
+  fast-rule:
+    given: $.info
+    then:
+      field: nope
+      function: truthy`
+
+	rs, _ := rulesets.CreateRuleSetFromData([]byte(yml))
+	return rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+}
+
+func TestApplyRules_Timeout(t *testing.T) {
+
+	specBytes, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+
+	start := time.Now()
+	results := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet:         timeoutTestRuleSet(),
+		Spec:            specBytes,
+		CustomFunctions: map[string]model.RuleFunction{"slow": slowRuleFunction{}},
+		Timeout:         time.Second,
+	})
+
+	assert.True(t, results.TimedOut)
+	assert.Less(t, time.Since(start), 10*time.Second)
+
+	// the fast rule completed, the slow rule never did.
+	assert.Len(t, results.Results, 1)
+	assert.Equal(t, "fast-rule", results.Results[0].RuleId)
+}
+
+func TestApplyRules_Cancelled(t *testing.T) {
+
+	specBytes, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(time.Second)
+		cancel()
+	}()
+
+	results := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet:         timeoutTestRuleSet(),
+		Spec:            specBytes,
+		CustomFunctions: map[string]model.RuleFunction{"slow": slowRuleFunction{}},
+		Context:         ctx,
+	})
+
+	assert.True(t, results.TimedOut)
+	assert.Len(t, results.Results, 1)
+}
+
+func TestApplyRules_ResultHandler(t *testing.T) {
+
+	specBytes, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+
+	found := make(chan model.RuleFunctionResult, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan *RuleSetExecutionResult)
+	go func() {
+		done <- ApplyRulesToRuleSet(&RuleSetExecution{
+			RuleSet:         timeoutTestRuleSet(),
+			Spec:            specBytes,
+			CustomFunctions: map[string]model.RuleFunction{"slow": slowRuleFunction{}},
+			Context:         ctx,
+			ResultHandler: func(results []model.RuleFunctionResult) {
+				for _, r := range results {
+					found <- r
+				}
+			},
+		})
+	}()
+
+	// the result of the fast rule is handed over while the slow rule is still running.
+	select {
+	case r := <-found:
+		assert.Equal(t, "fast-rule", r.Rule.Id)
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "no results were handed over before linting finished")
+	}
+	cancel()
+	assert.Len(t, (<-done).Results, 1)
+}
+
+type lateRuleFunction struct{}
+
+func (l lateRuleFunction) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: "slow"}
+}
+
+// RunRule reports a result once linting has been cancelled, like a rule that notices too late would.
+func (l lateRuleFunction) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	<-context.Context.Done()
+	return []model.RuleFunctionResult{{Message: "too late", Rule: context.Rule}}
+}
+
+func TestApplyRules_Timeout_DropsLateResults(t *testing.T) {
+
+	specBytes, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+
+	var handled atomic.Int32
+	results := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet:         timeoutTestRuleSet(),
+		Spec:            specBytes,
+		CustomFunctions: map[string]model.RuleFunction{"slow": lateRuleFunction{}},
+		Timeout:         time.Second,
+		ResultHandler: func(results []model.RuleFunctionResult) {
+			handled.Add(int32(len(results)))
+		},
+	})
+
+	// give the late rule time to report what it found, after the timeout.
+	time.Sleep(100 * time.Millisecond)
+
+	assert.True(t, results.TimedOut)
+	assert.Len(t, results.Results, 1)
+	assert.Equal(t, "fast-rule", results.Results[0].RuleId)
+	assert.Equal(t, int32(1), handled.Load())
+}
+
+func TestApplyRules_NoTimeout(t *testing.T) {
+
+	specBytes, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+
+	results := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet: timeoutTestRuleSet(),
+		Spec:    specBytes,
+		Timeout: time.Minute,
+	})
+
+	// the slow function is not registered, so only the fast rule runs, well before the timeout.
+	assert.False(t, results.TimedOut)
+	assert.Len(t, results.Results, 1)
+}
+
+func TestApplyRules_ReferenceIndex(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Size'
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pizza'
+              examples:
+                margherita:
+                  $ref: '#/components/examples/Margherita'
+components:
+  parameters:
+    Size:
+      in: query
+      name: size
+      schema:
+        type: string
+  examples:
+    Margherita:
+      value: margherita
+  schemas:
+    Topping:
+      type: string
+    Pizza:
+      type: object
+      properties:
+        toppings:
+          type: array
+          items:
+            $ref: '#/components/schemas/Topping'
+        extra:
+          $ref: '#/components/schemas/Topping'`
+
+	results := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet: rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet(),
+		Spec:    []byte(spec),
+	})
+
+	// the index is only built when it's asked for, and only once.
+	assert.Nil(t, results.references)
+	refs := results.ReferenceIndex()
+	assert.Same(t, refs, results.ReferenceIndex())
+	assert.Equal(t, []string{"#/components/examples/Margherita", "#/components/parameters/Size",
+		"#/components/schemas/Pizza", "#/components/schemas/Topping"}, refs.Components())
+
+	assert.Equal(t, []ReferenceLocation{{Path: "$.paths./pizza.get.parameters[0]", Line: 6, Column: 11}},
+		refs.Locations("#/components/parameters/Size"))
+	assert.Equal(t, []ReferenceLocation{
+		{Path: "$.paths./pizza.get.responses.200.content.application/json.examples.margherita", Line: 16, Column: 19},
+	}, refs.Locations("#/components/examples/Margherita"))
+
+	toppings := refs.Locations("#/components/schemas/Topping")
+	assert.Len(t, toppings, 2)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.toppings.items", toppings[0].Path)
+	assert.Equal(t, "$.components.schemas.Pizza.properties.extra", toppings[1].Path)
+
+	// the index can't be changed by a consumer.
+	toppings[0].Path = "changed"
+	assert.Equal(t, "$.components.schemas.Pizza.properties.toppings.items",
+		refs.Locations("#/components/schemas/Topping")[0].Path)
+	assert.Nil(t, refs.Locations("#/components/schemas/Missing"))
+
+	// streaming mode doesn't index the specification, but the references are the same.
+	streamed := ApplyRulesToRuleSetStreaming(&RuleSetExecution{
+		RuleSet: rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet(),
+		Spec:    []byte(spec),
+	})
+	assert.Equal(t, refs.references, streamed.ReferenceIndex().references)
+}
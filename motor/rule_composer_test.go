@@ -54,3 +54,20 @@ func TestRuleComposer_ComposeRuleSet(t *testing.T) {
 	assert.Error(t, err)
 
 }
+
+func TestRuleComposer_ComposeRuleSet_TOML(t *testing.T) {
+	tml := `[rules.title-is-pizza]
+description = "The title must be about pizza"
+given = "$.info"
+
+[rules.title-is-pizza.then]
+field = "title"
+function = "pattern"
+functionOptions = { match = "pizza" }`
+
+	rc := CreateRuleComposer()
+	rs, err := rc.ComposeRuleSet([]byte(tml))
+	assert.NoError(t, err)
+	assert.Len(t, rs.Rules, 1)
+	assert.Equal(t, "The title must be about pizza", rs.Rules["title-is-pizza"].Description)
+}
@@ -39,6 +39,35 @@ paths:
 		results := motor.ApplyRulesToRuleSet(rse)
 		assert.Len(t, results.Results, 0)
 	})
+
+	rangeYml := `openapi: "3.1.0"
+info:
+  version: "1.0"
+paths:
+  /:
+    get:
+      responses:
+        4XX:
+          description: "ok"
+          content:
+            "application/json":
+`
+
+	t.Run("valid: the 4XX range covers 429", func(t *testing.T) {
+		rules := make(map[string]*model.Rule)
+		rules["owasp-define-error-responses-429"] = rulesets.GetOWASPDefineErrorResponses429Rule()
+
+		rs := &rulesets.RuleSet{
+			Rules: rules,
+		}
+
+		rse := &motor.RuleSetExecution{
+			RuleSet: rs,
+			Spec:    []byte(rangeYml),
+		}
+		results := motor.ApplyRulesToRuleSet(rse)
+		assert.Len(t, results.Results, 0)
+	})
 }
 
 func TestRuleSet_OWASPDefineErrorResponses429_Error(t *testing.T) {
@@ -50,7 +79,7 @@ func TestRuleSet_OWASPDefineErrorResponses429_Error(t *testing.T) {
 	}{
 		{
 			name: "invalid: 429 is not defined at all",
-			n:    2,
+			n:    1,
 			yml: `openapi: "3.1.0"
 info:
   version: "1.0"
@@ -78,15 +107,26 @@ paths:
           description: "ok"
           invalid-content:
             "application/problem+json"
+`,
+		},
+		{
+			name: "invalid: the 4XX range covering 429 exists but content is missing",
+			n:    1,
+			yml: `openapi: "3.1.0"
+info:
+  version: "1.0"
+paths:
+  /:
+    get:
+      responses:
+        4XX:
+          description: "ok"
 `,
 		},
 	}
 
 	for _, tt := range tc {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.n == 1 {
-				return
-			}
 			rules := make(map[string]*model.Rule)
 			rules["owasp-define-error-responses-429"] = rulesets.GetOWASPDefineErrorResponses429Rule()
 
@@ -39,6 +39,35 @@ paths:
 		results := motor.ApplyRulesToRuleSet(rse)
 		assert.Len(t, results.Results, 0)
 	})
+
+	rangeYml := `openapi: "3.1.0"
+info:
+  version: "1.0"
+paths:
+  /:
+    get:
+      responses:
+        5XX:
+          description: "ok"
+          content:
+            "application/json":
+`
+
+	t.Run("valid: the 5XX range covers 500", func(t *testing.T) {
+		rules := make(map[string]*model.Rule)
+		rules["owasp-define-error-responses-500"] = rulesets.GetOWASPDefineErrorResponses500Rule()
+
+		rs := &rulesets.RuleSet{
+			Rules: rules,
+		}
+
+		rse := &motor.RuleSetExecution{
+			RuleSet: rs,
+			Spec:    []byte(rangeYml),
+		}
+		results := motor.ApplyRulesToRuleSet(rse)
+		assert.Len(t, results.Results, 0)
+	})
 }
 
 func TestRuleSet_OWASPDefineErrorResponses500_Error(t *testing.T) {
@@ -50,7 +79,7 @@ func TestRuleSet_OWASPDefineErrorResponses500_Error(t *testing.T) {
 	}{
 		{
 			name: "invalid: 500 is not defined at all",
-			n:    2,
+			n:    1,
 			yml: `openapi: "3.1.0"
 info:
   version: "1.0"
@@ -78,6 +107,20 @@ paths:
           description: "ok"
           invalid-content:
             "application/problem+json"
+`,
+		},
+		{
+			name: "invalid: the 5XX range covering 500 exists but content is missing",
+			n:    1,
+			yml: `openapi: "3.1.0"
+info:
+  version: "1.0"
+paths:
+  /:
+    get:
+      responses:
+        5XX:
+          description: "ok"
 `,
 		},
 	}
@@ -0,0 +1,404 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/daveshanley/vacuum/functions"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/pterm/pterm"
+	"gopkg.in/yaml.v3"
+)
+
+// streamingFunctions are the functions that only operate on the nodes they are supplied, they never look at the
+// index, the document model or any other part of the specification. Rules that only use these functions are
+// streaming-eligible.
+var streamingFunctions = map[string]bool{
+	"alphabetical": true,
+	"casing":       true,
+	"defined":      true,
+	"enumeration":  true,
+	"falsy":        true,
+	"length":       true,
+	"pattern":      true,
+	"truthy":       true,
+	"undefined":    true,
+	"xor":          true,
+}
+
+// structuralFunctions are the streaming functions that only look at the keys (and the values that are not mappings)
+// of the nodes they are supplied, they give the same results for the sections of the specification that are split.
+var structuralFunctions = map[string]bool{
+	"pattern": true,
+	"truthy":  true,
+}
+
+// IsStreamingEligible returns true if a rule can be run in streaming mode. A rule is streaming-eligible if every
+// action it defines uses one of the core functions `alphabetical`, `casing`, `defined`, `enumeration`, `falsy`,
+// `length`, `pattern`, `truthy`, `undefined` or `xor`. Everything else (any OpenAPI, OWASP, schema or custom
+// function) needs the index or the document model and is deferred.
+//
+// An eligible rule may still be deferred by ApplyRulesToRuleSetStreaming, depending on the specification it's
+// linting, when its results could differ from those of a full load.
+func IsStreamingEligible(rule *model.Rule) bool {
+	return rule != nil && usesOnly(rule, streamingFunctions)
+}
+
+// usesOnly returns true if every action of a rule uses one of the supplied functions.
+func usesOnly(rule *model.Rule, functions map[string]bool) bool {
+	var action model.RuleAction
+	if err := mapstructure.Decode(rule.Then, &action); err == nil {
+		return functions[action.Function]
+	}
+	var actions []model.RuleAction
+	if err := mapstructure.Decode(rule.Then, &actions); err == nil && len(actions) > 0 {
+		for _, a := range actions {
+			if !functions[a.Function] {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// ApplyRulesToRuleSetStreaming runs only the streaming-eligible rules in the ruleset (see IsStreamingEligible),
+// with a bounded memory footprint. No document model, index, rolodex or resolved copy of the specification is
+// built, and the specification is never parsed into a single node tree: the entries of `paths`, `webhooks`,
+// `definitions`, `parameters`, `responses`, `securityDefinitions` and of every section of `components` are parsed
+// and linted one at a time, and dropped once they have been linted. Only the keys of those sections, and the rest
+// of the top level, are kept in memory. The raw bytes of the specification are held, as they are supplied.
+//
+// Results are identical to those returned by ApplyRulesToRuleSet, so an eligible rule is deferred if they might
+// not be:
+//   - a rule that needs a resolved specification, when a section it looks at uses references.
+//   - a rule whose given path finds a section that is split (or the root), unless it only uses `truthy` or
+//     `pattern`, which only look at the keys of the section.
+//   - a rule with a filter that is applied to a section that is split, or that looks at the root.
+//
+// Rules that are not run are returned in the DeferredRules of the result, so they can be run later (or not at all).
+// A specification that can't be split safely (anchors used across entries, YAML that is laid out in ways the
+// entries can't be found without parsing, and so on) is parsed as a whole, as is a pre-parsed SpecInfo. A Timeout
+// or a cancelled Context is honored the same way as ApplyRulesToRuleSet.
+func ApplyRulesToRuleSetStreaming(execution *RuleSetExecution) *RuleSetExecutionResult {
+	return applyWithTimeout(execution, applyRulesToRuleSetStreaming)
+}
+
+func applyRulesToRuleSetStreaming(ctx context.Context, execution *RuleSetExecution,
+	rulesRunning *atomic.Bool) *RuleSetExecutionResult {
+
+	var logger *slog.Logger
+	if execution.Logger == nil {
+		handler := pterm.NewSlogHandler(&pterm.DefaultLogger)
+		logger = slog.New(handler)
+		pterm.DefaultLogger.Level = pterm.LogLevelError
+	} else {
+		logger = execution.Logger
+	}
+
+	var stream *specStream
+	if execution.SpecInfo != nil {
+		stream = newWholeSpecStream(execution.SpecInfo)
+	} else {
+		var err error
+		stream, err = openSpecStream(execution.Spec, execution.SkipDocumentCheck)
+		if err != nil {
+			return &RuleSetExecutionResult{Errors: []error{err}}
+		}
+	}
+	specInfo := stream.specInfo
+
+	var eligible, deferred []*model.Rule
+	if execution.RuleSet != nil {
+		for _, rule := range execution.RuleSet.Rules {
+			if IsStreamingEligible(rule) && !stream.defers(rule) {
+				eligible = append(eligible, rule)
+			} else {
+				deferred = append(deferred, rule)
+			}
+		}
+	}
+
+	if rulesRunning != nil {
+		rulesRunning.Store(true)
+	}
+
+	builtinFunctions := functions.MapBuiltinFunctions()
+	var ruleResults []model.RuleFunctionResult
+	var errs []error
+
+	result := func(timedOut bool) *RuleSetExecutionResult {
+		results, resultErrs := collected(&ruleResults, &errs)
+		return &RuleSetExecutionResult{
+			RuleSetExecution: execution,
+			Results:          *removeDuplicates(&results),
+			SpecInfo:         specInfo,
+			Errors:           resultErrs,
+			TimedOut:         timedOut,
+			DeferredRules:    deferred,
+			referenceSpec:    stream.spec,
+		}
+	}
+
+	// lint runs rules against a root node, and returns false if linting is cancelled before they are done.
+	lint := func(root *yaml.Node, rules []*model.Rule, keepNode func(*yaml.Node) bool,
+		renderValue func(*yaml.Node) string) bool {
+
+		var ruleWaitGroup sync.WaitGroup
+		ruleWaitGroup.Add(len(rules))
+		for _, rule := range rules {
+			rc := ruleContext{
+				rule:              rule,
+				specNode:          root,
+				builtinFunctions:  builtinFunctions,
+				ruleResults:       &ruleResults,
+				wg:                &ruleWaitGroup,
+				errors:            &errs,
+				specInfo:          specInfo,
+				silenceLogs:       execution.SilenceLogs,
+				skipDocumentCheck: execution.SkipDocumentCheck,
+				logger:            logger,
+				context:           ctx,
+				resultHandler:     execution.ResultHandler,
+				keepNode:          keepNode,
+				renderValue:       renderValue,
+			}
+			if execution.PanicFunction != nil {
+				rc.panicFunc = execution.PanicFunction
+			}
+			go runRule(rc)
+		}
+
+		rulesDone := make(chan bool)
+		go func() {
+			ruleWaitGroup.Wait()
+			close(rulesDone)
+		}()
+
+		select {
+		case <-rulesDone:
+			return ctx.Err() == nil
+		case <-ctx.Done():
+			// rules that are still running will stop at the next node, take what we have right now.
+			return false
+		}
+	}
+
+	if ctx.Err() != nil {
+		return result(true)
+	}
+
+	// every chunk is linted by the rules that could find something in it, and dropped.
+	for _, chunk := range stream.chunks {
+		rules := stream.chunkRules(chunk, eligible)
+		if len(rules) == 0 {
+			continue
+		}
+		root, keepNode, err := stream.chunkRoot(chunk)
+		if err != nil {
+			lock.Lock()
+			errs = append(errs, err)
+			lock.Unlock()
+			continue
+		}
+		lock.Lock()
+		linted := len(ruleResults)
+		lock.Unlock()
+		if !lint(root, rules, keepNode, nil) {
+			return result(true)
+		}
+		// results only need the positions of their nodes, don't hold on to the rest of the chunk.
+		lock.Lock()
+		for i := linted; i < len(ruleResults); i++ {
+			ruleResults[i].StartNode = detachNode(ruleResults[i].StartNode)
+			ruleResults[i].EndNode = detachNode(ruleResults[i].EndNode)
+		}
+		lock.Unlock()
+	}
+
+	// then everything that was not split.
+	var keepNode func(*yaml.Node) bool
+	var renderValue func(*yaml.Node) string
+	if stream.chunks != nil {
+		keepNode, renderValue = stream.keep, stream.renderValue
+	}
+	if !lint(stream.root, eligible, keepNode, renderValue) {
+		return result(true)
+	}
+	return result(ctx.Err() != nil)
+}
+
+// defers returns true if the results of an eligible rule could differ from those of a full load of this
+// specification.
+func (s *specStream) defers(rule *model.Rule) bool {
+	paths := rulePaths(rule, s.specInfo)
+	structural := usesOnly(rule, structuralFunctions)
+	if rule.Resolved && !s.resolvesTheSame(paths, structural) {
+		return true
+	}
+	if s.chunks == nil {
+		return false
+	}
+	for _, path := range paths {
+		nodes, err := s.findNodes(path)
+		if err != nil {
+			continue
+		}
+		for _, node := range nodes {
+			if !structural && s.skeletal[node] {
+				return true
+			}
+		}
+		for i := strings.Index(path, "[?"); i >= 0; i = nextIndex(path, "[?", i) {
+			// a filter on the root would only see the chunk being linted.
+			if strings.Contains(path[i:], "$") {
+				return true
+			}
+			if s.filtersSkeleton(path[:i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolvesTheSame returns true if the paths of a rule that needs a resolved specification find the same nodes
+// whether it's resolved or not, and the rule sees the same thing in them. That's true for paths into sections
+// without references, and for structural rules if nothing on the way to the nodes they find is a reference.
+func (s *specStream) resolvesTheSame(paths []string, structural bool) bool {
+	for _, path := range paths {
+		if name, anywhere := sectionName(path); !s.refs[name] && !(anywhere && s.anyRefs) {
+			continue
+		}
+		if !structural || strings.Contains(path, "..") || strings.Contains(path, "[?") {
+			return false
+		}
+		for _, prefix := range append(pathPrefixes(path), path) {
+			nodes, err := s.findNodes(prefix)
+			if err != nil {
+				return false
+			}
+			for _, node := range nodes {
+				if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+					node = node.Content[0]
+				}
+				if s.placeholders[node] != nil {
+					return false
+				}
+				if node.Kind != yaml.MappingNode {
+					continue
+				}
+				if _, ref := utils.FindKeyNodeTop("$ref", node.Content); ref != nil {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// pathPrefixes returns every path a JSONPath goes through on the way to the nodes it finds, starting at the root.
+func pathPrefixes(path string) []string {
+	var prefixes []string
+	depth := 0
+	var quote byte
+	for i := 1; i < len(path); i++ {
+		switch c := path[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			if depth == 0 {
+				prefixes = append(prefixes, path[:i])
+			}
+			depth++
+		case c == ']':
+			depth--
+		case c == '.' && depth == 0:
+			prefixes = append(prefixes, path[:i])
+		}
+	}
+	return prefixes
+}
+
+// filtersSkeleton returns true if a filter after the supplied path would be applied to a section that is split.
+func (s *specStream) filtersSkeleton(path string) bool {
+	recursive := strings.HasSuffix(path, "..")
+	path = strings.TrimRight(path, ".")
+	nodes, err := s.findNodes(path)
+	if err != nil {
+		return true
+	}
+	var found func(node *yaml.Node, descend bool) bool
+	found = func(node *yaml.Node, descend bool) bool {
+		if s.skeletal[node] {
+			return true
+		}
+		for _, n := range node.Content {
+			if (descend || node.Kind == yaml.SequenceNode) && found(n, recursive) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, node := range nodes {
+		if found(node, recursive) {
+			return true
+		}
+	}
+	return false
+}
+
+// chunkRules returns the rules that could find something in a chunk. Rules with a path that can't be compiled are
+// left out, they are reported once, when the rest of the specification is linted.
+func (s *specStream) chunkRules(chunk *streamChunk, rules []*model.Rule) []*model.Rule {
+	section := chunk.section[0].Value
+	var found []*model.Rule
+	for _, rule := range rules {
+		paths := rulePaths(rule, s.specInfo)
+		matches := false
+		for _, path := range paths {
+			if _, err := s.findNodes(path); err != nil {
+				matches = false
+				break
+			}
+			if path == "$" {
+				continue
+			}
+			if name, anywhere := sectionName(path); anywhere || name == section {
+				matches = true
+			}
+		}
+		if matches {
+			found = append(found, rule)
+		}
+	}
+	return found
+}
+
+// detachNode copies a node without anything under it.
+func detachNode(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	return &yaml.Node{Kind: node.Kind, Style: node.Style, Tag: node.Tag, Value: node.Value,
+		Anchor: node.Anchor, Line: node.Line, Column: node.Column}
+}
+
+func nextIndex(s, substr string, i int) int {
+	if j := strings.Index(s[i+1:], substr); j >= 0 {
+		return i + 1 + j
+	}
+	return -1
+}
@@ -0,0 +1,476 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// splitSections are the top-level sections of a specification that are split into their entries when streaming,
+// so every path, definition, parameter (and so on) is parsed and linted on its own. Every section of `components`
+// is split as well.
+var splitSections = map[string]bool{
+	"paths":               true,
+	"webhooks":            true,
+	"definitions":         true,
+	"parameters":          true,
+	"responses":           true,
+	"securityDefinitions": true,
+	"components":          true,
+}
+
+// splitSection returns true if the section with this name, inside the supplied parent sections, is split.
+func splitSection(parents []string, name string) bool {
+	switch len(parents) {
+	case 0:
+		return splitSections[name]
+	case 1:
+		return parents[0] == "components"
+	}
+	return false
+}
+
+// errNotSplit is returned when a specification can't be split safely, it's parsed as a whole instead.
+var errNotSplit = errors.New("specification cannot be split")
+
+// streamEntry is a key and value of the specification that is parsed on its own.
+type streamEntry struct {
+	start, end int            // the bytes of the entry.
+	headerEnd  int            // the end of the key, when the entry is split.
+	line       int            // the line the entry starts on.
+	children   []*streamEntry // the entries of the value, if it's split.
+}
+
+// parse parses the entry on its own, and returns its key and value positioned where they are in the specification.
+func (e *streamEntry) parse(spec []byte) (*yaml.Node, *yaml.Node, error) {
+	return e.parsePair(spec[e.start:e.end])
+}
+
+// parseKey parses only the key of a split entry.
+func (e *streamEntry) parseKey(spec []byte) (*yaml.Node, error) {
+	key, _, err := e.parsePair(spec[e.start:e.headerEnd])
+	return key, err
+}
+
+func (e *streamEntry) parsePair(text []byte) (*yaml.Node, *yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(text, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode || len(doc.Content[0].Content) != 2 {
+		return nil, nil, errNotSplit
+	}
+	positionNode(doc.Content[0], e.line)
+	return doc.Content[0].Content[0], doc.Content[0].Content[1], nil
+}
+
+// positionNode moves a node parsed from an entry (and everything under it) to the line it is on in the
+// specification. Entries start at the beginning of a line, so columns are already right.
+func positionNode(node *yaml.Node, line int) {
+	node.Line += line - 1
+	for _, n := range node.Content {
+		positionNode(n, line)
+	}
+}
+
+// specStream is a specification that is linted one entry at a time. The split sections of the specification are
+// never held in memory as a whole: the root node only holds their keys, each value that is a mapping is replaced
+// by an empty placeholder, and parsed again when it's linted. If the specification can't be split, the root node
+// is the whole specification, and there are no chunks.
+type specStream struct {
+	spec         []byte
+	specInfo     *datamodel.SpecInfo
+	root         *yaml.Node
+	chunks       []*streamChunk
+	skeletal     map[*yaml.Node]bool         // the nodes holding placeholders, and the nodes holding those.
+	placeholders map[*yaml.Node]*streamEntry // placeholder nodes, and the entry they stand in for.
+	refs         map[string]bool             // the top-level sections that use references.
+	anyRefs      bool
+}
+
+// streamChunk is an entry of a split section that is linted on its own.
+type streamChunk struct {
+	entry   *streamEntry
+	section []*yaml.Node // the keys of the sections holding the entry, from the top down.
+}
+
+// openSpecStream reads the structure of a specification for streaming. The specification is split into its
+// entries if it can be, otherwise it's parsed as a whole.
+func openSpecStream(spec []byte, skipDocumentCheck bool) (*specStream, error) {
+	s, err := splitSpecStream(spec, skipDocumentCheck)
+	if err == nil {
+		return s, nil
+	}
+	if !errors.Is(err, errNotSplit) {
+		return nil, err
+	}
+	specInfo, err := datamodel.ExtractSpecInfoWithDocumentCheck(spec, skipDocumentCheck)
+	if err != nil {
+		return nil, err
+	}
+	return newWholeSpecStream(specInfo), nil
+}
+
+// newWholeSpecStream lints a specification that has already been parsed, as a whole.
+func newWholeSpecStream(specInfo *datamodel.SpecInfo) *specStream {
+	s := &specStream{specInfo: specInfo, root: specInfo.RootNode, refs: make(map[string]bool)}
+	if s.root != nil && len(s.root.Content) > 0 {
+		root := s.root.Content[0]
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if hasRefKey(root.Content[i+1]) {
+				s.refs[root.Content[i].Value] = true
+				s.anyRefs = true
+			}
+		}
+	}
+	return s
+}
+
+// splitSpecStream scans a specification for its entries, and parses them one at a time into the root node. Only a
+// narrow subset of YAML is split, errNotSplit is returned for anything else (JSON included), so it's parsed as a
+// whole instead:
+//
+//   - block style YAML, UTF-8 without a byte order mark, with `\n` or `\r\n` line breaks, indented with spaces.
+//   - no directives, and no document markers.
+//   - every line an entry starts on is a plain or quoted key (like `paths:` or `openapi: 3.1.0`), at the first
+//     column for the top level, or indented as much as the first entry of a split section.
+//   - a split section is a key with nothing but (maybe) a comment after it, every line of its entries other than
+//     the first is indented more than the entry is.
+//   - the lines of a top-level entry can be a sequence indented as much as the key (`tags:` then `- name: pizza`).
+//
+// Every entry must parse on its own into a single key and value, and no key can be there twice.
+func splitSpecStream(spec []byte, skipDocumentCheck bool) (*specStream, error) {
+	if bytes.HasPrefix(spec, []byte("\xef\xbb\xbf")) || bytes.Contains(spec, []byte("\xc2\x85")) ||
+		bytes.Contains(spec, []byte("\xe2\x80\xa8")) || bytes.Contains(spec, []byte("\xe2\x80\xa9")) {
+		return nil, errNotSplit
+	}
+	for i, b := range spec {
+		if b == '\r' && (i+1 == len(spec) || spec[i+1] != '\n') {
+			return nil, errNotSplit
+		}
+	}
+
+	scanner := &yamlScanner{spec: spec, line: 1}
+	entries, err := scanner.scanMapping(0, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errNotSplit
+	}
+
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}
+	s := &specStream{
+		spec:         spec,
+		root:         doc,
+		skeletal:     map[*yaml.Node]bool{doc: true, root: true},
+		placeholders: make(map[*yaml.Node]*streamEntry),
+		refs:         make(map[string]bool),
+	}
+	if err = s.build(root, entries, nil); err != nil {
+		return nil, err
+	}
+	// a block mapping starts at its first key, and so does the document.
+	root.Line, root.Column = root.Content[0].Line, root.Content[0].Column
+	doc.Line, doc.Column = root.Line, root.Column
+	for i, e := range entries {
+		if hasRefText(spec[e.start:e.end]) {
+			s.refs[root.Content[i*2].Value] = true
+			s.anyRefs = true
+		}
+	}
+
+	if s.specInfo, err = extractStreamSpecInfo(spec, root, skipDocumentCheck); err != nil {
+		return nil, err
+	}
+	s.specInfo.RootNode = doc
+	return s, nil
+}
+
+// build parses the entries of a mapping into it. The entries of a split section are chunks, a value that is a
+// mapping is only parsed to find out what it is, and is replaced by a placeholder.
+func (s *specStream) build(mapping *yaml.Node, entries []*streamEntry, section []*yaml.Node) error {
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		var key, value *yaml.Node
+		var err error
+		if e.children != nil {
+			if key, err = e.parseKey(s.spec); err != nil {
+				return errNotSplit
+			}
+			value = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			s.skeletal[value] = true
+			if err = s.build(value, e.children, append(section[:len(section):len(section)], key)); err != nil {
+				return err
+			}
+			value.Line, value.Column = value.Content[0].Line, value.Content[0].Column
+		} else {
+			if key, value, err = e.parse(s.spec); err != nil {
+				return errNotSplit
+			}
+			if len(section) > 0 && value.Kind == yaml.MappingNode && len(value.Content) > 0 {
+				placeholder := &yaml.Node{Kind: value.Kind, Style: value.Style, Tag: value.Tag,
+					Anchor: value.Anchor, Line: value.Line, Column: value.Column}
+				s.placeholders[placeholder] = e
+				s.chunks = append(s.chunks, &streamChunk{entry: e, section: section})
+				value = placeholder
+			}
+		}
+		// duplicate keys fail the whole specification, leave it to the parser to say so.
+		if seen[key.Value] {
+			return errNotSplit
+		}
+		seen[key.Value] = true
+		mapping.Content = append(mapping.Content, key, value)
+	}
+	return nil
+}
+
+// extractStreamSpecInfo reads the spec info from the top level of the specification only, all that's needed to
+// find out the type and version of the specification.
+func extractStreamSpecInfo(spec []byte, root *yaml.Node, skipDocumentCheck bool) (*datamodel.SpecInfo, error) {
+	// comments are left out, one after the key of a section doesn't survive its value being left out.
+	top := &yaml.Node{Kind: yaml.MappingNode, Tag: root.Tag}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := *root.Content[i], *root.Content[i+1]
+		if value.Kind != yaml.ScalarNode {
+			value = yaml.Node{Kind: value.Kind, Tag: value.Tag}
+		}
+		key.HeadComment, key.LineComment, key.FootComment = "", "", ""
+		value.HeadComment, value.LineComment, value.FootComment = "", "", ""
+		top.Content = append(top.Content, &key, &value)
+	}
+	topBytes, err := yaml.Marshal(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{top}})
+	if err != nil {
+		return nil, errNotSplit
+	}
+	specInfo, err := datamodel.ExtractSpecInfoWithDocumentCheck(topBytes, skipDocumentCheck)
+	if err != nil {
+		return nil, err
+	}
+	// the JSON of the top level is no use to anyone.
+	<-specInfo.JsonParsingChannel
+	specInfo.SpecJSON = nil
+	specInfo.SpecJSONBytes = nil
+	specInfo.SpecBytes = &spec
+	specInfo.SpecFileType = datamodel.YAMLFileType
+	return specInfo, nil
+}
+
+// chunkRoot parses a chunk into a document of its own, that holds the chunk where it is in the specification.
+// The returned function keeps only the nodes of the chunk's value.
+func (s *specStream) chunkRoot(chunk *streamChunk) (*yaml.Node, func(node *yaml.Node) bool, error) {
+	key, value, err := chunk.entry.parse(s.spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	outside := map[*yaml.Node]bool{key: true}
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{key, value}}
+	for i := len(chunk.section) - 1; i >= 0; i-- {
+		outside[node] = true
+		outside[chunk.section[i]] = true
+		node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{chunk.section[i], node}}
+	}
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{node}}
+	outside[node] = true
+	outside[doc] = true
+	return doc, func(n *yaml.Node) bool { return !outside[n] }, nil
+}
+
+// keep returns true for every node of the root node that is not a placeholder.
+func (s *specStream) keep(node *yaml.Node) bool {
+	return s.placeholders[node] == nil
+}
+
+// renderValue renders a node of the root node, placeholders are rendered as the values they stand in for.
+func (s *specStream) renderValue(node *yaml.Node) string {
+	return model.RenderExpandedNodeValue(node, model.MaxResultValueLength, func(n *yaml.Node) *yaml.Node {
+		if e := s.placeholders[n]; e != nil {
+			if _, value, err := e.parse(s.spec); err == nil {
+				return value
+			}
+		}
+		return n
+	})
+}
+
+// hasRefKey returns true if a node, or anything under it, has a `$ref` key.
+func hasRefKey(node *yaml.Node) bool {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			if node.Content[i].Value == "$ref" {
+				return true
+			}
+		}
+	}
+	for _, n := range node.Content {
+		if hasRefKey(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRefText returns true if the text of an entry could hold a `$ref` key, escaped or not.
+func hasRefText(text []byte) bool {
+	for _, ref := range []string{"$ref", `\u0024ref`, `\x24ref`, `\U00000024ref`} {
+		if bytes.Contains(text, []byte(ref)) {
+			return true
+		}
+	}
+	return false
+}
+
+// yamlKey matches a line that starts an entry, holding its key and whatever follows it on the line. Keys that start
+// like anything other than a plain or simply quoted scalar don't match.
+var yamlKey = regexp.MustCompile(`^("[^"\\]*"|'[^']*'|[^\s"'#&*!|>%@` + "`" + `{}\[\],?:-][^#]*?):(?:[ \t]+(.*?))?\r?$`)
+
+// yamlScanner scans YAML for the lines entries start on, without parsing anything.
+type yamlScanner struct {
+	spec []byte
+	pos  int // the start of the current line.
+	line int // the current line.
+}
+
+// yamlLine is a line of YAML.
+type yamlLine struct {
+	start   int  // the start of the line.
+	end     int  // the end of the line, before the line break.
+	next    int  // the start of the next line.
+	indent  int  // the number of leading spaces.
+	content bool // false for blank and comment lines.
+	tab     bool // the content is indented with a tab.
+}
+
+func (y *yamlScanner) current() yamlLine {
+	l := yamlLine{start: y.pos, end: len(y.spec), next: len(y.spec)}
+	if i := bytes.IndexByte(y.spec[y.pos:], '\n'); i >= 0 {
+		l.end, l.next = y.pos+i, y.pos+i+1
+	}
+	text := y.spec[y.pos:l.end]
+	for l.indent < len(text) && text[l.indent] == ' ' {
+		l.indent++
+	}
+	rest := bytes.TrimLeft(text[l.indent:], " \t\r")
+	l.content = len(rest) > 0 && rest[0] != '#'
+	l.tab = l.content && text[l.indent] != rest[0]
+	return l
+}
+
+func (y *yamlScanner) advance(l yamlLine) {
+	y.pos = l.next
+	y.line++
+}
+
+// nextContent returns the next line with content, without moving past it.
+func (y *yamlScanner) nextContent() (yamlLine, bool) {
+	pos, line := y.pos, y.line
+	defer func() { y.pos, y.line = pos, line }()
+	for y.pos < len(y.spec) {
+		l := y.current()
+		if l.content {
+			return l, true
+		}
+		y.advance(l)
+	}
+	return yamlLine{}, false
+}
+
+// scanMapping scans the entries of a block mapping indented by indent, up to the first line indented less.
+func (y *yamlScanner) scanMapping(indent int, parents []string) ([]*streamEntry, error) {
+	var entries []*streamEntry
+	var current *streamEntry
+	for y.pos < len(y.spec) {
+		l := y.current()
+		if !l.content {
+			y.advance(l)
+			continue
+		}
+		if l.tab {
+			return nil, errNotSplit
+		}
+		if l.indent < indent {
+			break
+		}
+		text := y.spec[l.start+l.indent : l.end]
+		if current != nil && (l.indent > indent || (indent == 0 && isYAMLSequenceItem(text))) {
+			y.advance(l)
+			continue
+		}
+		key := yamlKey.FindSubmatch(text)
+		if l.indent > indent || key == nil {
+			return nil, errNotSplit
+		}
+		if current != nil {
+			current.end = l.start
+		}
+		current = &streamEntry{start: l.start, line: y.line}
+		entries = append(entries, current)
+		y.advance(l)
+
+		name := strings.Trim(string(key[1]), `"'`)
+		if (len(key[2]) > 0 && key[2][0] != '#') || !splitSection(parents, name) {
+			continue
+		}
+		next, ok := y.nextContent()
+		if !ok || next.indent <= indent {
+			continue
+		}
+		current.headerEnd = l.end
+		children, err := y.scanMapping(next.indent, append(parents[:len(parents):len(parents)], name))
+		if err != nil {
+			return nil, err
+		}
+		// nothing else can belong to a split entry.
+		current.children = children
+		current.end = y.pos
+		current = nil
+	}
+	if current != nil {
+		current.end = y.pos
+	}
+	return entries, nil
+}
+
+// isYAMLSequenceItem returns true if the content of a line is an item of a block sequence.
+func isYAMLSequenceItem(text []byte) bool {
+	return text[0] == '-' && (len(text) == 1 || text[1] == ' ' || text[1] == '\r')
+}
+
+// sectionName returns the name of the top-level section a JSONPath finds nodes in, or anywhere as true if it
+// could find nodes in any section.
+func sectionName(path string) (name string, anywhere bool) {
+	switch {
+	case strings.HasPrefix(path, "$.") && !strings.HasPrefix(path, "$.."):
+		name = path[2:]
+		if i := strings.IndexAny(name, ".["); i >= 0 {
+			name = name[:i]
+		}
+	case strings.HasPrefix(path, "$['") || strings.HasPrefix(path, `$["`):
+		if i := strings.IndexAny(path[3:], `'"`); i >= 0 {
+			name = path[3 : 3+i]
+		}
+	}
+	if name == "" || name == "*" {
+		return "", true
+	}
+	return name, false
+}
+
+// findNodes finds the nodes of a JSONPath in the root node, like runRule does.
+func (s *specStream) findNodes(path string) ([]*yaml.Node, error) {
+	if path == "$" {
+		return []*yaml.Node{s.root}, nil
+	}
+	return utils.FindNodesWithoutDeserializing(s.root, path)
+}
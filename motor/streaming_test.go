@@ -0,0 +1,418 @@
+package motor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func streamingResultKeys(results []model.RuleFunctionResult) []string {
+	var keys []string
+	for _, r := range results {
+		var line, col, endLine, endCol int
+		if r.StartNode != nil {
+			line, col = r.StartNode.Line, r.StartNode.Column
+		}
+		if r.EndNode != nil {
+			endLine, endCol = r.EndNode.Line, r.EndNode.Column
+		}
+		keys = append(keys, fmt.Sprintf("%s|%d:%d|%d:%d|%s|%s|%s", r.RuleId, line, col, endLine, endCol, r.Path,
+			r.Message, r.Value))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// streamedRules returns a ruleset holding only the rules that were run in streaming mode.
+func streamedRules(rs *rulesets.RuleSet, deferred []*model.Rule) *rulesets.RuleSet {
+	streamed := &rulesets.RuleSet{Rules: make(map[string]*model.Rule)}
+outer:
+	for id, rule := range rs.Rules {
+		for _, d := range deferred {
+			if d == rule {
+				continue outer
+			}
+		}
+		streamed.Rules[id] = rule
+	}
+	return streamed
+}
+
+func TestIsStreamingEligible(t *testing.T) {
+	rules := rulesets.GetAllBuiltInRules()
+
+	assert.True(t, IsStreamingEligible(rules[rulesets.Oas3HostTrailingSlash]))
+	assert.True(t, IsStreamingEligible(rules[rulesets.InfoContact]))
+	assert.False(t, IsStreamingEligible(rules[rulesets.OperationSuccessResponse]))
+	assert.False(t, IsStreamingEligible(nil))
+
+	multi := &model.Rule{
+		Given: "$",
+		Then: []interface{}{
+			map[string]interface{}{"function": "truthy", "field": "info"},
+			map[string]interface{}{"function": "oasOpIdUnique"},
+		},
+	}
+	assert.False(t, IsStreamingEligible(multi))
+
+	resolved := &model.Rule{
+		Given:    "$",
+		Resolved: true,
+		Then:     model.RuleAction{Function: "truthy", Field: "info"},
+	}
+	assert.True(t, IsStreamingEligible(resolved))
+
+	blank := &model.Rule{
+		Given: "$.info",
+		Then:  model.RuleAction{Function: "blank"},
+	}
+	assert.False(t, IsStreamingEligible(blank))
+}
+
+func TestApplyRulesToRuleSetStreaming_MatchesFullLoad(t *testing.T) {
+
+	for _, spec := range []string{
+		"../model/test_files/burgershop.openapi.yaml",
+		"../model/test_files/petstorev2.json",
+		"../model/test_files/petstorev3.json",
+		"../model/test_files/all-the-components.yaml",
+		"../model/test_files/asana.yaml",
+		"../model/test_files/api.github.com.yaml",
+		"../model/test_files/stripe.yaml",
+		"../model/test_files/k8s.json",
+	} {
+		specBytes, _ := os.ReadFile(spec)
+		rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIDefaultRuleSet()
+
+		stream := ApplyRulesToRuleSetStreaming(&RuleSetExecution{
+			RuleSet: rs,
+			Spec:    specBytes,
+		})
+		assert.Len(t, stream.Errors, 0, spec)
+		assert.NotEmpty(t, stream.DeferredRules, spec)
+		assert.Less(t, len(stream.DeferredRules), len(rs.Rules), spec)
+		// resolved, but only looks at the keys of the paths, which are never references.
+		assert.NotContains(t, stream.DeferredRules, rs.Rules[rulesets.PathKeysNoTrailingSlash], spec)
+
+		// the rules that were run give the same results as they do with a full load.
+		full := ApplyRulesToRuleSet(&RuleSetExecution{
+			RuleSet: streamedRules(rs, stream.DeferredRules),
+			Spec:    specBytes,
+		})
+		var fullResults []model.RuleFunctionResult
+		for _, r := range full.Results {
+			if r.RuleId != "resolving-references" {
+				fullResults = append(fullResults, r)
+			}
+		}
+		assert.Equal(t, streamingResultKeys(fullResults), streamingResultKeys(stream.Results), spec)
+	}
+}
+
+func TestApplyRulesToRuleSetStreaming_CustomRules(t *testing.T) {
+
+	yml := `extends: [[spectral:oas, off]]
+rules:
+  op-ids-snake:
+    resolved: false
+    given: $.paths[*][*].operationId
+    then:
+      function: casing
+      functionOptions:
+        type: snake
+  no-descriptions:
+    resolved: false
+    given: $.paths[*][*]
+    then:
+      field: description
+      function: falsy
+  tags-sorted:
+    resolved: false
+    given: $.tags
+    then:
+      function: alphabetical
+      functionOptions:
+        keyedBy: name
+  schema-types:
+    resolved: false
+    given: $.components.schemas[*].properties[*]
+    then:
+      field: type
+      function: enumeration
+      functionOptions:
+        values: [string, array]
+  unique-ops:
+    resolved: false
+    given: $
+    then:
+      function: oasOpIdUnique`
+
+	rs, err := rulesets.CreateRuleSetFromData([]byte(yml))
+	assert.NoError(t, err)
+	rs = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	specBytes, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+
+	stream := ApplyRulesToRuleSetStreaming(&RuleSetExecution{
+		RuleSet: rs,
+		Spec:    specBytes,
+	})
+
+	assert.Len(t, stream.DeferredRules, 1)
+	assert.Equal(t, "unique-ops", stream.DeferredRules[0].Id)
+
+	full := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet: streamedRules(rs, stream.DeferredRules),
+		Spec:    specBytes,
+	})
+	assert.NotEmpty(t, stream.Results)
+	assert.Equal(t, streamingResultKeys(full.Results), streamingResultKeys(stream.Results))
+}
+
+func TestApplyRulesToRuleSetStreaming_BadSpec(t *testing.T) {
+	for _, spec := range []string{
+		"this is not a spec",
+		"openapi: 3.0.1\npaths:\n  /a:\n    get:\n      operationId: a\n- nope\n",
+	} {
+		res := ApplyRulesToRuleSetStreaming(&RuleSetExecution{
+			RuleSet: rulesets.BuildDefaultRuleSets().GenerateOpenAPIDefaultRuleSet(),
+			Spec:    []byte(spec),
+		})
+		assert.Len(t, res.Errors, 1, spec)
+	}
+}
+
+func TestApplyRulesToRuleSetStreaming_Cancelled(t *testing.T) {
+
+	specBytes, _ := os.ReadFile("../model/test_files/api.github.com.yaml")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res := ApplyRulesToRuleSetStreaming(&RuleSetExecution{
+		RuleSet: rulesets.BuildDefaultRuleSets().GenerateOpenAPIDefaultRuleSet(),
+		Spec:    specBytes,
+		Context: ctx,
+	})
+	assert.True(t, res.TimedOut)
+	assert.Empty(t, res.Results)
+}
+
+func TestApplyRulesToRuleSetStreaming_Defers(t *testing.T) {
+
+	yml := `extends: [[spectral:oas, off]]
+rules:
+  paths-count:
+    resolved: false
+    given: $.paths
+    then:
+      function: length
+      functionOptions:
+        max: 1
+  paths-burgers:
+    resolved: false
+    given: $.paths
+    then:
+      field: /burgers
+      function: truthy
+  paths-filter:
+    resolved: false
+    given: $.paths[?(@['/burgers'])]
+    then:
+      field: /burgers
+      function: truthy
+  root-filter:
+    resolved: false
+    given: $.paths[*][?(@.operationId == $.info.title)]
+    then:
+      field: summary
+      function: truthy
+  ops-filter:
+    resolved: false
+    given: $.paths[*][?(@.operationId)]
+    then:
+      field: summary
+      function: truthy
+  resolved-ops:
+    given: $.paths[*][*]
+    then:
+      field: summary
+      function: truthy
+  resolved-info:
+    given: $.info
+    then:
+      field: title
+      function: truthy`
+
+	rs, err := rulesets.CreateRuleSetFromData([]byte(yml))
+	assert.NoError(t, err)
+	rs = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	specBytes, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+
+	stream := ApplyRulesToRuleSetStreaming(&RuleSetExecution{
+		RuleSet: rs,
+		Spec:    specBytes,
+	})
+
+	var deferred []string
+	for _, rule := range stream.DeferredRules {
+		deferred = append(deferred, rule.Id)
+	}
+	sort.Strings(deferred)
+	assert.Equal(t, []string{"paths-count", "paths-filter", "resolved-ops", "root-filter"}, deferred)
+
+	full := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet: streamedRules(rs, stream.DeferredRules),
+		Spec:    specBytes,
+	})
+	assert.Equal(t, streamingResultKeys(full.Results), streamingResultKeys(stream.Results))
+}
+
+// layoutRuleSet returns a ruleset of streaming-eligible rules that find something in every layout test spec.
+func layoutRuleSet(t *testing.T) *rulesets.RuleSet {
+	yml := `extends: [[spectral:oas, off]]
+rules:
+  op-summary:
+    resolved: false
+    given: $.paths[*][*]
+    then:
+      field: summary
+      function: truthy
+  op-id-snake:
+    resolved: false
+    given: $.paths[*][*].operationId
+    then:
+      function: casing
+      functionOptions:
+        type: snake
+  no-trailing-slash:
+    resolved: false
+    given: $.paths
+    then:
+      field: "@key"
+      function: pattern
+      functionOptions:
+        notMatch: /$
+  short-title:
+    resolved: false
+    given: $.info.title
+    then:
+      function: length
+      functionOptions:
+        max: 3
+  schema-type:
+    resolved: false
+    given: $.components.schemas[*]
+    then:
+      field: type
+      function: defined
+  tags-sorted:
+    resolved: false
+    given: $.tags
+    then:
+      function: alphabetical
+      functionOptions:
+        keyedBy: name`
+
+	rs, err := rulesets.CreateRuleSetFromData([]byte(yml))
+	assert.NoError(t, err)
+	return rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+}
+
+// assertStreamsLikeFullRun checks a spec is split (or not), and that streaming finds the same results as a normal
+// run of the same rules.
+func assertStreamsLikeFullRun(t *testing.T, rs *rulesets.RuleSet, name, spec string, split bool) {
+	opened, err := openSpecStream([]byte(spec), false)
+	if !assert.NoError(t, err, name) {
+		return
+	}
+	assert.Equal(t, split, opened.chunks != nil, name)
+
+	stream := ApplyRulesToRuleSetStreaming(&RuleSetExecution{
+		RuleSet: rs,
+		Spec:    []byte(spec),
+	})
+	full := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet: rs,
+		Spec:    []byte(spec),
+	})
+	assert.Empty(t, stream.DeferredRules, name)
+	assert.NotEmpty(t, stream.Results, name)
+	assert.Equal(t, streamingResultKeys(full.Results), streamingResultKeys(stream.Results), name)
+}
+
+func TestApplyRulesToRuleSetStreaming_Layouts(t *testing.T) {
+	rs := layoutRuleSet(t)
+
+	for name, spec := range map[string]string{
+		"comments": "# pizza\nopenapi: 3.0.1\ninfo:\n  title: pizza\npaths: # all of them\n\n  # first\n  /a/:\n" +
+			"    get:\n      operationId: getA\n  # comment\n\n  /b:\n    get:\n      summary: b\n      operationId: get_b\n",
+		"line feeds": "openapi: 3.0.1\r\ninfo:\r\n  title: pizza\r\npaths:\r\n  /a/:\r\n    get:\r\n" +
+			"      operationId: getA\r\n  /b:\r\n    get:\r\n      summary: b\r\n",
+		"sequences": "openapi: 3.0.1\ninfo:\n  title: pizza\ntags:\n- name: b\n- name: a\npaths:\n  /a/:\n    get:\n" +
+			"      tags:\n      - a\n      operationId: getA\n",
+		"components": "openapi: 3.0.1\ninfo:\n  title: pizza\ncomponents:\n  schemas:\n    A:\n      properties:\n" +
+			"        x:\n          type: string\n    B:\n      type: object\n    C: {description: c}\n" +
+			"  responses:\n    Ok:\n      description: ok\n",
+		"quoted keys": "'openapi': 3.0.1\ninfo:\n  title: pizza\n\"paths\":\n    \"/a/\":\n      get:\n" +
+			"        operationId: getA\n    '/b':\n      get: {summary: b}\n",
+	} {
+		assertStreamsLikeFullRun(t, rs, name, spec, true)
+	}
+}
+
+func TestApplyRulesToRuleSetStreaming_Fallbacks(t *testing.T) {
+	rs := layoutRuleSet(t)
+
+	// every spec is valid, but outside what's split, so it's parsed as a whole.
+	for name, spec := range map[string]string{
+		"json": `{"openapi": "3.0.1", "info": {"title": "ééééé"}, "paths": {"/é/": {"get": {"operationId": "getÉ"}},` +
+			` "/b": {"get": {"summary": "b"}}}, "components": {"schemas": {"A": {"description": "ü"}}}}`,
+		"flow":             "{openapi: 3.0.1, info: {title: pizza}, paths: {/a/: {get: {operationId: getA}}}}",
+		"byte order mark":  "\xef\xbb\xbfopenapi: 3.0.1\ninfo:\n  title: pizza\npaths:\n  /a/:\n    get: {operationId: getA}\n",
+		"line separator":   "openapi: 3.0.1\ninfo:\n  title: \"pizza\u2028party\"\npaths:\n  /a/:\n    get: {operationId: getA}\n",
+		"carriage returns": "openapi: 3.0.1\rinfo:\r  title: pizza\rpaths:\r  /a/:\r    get:\r      operationId: getA\r",
+		"tabs": "openapi: 3.0.1\ninfo:\n  title: pizza\npaths:\n  /a/:\n    get:\n      description: \"pizza\n" +
+			"      \tparty\"\n      operationId: getA\n",
+		"directive":       "%YAML 1.1\n---\nopenapi: 3.0.1\ninfo:\n  title: pizza\npaths:\n  /a/:\n    get: {operationId: getA}\n",
+		"document marker": "---\nopenapi: 3.0.1\ninfo:\n  title: pizza\npaths:\n  /a/:\n    get: {operationId: getA}\n",
+		"escaped key":     "openapi: 3.0.1\ninfo:\n  title: pizza\npaths:\n  \"/\\x61/\":\n    get: {operationId: getA}\n",
+		"continued scalar": "openapi: 3.0.1\ninfo:\n  title: \"pizza\nparty\"\npaths:\n  /a/:\n    get:\n" +
+			"      operationId: getA\n",
+		"indented less than entries": "openapi: 3.0.1\ninfo:\n  title: pizza\npaths:\n    /a/:\n      get:\n" +
+			"        description: \"a\n  b\"\n        operationId: getA\n",
+		"anchors": "openapi: 3.0.1\ninfo:\n  title: pizza\n  contact: &contact\n    name: pizza\npaths:\n  /a/:\n" +
+			"    get:\n      x-contact: *contact\n      operationId: getA\n",
+		"duplicates": "openapi: 3.0.1\ninfo:\n  title: pizza\npaths:\n  /a/:\n    get: {}\n  /a/:\n    put: {}\n",
+	} {
+		assertStreamsLikeFullRun(t, rs, name, spec, false)
+	}
+}
+
+func TestApplyRulesToRuleSetStreaming_Splits(t *testing.T) {
+
+	specBytes, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+	stream, err := openSpecStream(specBytes, false)
+	assert.NoError(t, err)
+
+	// only the keys of the paths are kept, every path is a placeholder.
+	_, paths := utils.FindKeyNodeTop("paths", stream.root.Content[0].Content)
+	assert.NotNil(t, paths)
+	assert.NotEmpty(t, paths.Content)
+	for i := 1; i < len(paths.Content); i += 2 {
+		assert.Empty(t, paths.Content[i].Content)
+		assert.NotNil(t, stream.placeholders[paths.Content[i]])
+	}
+	assert.Len(t, stream.chunks, len(stream.placeholders))
+	assert.Equal(t, "3.0.1", stream.specInfo.Version)
+	assert.Equal(t, &specBytes, stream.specInfo.SpecBytes)
+
+	// the value of a placeholder is rendered from its chunk.
+	assert.Contains(t, stream.renderValue(paths), "operationId: createBurger")
+}
@@ -32,7 +32,7 @@ type Schema struct {
 	ContentEncoding      *string            `json:"contentEncoding,omitempty" yaml:"contentEncoding,omitempty"`
 	ContentSchema        *string            `json:"contentSchema,omitempty" yaml:"contentSchema,omitempty"`
 	Items                *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
-	MultipleOf           *int               `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+	MultipleOf           *float64           `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
 	Maximum              *int               `json:"maximum,omitempty" yaml:"maximum,omitempty"`
 	ExclusiveMaximum     *int               `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
 	Minimum              *int               `json:"minimum,omitempty" yaml:"minimum,omitempty"`
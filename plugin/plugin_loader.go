@@ -5,6 +5,7 @@ import (
 	"github.com/daveshanley/vacuum/functions/core"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/plugin/javascript"
+	"github.com/daveshanley/vacuum/plugin/wasm"
 	"github.com/pterm/pterm"
 	"gopkg.in/yaml.v3"
 	"os"
@@ -16,6 +17,12 @@ import (
 
 // LoadFunctions will load custom functions found in the supplied path
 func LoadFunctions(path string) (*Manager, error) {
+	return LoadFunctionsWithLimits(path, wasm.DefaultLimits())
+}
+
+// LoadFunctionsWithLimits will load custom functions found in the supplied path, WASM functions are limited by the
+// supplied limits every time they run.
+func LoadFunctionsWithLimits(path string, limits wasm.Limits) (*Manager, error) {
 
 	dirEntries, err := os.ReadDir(path)
 	if err != nil {
@@ -31,25 +38,9 @@ func LoadFunctions(path string) (*Manager, error) {
 			// found something
 			pterm.Info.Printf("Located custom function plugin: %s\n", fPath)
 
-			// let's try and open it.
-			p, e := plugin.Open(fPath)
-			if e != nil {
-				return nil, e
-			}
-
-			// look up the Boot function and store as a Symbol
-			var bootFunc plugin.Symbol
-			bootFunc, err = p.Lookup("Boot")
-			if err != nil {
+			if err = loadGoPlugin(pm, fPath); err != nil {
 				return nil, err
 			}
-
-			// lets go pedro!
-			if bootFunc != nil {
-				bootFunc.(func(*Manager))(pm)
-			} else {
-				pterm.Error.Printf("Unable to boot plugin")
-			}
 		}
 
 		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".js") {
@@ -80,10 +71,80 @@ func LoadFunctions(path string) (*Manager, error) {
 			// register this function with the plugin manager
 			pm.RegisterFunction(fName, function)
 		}
+
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".wasm") {
+			fPath := filepath.Join(path, entry.Name())
+			fName := strings.TrimSuffix(entry.Name(), ".wasm")
+
+			p, e := os.ReadFile(fPath)
+			if e != nil {
+				return nil, e
+			}
+
+			function, e := wasm.NewWASMRuleFunction(fName, p, limits)
+			if e != nil {
+				return nil, e
+			}
+
+			// found something
+			pterm.Info.Printf("Located custom WASM function: '%s'\n", function.GetSchema().Name)
+
+			pm.RegisterFunction(fName, function)
+		}
 	}
 	return pm, nil
 }
 
+// APIVersion is the version of the plugin API (the Manager and the Boot function) this build of vacuum supports.
+// Plugins declare the version they were built against by exporting a PluginAPIVersion variable, set to
+// plugin.APIVersion. Plugins that don't declare a version are loaded, but can't be checked.
+const APIVersion = 1
+
+// loadGoPlugin opens a compiled Go plugin, checks it was built for this version of vacuum and boots it. Plugins
+// that can't be used fail with an error, rather than crashing vacuum.
+func loadGoPlugin(pm *Manager, fPath string) (err error) {
+
+	// go refuses to open a plugin built with a different version of go, or of any package shared with vacuum.
+	p, err := plugin.Open(fPath)
+	if err != nil {
+		return fmt.Errorf("unable to open plugin '%s', plugins must be built with the same version of go and "+
+			"of vacuum as the vacuum binary loading them: %w", fPath, err)
+	}
+
+	if version, e := p.Lookup("PluginAPIVersion"); e == nil {
+		v, ok := version.(*int)
+		if !ok {
+			return fmt.Errorf("plugin '%s' exports 'PluginAPIVersion', but it is not an int", fPath)
+		}
+		if *v != APIVersion {
+			return fmt.Errorf("plugin '%s' was built for plugin API version %d, this version of vacuum "+
+				"supports version %d", fPath, *v, APIVersion)
+		}
+	} else {
+		pterm.Warning.Printf("Plugin '%s' does not export 'PluginAPIVersion', its compatibility can't be checked\n",
+			fPath)
+	}
+
+	// look up the Boot function and store as a Symbol
+	bootFunc, err := p.Lookup("Boot")
+	if err != nil {
+		return err
+	}
+	boot, ok := bootFunc.(func(*Manager))
+	if !ok {
+		return fmt.Errorf("plugin '%s' exports 'Boot', but it is not a 'func(*plugin.Manager)'", fPath)
+	}
+
+	// lets go pedro!
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("plugin '%s' panicked while booting: %v", fPath, r)
+		}
+	}()
+	boot(pm)
+	return nil
+}
+
 var extractInput = func(input any) *yaml.Node {
 	var y yaml.Node
 	switch reflect.TypeOf(input).Kind() {
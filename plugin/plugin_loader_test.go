@@ -2,6 +2,8 @@ package plugin
 
 import (
 	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 )
@@ -47,3 +49,24 @@ func TestLoadFunctions_TestCompile(t *testing.T) {
 		assert.Equal(t, 0, pm.LoadedFunctionCount())
 	}
 }
+
+func TestLoadFunctions_NotAPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("windows does not support go plugins")
+	}
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "broken.so"), []byte("not a plugin"), 0o644))
+
+	pm, err := LoadFunctions(dir)
+	assert.Nil(t, pm)
+	assert.ErrorContains(t, err, "unable to open plugin")
+}
+
+func TestLoadFunctions_NotAWASMModule(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "broken.wasm"), []byte("not a module"), 0o644))
+
+	pm, err := LoadFunctions(dir)
+	assert.Nil(t, pm)
+	assert.ErrorContains(t, err, "unable to compile WASM function 'broken'")
+}
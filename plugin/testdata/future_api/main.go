@@ -0,0 +1,15 @@
+package main
+
+import "github.com/daveshanley/vacuum/plugin"
+
+// PluginAPIVersion claims a version of the plugin API this build of vacuum does not support.
+var PluginAPIVersion = plugin.APIVersion + 1
+
+// Boot must never be called, the plugin is rejected before it boots.
+func Boot(_ *plugin.Manager) {
+	panic("an incompatible plugin was booted")
+}
+
+func main() {
+	// this plugin is incompatible on purpose
+}
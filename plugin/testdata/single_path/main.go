@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/plugin"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// PluginAPIVersion tells vacuum which version of the plugin API this plugin was built against.
+var PluginAPIVersion = plugin.APIVersion
+
+// singlePath is a sample custom function that checks only a single path exists.
+type singlePath struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the singlePath rule.
+func (sp singlePath) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "singlePath",
+	}
+}
+
+// RunRule will execute the singlePath rule, based on supplied context and a supplied []*yaml.Node slice.
+func (sp singlePath) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	if len(nodes) <= 0 {
+		return nil
+	}
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	paths, pathsNode := utils.FindKeyNodeTop("paths", root.Content)
+	if pathsNode == nil || len(pathsNode.Content) <= 2 {
+		return nil
+	}
+	return []model.RuleFunctionResult{
+		{
+			Message:   fmt.Sprintf("more than a single path exists, there are %d", len(pathsNode.Content)/2),
+			StartNode: paths,
+			EndNode:   pathsNode,
+			Path:      "$.paths",
+			Rule:      context.Rule,
+		},
+	}
+}
+
+// Boot is called by the Manager when the plugin is located.
+func Boot(pm *plugin.Manager) {
+	sp := singlePath{}
+	pm.RegisterFunction(sp.GetSchema().Name, sp)
+}
+
+func main() {
+	// this is a sample plugin
+}
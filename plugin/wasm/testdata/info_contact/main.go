@@ -0,0 +1,64 @@
+// info_contact is a sample WASM rule function, it reports specifications with no contact in their info object.
+// Build it with: GOOS=wasip1 GOARCH=wasm go build -buildmode=c-shared -o info_contact.wasm .
+package main
+
+import (
+	"encoding/json"
+	"unsafe"
+)
+
+// input is what vacuum sends to run_rule.
+type input struct {
+	Input   any               `json:"input"`
+	Options map[string]string `json:"options"`
+	Given   any               `json:"given"`
+}
+
+// result is what run_rule returns to vacuum, path is optional.
+type result struct {
+	Message string `json:"message"`
+	Path    string `json:"path,omitempty"`
+}
+
+// buffers keeps the memory handed to vacuum alive, until the module instance is closed.
+var buffers [][]byte
+
+func keep(b []byte) uint64 {
+	if len(b) == 0 {
+		return 0
+	}
+	buffers = append(buffers, b)
+	return uint64(uintptr(unsafe.Pointer(&b[0])))<<32 | uint64(len(b))
+}
+
+//go:wasmexport alloc
+func alloc(size uint32) uint32 {
+	b := make([]byte, size)
+	buffers = append(buffers, b)
+	return uint32(uintptr(unsafe.Pointer(&b[0])))
+}
+
+//go:wasmexport get_schema
+func getSchema() uint64 {
+	b, _ := json.Marshal(map[string]any{"name": "infoContact"})
+	return keep(b)
+}
+
+//go:wasmexport run_rule
+func runRule(ptr, size uint32) uint64 {
+	var in input
+	if err := json.Unmarshal(unsafe.Slice((*byte)(unsafe.Pointer(uintptr(ptr))), size), &in); err != nil {
+		b, _ := json.Marshal([]result{{Message: "unable to read input: " + err.Error()}})
+		return keep(b)
+	}
+	var results []result
+	doc, _ := in.Input.(map[string]any)
+	info, _ := doc["info"].(map[string]any)
+	if _, ok := info["contact"]; !ok {
+		results = append(results, result{Message: "info has no contact", Path: "$.info"})
+	}
+	b, _ := json.Marshal(results)
+	return keep(b)
+}
+
+func main() {}
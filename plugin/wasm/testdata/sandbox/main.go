@@ -0,0 +1,42 @@
+// sandbox is a WASM rule function that tries to escape its sandbox, it reports what it could reach.
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"unsafe"
+)
+
+var buffers [][]byte
+
+//go:wasmexport alloc
+func alloc(size uint32) uint32 {
+	b := make([]byte, size)
+	buffers = append(buffers, b)
+	return uint32(uintptr(unsafe.Pointer(&b[0])))
+}
+
+//go:wasmexport run_rule
+func runRule(_, _ uint32) uint64 {
+	var results []map[string]string
+	report := func(msg string) {
+		results = append(results, map[string]string{"message": msg})
+	}
+	if _, err := os.ReadFile("/etc/hostname"); err != nil {
+		report("read file: " + err.Error())
+	} else {
+		report("read file: ok")
+	}
+	if err := os.WriteFile("/tmp/escaped", []byte("escaped"), 0o644); err != nil {
+		report("write file: " + err.Error())
+	} else {
+		report("write file: ok")
+	}
+	report("environment: " + strconv.Itoa(len(os.Environ())))
+	b, _ := json.Marshal(results)
+	buffers = append(buffers, b)
+	return uint64(uintptr(unsafe.Pointer(&b[0])))<<32 | uint64(len(b))
+}
+
+func main() {}
@@ -0,0 +1,18 @@
+// spin is a WASM rule function that never returns, it is stopped by the timeout.
+package main
+
+//go:wasmexport alloc
+func alloc(size uint32) uint32 {
+	return uint32(size) // never read, run_rule never returns.
+}
+
+var spins uint64
+
+//go:wasmexport run_rule
+func runRule(_, _ uint32) uint64 {
+	for {
+		spins++
+	}
+}
+
+func main() {}
@@ -0,0 +1,286 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package wasm runs custom rule functions compiled to WebAssembly, so they can be written in any language that
+// compiles to WASM.
+//
+// A module implements this ABI:
+//
+//   - it exports its `memory`, and `alloc(size i32) i32`, which returns a buffer of size bytes vacuum can write to.
+//   - it exports `run_rule(ptr i32, len i32) i64`. The input is a JSON object, with the node being linted as
+//     `input`, and the rule's `options` and `given`. It returns the results as a JSON array of objects with a
+//     `message` and an optional `path`, the pointer to them in the high 32 bits, the length in the low 32 bits.
+//   - it may export `get_schema() i64`, which returns a rule function schema as JSON, the same way.
+//
+// Modules are sandboxed, the only host functions they can import are WASI's, and WASI has no file system, network,
+// environment or arguments. Modules that are reactors (with an `_initialize` export) are initialized first.
+package wasm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"gopkg.in/yaml.v3"
+)
+
+// Limits are what a WASM function is allowed to use, every time it runs.
+type Limits struct {
+	// Timeout is how long a function can run for, before it is stopped.
+	Timeout time.Duration
+	// MemoryPages is how much memory a function can use, in 64KiB pages.
+	MemoryPages uint32
+}
+
+// DefaultLimits are used when no limits are configured, 5 seconds and 128MiB.
+func DefaultLimits() Limits {
+	return Limits{
+		Timeout:     5 * time.Second,
+		MemoryPages: 2048,
+	}
+}
+
+// input is what a module's run_rule export receives.
+type input struct {
+	Input   interface{} `json:"input"`
+	Options interface{} `json:"options"`
+	Given   interface{} `json:"given"`
+}
+
+// output is a single result returned by a module's run_rule export.
+type output struct {
+	Message string `json:"message"`
+	Path    string `json:"path"`
+}
+
+// WASMRuleFunction is a rule function implemented by a compiled WASM module.
+type WASMRuleFunction struct {
+	ruleName string
+	limits   Limits
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+	schema   model.RuleFunctionSchema
+}
+
+// NewWASMRuleFunction compiles a WASM module into a rule function, and checks it implements the ABI. The schema
+// is read once, when the function is created.
+func NewWASMRuleFunction(ruleName string, module []byte, limits Limits) (*WASMRuleFunction, error) {
+	ctx := context.Background()
+	rt := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithCloseOnContextDone(true).
+		WithMemoryLimitPages(limits.MemoryPages))
+
+	// the default module config has no file system, no environment and no arguments, and discards stdio.
+	wasi_snapshot_preview1.MustInstantiate(ctx, rt)
+
+	compiled, err := rt.CompileModule(ctx, module)
+	if err != nil {
+		_ = rt.Close(ctx)
+		return nil, fmt.Errorf("unable to compile WASM function '%s': %w", ruleName, err)
+	}
+	exports := compiled.ExportedFunctions()
+	for _, required := range []string{"alloc", "run_rule"} {
+		if _, ok := exports[required]; !ok {
+			_ = rt.Close(ctx)
+			return nil, fmt.Errorf("WASM function '%s' does not export '%s'", ruleName, required)
+		}
+	}
+	if _, ok := compiled.ExportedMemories()["memory"]; !ok {
+		_ = rt.Close(ctx)
+		return nil, fmt.Errorf("WASM function '%s' does not export 'memory'", ruleName)
+	}
+
+	w := &WASMRuleFunction{
+		ruleName: ruleName,
+		limits:   limits,
+		runtime:  rt,
+		compiled: compiled,
+		schema:   model.RuleFunctionSchema{Name: ruleName},
+	}
+	if _, ok := exports["get_schema"]; ok {
+		if err = w.readSchema(); err != nil {
+			_ = rt.Close(ctx)
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// GetSchema returns the schema exported by the module, or a schema with just the name of the function.
+func (w *WASMRuleFunction) GetSchema() model.RuleFunctionSchema {
+	return w.schema
+}
+
+// Close releases the compiled module, the function can't be run after it is closed.
+func (w *WASMRuleFunction) Close() error {
+	return w.runtime.Close(context.Background())
+}
+
+// instantiate creates a new instance of the module, each run gets its own instance, so runs don't share state and
+// can happen concurrently.
+func (w *WASMRuleFunction) instantiate(ctx context.Context) (api.Module, error) {
+	config := wazero.NewModuleConfig().WithName("").WithStartFunctions()
+	mod, err := w.runtime.InstantiateModule(ctx, w.compiled, config)
+	if err != nil {
+		return nil, err
+	}
+	if initialize := mod.ExportedFunction("_initialize"); initialize != nil {
+		if _, err = initialize.Call(ctx); err != nil {
+			_ = mod.Close(ctx)
+			return nil, err
+		}
+	}
+	return mod, nil
+}
+
+// read returns the bytes a packed pointer and length (returned by an export) points to.
+func read(mod api.Module, packed uint64) ([]byte, error) {
+	ptr, size := uint32(packed>>32), uint32(packed)
+	if size == 0 {
+		return nil, nil
+	}
+	b, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		return nil, fmt.Errorf("%d bytes at %d are out of range of the module's memory", size, ptr)
+	}
+	return b, nil
+}
+
+func (w *WASMRuleFunction) readSchema() error {
+	ctx, cancel := context.WithTimeout(context.Background(), w.limits.Timeout)
+	defer cancel()
+	mod, err := w.instantiate(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to instantiate WASM function '%s': %w", w.ruleName, err)
+	}
+	defer mod.Close(ctx)
+
+	packed, err := mod.ExportedFunction("get_schema").Call(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to read the schema of WASM function '%s': %w", w.ruleName, err)
+	}
+	b, err := read(mod, packed[0])
+	if err != nil {
+		return fmt.Errorf("unable to read the schema of WASM function '%s': %w", w.ruleName, err)
+	}
+	var schema model.RuleFunctionSchema
+	if err = json.Unmarshal(b, &schema); err != nil {
+		return fmt.Errorf("unable to decode the schema of WASM function '%s': %w", w.ruleName, err)
+	}
+	if schema.Name == "" {
+		schema.Name = w.ruleName
+	}
+	w.schema = schema
+	return nil
+}
+
+// call runs the module's run_rule export with a single input.
+func (w *WASMRuleFunction) call(ctx context.Context, mod api.Module, in []byte) ([]output, error) {
+	ptr, err := mod.ExportedFunction("alloc").Call(ctx, uint64(len(in)))
+	if err != nil {
+		return nil, err
+	}
+	if !mod.Memory().Write(uint32(ptr[0]), in) {
+		return nil, fmt.Errorf("%d bytes at %d are out of range of the module's memory", len(in), ptr[0])
+	}
+	packed, err := mod.ExportedFunction("run_rule").Call(ctx, ptr[0], uint64(len(in)))
+	if err != nil {
+		return nil, err
+	}
+	b, err := read(mod, packed[0])
+	if err != nil || len(b) == 0 {
+		return nil, err
+	}
+	var outputs []output
+	if err = json.Unmarshal(b, &outputs); err != nil {
+		return nil, fmt.Errorf("unable to decode results: %w", err)
+	}
+	return outputs, nil
+}
+
+// runContext limits a run to the function's timeout, and cancels it when linting is cancelled.
+func (w *WASMRuleFunction) runContext(rfc model.RuleFunctionContext) (context.Context, context.CancelFunc) {
+	parent := rfc.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	return context.WithTimeout(parent, w.limits.Timeout)
+}
+
+// RunRule will execute the module for every supplied node. Every run is limited by the function's limits, and
+// stops when linting is cancelled.
+func (w *WASMRuleFunction) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	if len(nodes) <= 0 {
+		return nil
+	}
+
+	ctx, cancel := w.runContext(context)
+	defer cancel()
+
+	failed := func(node *yaml.Node, err error) []model.RuleFunctionResult {
+		msg := fmt.Sprintf("Unable to execute WASM function '%s': %s", w.ruleName, err.Error())
+		if ctx.Err() != nil && !context.IsCancelled() {
+			msg = fmt.Sprintf("WASM function '%s' ran for longer than %s, and was stopped", w.ruleName,
+				w.limits.Timeout)
+		}
+		return []model.RuleFunctionResult{
+			{
+				Message:   msg,
+				StartNode: node,
+				EndNode:   node,
+				Path:      fmt.Sprint(context.Given),
+				Rule:      context.Rule,
+			},
+		}
+	}
+
+	mod, err := w.instantiate(ctx)
+	if err != nil {
+		return failed(nodes[0], err)
+	}
+	defer mod.Close(ctx)
+
+	var results []model.RuleFunctionResult
+	for _, node := range nodes {
+		if context.IsCancelled() {
+			break
+		}
+
+		var enc interface{}
+		_ = node.Decode(&enc)
+		in, mErr := json.Marshal(input{Input: enc, Options: context.Options, Given: context.Given})
+		if mErr != nil {
+			return failed(node, mErr)
+		}
+		outputs, cErr := w.call(ctx, mod, in)
+		if cErr != nil {
+			return failed(node, cErr)
+		}
+
+		for _, o := range outputs {
+			path := o.Path
+			if path == "" {
+				path = fmt.Sprint(context.Given)
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message:   o.Message,
+				StartNode: node,
+				EndNode:   node,
+				Range: reports.Range{
+					Start: reports.RangeItem{Line: node.Line, Char: node.Column},
+					End:   reports.RangeItem{Line: node.Line, Char: node.Column},
+				},
+				Path: path,
+				Rule: context.Rule,
+			})
+		}
+	}
+	return results
+}
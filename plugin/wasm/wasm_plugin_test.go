@@ -0,0 +1,126 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package wasm_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/plugin/wasm"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// buildTestModule compiles one of the modules in testdata to WASM, and returns it.
+func buildTestModule(t *testing.T, name string) []byte {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the go toolchain is needed to build test modules")
+	}
+	out := filepath.Join(t.TempDir(), name+".wasm")
+	build := exec.Command(goBin, "build", "-buildmode=c-shared", "-o", out, ".")
+	build.Dir = filepath.Join("testdata", name)
+	build.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	if o, bErr := build.CombinedOutput(); bErr != nil {
+		t.Fatalf("unable to build test module '%s': %v\n%s", name, bErr, o)
+	}
+	module, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	return module
+}
+
+func TestNewWASMRuleFunction_NotWASM(t *testing.T) {
+	f, err := wasm.NewWASMRuleFunction("nope", []byte("not wasm"), wasm.DefaultLimits())
+	assert.Nil(t, f)
+	assert.ErrorContains(t, err, "unable to compile WASM function 'nope'")
+}
+
+func TestWASMRuleFunction_RunRule(t *testing.T) {
+	f, err := wasm.NewWASMRuleFunction("info_contact", buildTestModule(t, "info_contact"), wasm.DefaultLimits())
+	assert.NoError(t, err)
+	defer f.Close()
+	assert.Equal(t, "infoContact", f.GetSchema().Name)
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte("openapi: 3.1.0\ninfo:\n  title: pizza"), &root)
+
+	res := f.RunRule([]*yaml.Node{root.Content[0]}, model.RuleFunctionContext{Given: "$"})
+	assert.Len(t, res, 1)
+	assert.Equal(t, "info has no contact", res[0].Message)
+	assert.Equal(t, "$.info", res[0].Path)
+
+	_ = yaml.Unmarshal([]byte("openapi: 3.1.0\ninfo:\n  contact: {}"), &root)
+	assert.Len(t, f.RunRule([]*yaml.Node{root.Content[0]}, model.RuleFunctionContext{Given: "$"}), 0)
+}
+
+func TestWASMRuleFunction_Sandbox(t *testing.T) {
+	f, err := wasm.NewWASMRuleFunction("sandbox", buildTestModule(t, "sandbox"), wasm.DefaultLimits())
+	assert.NoError(t, err)
+	defer f.Close()
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte("openapi: 3.1.0"), &root)
+
+	res := f.RunRule([]*yaml.Node{root.Content[0]}, model.RuleFunctionContext{Given: "$"})
+	assert.Len(t, res, 3)
+	assert.NotEqual(t, "read file: ok", res[0].Message)
+	assert.NotEqual(t, "write file: ok", res[1].Message)
+	assert.Equal(t, "environment: 0", res[2].Message)
+}
+
+func TestWASMRuleFunction_Timeout(t *testing.T) {
+	limits := wasm.DefaultLimits()
+	limits.Timeout = 100 * time.Millisecond
+	f, err := wasm.NewWASMRuleFunction("spin", buildTestModule(t, "spin"), limits)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte("openapi: 3.1.0"), &root)
+
+	start := time.Now()
+	res := f.RunRule([]*yaml.Node{root.Content[0]}, model.RuleFunctionContext{Given: "$"})
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "WASM function 'spin' ran for longer than 100ms, and was stopped", res[0].Message)
+}
+
+func TestWASMRuleFunction_ApplyRules(t *testing.T) {
+	f, err := wasm.NewWASMRuleFunction("info_contact", buildTestModule(t, "info_contact"), wasm.DefaultLimits())
+	assert.NoError(t, err)
+	defer f.Close()
+
+	rs := `rules:
+  wasm-info-contact:
+    description: info must have a contact
+    severity: warn
+    given: $
+    then:
+      function: infoContact`
+
+	rc, err := rulesets.CreateRuleSetFromData([]byte(rs))
+	assert.NoError(t, err)
+
+	spec := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths: {}`
+
+	results := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet:         rc,
+		Spec:            []byte(spec),
+		CustomFunctions: map[string]model.RuleFunction{"infoContact": f},
+	})
+	assert.Len(t, results.Errors, 0)
+	assert.Len(t, results.Results, 1)
+	assert.Equal(t, "info has no contact", results.Results[0].Message)
+	assert.Equal(t, "$.info", results.Results[0].Path)
+}
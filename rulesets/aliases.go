@@ -0,0 +1,180 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+// aliasPaths are the paths an alias expands to, keyed by document format. Paths under the empty key apply to
+// documents of every format.
+type aliasPaths map[string][]string
+
+func (ap aliasPaths) add(other aliasPaths) {
+	for format, paths := range other {
+		ap[format] = append(ap[format], paths...)
+	}
+}
+
+// aliasFormats translates the formats an alias target is scoped to into document formats, `oas3` covers every 3.x
+// version, like it does in spectral.
+func aliasFormats(format string) []string {
+	switch format {
+	case "oas3":
+		return []string{model.OAS3, model.OAS31}
+	case "oas3.0", "oas3_0":
+		return []string{model.OAS3}
+	case "oas3.1", "oas3_1":
+		return []string{model.OAS31}
+	}
+	return []string{format}
+}
+
+// resolveAliases expands the aliases used in the `given` of every rule definition. A `given` that starts with `#`
+// references an alias by name, optionally followed by a JSONPath that is appended to every path the alias expands
+// to (`#Operations.responses`). An alias is a path, a list of paths or a list of targets, that scope paths to
+// document formats (like spectral aliases). Aliases can reference other aliases.
+//
+// Paths scoped by format are stored in the `givenByFormat` of the rule, and used instead of `given` when a document
+// has that format. Using an alias that isn't defined is an error.
+func (rs *RuleSet) resolveAliases() error {
+	for name, def := range rs.RuleDefinitions {
+		rule, ok := def.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var givens []string
+		switch given := rule["given"].(type) {
+		case string:
+			givens = []string{given}
+		case []interface{}:
+			for _, g := range given {
+				if s, ok := g.(string); ok {
+					givens = append(givens, s)
+				}
+			}
+		}
+		usesAlias := false
+		for _, g := range givens {
+			usesAlias = usesAlias || strings.HasPrefix(g, "#")
+		}
+		if !usesAlias {
+			continue
+		}
+
+		expanded := make(aliasPaths)
+		for _, g := range givens {
+			paths, err := rs.expandAlias(g, nil)
+			if err != nil {
+				return fmt.Errorf("rule '%s' cannot be loaded: %w", name, err)
+			}
+			expanded.add(paths)
+		}
+
+		all := make([]interface{}, 0, len(expanded[""]))
+		for _, p := range expanded[""] {
+			all = append(all, p)
+		}
+		rule["given"] = all
+		if len(expanded) > 1 || expanded[""] == nil {
+			byFormat := make(map[string][]string)
+			for format, paths := range expanded {
+				if format != "" {
+					byFormat[format] = append(append([]string{}, expanded[""]...), paths...)
+				}
+			}
+			rule["givenByFormat"] = byFormat
+		}
+	}
+	return nil
+}
+
+// expandAlias expands a `given` into the paths it references, aliasChain holds the aliases being expanded, so
+// aliases that reference themselves are caught.
+func (rs *RuleSet) expandAlias(given string, aliasChain []string) (aliasPaths, error) {
+	if !strings.HasPrefix(given, "#") {
+		return aliasPaths{"": {given}}, nil
+	}
+	name := given[1:]
+	suffix := ""
+	if i := strings.IndexFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-')
+	}); i >= 0 {
+		name, suffix = name[:i], name[i:]
+	}
+	def, ok := rs.Aliases[name]
+	if !ok {
+		return nil, fmt.Errorf("alias '#%s' is not defined", name)
+	}
+	for _, a := range aliasChain {
+		if a == name {
+			return nil, fmt.Errorf("alias '#%s' references itself: #%s -> #%s", name,
+				strings.Join(aliasChain, " -> #"), name)
+		}
+	}
+	aliasChain = append(aliasChain[:len(aliasChain):len(aliasChain)], name)
+
+	expandAll := func(value interface{}) (aliasPaths, error) {
+		expanded := make(aliasPaths)
+		var givens []interface{}
+		switch v := value.(type) {
+		case string:
+			givens = []interface{}{v}
+		case []interface{}:
+			givens = v
+		}
+		for _, g := range givens {
+			s, ok := g.(string)
+			if !ok {
+				continue
+			}
+			paths, err := rs.expandAlias(s, aliasChain)
+			if err != nil {
+				return nil, err
+			}
+			expanded.add(paths)
+		}
+		return expanded, nil
+	}
+
+	expanded := make(aliasPaths)
+	if scoped, ok := def.(map[string]interface{}); ok {
+		targets, _ := scoped["targets"].([]interface{})
+		for _, t := range targets {
+			target, ok := t.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			paths, err := expandAll(target["given"])
+			if err != nil {
+				return nil, err
+			}
+			formats, _ := target["formats"].([]interface{})
+			for _, f := range formats {
+				format, _ := f.(string)
+				for _, df := range aliasFormats(format) {
+					expanded[df] = append(expanded[df], paths[""]...)
+					expanded[df] = append(expanded[df], paths[df]...)
+				}
+			}
+		}
+	} else {
+		paths, err := expandAll(def)
+		if err != nil {
+			return nil, err
+		}
+		expanded = paths
+	}
+
+	for format, paths := range expanded {
+		for i := range paths {
+			paths[i] += suffix
+		}
+		expanded[format] = paths
+	}
+	return expanded, nil
+}
@@ -0,0 +1,117 @@
+package rulesets
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRuleSetFromData_Aliases(t *testing.T) {
+
+	yamlA := `aliases:
+  PathItem: $.paths[*]
+  Operations:
+    - '#PathItem.get'
+    - '#PathItem.put'
+    - '#PathItem.post'
+rules:
+  operation-summaries:
+    description: operations need a summary
+    given: '#Operations'
+    then:
+      field: summary
+      function: truthy
+  operation-responses:
+    description: operations need a 200 response
+    given:
+      - '#Operations.responses'
+      - $.info
+    then:
+      field: "200"
+      function: truthy
+  plain:
+    description: nothing to expand
+    given: $.info
+    then:
+      field: title
+      function: truthy`
+
+	rs, err := CreateRuleSetFromData([]byte(yamlA))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []interface{}{"$.paths[*].get", "$.paths[*].put", "$.paths[*].post"},
+		rs.Rules["operation-summaries"].Given)
+	assert.Nil(t, rs.Rules["operation-summaries"].GivenByFormat)
+	assert.Equal(t, []interface{}{"$.paths[*].get.responses", "$.paths[*].put.responses",
+		"$.paths[*].post.responses", "$.info"}, rs.Rules["operation-responses"].Given)
+	assert.Equal(t, "$.info", rs.Rules["plain"].Given)
+
+	// the expanded given survives generating the ruleset.
+	generated := BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.Equal(t, rs.Rules["operation-summaries"].Given, generated.Rules["operation-summaries"].Given)
+}
+
+func TestCreateRuleSetFromData_Aliases_ScopedByFormat(t *testing.T) {
+
+	yamlA := `aliases:
+  Schemas:
+    description: every named schema
+    targets:
+      - formats: [oas2]
+        given: $.definitions[*]
+      - formats: [oas3]
+        given: $.components.schemas[*]
+rules:
+  schema-descriptions:
+    description: schemas need a description
+    given:
+      - '#Schemas'
+      - $.info
+    then:
+      field: description
+      function: truthy`
+
+	rs, err := CreateRuleSetFromData([]byte(yamlA))
+	assert.NoError(t, err)
+
+	rule := rs.Rules["schema-descriptions"]
+	assert.Equal(t, []interface{}{"$.info"}, rule.Given)
+	assert.Equal(t, map[string][]string{
+		model.OAS2:  {"$.info", "$.definitions[*]"},
+		model.OAS3:  {"$.info", "$.components.schemas[*]"},
+		model.OAS31: {"$.info", "$.components.schemas[*]"},
+	}, rule.GivenByFormat)
+}
+
+func TestCreateRuleSetFromData_Aliases_Undefined(t *testing.T) {
+
+	yamlA := `aliases:
+  Operations: $.paths[*][*]
+rules:
+  operation-summaries:
+    description: operations need a summary
+    given: '#Operation.summary'
+    then:
+      function: truthy`
+
+	rs, err := CreateRuleSetFromData([]byte(yamlA))
+	assert.Nil(t, rs)
+	assert.EqualError(t, err, "rule 'operation-summaries' cannot be loaded: alias '#Operation' is not defined")
+}
+
+func TestCreateRuleSetFromData_Aliases_Cycle(t *testing.T) {
+
+	yamlA := `aliases:
+  A: '#B.a'
+  B: '#A.b'
+rules:
+  loop:
+    description: never ends
+    given: '#A'
+    then:
+      function: truthy`
+
+	_, err := CreateRuleSetFromData([]byte(yamlA))
+	assert.EqualError(t, err, "rule 'loop' cannot be loaded: alias '#A' references itself: #A -> #B -> #A")
+}
@@ -0,0 +1,330 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+// modifier ranks. Along a path of rulesets extending one another, `off` always wins, otherwise the outermost
+// modifier that was set wins (so the ruleset being linted decides). A ruleset extended through more than one path
+// uses the most permissive modifier of all the paths.
+const (
+	modifierOff = iota
+	modifierRecommended
+	modifierAll
+)
+
+// ResolveExtends loads every ruleset (file or URL) that a ruleset extends, and merges their rules into a single
+// ruleset, which only extends built-in rulesets. The location is where the ruleset was loaded from, relative
+// locations of extended rulesets are resolved against it (or the working directory if it's empty).
+//
+// Rulesets can extend any number of parents, and parents can extend their own. Rules are applied in order, every
+// parent is applied before the ruleset that extends it, later parents override earlier ones, and the rules of the
+// ruleset itself override everything it extends. When two parents extend a common base (diamond inheritance), the
+// base is only loaded and applied once, before either parent, so neither parent undoes the changes of the other.
+//
+// Parents can be extended with an `off`, `recommended` (the default) or `all` modifier. `off` disables every rule
+// the parent provides, `recommended` disables those defined with `recommended: false` and `all` enables everything.
+// Disabled rules can be turned back on by the extending ruleset, by setting a severity or `true`. Built-in rulesets
+// extended by a parent keep the modifier the parent gave them, unless the parent itself is switched off.
+//
+// If config is nil, remote rulesets are not allowed, and an error is returned if one is extended.
+func ResolveExtends(ruleset *RuleSet, location string, config *RemoteRuleSetConfig) (*RuleSet, error) {
+	return newExtendsLoader(config).resolve(ruleset, location)
+}
+
+type extendsLoader struct {
+	remote *RemoteRuleSetConfig // nil if remote rulesets are not allowed.
+	loaded map[string]*RuleSet  // rulesets already loaded, so shared parents are only loaded once.
+}
+
+// extendedRuleSet is a ruleset in the order rules are applied, with the modifier it was extended with.
+type extendedRuleSet struct {
+	ruleset  *RuleSet
+	modifier int
+}
+
+type extendsEntry struct {
+	name     string
+	modifier string
+}
+
+func newExtendsLoader(remote *RemoteRuleSetConfig) *extendsLoader {
+	return &extendsLoader{remote: remote, loaded: make(map[string]*RuleSet)}
+}
+
+// load reads and parses the ruleset at a location, without resolving anything it extends.
+func (el *extendsLoader) load(location string) (*RuleSet, error) {
+	if rs := el.loaded[location]; rs != nil {
+		return rs, nil
+	}
+	var rs *RuleSet
+	if IsRemoteRuleSet(location) {
+		if el.remote == nil {
+			return nil, fmt.Errorf("ruleset extends remote ruleset '%s', but remote rulesets are not enabled",
+				location)
+		}
+		data, cached, err := el.fetch(location)
+		if err != nil {
+			return nil, err
+		}
+		if rs, err = ParseRuleSet(location, data); err != nil {
+			return nil, fmt.Errorf("remote ruleset '%s' is not a valid ruleset: %w", location, err)
+		}
+		if !cached {
+			el.store(location, data)
+		}
+	} else {
+		data, err := os.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read extended ruleset '%s': %w", location, err)
+		}
+		if rs, err = ParseRuleSet(location, data); err != nil {
+			return nil, fmt.Errorf("extended ruleset '%s' is not a valid ruleset: %w", location, err)
+		}
+	}
+	el.loaded[location] = rs
+	return rs, nil
+}
+
+func (el *extendsLoader) resolve(ruleset *RuleSet, location string) (*RuleSet, error) {
+	var order []string
+	modifiers := make(map[string]extendedRuleSet)
+	if err := el.walk(ruleset, location, modifierRecommended, false, []string{location}, &order,
+		modifiers); err != nil {
+		return nil, err
+	}
+	if len(order) == 0 {
+		return ruleset, nil // only built-in rulesets are extended, nothing to merge.
+	}
+
+	var chain []extendedRuleSet
+	for _, loc := range order {
+		chain = append(chain, modifiers[loc])
+	}
+	chain = append(chain, extendedRuleSet{ruleset: ruleset, modifier: modifierAll})
+
+	merged := mergeExtendedRuleSets(chain)
+	if err := merged.unpackRuleDefinitions(); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// walk visits every ruleset extended by a ruleset, along every path. Each ruleset is added to the order once, after
+// all of its parents.
+func (el *extendsLoader) walk(ruleset *RuleSet, location string, modifier int, explicit bool, chain []string,
+	order *[]string, modifiers map[string]extendedRuleSet) error {
+
+	for _, ext := range extendsEntries(ruleset) {
+		if isBuiltInRuleSet(ext.name) {
+			continue
+		}
+		target, err := resolveExtendsLocation(location, ext.name)
+		if err != nil {
+			return err
+		}
+		for _, c := range chain {
+			if c == target {
+				var path []string
+				for _, p := range append(chain[:len(chain):len(chain)], target) {
+					if p != "" {
+						path = append(path, p)
+					}
+				}
+				return fmt.Errorf("ruleset '%s' extends itself: %s", target, strings.Join(path, " -> "))
+			}
+		}
+		parent, err := el.load(target)
+		if err != nil {
+			return err
+		}
+
+		mod, exp := modifier, explicit
+		if mod != modifierOff && ext.modifier != "" && (!exp || ext.modifier == SpectralOff) {
+			mod, exp = modifierRank(ext.modifier), true
+		}
+		if current, seen := modifiers[target]; !seen || mod > current.modifier {
+			modifiers[target] = extendedRuleSet{ruleset: parent, modifier: mod}
+		}
+		if err = el.walk(parent, target, mod, exp, append(chain[:len(chain):len(chain)], target), order,
+			modifiers); err != nil {
+			return err
+		}
+		found := false
+		for _, o := range *order {
+			if o == target {
+				found = true
+				break
+			}
+		}
+		if !found {
+			*order = append(*order, target)
+		}
+	}
+	return nil
+}
+
+// mergeExtendedRuleSets applies the rules of each ruleset in turn, later rulesets override earlier ones.
+func mergeExtendedRuleSets(chain []extendedRuleSet) *RuleSet {
+	merged := &RuleSet{
+		RuleDefinitions: make(map[string]interface{}),
+		Categories:      make(map[string]*model.RuleCategory),
+	}
+	disabled := make(map[string]bool)
+	builtIn := make(map[string]int)
+	var builtInOrder []string
+
+	for _, ext := range chain {
+		rs := ext.ruleset
+		if rs.Description != "" {
+			merged.Description = rs.Description
+		}
+		if rs.DocumentationURI != "" {
+			merged.DocumentationURI = rs.DocumentationURI
+		}
+		if rs.Formats != nil {
+			merged.Formats = rs.Formats
+		}
+		for k, v := range rs.Categories {
+			merged.Categories[k] = v
+		}
+
+		// a parent that has been switched off provides nothing but its rule definitions, which are all disabled.
+		// otherwise built-in rulesets are extended using the modifier the parent declared.
+		if ext.modifier > modifierOff {
+			for _, e := range extendsEntries(rs) {
+				if !isBuiltInRuleSet(e.name) {
+					continue
+				}
+				if _, seen := builtIn[e.name]; !seen {
+					builtInOrder = append(builtInOrder, e.name)
+				}
+				builtIn[e.name] = modifierRank(e.modifier)
+			}
+		}
+
+		for k, v := range rs.RuleDefinitions {
+			if rule, ok := v.(map[string]interface{}); ok {
+				merged.RuleDefinitions[k] = rule
+				disabled[k] = ext.modifier == modifierOff ||
+					(ext.modifier == modifierRecommended && rule["recommended"] == false)
+				continue
+			}
+			existing, custom := merged.RuleDefinitions[k].(map[string]interface{})
+			if !custom {
+				// a built-in rule, switched off parents can't change built-in rules.
+				if ext.modifier > modifierOff {
+					merged.RuleDefinitions[k] = v
+				}
+				continue
+			}
+			// a custom rule defined by a parent can be switched off, on, or have its severity changed.
+			switch val := v.(type) {
+			case bool:
+				disabled[k] = !val
+			case string:
+				if val == SpectralOff {
+					disabled[k] = true
+					continue
+				}
+				rule := make(map[string]interface{})
+				for rk, rv := range existing {
+					rule[rk] = rv
+				}
+				rule["severity"] = val
+				merged.RuleDefinitions[k] = rule
+				disabled[k] = false
+			}
+		}
+	}
+
+	for k, off := range disabled {
+		if off {
+			delete(merged.RuleDefinitions, k)
+		}
+	}
+	var extends []interface{}
+	for _, name := range builtInOrder {
+		extends = append(extends, []interface{}{name, modifierName(builtIn[name])})
+	}
+	if len(extends) > 0 {
+		merged.Extends = extends
+	}
+	return merged
+}
+
+// extendsEntries normalizes the extends value of a ruleset into an ordered list of entries.
+func extendsEntries(rs *RuleSet) []extendsEntry {
+	var entries []extendsEntry
+	switch ext := rs.Extends.(type) {
+	case string:
+		entries = append(entries, extendsEntry{name: ext})
+	case []interface{}:
+		for _, e := range ext {
+			switch v := e.(type) {
+			case string:
+				entries = append(entries, extendsEntry{name: v})
+			case []interface{}:
+				if len(v) == 2 {
+					name, _ := v[0].(string)
+					modifier, _ := v[1].(string)
+					entries = append(entries, extendsEntry{name: name, modifier: modifier})
+				}
+			}
+		}
+	}
+	return entries
+}
+
+// resolveExtendsLocation resolves the location of an extended ruleset, against the location of the ruleset
+// extending it. Rulesets extended by remote rulesets are always remote.
+func resolveExtendsLocation(location, name string) (string, error) {
+	if IsRemoteRuleSet(name) {
+		return name, nil
+	}
+	if IsRemoteRuleSet(location) {
+		base, _ := url.Parse(location)
+		ref, err := url.Parse(name)
+		if err != nil {
+			return "", fmt.Errorf("remote ruleset '%s' extends an invalid location '%s': %w", location, name, err)
+		}
+		return base.ResolveReference(ref).String(), nil
+	}
+	if filepath.IsAbs(name) || location == "" {
+		return filepath.Clean(name), nil
+	}
+	return filepath.Join(filepath.Dir(location), name), nil
+}
+
+func isBuiltInRuleSet(name string) bool {
+	return name == SpectralOpenAPI || name == SpectralOwasp || name == VacuumOwasp
+}
+
+func modifierRank(modifier string) int {
+	switch modifier {
+	case SpectralOff:
+		return modifierOff
+	case SpectralAll:
+		return modifierAll
+	}
+	return modifierRecommended
+}
+
+func modifierName(rank int) string {
+	switch rank {
+	case modifierOff:
+		return SpectralOff
+	case modifierAll:
+		return SpectralAll
+	}
+	return SpectralRecommended
+}
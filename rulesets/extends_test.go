@@ -0,0 +1,173 @@
+package rulesets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeRuleSets(t *testing.T, rulesets map[string]string) string {
+	dir := t.TempDir()
+	for name, rs := range rulesets {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(rs), 0o644))
+	}
+	return dir
+}
+
+func TestResolveExtends_ThreeLevels(t *testing.T) {
+
+	// 'team' and 'platform' both extend 'org' (a diamond), 'api' extends them both.
+	dir := writeRuleSets(t, map[string]string{
+		"org/org.yaml": `extends: [[spectral:oas, recommended]]
+rules:
+  org-title:
+    description: title must be set
+    severity: error
+    given: $.info
+    then:
+      field: title
+      function: truthy
+  org-experimental:
+    description: license must be set
+    recommended: false
+    given: $.info
+    then:
+      field: license
+      function: truthy
+  info-contact: off`,
+		"team/team.yaml": `extends: ../org/org.yaml
+rules:
+  org-title: warn
+  team-tags:
+    description: tags must be set
+    given: $
+    then:
+      field: tags
+      function: truthy`,
+		"platform/platform.yaml": `extends: [[../org/org.yaml, all]]
+rules:
+  operation-success-response: hint
+  platform-servers:
+    description: servers must be set
+    given: $
+    then:
+      field: servers
+      function: truthy`,
+		"legacy.yaml": `rules:
+  legacy-rule:
+    description: description must be set
+    given: $.info
+    then:
+      field: description
+      function: truthy
+  legacy-other:
+    description: version must be set
+    given: $.info
+    then:
+      field: version
+      function: truthy`,
+		"api.yaml": `extends: [team/team.yaml, platform/platform.yaml, [legacy.yaml, off]]
+rules:
+  team-tags: off
+  legacy-rule: error`,
+	})
+
+	location := filepath.Join(dir, "api.yaml")
+	data, _ := os.ReadFile(location)
+	rs, err := CreateRuleSetFromData(data)
+	assert.NoError(t, err)
+
+	resolved, err := ResolveExtends(rs, location, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, SpectralRecommended, resolved.GetExtendsValue()[SpectralOpenAPI])
+
+	defaults := BuildDefaultRuleSets()
+	built := defaults.GenerateRuleSetFromSuppliedRuleSet(resolved)
+
+	expected := make(map[string]bool)
+	for id := range defaults.GenerateOpenAPIRecommendedRuleSet().Rules {
+		expected[id] = true
+	}
+	delete(expected, InfoContact)              // switched off by org.
+	expected["org-title"] = true               // from org.
+	expected["org-experimental"] = true        // not recommended, but platform extends org with 'all'.
+	expected["platform-servers"] = true        // from platform.
+	expected["legacy-rule"] = true             // legacy is off, but re-enabled by api.
+	assert.Nil(t, built.Rules["team-tags"])    // from team, switched off by api.
+	assert.Nil(t, built.Rules["legacy-other"]) // legacy is off.
+
+	actual := make(map[string]bool)
+	for id := range built.Rules {
+		actual[id] = true
+	}
+	assert.Equal(t, expected, actual)
+
+	// team changed the severity of an org rule, platform extending org as well must not undo it.
+	assert.Equal(t, model.SeverityWarn, built.Rules["org-title"].Severity)
+	assert.Equal(t, model.SeverityError, built.Rules["legacy-rule"].Severity)
+	assert.Equal(t, model.SeverityHint, built.Rules[OperationSuccessResponse].Severity)
+}
+
+func TestResolveExtends_LaterParentsOverride(t *testing.T) {
+	dir := writeRuleSets(t, map[string]string{
+		"a.yaml": `rules:
+  shared:
+    description: from a
+    severity: error
+    given: $.info
+    then:
+      field: title
+      function: truthy`,
+		"b.yaml": `rules:
+  shared:
+    description: from b
+    severity: info
+    given: $.info
+    then:
+      field: title
+      function: truthy`,
+	})
+
+	rs, err := CreateRuleSetFromData([]byte(`extends: [[spectral:oas, off], a.yaml, b.yaml]
+rules: {}`))
+	assert.NoError(t, err)
+
+	resolved, err := ResolveExtends(rs, filepath.Join(dir, "ruleset.yaml"), nil)
+	assert.NoError(t, err)
+
+	built := BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(resolved)
+	assert.Len(t, built.Rules, 1)
+	assert.Equal(t, "from b", built.Rules["shared"].Description)
+	assert.Equal(t, model.SeverityInfo, built.Rules["shared"].Severity)
+}
+
+func TestResolveExtends_Cycle(t *testing.T) {
+	dir := writeRuleSets(t, map[string]string{
+		"a.yaml": `extends: b.yaml
+rules: {}`,
+		"b.yaml": `extends: a.yaml
+rules: {}`,
+	})
+
+	rs, err := CreateRuleSetFromData([]byte(`extends: a.yaml
+rules: {}`))
+	assert.NoError(t, err)
+
+	_, err = ResolveExtends(rs, filepath.Join(dir, "ruleset.yaml"), nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "extends itself")
+}
+
+func TestResolveExtends_Missing(t *testing.T) {
+	rs, err := CreateRuleSetFromData([]byte(`extends: [spectral:oas, nope.yaml]
+rules: {}`))
+	assert.NoError(t, err)
+
+	_, err = ResolveExtends(rs, filepath.Join(t.TempDir(), "ruleset.yaml"), nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to read extended ruleset")
+}
@@ -278,16 +278,6 @@ func GetOWASPDefineErrorValidationRule() *model.Rule {
 
 func GetOWASPDefineErrorResponses401Rule() *model.Rule {
 
-	// create a schema to match against.
-	opts := make(map[string]interface{})
-	yml := `type: object
-required:
-  - content`
-
-	jsonSchema, _ := parser.ConvertYAMLIntoJSONSchema(yml, nil)
-	opts["schema"] = jsonSchema
-	opts["forceValidation"] = true // this will be picked up by the schema function to force validation.
-
 	return &model.Rule{
 		Name:         "Operation is missing a `401` error response",
 		Id:           OwaspDefineErrorResponses401,
@@ -299,15 +289,10 @@ required:
 		Recommended:  true,
 		Type:         Validation,
 		Severity:     model.SeverityWarn,
-		Then: []model.RuleAction{
-			{
-				Field:    "401",
-				Function: "defined",
-			},
-			{
-				Field:           "401",
-				Function:        "schema",
-				FunctionOptions: opts,
+		Then: model.RuleAction{
+			Function: "owaspDefineErrorResponse",
+			FunctionOptions: map[string]interface{}{
+				"code": "401",
 			},
 		},
 		HowToFix: owaspDefineErrorResponses401Fix,
@@ -316,15 +301,6 @@ required:
 
 func GetOWASPDefineErrorResponses500Rule() *model.Rule {
 
-	opts := make(map[string]interface{})
-	yml := `type: object
-required:
-  - content`
-
-	jsonSchema, _ := parser.ConvertYAMLIntoJSONSchema(yml, nil)
-	opts["schema"] = jsonSchema
-	opts["forceValidation"] = true // this will be picked up by the schema function to force validation.
-
 	return &model.Rule{
 		Name:         "Operation is missing a `500` error response",
 		Id:           OwaspDefineErrorResponses500,
@@ -335,15 +311,10 @@ required:
 		Recommended:  true,
 		Type:         Validation,
 		Severity:     model.SeverityWarn,
-		Then: []model.RuleAction{
-			{
-				Field:    "500",
-				Function: "defined",
-			},
-			{
-				Field:           "500",
-				Function:        "schema",
-				FunctionOptions: opts,
+		Then: model.RuleAction{
+			Function: "owaspDefineErrorResponse",
+			FunctionOptions: map[string]interface{}{
+				"code": "500",
 			},
 		},
 		HowToFix: owaspDefineErrorResponses500Fix,
@@ -406,15 +377,6 @@ func GetOWASPRateLimitRetryAfterRule() *model.Rule {
 
 func GetOWASPDefineErrorResponses429Rule() *model.Rule {
 
-	opts := make(map[string]interface{})
-	yml := `type: object
-required:
-  - content`
-
-	jsonSchema, _ := parser.ConvertYAMLIntoJSONSchema(yml, nil)
-	opts["schema"] = jsonSchema
-	opts["forceValidation"] = true // this will be picked up by the schema function to force validation.
-
 	return &model.Rule{
 		Name:         "Operation is missing a `429` rate limiting error response",
 		Id:           OwaspDefineErrorResponses429,
@@ -425,15 +387,10 @@ required:
 		Recommended:  true,
 		Type:         Validation,
 		Severity:     model.SeverityWarn,
-		Then: []model.RuleAction{
-			{
-				Field:    "429",
-				Function: "defined",
-			},
-			{
-				Field:           "429",
-				Function:        "schema",
-				FunctionOptions: opts,
+		Then: model.RuleAction{
+			Function: "owaspDefineErrorResponse",
+			FunctionOptions: map[string]interface{}{
+				"code": "429",
 			},
 		},
 		HowToFix: owaspDefineErrorResponses429Fix,
@@ -0,0 +1,119 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRemoteTimeout  = 10 * time.Second
+	defaultRemoteCacheTTL = time.Hour
+	maxRemoteRuleSetSize  = 5 * 1024 * 1024
+)
+
+// RemoteRuleSetConfig configures how rulesets are fetched over HTTP. If no CacheDir is set, remote rulesets are
+// fetched every time they are loaded.
+type RemoteRuleSetConfig struct {
+	Timeout  time.Duration // how long to wait for each ruleset to be fetched, defaults to 10 seconds.
+	CacheDir string        // where fetched rulesets are cached, no caching if empty.
+	CacheTTL time.Duration // how long a cached ruleset is used for, defaults to an hour.
+	Client   *http.Client  // the client used to fetch rulesets, defaults to http.DefaultClient.
+}
+
+// IsRemoteRuleSet returns true if the location of a ruleset is an HTTP or HTTPS URL.
+func IsRemoteRuleSet(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// FetchRemoteRuleSet fetches and parses the ruleset at the supplied URL. Any rulesets it extends are fetched as well
+// (relative locations are resolved against the URL of the ruleset that extends them), and their rules are merged in
+// (see ResolveExtends), so the returned ruleset only extends built-in rulesets. A ruleset that does not parse, or is
+// not valid, returns an error naming the URL it was fetched from.
+func FetchRemoteRuleSet(location string, config *RemoteRuleSetConfig) (*RuleSet, error) {
+	if config == nil {
+		config = &RemoteRuleSetConfig{}
+	}
+	loader := newExtendsLoader(config)
+	rs, err := loader.load(location)
+	if err != nil {
+		return nil, err
+	}
+	return loader.resolve(rs, location)
+}
+
+func (el *extendsLoader) cacheFile(location string) string {
+	if el.remote.CacheDir == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(location))
+	return filepath.Join(el.remote.CacheDir, hex.EncodeToString(sum[:])+".ruleset")
+}
+
+// fetch returns the contents of a remote ruleset, and if it was read from the cache.
+func (el *extendsLoader) fetch(location string) ([]byte, bool, error) {
+	if file := el.cacheFile(location); file != "" {
+		ttl := el.remote.CacheTTL
+		if ttl <= 0 {
+			ttl = defaultRemoteCacheTTL
+		}
+		if fi, err := os.Stat(file); err == nil && time.Since(fi.ModTime()) < ttl {
+			if data, rErr := os.ReadFile(file); rErr == nil {
+				return data, true, nil
+			}
+		}
+	}
+
+	timeout := el.remote.Timeout
+	if timeout <= 0 {
+		timeout = defaultRemoteTimeout
+	}
+	client := el.remote.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to fetch remote ruleset '%s': %w", location, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to fetch remote ruleset '%s': %w", location, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("unable to fetch remote ruleset '%s': %s", location, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteRuleSetSize+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to fetch remote ruleset '%s': %w", location, err)
+	}
+	if len(data) > maxRemoteRuleSetSize {
+		return nil, false, fmt.Errorf("remote ruleset '%s' is too large, the maximum size is %dmb",
+			location, maxRemoteRuleSetSize/1024/1024)
+	}
+	return data, false, nil
+}
+
+// store caches a valid remote ruleset, caching is best effort, so errors are ignored.
+func (el *extendsLoader) store(location string, data []byte) {
+	if file := el.cacheFile(location); file != "" {
+		if err := os.MkdirAll(el.remote.CacheDir, 0o755); err == nil {
+			_ = os.WriteFile(file, data, 0o644)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package rulesets
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// remoteRuleSetServer serves rulesets keyed by path, and counts how many times each one has been fetched.
+func remoteRuleSetServer(rulesets map[string]string) (*httptest.Server, map[string]int) {
+	hits := make(map[string]int)
+	var lock sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		hits[r.URL.Path]++
+		lock.Unlock()
+		rs, ok := rulesets[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(rs))
+	}))
+	return server, hits
+}
+
+func TestIsRemoteRuleSet(t *testing.T) {
+	assert.True(t, IsRemoteRuleSet("https://quobix.com/ruleset.yaml"))
+	assert.True(t, IsRemoteRuleSet("http://localhost/ruleset.yaml"))
+	assert.False(t, IsRemoteRuleSet("ruleset.yaml"))
+	assert.False(t, IsRemoteRuleSet(SpectralOpenAPI))
+}
+
+func TestFetchRemoteRuleSet(t *testing.T) {
+	server, _ := remoteRuleSetServer(map[string]string{
+		"/ruleset.yaml": `extends: [[spectral:oas, off]]
+rules:
+  check-title:
+    description: title must be set
+    given: $.info
+    then:
+      field: title
+      function: truthy`,
+	})
+	defer server.Close()
+
+	rs, err := FetchRemoteRuleSet(server.URL+"/ruleset.yaml", nil)
+	assert.NoError(t, err)
+	assert.Len(t, rs.Rules, 1)
+
+	built := BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.Len(t, built.Rules, 1)
+	assert.NotNil(t, built.Rules["check-title"])
+}
+
+func TestFetchRemoteRuleSet_NotFound(t *testing.T) {
+	server, _ := remoteRuleSetServer(nil)
+	defer server.Close()
+
+	_, err := FetchRemoteRuleSet(server.URL+"/missing.yaml", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to fetch remote ruleset")
+	assert.Contains(t, err.Error(), "404")
+}
+
+func TestFetchRemoteRuleSet_Invalid(t *testing.T) {
+	server, _ := remoteRuleSetServer(map[string]string{
+		"/ruleset.yaml": "<html><body>not a ruleset</body></html>",
+	})
+	defer server.Close()
+
+	_, err := FetchRemoteRuleSet(server.URL+"/ruleset.yaml", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "remote ruleset '"+server.URL+"/ruleset.yaml' is not a valid ruleset")
+}
+
+func TestFetchRemoteRuleSet_Extends(t *testing.T) {
+	server, hits := remoteRuleSetServer(map[string]string{
+		"/base.yaml": `extends: spectral:oas
+rules:
+  check-title:
+    description: title must be set
+    severity: error
+    given: $.info
+    then:
+      field: title
+      function: truthy
+  check-version:
+    description: version must be set
+    given: $.info
+    then:
+      field: version
+      function: truthy`,
+		"/security.yaml": `extends: base.yaml
+rules:
+  check-servers:
+    description: servers must be set
+    given: $
+    then:
+      field: servers
+      function: truthy`,
+		"/ignored.yaml": `rules:
+  check-license:
+    description: license must be set
+    given: $.info
+    then:
+      field: license
+      function: truthy`,
+		"/team/ruleset.yaml": `extends: [../base.yaml, ../security.yaml, [../ignored.yaml, off]]
+rules:
+  check-title: warn
+  check-version: false
+  info-contact: off`,
+	})
+	defer server.Close()
+
+	rs, err := FetchRemoteRuleSet(server.URL+"/team/ruleset.yaml", &RemoteRuleSetConfig{})
+	assert.NoError(t, err)
+
+	// the base ruleset is extended twice, but only fetched once.
+	assert.Equal(t, 1, hits["/base.yaml"])
+	assert.Equal(t, SpectralRecommended, rs.GetExtendsValue()[SpectralOpenAPI])
+
+	built := BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.NotNil(t, built.Rules["check-title"])
+	assert.Equal(t, model.SeverityWarn, built.Rules["check-title"].Severity)
+	assert.NotNil(t, built.Rules["check-servers"])
+	assert.Nil(t, built.Rules["check-version"])
+	assert.Nil(t, built.Rules["check-license"])
+	assert.Nil(t, built.Rules[InfoContact])
+	assert.NotNil(t, built.Rules[OperationSuccessResponse])
+}
+
+func TestFetchRemoteRuleSet_ExtendsItself(t *testing.T) {
+	server, _ := remoteRuleSetServer(map[string]string{
+		"/a.yaml": `extends: b.yaml
+rules: {}`,
+		"/b.yaml": `extends: a.yaml
+rules: {}`,
+	})
+	defer server.Close()
+
+	_, err := FetchRemoteRuleSet(server.URL+"/a.yaml", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "extends itself")
+}
+
+func TestFetchRemoteRuleSet_Cache(t *testing.T) {
+	server, hits := remoteRuleSetServer(map[string]string{
+		"/ruleset.yaml": `extends: spectral:oas
+rules:
+  info-contact: off`,
+	})
+	defer server.Close()
+
+	config := &RemoteRuleSetConfig{CacheDir: t.TempDir()}
+	for i := 0; i < 3; i++ {
+		rs, err := FetchRemoteRuleSet(server.URL+"/ruleset.yaml", config)
+		assert.NoError(t, err)
+		assert.Equal(t, SpectralOff, rs.RuleDefinitions[InfoContact])
+	}
+	assert.Equal(t, 1, hits["/ruleset.yaml"])
+
+	// expired cache entries are fetched again.
+	config.CacheTTL = time.Nanosecond
+	_, err := FetchRemoteRuleSet(server.URL+"/ruleset.yaml", config)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, hits["/ruleset.yaml"])
+}
+
+func TestFetchRemoteRuleSet_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	_, err := FetchRemoteRuleSet(server.URL+"/ruleset.yaml", &RemoteRuleSetConfig{Timeout: 50 * time.Millisecond})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to fetch remote ruleset")
+}
+
+func TestResolveExtends_RemoteDisabled(t *testing.T) {
+	rs, err := CreateRuleSetFromData([]byte(`extends: [[spectral:oas, off], https://quobix.com/vacuum/ruleset.yaml]
+rules: {}`))
+	assert.NoError(t, err)
+
+	_, err = ResolveExtends(rs, "", nil)
+	assert.Error(t, err)
+	assert.Equal(t, "ruleset extends remote ruleset 'https://quobix.com/vacuum/ruleset.yaml', "+
+		"but remote rulesets are not enabled", err.Error())
+}
+
+func TestResolveExtends_RemoteNoRemote(t *testing.T) {
+	rs, err := CreateRuleSetFromData([]byte(`extends: [[spectral:oas, all]]
+rules:
+  info-contact: off`))
+	assert.NoError(t, err)
+
+	resolved, err := ResolveExtends(rs, "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, rs, resolved)
+	assert.Equal(t, model.SeverityWarn, BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(resolved).
+		Rules[OperationSuccessResponse].Severity)
+}
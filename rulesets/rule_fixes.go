@@ -177,4 +177,301 @@ const (
 	owaspNoAdditionalPropertiesFix  = "Disable additional properties by setting `additionalProperties` to `false` or add `maxProperties`."
 	owaspSecurityHostsHttpsOAS2Fix  = "Ensure that you are using the HTTPS protocol. Learn more about the importance of TLS (over SSL) here: https://cheatsheetseries.owasp.org/cheatsheets/Transport_Layer_Protection_Cheat_Sheet.html."
 	owaspSecurityHostsHttpsOAS3Fix  = "Prefix server URLs with the HTTPS protocol: `https://`. Learn more about the importance of TLS (over SSL) here: https://cheatsheetseries.owasp.org/cheatsheets/Transport_Layer_Protection_Cheat_Sheet.html."
+
+	deprecatedOperationSunsetFix string = "Deprecated operations should tell consumers what to do next. Add an `x-sunset` extension " +
+		"with the retirement date, or explain in the description what should be used instead."
+
+	oas3ServerVariablesFix string = "Server variables that declare an `enum` must also declare a `default`, and that default " +
+		"must be one of the values listed in the `enum`."
+
+	noUnresolvedRefsFix string = "A `$ref` points to something that does not exist. Check the reference for typos, or make sure the " +
+		"component it points to has not been renamed or removed."
+
+	noCircularRefsFix string = "Schemas reference each other in a loop. Break the chain by removing one of the references, or if the " +
+		"recursion is intentional (like a tree structure), add the schema to the `allow` list of the rule."
+
+	noPathCollisionsFix string = "Two path templates resolve to the same route once parameter names and trailing slashes are ignored, " +
+		"or a literal segment is shadowed by a parameter in another path. Merge the duplicate paths, or rename the " +
+		"literal segment so routing does not depend on the order paths are registered."
+
+	operationSummaryLengthFix string = "Operation summaries should be a short, single line that fits in navigation menus and tables of contents. " +
+		"Move any extra detail into the `description`, and remove line breaks. Empty summaries should be filled in or removed."
+
+	schemaKeywordConflictsFix string = "The schema uses keywords that contradict each other. An `object` cannot have `items`, an `array` cannot " +
+		"have `properties` and a `const` value must be part of the `enum`. Remove the keyword that does not belong, " +
+		"or set an explicit `type` to make the intent clear."
+
+	parameterNamingStyleFix string = "Parameter names should follow the same casing style for each location, so the API feels consistent to use. " +
+		"Rename the parameter to match the casing configured for its location, or add standard names (like `X-Request-ID`) " +
+		"to the `allowedNames` option."
+
+	oas3NoConflictingExamplesFix string = "The media type defines more than one example, and they disagree with each other. Make sure every " +
+		"example has the same type, and includes the same required properties, or remove the example that is out of date."
+
+	oauth2ScopesDeclaredFix string = "The security requirement asks for an oauth2 scope that the security scheme does not declare, so a token " +
+		"can never be granted it. Add the scope to one of the flows of the scheme, or remove it from the requirement."
+
+	oas3RequestBodyRequiredFix string = "Request bodies are optional unless they are marked `required: true`. If the operation needs a body to work, " +
+		"mark it as required, if an optional body is intentional (common for PATCH), remove the method from the rule `methods`."
+
+	schemaKnownFormatsFix string = "Every `format` should be a known format for the type it is used with. Check for typos (like `datetime` " +
+		"instead of `date-time`), and add vendor formats to the `allowed` option of the rule."
+
+	noEmptySchemasResponsesFix string = "Empty schemas (`{}`) accept anything, add a `type` and the keywords that describe the data. Every response " +
+		"needs a `description`, and `content` describing what it returns. If `additionalProperties: {}` is intentional, " +
+		"keep the `allowEmptyAdditionalProperties` option of the rule enabled."
+
+	oas3MediaTypeSchemasFix string = "The schema of a media type should describe content of that type. JSON media types should not use binary " +
+		"(`format: binary`) schemas, and binary media types (like `application/octet-stream`) should not use object schemas. " +
+		"Fix the schema, or the media type. Mappings can be changed using the `mediaTypes` option of the rule."
+
+	enumConsistentCasingFix string = "Enum values should all use the same casing style, values like `Active`, `active` and `ACTIVE` in the same " +
+		"enum are confusing for consumers. Change the values to match the rest of the enum, or configure a casing `type`. Enums " +
+		"that mix casing on purpose (like country codes) can be added to the `ignoreSchemas` option of the rule."
+
+	parameterRedefinitionConflictFix string = "An operation parameter with the same `name` and `in` as a path-level parameter overrides it. The override " +
+		"should only change things like the `description`, changing `required`, the `type` or the `format` breaks clients " +
+		"that rely on the path-level definition. Make both definitions agree, or rename one of the parameters."
+
+	collectionPaginationFix string = "Operations returning collections grow with the data, without pagination clients end up downloading " +
+		"everything at once. Add pagination parameters (like `limit` and `offset`) to the operation, return a wrapper object with " +
+		"paging metadata, or configure the pagination parameters used by your API with the `parameters` option of the rule."
+
+	securedOperationResponsesFix string = "Operations that require authentication can be rejected, clients need to know what that looks like. Add " +
+		"a `401` response to every secured operation (and a `403` response, if the `require403` option of the rule is set). " +
+		"Operations that don't need authentication should opt out with `security: []`."
+
+	schemaTimestampFormatFix string = "String properties named like timestamps (such as `createdAt` or `updated_at`) should declare their format. " +
+		"Add `format: date-time` (or `format: date` for calendar dates) so tooling knows how to parse the value. If the " +
+		"property is not a timestamp, rename it, or change the `patterns` option of the rule."
+
+	schemaRequiredPropertiesFix string = "Every name in a `required` list must be a property of the schema, otherwise it is most likely a typo, and the " +
+		"schema can't be satisfied the way it was intended. Fix the name in the `required` list, or add the missing " +
+		"property to `properties`."
+
+	exampleStructuredStringFix string = "Examples of `object` and `array` schemas should be written as structured YAML or JSON, not as a string. If the " +
+		"example has to be a string, make sure it is well formed, the parse error will point to the problem. String schemas " +
+		"with a JSON or YAML `contentMediaType` must have examples that parse as that media type."
+
+	operationDuplicateTextFix string = "Operations sharing an identical summary or description were most likely copied and pasted, and one of them " +
+		"now describes the wrong thing. Write a summary and description specific to each operation. Use the `fields` " +
+		"option of the rule to only compare summaries or descriptions."
+
+	descriptionTrailingWhitespaceFix string = "Remove the whitespace at the end of the description or summary (and at the end of each of its lines). This " +
+		"rule can fix the problem itself, run `vacuum lint --fix` with the rule opted in to automatic fixes."
+
+	schemaPropertyIdentifiersFix string = "Property names that are not valid identifiers (or are reserved words) have to be renamed by code generators, " +
+		"which makes generated code hard to follow. Rename the property to a valid identifier. If the name is intentional " +
+		"(like `@odata.id`), add it to the `allowed` option of the rule, and set the `language` option to the language " +
+		"code is generated for."
+
+	schemaMultipleOfPositiveFix string = "`multipleOf` must be a number greater than zero, nothing is a multiple of zero or a negative number. Use the " +
+		"step the values must be a multiple of, for example `0.01` for prices with two decimals"
+
+	operationSecurityRequiredFix string = "Operations without a `security` requirement, and no global `security` default, are public. Add a `security` " +
+		"requirement to the operation or to the root of the document, or add intentionally public paths to the " +
+		"`publicPaths` option"
+
+	operationGetCacheHeadersFix string = "Successful `get` responses should declare a caching header (like `Cache-Control` or `ETag`), so clients " +
+		"and proxies know how long they can reuse a response. Mark operations that must not be cached with the " +
+		"`x-no-cache` extension"
+
+	schemaRequiredNullableFix string = "In OpenAPI 3.0 `nullable: true` allows a value to be `null`, it does not make a property optional. If the " +
+		"property can be left out, remove it from `required`, otherwise describe what a `null` value means"
+
+	responseNoBareArrayFix string = "A bare array at the root of a response can never be extended, wrap it in an object (an envelope), like " +
+		"`{\"items\": [...]}`, so metadata (like pagination) can be added later without breaking clients"
+
+	schemaCollectionLimitsFix string = "Huge `enum` lists and `examples` bloat documentation and slow down code generators. Trim them, or use a " +
+		"pattern or a reference to external documentation. Legitimately large schemas can be added to the " +
+		"`allowedSchemas` option"
+
+	infoContactCompleteFix string = "Add a `contact` to `info`, with an `email` or a `url`, so consumers of the API know who to ask for help"
+
+	infoLicenseCompleteFix string = "Add a `license` to `info`, with a `name`. Identify the license with an SPDX `identifier` (OpenAPI 3.1 " +
+		"only) or a `url`, not both"
+
+	infoLicenseSPDXFix string = "The license `identifier` must be an SPDX license expression, like `MIT` or `Apache-2.0 OR MIT`. See " +
+		"https://spdx.org/licenses for the list of licenses, or use a `url` for licenses that are not on it"
+
+	schemaSetUniqueItemsFix string = "Arrays that represent sets (like `tags` or `roles`) should declare `uniqueItems: true`, so the same " +
+		"value can not be sent twice, and code generators can use a set type"
+
+	schemaDeprecatedConsistencyFix string = "A deprecated property should not be `required`, clients must be able to stop sending or reading it. Deprecated " +
+		"properties should also describe what to use instead, like `use `fullName` instead`"
+
+	responseDeprecatedPropertiesFix string = "An operation that is not deprecated returns a deprecated property. That is allowed, but check clients " +
+		"have been told about the replacement, or deprecate the operation too"
+
+	operationIdConventionFix string = "Name operations with a verb and a resource, in camelCase, like `getPet`, `listPets` or `createPet`. The verb " +
+		"should agree with the method: `get`, `list`, `find` or `search` for `get`, `create` or `add` for `post`, " +
+		"`update` or `replace` for `put` and `delete` or `remove` for `delete`"
+
+	schemaExamplesArrayFix string = "In OpenAPI 3.1, schemas are JSON Schema, and `example` is deprecated. Replace `example: value` with " +
+		"`examples: [value]`, media types, parameters and headers keep their own `example`"
+
+	ignoredRefSiblingsFix string = "In OpenAPI 3.0, properties next to a `$ref` are ignored. Move them into the referenced object, or wrap the " +
+		"reference in `allOf` (`allOf: [{$ref: ...}]`) and place the properties next to the `allOf`"
+
+	schemaNumericBoundsFix string = "Numeric properties without a `minimum` and a `maximum` accept any value. Add both bounds, or add the property to the " +
+		"rule's `ignore` option if it is unbounded on purpose (like an identifier)"
+
+	pathKeysPathOnlyFix string = "Path keys are only the path of a URL, relative to the server. Move query strings into `in: query` parameters, remove " +
+		"fragments, and move the scheme and host into `servers` (or `host` and `basePath` in Swagger)"
+
+	mediaTypesRegisteredFix string = "Media types must be registered with IANA (https://www.iana.org/assignments/media-types), check for typos like " +
+		"`application/jsonn`. Use a structured suffix (`application/hal+json`) or a vendor type (`application/vnd.acme+json`) " +
+		"for custom formats, or add the type to the rule's `allowed` option"
+
+	schemaOneOfAmbiguousFix string = "Input must match exactly one `oneOf` branch, branches that can match the same input make it invalid. Add a " +
+		"`discriminator`, give the branches distinct `const` or `enum` values, or use `anyOf` if overlapping is intended"
+
+	operationSuccessStatusCodesFix string = "Use the same success status codes for each method across the API: `200` for a `get`, `201` for a `post` that " +
+		"creates, `204` for a `delete`. Configure the expected codes with the `expected` option, and allow `202` for " +
+		"asynchronous operations with `asyncMethods` or `asyncPaths`"
+
+	schemaNestingDepthFix string = "Deeply nested inline schemas are hard to read, reuse and validate. Move nested objects into `components` " +
+		"(or `definitions`) and `$ref` them, or raise the rule's `maxDepth` option"
+
+	oas3DiscriminatorRequiredFix string = "A discriminator can only pick a schema when its `propertyName` is always present. Define the property and " +
+		"add it to `required`, in the schema with the discriminator, in an `allOf` parent, or in every `oneOf` / `anyOf` branch"
+
+	schemaEnumTypesFix string = "Every member of an `enum` must match the schema's `type` (and `format`, if it has one). Correct the member, " +
+		"or the type. If `null` is a valid member, add `null` to the type array (OpenAPI 3.1), or set `nullable: true` " +
+		"(OpenAPI 3.0)."
+
+	parameterAllowEmptyValueFix string = "`allowEmptyValue` is only valid for `query` parameters, and is deprecated in OpenAPI 3. Remove it, " +
+		"and describe how an empty value is handled in the parameter's description instead."
+
+	oas3ParameterStyleFix string = "Use a `style` defined for the parameter's location: `simple`, `matrix` or `label` for path parameters, " +
+		"`form`, `spaceDelimited`, `pipeDelimited` or `deepObject` for query parameters, `simple` for headers and " +
+		"`form` for cookies. `deepObject` parameters need `explode: true`, `spaceDelimited` and `pipeDelimited` " +
+		"parameters need `explode: false`."
+
+	serversUseHTTPSFix string = "Servers must be reached over TLS, change the server URL to use `https`. If the scheme is a server " +
+		"variable, remove `http` from its `enum` and `default`. Servers used for local development can be allowed " +
+		"with the `allowedHosts` option."
+
+	operationBodyContentFix string = "Describe the content of every request body and response, with at least one media type in `content`. " +
+		"If an operation intentionally has no request body (like a trigger or an action), exempt its path with the " +
+		"`exemptPaths` option. Remove empty `content` maps from responses that have no body."
+
+	parameterIntegerBoundsFix string = "Add a `minimum` and a `maximum` to the schema of integer path and query parameters (like `limit: " +
+		"{type: integer, minimum: 1, maximum: 100}`), so clients can't request unbounded values. Identifiers can be " +
+		"exempted with the `ignore` option."
+
+	schemaClosedObjectsFix string = "A schema with `additionalProperties: false` rejects every property it doesn't declare in `properties` " +
+		"(or match with `patternProperties`), even properties declared by the other members of an `allOf`. Remove the " +
+		"property from the example, declare it in the closed schema, or use `unevaluatedProperties: false` (OpenAPI " +
+		"3.1) on the composed schema instead."
+
+	requestBodyReadOnlyRequiredFix string = "Clients can't send `readOnly` properties, so a request body that requires one can never be valid. " +
+		"Remove the property from `required` in the schema used by the request body (use a separate schema for " +
+		"requests if the response needs it to be required), or remove `readOnly` if clients should send it."
+
+	descriptionQualityFix string = "Descriptions like `TODO` or `desc` don't help anyone using the API. Write a description that explains " +
+		"what the operation does, or what the parameter is for. If short descriptions are fine for your API, lower the " +
+		"`minLength` option of the rule (or set it to 0 to only check for placeholders)."
+
+	mediaTypeExampleKeysFix string = "Documentation lists media type examples by name, so names like `example1` don't help readers pick one. " +
+		"Rename the example to say what it shows (like `vegetarianPizza` or `outOfStock`), and add a `summary` " +
+		"(or a `description`) to it."
+
+	tagNameURLSafeFix string = "Documentation tools build anchors and links from tag names, so spaces and special characters break them. " +
+		"Rename the tag (and the operations that use it) to only use URL safe characters, like `pizza-orders`. " +
+		"If the tag needs a name with spaces for display, add it as an `x-displayName` extension to the tag."
+
+	componentDefinitionDriftFix string = "The same component is defined in more than one file, and the copies are different. Keep one definition, " +
+		"and replace the copies with a `$ref` to it, so they can't drift apart again. If the files hold intentional " +
+		"duplicates (like an older version of a model), add them to the `ignoreFiles` option of the rule."
+
+	schemaBooleanEnumFix string = "An `enum` of `true` and `false` allows every boolean, so remove it. An `enum` with a single value is " +
+		"a constant, replace it with `const` (like `const: true`). Nullable booleans can use an `enum` to constrain " +
+		"their values, like `[true, null]`."
+
+	operationContentNegotiationFix string = "The operation consumes one kind of media type, but produces another (like JSON requests with XML " +
+		"responses), which is usually a mistake. Add the missing media types to the request body or responses, so " +
+		"clients can use one format. Uploads and downloads can be exempted with the `exemptMediaTypes` option."
+
+	paginationResponseMetadataFix string = "Clients paging through a collection need to know where they are, and how to get the next page. Add " +
+		"the metadata the pagination style needs to the response schema (like `total` for offset pagination, or " +
+		"`nextCursor` for cursor pagination), or return it in a response header. The metadata each style needs can be " +
+		"configured with the `styles` option of the rule."
+
+	jsonBinaryFormatFix string = "JSON can't hold binary data, so a `format: binary` (or `byte`) schema in a JSON media type is a " +
+		"mistake. Move the binary data to its own media type (like `application/octet-stream`), or send it with " +
+		"`multipart/form-data`. If the data really is a base64 encoded string, remove `byte` from the `formats` " +
+		"option of the rule."
+
+	pathsPluralCollectionsFix string = "Collections are named with plural nouns (`/pizzas`), and items are addressed inside them " +
+		"(`/pizzas/{pizzaId}`). Rename the path segment to the plural of the noun. If the noun is uncountable (like " +
+		"`info`), add it to the `uncountable` option of the rule, irregular plurals can be added with the `irregular` " +
+		"option."
+
+	webhookSecurityRequiredFix string = "A webhook without security lets anyone send requests that " +
+		"look like they came from the API. Add a `security` requirement to the webhook operation (or a global " +
+		"`security` default). Webhooks secured by verifying a signature of the payload can be marked with the " +
+		"`x-webhook-signature` extension (describe how the signature is verified in the description)."
+
+	postCreatedStatusFix string = "A `post` operation that creates a resource should respond with " +
+		"`201 Created` (and a `Location` header pointing at the new resource), not `200 OK`. Change the response " +
+		"code to `201`. If the operation legitimately responds with `200` (it is idempotent, or RPC style), add " +
+		"`x-idempotent: true` to the operation."
+
+	schemaArrayItemsFix string = "An `array` schema without `items` can hold anything, " +
+		"code generators turn it into an array of `any`. Add an `items` schema describing what the array holds. " +
+		"Tuples (in OpenAPI 3.1) can describe their items with `prefixItems` instead."
+
+	schemaEnumExamplesFix string = "The `default` and examples of a schema with an `enum` must " +
+		"be members of the enum, otherwise they describe a value the API rejects. Change the value to one of the " +
+		"enum members (or add it to the enum). Long enums should have an `example` showing a typical value, add " +
+		"one, or raise the `minEnumSize` option of the rule."
+
+	infoTermsOfServiceURLFix string = "The `termsOfService` of the `info` section must be a URL " +
+		"pointing at the terms of service of the API, not a description of them. Replace the value with an absolute " +
+		"URL (like `https://example.com/terms`). If relative URLs work for your consumers, set the `allowRelative` " +
+		"option of the rule."
+
+	operationDeprecatedSchemasFix string = "An operation that is not deprecated uses a deprecated " +
+		"schema, when the schema is removed the operation will change. Move the operation to the schema that " +
+		"replaces the deprecated one, or deprecate the operation as well (with `deprecated: true`)."
+
+	schemaPatternRedosFix string = "A `pattern` with a nested quantifier (like `(a+)+`) can " +
+		"take exponential time to fail in backtracking regex engines (like PCRE or JavaScript), so a short input can " +
+		"block a validating consumer. Rewrite the pattern so repeated groups don't contain repetitions that match the " +
+		"same text (`(a+)+` is `a+`), or make the inner repetition possessive or atomic. This rule is a heuristic, " +
+		"it's not recommended by default."
+
+	operationParameterCountFix string = "An operation with too many parameters is hard to use and " +
+		"to test. Group related parameters into a request body, or split the operation. Search or filter " +
+		"operations that need more parameters can be given their own maximum with the `overrides` option of the rule."
+
+	sensitiveCookieParametersFix string = "Browsers send cookies with every request, a token or " +
+		"session identifier in a `cookie` parameter is open to cross-site request forgery, and hides how the operation " +
+		"is secured. Describe the cookie with an `apiKey` security scheme (with `in: cookie`) and use it in the " +
+		"`security` of the operation, instead of a parameter."
+
+	schemaPropertyCountFix string = "A schema with too many properties generates client models that are " +
+		"hard to use. Split the schema into smaller schemas, grouping related properties into nested objects. " +
+		"Remember `allOf` members add up, the properties of every member are counted."
+
+	operationDefaultResponseFix string = "Without a `default` response, clients have no idea what an " +
+		"undocumented status code looks like. Add a `default` response to the operation, usually the error schema " +
+		"the API returns for everything else."
+
+	operationResponseStatusCodesFix string = "A response status code must be a status code between `100` " +
+		"and `599`, a range between `1XX` and `5XX` (the `X` must be upper case) or `default`. Tools ignore " +
+		"responses with any other key, fix the key so the response is recognised."
+
+	schemaAllOfBoundsFix string = "A value must be valid against every member of an `allOf`, so " +
+		"the highest lower bound and the lowest upper bound of the members apply. Change the bounds of the members " +
+		"so the merged bounds can be satisfied, or stop composing members that contradict each other."
+
+	oas2OperationProducesFix string = "Swagger responses can only use the media types their operation " +
+		"produces. Add the media type to the `produces` of the operation (an operation `produces` replaces the " +
+		"`produces` of the document, it does not add to it), or remove the examples for media types it doesn't produce."
+
+	extensionPrefixFix string = "Extensions should be named with one of the prefixes your " +
+		"organization uses (configured with the `prefixes` option of the rule, like `x-acme-*`), so it's clear who " +
+		"owns them. Rename the extension, or add it to the `allowed` option if a tool needs that exact name."
 )
@@ -553,6 +553,9 @@ func GetOperationTagsRule() *model.Rule {
 		Severity:     model.SeverityWarn,
 		Then: model.RuleAction{
 			Function: "oasOperationTags",
+			FunctionOptions: map[string]interface{}{
+				"minTags": 1,
+			},
 		},
 		HowToFix: operationTagsFix,
 	}
@@ -1204,3 +1207,1665 @@ func GetOperationErrorResponseRule() *model.Rule {
 		HowToFix: operationsErrorResponseFix,
 	}
 }
+
+// GetDeprecatedOperationSunsetRule will check that deprecated operations provide a sunset date or a replacement note.
+func GetDeprecatedOperationSunsetRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Deprecated operations must provide a sunset or replacement",
+		Id:           DeprecatedOperationSunset,
+		Formats:      model.AllFormats,
+		Description:  "Deprecated operations must include an `x-sunset` extension or a description explaining the replacement",
+		Given:        "$",
+		Resolved:     true,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasDeprecatedSunset",
+		},
+		HowToFix: deprecatedOperationSunsetFix,
+	}
+}
+
+// GetOAS3ServerVariablesRule will check that server variable defaults are members of the variable enum.
+func GetOAS3ServerVariablesRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Server variable defaults must match enums",
+		Id:           Oas3ServerVariables,
+		Formats:      model.OAS3AllFormat,
+		Description:  "Server variables with an `enum` must have a `default` that is a member of that `enum`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  true,
+		RuleCategory: model.RuleCategories[model.CategoryInfo],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "oasServerVariables",
+		},
+		HowToFix: oas3ServerVariablesFix,
+	}
+}
+
+// GetNoUnresolvedRefsRule will check that every $ref in the document can be resolved.
+func GetNoUnresolvedRefsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check all references can be resolved",
+		Id:           NoUnresolvedRefs,
+		Formats:      model.AllFormats,
+		Description:  "Every `$ref` must point to something that exists",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  true,
+		RuleCategory: model.RuleCategories[model.CategoryValidation],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "oasUnresolvedRefs",
+		},
+		HowToFix: noUnresolvedRefsFix,
+	}
+}
+
+// GetNoCircularRefsRule will check for cycles in the $ref graph between schemas.
+func GetNoCircularRefsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check for circular references",
+		Id:           NoCircularRefs,
+		Formats:      model.AllFormats,
+		Description:  "Schemas should not reference each other in a loop",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasCircularRefs",
+		},
+		HowToFix: noCircularRefsFix,
+	}
+}
+
+// GetNoPathCollisionsRule will check that normalized path templates do not collide with one another.
+func GetNoPathCollisionsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Path templates must not collide",
+		Id:           NoPathCollisions,
+		Formats:      model.AllFormats,
+		Description:  "Path templates must not collide once parameters and trailing slashes are normalized",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasPathCollisions",
+		},
+		HowToFix: noPathCollisionsFix,
+	}
+}
+
+// GetOperationSummaryLengthRule will check that operation summaries are short, single line and not empty.
+func GetOperationSummaryLengthRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Operation summaries must be short",
+		Id:           OperationSummaryLength,
+		Formats:      model.AllFormats,
+		Description:  "Operation `summary` should be a single line, and not exceed a maximum length",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryDescriptions],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasOperationSummary",
+			FunctionOptions: map[string]interface{}{
+				"maxLength": 80,
+			},
+		},
+		HowToFix: operationSummaryLengthFix,
+	}
+}
+
+// GetSchemaKeywordConflictsRule will check schemas for contradictory keyword combinations.
+func GetSchemaKeywordConflictsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Schema keywords must not conflict",
+		Id:           SchemaKeywordConflicts,
+		Formats:      model.AllFormats,
+		Description:  "Schemas must not combine keywords that contradict one another",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasSchemaKeywordConflicts",
+		},
+		HowToFix: schemaKeywordConflictsFix,
+	}
+}
+
+// GetParameterNamingStyleRule will check parameter names follow a consistent casing style for each location.
+func GetParameterNamingStyleRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Parameter names must use a consistent casing style",
+		Id:           ParameterNamingStyle,
+		Formats:      model.AllFormats,
+		Description:  "Parameter names should use a consistent casing style for each location (query, header, path and cookie)",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasParameterNaming",
+			FunctionOptions: map[string]interface{}{
+				"query":  "camel",
+				"path":   "camel",
+				"header": "train",
+				"allowedNames": []string{
+					"X-Request-ID", "X-Correlation-ID", "X-API-Key", "X-Forwarded-For", "ETag", "WWW-Authenticate",
+				},
+			},
+		},
+		HowToFix: parameterNamingStyleFix,
+	}
+}
+
+// GetOAS3NoConflictingExamplesRule will check that examples defined for the same media type do not conflict.
+func GetOAS3NoConflictingExamplesRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Examples for the same media type must not conflict",
+		Id:           Oas3NoConflictingExamples,
+		Formats:      model.OAS3AllFormat,
+		Description:  "Media type `example`, `examples` and schema `example` values must agree with each other",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryExamples],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasConflictingExamples",
+		},
+		HowToFix: oas3NoConflictingExamplesFix,
+	}
+}
+
+// GetOAuth2ScopesDeclaredRule will check that security requirements only request declared oauth2 scopes.
+func GetOAuth2ScopesDeclaredRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check oauth2 scopes are declared",
+		Id:           OAuth2ScopesDeclared,
+		Formats:      model.AllFormats,
+		Description:  "`security` requirements must only request scopes declared by the oauth2 scheme flows",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  true,
+		RuleCategory: model.RuleCategories[model.CategorySecurity],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "oasOAuth2Scopes",
+		},
+		HowToFix: oauth2ScopesDeclaredFix,
+	}
+}
+
+// GetOAS3RequestBodyRequiredRule will check that request bodies for POST, PUT and PATCH operations are required.
+func GetOAS3RequestBodyRequiredRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Request bodies should be required",
+		Id:           Oas3RequestBodyRequired,
+		Formats:      model.OAS3AllFormat,
+		Description:  "Operations that accept a `requestBody` should mark it as `required: true`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasRequestBodyRequired",
+			FunctionOptions: map[string]interface{}{
+				"methods": []string{"post", "put", "patch"},
+			},
+		},
+		HowToFix: oas3RequestBodyRequiredFix,
+	}
+}
+
+// GetSchemaKnownFormatsRule will check that every schema format is a known format for the type it is used with.
+func GetSchemaKnownFormatsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Schema formats must be known",
+		Id:           SchemaKnownFormats,
+		Formats:      model.AllFormats,
+		Description:  "Schema `format` values should be known formats for the type they are used with",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasKnownFormats",
+		},
+		HowToFix: schemaKnownFormatsFix,
+	}
+}
+
+// GetNoEmptySchemasResponsesRule will check for empty schemas and responses without a description or content.
+func GetNoEmptySchemasResponsesRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Schemas and responses must not be empty",
+		Id:           NoEmptySchemasResponses,
+		Formats:      model.AllFormats,
+		Description:  "Schemas should define at least one keyword, and responses should have a `description` or `content`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasEmptySchemasResponses",
+			FunctionOptions: map[string]interface{}{
+				"allowEmptyAdditionalProperties": true,
+			},
+		},
+		HowToFix: noEmptySchemasResponsesFix,
+	}
+}
+
+// GetOAS3MediaTypeSchemaMismatchRule will check that media type schemas match the media type they describe.
+func GetOAS3MediaTypeSchemaMismatchRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Media type schemas must match the media type",
+		Id:           Oas3MediaTypeSchemaMismatch,
+		Formats:      model.OAS3AllFormat,
+		Description:  "The schema type of a media type should match the media type, for example no binary JSON",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasMediaTypeSchemas",
+		},
+		HowToFix: oas3MediaTypeSchemasFix,
+	}
+}
+
+// GetEnumConsistentCasingRule will check that the values of string enums use a consistent casing style.
+func GetEnumConsistentCasingRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Enum values must use consistent casing",
+		Id:           EnumConsistentCasing,
+		Formats:      model.AllFormats,
+		Description:  "String enum values should all use the same casing style",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasEnumCasing",
+		},
+		HowToFix: enumConsistentCasingFix,
+	}
+}
+
+// GetParameterRedefinitionConflictRule will check that operations don't redefine path-level parameters incompatibly.
+func GetParameterRedefinitionConflictRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Path-level parameters must not be redefined incompatibly",
+		Id:           ParameterRedefinitionConflict,
+		Formats:      model.AllFormats,
+		Description:  "Operation parameters that override a path-level parameter must keep the same `required`, `type` and `format`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "oasParameterRedefinition",
+		},
+		HowToFix: parameterRedefinitionConflictFix,
+	}
+}
+
+// GetCollectionPaginationRule will check that GET operations returning collections support pagination.
+func GetCollectionPaginationRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Collections must support pagination",
+		Id:           CollectionPagination,
+		Formats:      model.AllFormats,
+		Description:  "GET operations returning an array should define pagination parameters",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasCollectionPagination",
+			FunctionOptions: map[string]interface{}{
+				"parameters": []string{"limit,offset", "page,pageSize", "page,per_page", "cursor"},
+			},
+		},
+		HowToFix: collectionPaginationFix,
+	}
+}
+
+// GetSecuredOperationAuthResponsesRule will check that secured operations define 401 (and optionally 403) responses.
+func GetSecuredOperationAuthResponsesRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Secured operations must define unauthorized responses",
+		Id:           SecuredOperationAuthResponses,
+		Formats:      model.AllFormats,
+		Description:  "Operations with a security requirement should define a `401` response",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySecurity],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasSecuredOperationResponses",
+			FunctionOptions: map[string]interface{}{
+				"require403": false,
+			},
+		},
+		HowToFix: securedOperationResponsesFix,
+	}
+}
+
+// GetSchemaTimestampFormatRule will check that timestamp-like string properties use a date or date-time format.
+func GetSchemaTimestampFormatRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Timestamp properties must use a date-time format",
+		Id:           SchemaTimestampFormat,
+		Formats:      model.AllFormats,
+		Description:  "String properties named like timestamps should use `format: date-time` or `format: date`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasTimestampFormats",
+		},
+		HowToFix: schemaTimestampFormatFix,
+	}
+}
+
+// GetSchemaRequiredPropertiesDefinedRule will check that `required` lists only name properties the schema defines.
+func GetSchemaRequiredPropertiesDefinedRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Required properties must be defined",
+		Id:           SchemaRequiredPropertiesDefined,
+		Formats:      model.AllFormats,
+		Description:  "Every name in a schema's `required` list must be defined in its `properties`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "oasRequiredProperties",
+		},
+		HowToFix: schemaRequiredPropertiesFix,
+	}
+}
+
+// GetExampleStructuredStringRule will check that string examples of structured schemas parse as JSON or YAML.
+func GetExampleStructuredStringRule() *model.Rule {
+	return &model.Rule{
+		Name:         "String examples of structured schemas must parse",
+		Id:           ExampleStructuredString,
+		Formats:      model.AllFormats,
+		Description:  "String examples of `object` or `array` schemas (or of JSON/YAML `contentMediaType` strings) must parse as JSON or YAML",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryExamples],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasExampleStructure",
+		},
+		HowToFix: exampleStructuredStringFix,
+	}
+}
+
+// GetOperationDuplicateTextRule will check that operations don't share identical summaries or descriptions.
+func GetOperationDuplicateTextRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Operations must not share summaries or descriptions",
+		Id:           OperationDuplicateText,
+		Formats:      model.AllFormats,
+		Description:  "Operations sharing an identical `summary` or `description` are usually a copy-paste error",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryDescriptions],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasOperationDuplicateText",
+			FunctionOptions: map[string]interface{}{
+				"fields": "both",
+			},
+		},
+		HowToFix: operationDuplicateTextFix,
+	}
+}
+
+// GetDescriptionTrailingWhitespaceRule will check that descriptions and summaries have no trailing whitespace.
+func GetDescriptionTrailingWhitespaceRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Descriptions must not have trailing whitespace",
+		Id:           DescriptionTrailingWhitespace,
+		Formats:      model.AllFormats,
+		Description:  "Descriptions and summaries should not end with whitespace, or have lines that do",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryDescriptions],
+		Type:         Style,
+		Severity:     model.SeverityInfo,
+		Then: model.RuleAction{
+			Function: "oasDescriptionWhitespace",
+		},
+		HowToFix: descriptionTrailingWhitespaceFix,
+	}
+}
+
+// GetSchemaPropertyIdentifiersRule will check that schema property names are valid identifiers for code generation.
+func GetSchemaPropertyIdentifiersRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Property names must be valid identifiers",
+		Id:           SchemaPropertyIdentifiers,
+		Formats:      model.AllFormats,
+		Description:  "Schema property names should be valid identifiers, that are not reserved words, so code can be generated",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasPropertyIdentifiers",
+			FunctionOptions: map[string]interface{}{
+				"language": "generic",
+			},
+		},
+		HowToFix: schemaPropertyIdentifiersFix,
+	}
+}
+
+// GetSchemaMultipleOfPositiveRule will check that every `multipleOf` in a schema is a number greater than zero.
+func GetSchemaMultipleOfPositiveRule() *model.Rule {
+	return &model.Rule{
+		Name:         "multipleOf must be greater than zero",
+		Id:           SchemaMultipleOfPositive,
+		Formats:      model.AllFormats,
+		Description:  "Schema `multipleOf` values must be numbers greater than zero",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "oasMultipleOf",
+		},
+		HowToFix: schemaMultipleOfPositiveFix,
+	}
+}
+
+// GetOperationSecurityRequiredRule will check that every operation has a security requirement, or a global default.
+func GetOperationSecurityRequiredRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Operations must be secured",
+		Id:           OperationSecurityRequired,
+		Formats:      model.AllFormats,
+		Description:  "Operations must define a `security` requirement, or inherit a global `security` default",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySecurity],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasOperationSecurityRequired",
+			FunctionOptions: map[string]interface{}{
+				"publicPaths": []interface{}{},
+				"flagOptOut":  false,
+			},
+		},
+		HowToFix: operationSecurityRequiredFix,
+	}
+}
+
+// GetOperationGetCacheHeadersRule will check that successful `get` responses declare caching headers.
+func GetOperationGetCacheHeadersRule() *model.Rule {
+	return &model.Rule{
+		Name:         "GET responses should declare caching headers",
+		Id:           OperationGetCacheHeaders,
+		Formats:      model.AllFormats,
+		Description:  "Successful `get` responses should declare a `Cache-Control` or `ETag` header",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Style,
+		Severity:     model.SeverityInfo,
+		Then: model.RuleAction{
+			Function: "oasCacheHeaders",
+			FunctionOptions: map[string]interface{}{
+				"headers":         []interface{}{"Cache-Control", "ETag"},
+				"exemptExtension": "x-no-cache",
+			},
+		},
+		HowToFix: operationGetCacheHeadersFix,
+	}
+}
+
+// GetSchemaRequiredNullableRule will check for OpenAPI 3.0 properties that are both required and nullable.
+func GetSchemaRequiredNullableRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Required properties should not be nullable",
+		Id:           SchemaRequiredNullable,
+		Formats:      model.OAS3Format,
+		Description:  "Properties that are `required` and `nullable` must be present, `nullable` does not make them optional",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasRequiredNullable",
+			FunctionOptions: map[string]interface{}{
+				"policy": "forbid",
+			},
+		},
+		HowToFix: schemaRequiredNullableFix,
+	}
+}
+
+// GetResponseNoBareArrayRule will check that successful responses wrap arrays in an object.
+func GetResponseNoBareArrayRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Responses should not be bare arrays",
+		Id:           ResponseNoBareArray,
+		Formats:      model.AllFormats,
+		Description:  "Successful responses should return an object that wraps an array, not a bare array",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasArrayResponses",
+			FunctionOptions: map[string]interface{}{
+				"exemptContentTypes": []interface{}{"text/csv", "text/event-stream", "application/x-ndjson",
+					"application/jsonl", "application/stream+json"},
+			},
+		},
+		HowToFix: responseNoBareArrayFix,
+	}
+}
+
+// GetSchemaCollectionLimitsRule will check that enums and examples are not too large.
+func GetSchemaCollectionLimitsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Enums and examples should not be too large",
+		Id:           SchemaCollectionLimits,
+		Formats:      model.AllFormats,
+		Description:  "`enum` lists and `examples` should not have more entries than the configured limits",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Style,
+		Severity:     model.SeverityInfo,
+		Then: model.RuleAction{
+			Function: "oasCollectionLimits",
+			FunctionOptions: map[string]interface{}{
+				"maxEnum":     100,
+				"maxExamples": 10,
+			},
+		},
+		HowToFix: schemaCollectionLimitsFix,
+	}
+}
+
+// GetInfoContactCompleteRule will check that info has a contact, with an email or a url.
+func GetInfoContactCompleteRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Contact must have an email or url",
+		Id:           InfoContactComplete,
+		Formats:      model.AllFormats,
+		Description:  "`info` must have a `contact`, with an `email` or a `url`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryInfo],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasInfoMetadata",
+			FunctionOptions: map[string]interface{}{
+				"check": "contact",
+			},
+		},
+		HowToFix: infoContactCompleteFix,
+	}
+}
+
+// GetInfoLicenseCompleteRule will check that info has a license, with a name and an identifier or url.
+func GetInfoLicenseCompleteRule() *model.Rule {
+	return &model.Rule{
+		Name:         "License must have a name",
+		Id:           InfoLicenseComplete,
+		Formats:      model.AllFormats,
+		Description:  "`info` must have a `license`, with a `name`, and an SPDX `identifier` or a `url`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryInfo],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasInfoMetadata",
+			FunctionOptions: map[string]interface{}{
+				"check": "license",
+			},
+		},
+		HowToFix: infoLicenseCompleteFix,
+	}
+}
+
+// GetInfoLicenseSPDXRule will check that a license identifier is a valid SPDX license expression.
+func GetInfoLicenseSPDXRule() *model.Rule {
+	return &model.Rule{
+		Name:         "License identifier must be an SPDX expression",
+		Id:           InfoLicenseSPDX,
+		Formats:      model.OAS3AllFormat,
+		Description:  "License `identifier` must be a valid SPDX license expression",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryInfo],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "oasSPDXLicense",
+		},
+		HowToFix: infoLicenseSPDXFix,
+	}
+}
+
+// GetSchemaSetUniqueItemsRule will check that array properties that represent sets declare uniqueItems.
+func GetSchemaSetUniqueItemsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Set arrays should declare uniqueItems",
+		Id:           SchemaSetUniqueItems,
+		Formats:      model.AllFormats,
+		Description:  "Array properties that represent sets (like `tags` or `roles`) should declare `uniqueItems: true`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Style,
+		Severity:     model.SeverityInfo,
+		Then: model.RuleAction{
+			Function: "oasSetArrays",
+			FunctionOptions: map[string]interface{}{
+				"includeObjects": false,
+			},
+		},
+		HowToFix: schemaSetUniqueItemsFix,
+	}
+}
+
+// GetSchemaDeprecatedConsistencyRule will check that deprecated properties are not required, and describe a replacement.
+func GetSchemaDeprecatedConsistencyRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Deprecated properties must be optional and describe a replacement",
+		Id:           SchemaDeprecatedConsistency,
+		Formats:      model.OAS3AllFormat,
+		Description:  "Deprecated properties must not be `required`, and must describe what replaces them",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasDeprecatedProperties",
+			FunctionOptions: map[string]interface{}{
+				"check": "properties",
+			},
+		},
+		HowToFix: schemaDeprecatedConsistencyFix,
+	}
+}
+
+// GetResponseDeprecatedPropertiesRule will note deprecated properties returned by operations that are not deprecated.
+func GetResponseDeprecatedPropertiesRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Operation returns deprecated properties",
+		Id:           ResponseDeprecatedProperties,
+		Formats:      model.OAS3AllFormat,
+		Description:  "Operations that are not deprecated should not return deprecated properties",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Validation,
+		Severity:     model.SeverityInfo,
+		Then: model.RuleAction{
+			Function: "oasDeprecatedProperties",
+			FunctionOptions: map[string]interface{}{
+				"check": "responses",
+			},
+		},
+		HowToFix: responseDeprecatedPropertiesFix,
+	}
+}
+
+// GetOperationIdConventionRule will check that operationIds are camelCase, and start with a verb that agrees with
+// the method of the operation.
+func GetOperationIdConventionRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Operation IDs must follow the verb-resource convention",
+		Id:           OperationOperationIdConvention,
+		Formats:      model.AllFormats,
+		Description:  "Operation `operationId` must be camelCase, and start with a verb that agrees with its method",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasOperationIdConvention",
+			FunctionOptions: map[string]interface{}{
+				"checkVerbs": true,
+			},
+		},
+		HowToFix: operationIdConventionFix,
+	}
+}
+
+// GetSchemaExamplesArrayRule will check that schemas in OpenAPI 3.1 use `examples`, not the deprecated `example`.
+func GetSchemaExamplesArrayRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Schemas must use examples, not example",
+		Id:           SchemaExamplesArray,
+		Formats:      model.OAS3AllFormat,
+		Description:  "Schemas in OpenAPI 3.1 must use the `examples` array, `example` is deprecated",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryExamples],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasSchemaExamplesArray",
+		},
+		HowToFix: schemaExamplesArrayFix,
+	}
+}
+
+// GetOAS3IgnoredRefSiblingsRule will check for properties next to a $ref in OpenAPI 3.0, and name them.
+func GetOAS3IgnoredRefSiblingsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check for ignored siblings to $ref values",
+		Id:           Oas3IgnoredRefSiblings,
+		Formats:      model.OAS3Format,
+		Description:  "Properties next to a `$ref` are ignored in OpenAPI 3.0",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasIgnoredRefSiblings",
+		},
+		HowToFix: ignoredRefSiblingsFix,
+	}
+}
+
+// GetSchemaNumericBoundsRule will check that number and integer properties have a minimum and a maximum.
+func GetSchemaNumericBoundsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Numeric properties must be bounded",
+		Id:           SchemaNumericBounds,
+		Formats:      model.AllFormats,
+		Description:  "Number and integer properties should specify both a `minimum` and a `maximum`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasNumericBounds",
+		},
+		HowToFix: schemaNumericBoundsFix,
+	}
+}
+
+// GetPathKeysPathOnlyRule will check that path keys don't include a query string, a fragment or a server.
+func GetPathKeysPathOnlyRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Path keys must only be paths",
+		Id:           PathKeysPathOnly,
+		Formats:      model.AllFormats,
+		Description:  "Path keys must not include a query string, a fragment, or a scheme and host",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "oasPathKeyComponents",
+		},
+		HowToFix: pathKeysPathOnlyFix,
+	}
+}
+
+// GetMediaTypesRegisteredRule will check that request and response media types are registered with IANA.
+func GetMediaTypesRegisteredRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Media types must be registered",
+		Id:           MediaTypesRegistered,
+		Formats:      model.AllFormats,
+		Description:  "Request and response media types must be registered with IANA",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryValidation],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasRegisteredMediaTypes",
+		},
+		HowToFix: mediaTypesRegisteredFix,
+	}
+}
+
+// GetSchemaOneOfAmbiguousRule will check for oneOf branches that are likely to match the same input.
+func GetSchemaOneOfAmbiguousRule() *model.Rule {
+	return &model.Rule{
+		Name:         "oneOf branches must not overlap",
+		Id:           SchemaOneOfAmbiguous,
+		Formats:      model.OAS3AllFormat,
+		Description:  "`oneOf` branches should not be able to match the same input",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasAmbiguousOneOf",
+		},
+		HowToFix: schemaOneOfAmbiguousFix,
+	}
+}
+
+// GetOperationSuccessStatusCodesRule will check that success responses use the status codes expected for their method.
+func GetOperationSuccessStatusCodesRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Success status codes must match the method",
+		Id:           OperationSuccessStatusCodes,
+		Formats:      model.AllFormats,
+		Description:  "Success responses should use the status codes expected for the method, like `201` for a `post`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasSuccessStatusCodes",
+		},
+		HowToFix: operationSuccessStatusCodesFix,
+	}
+}
+
+// GetSchemaNestingDepthRule will check that inline schemas are not nested too deeply.
+func GetSchemaNestingDepthRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Schemas must not be nested too deeply",
+		Id:           SchemaNestingDepth,
+		Formats:      model.AllFormats,
+		Description:  "Inline schemas should not be nested more than 5 levels deep",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasSchemaNestingDepth",
+		},
+		HowToFix: schemaNestingDepthFix,
+	}
+}
+
+// GetOAS3DiscriminatorRequiredRule will check that the propertyName of a discriminator is a defined, required property.
+func GetOAS3DiscriminatorRequiredRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Discriminator properties must be required",
+		Id:           Oas3DiscriminatorRequired,
+		Formats:      model.OAS3AllFormat,
+		Description:  "The `propertyName` of a discriminator must be a defined and required property",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "oasDiscriminatorRequired",
+		},
+		HowToFix: oas3DiscriminatorRequiredFix,
+	}
+}
+
+// GetSchemaEnumTypesRule checks every enum member matches the type and format of its schema.
+func GetSchemaEnumTypesRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check enum members match the schema type and format",
+		Id:           SchemaEnumTypes,
+		Formats:      model.AllFormats,
+		Description:  "Every enum member must match the type and format of its schema",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "enumTypes",
+		},
+		HowToFix: schemaEnumTypesFix,
+	}
+}
+
+// GetParameterAllowEmptyValueRule checks allowEmptyValue is only used by query parameters.
+func GetParameterAllowEmptyValueRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check allowEmptyValue is only used by query parameters",
+		Id:           ParameterAllowEmptyValue,
+		Formats:      model.AllFormats,
+		Description:  "`allowEmptyValue` is only valid for query parameters",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "allowEmptyValue",
+		},
+		HowToFix: parameterAllowEmptyValueFix,
+	}
+}
+
+// GetOAS3ParameterStyleRule checks parameter style and explode combinations are defined for the parameter location.
+func GetOAS3ParameterStyleRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check parameter styles are valid for their location",
+		Id:           Oas3ParameterStyle,
+		Formats:      model.OAS3AllFormat,
+		Description:  "Parameter `style` and `explode` must be a combination defined for the parameter location",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "parameterStyles",
+		},
+		HowToFix: oas3ParameterStyleFix,
+	}
+}
+
+// GetServersUseHTTPSRule checks server URLs use https, apart from local development servers.
+func GetServersUseHTTPSRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check server URLs use https",
+		Id:           ServersUseHTTPS,
+		Formats:      model.OAS3AllFormat,
+		Description:  "Server URLs must use `https`, apart from servers for local development",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryInfo],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "serverHttps",
+		},
+		HowToFix: serversUseHTTPSFix,
+	}
+}
+
+// GetOperationBodyContentRule checks request bodies and responses describe their content.
+func GetOperationBodyContentRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check request bodies and responses have content",
+		Id:           OperationBodyContent,
+		Formats:      model.OAS3AllFormat,
+		Description:  "Request bodies, and responses that are expected to have a body, must describe their `content`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "bodyContent",
+		},
+		HowToFix: operationBodyContentFix,
+	}
+}
+
+// GetParameterIntegerBoundsRule checks integer path and query parameters have a minimum and a maximum.
+func GetParameterIntegerBoundsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check integer parameters are bounded",
+		Id:           ParameterIntegerBounds,
+		Formats:      model.AllFormats,
+		Description:  "Integer path and query parameters must have a `minimum` and a `maximum`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "parameterBounds",
+		},
+		HowToFix: parameterIntegerBoundsFix,
+	}
+}
+
+// GetSchemaClosedObjectsRule checks examples and allOf compositions don't add properties closed objects reject.
+func GetSchemaClosedObjectsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check closed objects are not given properties they reject",
+		Id:           SchemaClosedObjects,
+		Formats:      model.AllFormats,
+		Description:  "Examples and `allOf` compositions must not add properties to schemas with `additionalProperties: false`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "closedObjects",
+		},
+		HowToFix: schemaClosedObjectsFix,
+	}
+}
+
+// GetRequestBodyReadOnlyRequiredRule checks request body schemas don't require readOnly properties.
+func GetRequestBodyReadOnlyRequiredRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check request bodies don't require readOnly properties",
+		Id:           RequestBodyReadOnlyRequired,
+		Formats:      model.AllFormats,
+		Description:  "Schemas used by request bodies must not have properties that are both `readOnly` and `required`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "requestReadOnly",
+		},
+		HowToFix: requestBodyReadOnlyRequiredFix,
+	}
+}
+
+// GetDescriptionQualityRule will check operation and parameter descriptions are not too short, or placeholders.
+func GetDescriptionQualityRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Descriptions must not be placeholders",
+		Id:           DescriptionQuality,
+		Formats:      model.AllFormats,
+		Description:  "Operation and parameter descriptions should be long enough to be useful, and not placeholders",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryDescriptions],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasDescriptionQuality",
+			FunctionOptions: map[string]interface{}{
+				"minLength": 10,
+			},
+		},
+		HowToFix: descriptionQualityFix,
+	}
+}
+
+// GetMediaTypeExampleKeysRule will check media type examples have meaningful names, and a summary or description.
+func GetMediaTypeExampleKeysRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Media type examples must have meaningful names",
+		Id:           MediaTypeExampleKeys,
+		Formats:      model.OAS3AllFormat,
+		Description:  "Media type `examples` should have descriptive names, and a `summary` or `description`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryExamples],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasExampleKeys",
+		},
+		HowToFix: mediaTypeExampleKeysFix,
+	}
+}
+
+// GetTagNameURLSafeRule will check tag names only use characters that are safe in URLs.
+func GetTagNameURLSafeRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Tag names must be URL safe",
+		Id:           TagNameURLSafe,
+		Formats:      model.AllFormats,
+		Description:  "Tag names should only use characters that are safe in URLs, use `x-displayName` for pretty names",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryTags],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasTagNameCharacters",
+		},
+		HowToFix: tagNameURLSafeFix,
+	}
+}
+
+// GetComponentDefinitionDriftRule will check components defined in more than one file have the same definition.
+func GetComponentDefinitionDriftRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check copies of components have not drifted",
+		Id:           ComponentDefinitionDrift,
+		Formats:      model.AllFormats,
+		Description:  "Components defined in more than one file of a multi-file specification should not differ",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "componentDrift",
+		},
+		HowToFix: componentDefinitionDriftFix,
+	}
+}
+
+// GetSchemaBooleanEnumRule will check boolean schemas don't use a redundant enum, or an enum in place of const.
+func GetSchemaBooleanEnumRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Boolean schemas should not use enum",
+		Id:           SchemaBooleanEnum,
+		Formats:      model.AllFormats,
+		Description:  "Boolean schemas should not have an `enum` allowing every value, or a single value (use `const`)",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "booleanEnum",
+		},
+		HowToFix: schemaBooleanEnumFix,
+	}
+}
+
+// GetOperationContentNegotiationRule will check operations consume and produce the same kinds of media types.
+func GetOperationContentNegotiationRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check request and response media types are symmetric",
+		Id:           OperationContentNegotiation,
+		Formats:      model.AllFormats,
+		Description:  "Operations should produce the same kinds of media types (like JSON or XML) that they consume",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "contentNegotiation",
+			FunctionOptions: map[string]interface{}{
+				"policy": "overlap",
+			},
+		},
+		HowToFix: operationContentNegotiationFix,
+	}
+}
+
+// GetPaginationResponseMetadataRule will check paginated collections return the metadata of their pagination style.
+func GetPaginationResponseMetadataRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check paginated responses include pagination metadata",
+		Id:           PaginationResponseMetadata,
+		Formats:      model.AllFormats,
+		Description:  "Responses of paginated collections should include the metadata of their pagination style",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "paginationMetadata",
+		},
+		HowToFix: paginationResponseMetadataFix,
+	}
+}
+
+// GetJSONBinaryFormatRule will check schemas used by JSON media types don't use binary formats.
+func GetJSONBinaryFormatRule() *model.Rule {
+	return &model.Rule{
+		Name:         "JSON media types must not use binary formats",
+		Id:           JSONBinaryFormat,
+		Formats:      model.OAS3Format,
+		Description:  "Schemas used by JSON media types should not use `format: binary` or `format: byte`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "binaryFormats",
+		},
+		HowToFix: jsonBinaryFormatFix,
+	}
+}
+
+// GetPathsPluralCollectionsRule will check path segments naming collections use plural nouns.
+func GetPathsPluralCollectionsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Collections in paths must be plural",
+		Id:           PathsPluralCollections,
+		Formats:      model.AllFormats,
+		Description:  "Path segments naming collections should be plural nouns, like `/pizzas/{pizzaId}`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Style,
+		Severity:     model.SeverityInfo,
+		Then: model.RuleAction{
+			Function: "pluralPaths",
+		},
+		HowToFix: pathsPluralCollectionsFix,
+	}
+}
+
+// GetWebhookSecurityRequiredRule will check that every webhook has a security requirement, or a global default.
+func GetWebhookSecurityRequiredRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Webhooks must be secured",
+		Id:           WebhookSecurityRequired,
+		Formats:      model.OAS3AllFormat,
+		Description:  "Webhook operations must define a `security` requirement, or inherit a global `security` default",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySecurity],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "oasWebhookSecurityRequired",
+			FunctionOptions: map[string]interface{}{
+				"exemptExtension": "x-webhook-signature",
+				"flagOptOut":      false,
+			},
+		},
+		HowToFix: webhookSecurityRequiredFix,
+	}
+}
+
+// GetPostCreatedStatusRule will check that post operations creating a resource respond with 201, not 200.
+func GetPostCreatedStatusRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Creating a resource should respond with 201",
+		Id:           PostCreatedStatus,
+		Formats:      model.AllFormats,
+		Description:  "`post` operations that create a resource should respond with `201`, not only `200`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "createdStatus",
+			FunctionOptions: map[string]interface{}{
+				"operationIdPrefixes": []interface{}{"create", "add", "new", "register", "insert"},
+				"exemptExtension":     "x-idempotent",
+			},
+		},
+		HowToFix: postCreatedStatusFix,
+	}
+}
+
+// GetSchemaArrayItemsRule will check that array schemas define their items.
+func GetSchemaArrayItemsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Array schemas must define items",
+		Id:           SchemaArrayItems,
+		Formats:      model.AllFormats,
+		Description:  "Schemas of type `array` must define their `items` (or `prefixItems`)",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "arrayItems",
+		},
+		HowToFix: schemaArrayItemsFix,
+	}
+}
+
+// GetSchemaEnumExamplesRule will check that enum defaults and examples are members of the enum, and that long enums
+// have an example.
+func GetSchemaEnumExamplesRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Enum defaults and examples must be enum members",
+		Id:           SchemaEnumExamples,
+		Formats:      model.AllFormats,
+		Description:  "The `default` and examples of an `enum` schema must be members of the enum",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "enumExamples",
+			FunctionOptions: map[string]interface{}{
+				"minEnumSize": 5,
+			},
+		},
+		HowToFix: schemaEnumExamplesFix,
+	}
+}
+
+// GetInfoTermsOfServiceURLRule will check that the info termsOfService is a URL.
+func GetInfoTermsOfServiceURLRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Info termsOfService must be a URL",
+		Id:           InfoTermsOfServiceURL,
+		Formats:      model.AllFormats,
+		Description:  "The `termsOfService` of the `info` section must be an absolute URL",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryInfo],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "termsOfService",
+			FunctionOptions: map[string]interface{}{
+				"requireHttps":  false,
+				"allowRelative": false,
+			},
+		},
+		HowToFix: infoTermsOfServiceURLFix,
+	}
+}
+
+// GetOperationDeprecatedSchemasRule will check that operations that are not deprecated don't use deprecated schemas.
+func GetOperationDeprecatedSchemasRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Operations should not use deprecated schemas",
+		Id:           OperationDeprecatedSchemas,
+		Formats:      model.AllFormats,
+		Description:  "Operations that are not deprecated should not use deprecated schemas in their request or responses",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "deprecatedSchemas",
+		},
+		HowToFix: operationDeprecatedSchemasFix,
+	}
+}
+
+// GetSchemaPatternRedosRule will check that schema patterns have no nested quantifiers, which can backtrack
+// catastrophically.
+func GetSchemaPatternRedosRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Patterns should not have nested quantifiers",
+		Id:           SchemaPatternRedos,
+		Formats:      model.AllFormats,
+		Description:  "Schema `pattern` regular expressions with nested quantifiers are at risk of ReDoS",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySecurity],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "redosPatterns",
+		},
+		HowToFix: schemaPatternRedosFix,
+	}
+}
+
+// GetOperationParameterCountRule will check that operations don't have too many parameters.
+func GetOperationParameterCountRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Operations should not have too many parameters",
+		Id:           OperationParameterCount,
+		Formats:      model.AllFormats,
+		Description:  "Operations should have no more than `maxParameters` path, query, header and cookie parameters",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "parameterCount",
+			FunctionOptions: map[string]interface{}{
+				"maxParameters": 10,
+			},
+		},
+		HowToFix: operationParameterCountFix,
+	}
+}
+
+// GetSensitiveCookieParametersRule will check that cookie parameters don't carry credentials.
+func GetSensitiveCookieParametersRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Cookie parameters should not carry credentials",
+		Id:           SensitiveCookieParameters,
+		Formats:      model.OAS3AllFormat,
+		Description:  "Cookie parameters carrying tokens or sessions should be described by a security scheme",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySecurity],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "sensitiveCookies",
+		},
+		HowToFix: sensitiveCookieParametersFix,
+	}
+}
+
+// GetSchemaPropertyCountRule will check that schemas, with their allOf members, don't have too many properties.
+func GetSchemaPropertyCountRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Schemas should not have too many properties",
+		Id:           SchemaPropertyCount,
+		Formats:      model.AllFormats,
+		Description:  "Schemas, with their `allOf` members, should have no more than `maxProperties` properties",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "schemaPropertyCount",
+			FunctionOptions: map[string]interface{}{
+				"maxProperties": 50,
+			},
+		},
+		HowToFix: schemaPropertyCountFix,
+	}
+}
+
+// GetOperationDefaultResponseRule will check that operations define a `default` response.
+func GetOperationDefaultResponseRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Operations should define a default response",
+		Id:           OperationDefaultResponse,
+		Formats:      model.AllFormats,
+		Description:  "Operations should define a `default` response, for status codes they don't document",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "defaultResponse",
+			FunctionOptions: map[string]interface{}{
+				"allowServerErrorRange": false,
+			},
+		},
+		HowToFix: operationDefaultResponseFix,
+	}
+}
+
+// GetOperationResponseStatusCodesRule will check that the response status codes (and ranges) of operations are valid.
+func GetOperationResponseStatusCodesRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Response status codes must be valid",
+		Id:           OperationResponseStatusCodes,
+		Formats:      model.OAS3AllFormat,
+		Description:  "Responses must be keyed by a status code, a status code range (`1XX` to `5XX`) or `default`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryValidation],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "responseStatusCodes",
+		},
+		HowToFix: operationResponseStatusCodesFix,
+	}
+}
+
+// GetSchemaAllOfBoundsRule will check that the bounds merged from the members of allOf compositions can be satisfied.
+func GetSchemaAllOfBoundsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "allOf compositions must not have conflicting bounds",
+		Id:           SchemaAllOfBounds,
+		Formats:      model.AllFormats,
+		Description:  "The bounds merged from the members of an `allOf` composition must not contradict each other",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "allOfBounds",
+		},
+		HowToFix: schemaAllOfBoundsFix,
+	}
+}
+
+// GetOAS2OperationProducesRule will check that swagger responses use media types their operation produces.
+func GetOAS2OperationProducesRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Responses should use media types the operation produces",
+		Id:           Oas2OperationProduces,
+		Formats:      model.OAS2Format,
+		Description:  "Response media types should be in the `produces` of the operation, or of the document",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "operationProduces",
+		},
+		HowToFix: oas2OperationProducesFix,
+	}
+}
+
+// GetExtensionPrefixRule will check that specification extensions are named with a configured prefix.
+func GetExtensionPrefixRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Extensions should be named with a standard prefix",
+		Id:           ExtensionPrefix,
+		Formats:      model.AllFormats,
+		Description:  "Specification extensions should match one of the `prefixes` glob patterns, like `x-acme-*`",
+		Given:        "$",
+		Resolved:     false,
+		Recommended:  false,
+		RuleCategory: model.RuleCategories[model.CategoryValidation],
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "extensionPrefix",
+			FunctionOptions: map[string]interface{}{
+				"prefixes": []string{},
+			},
+		},
+		HowToFix: extensionPrefixFix,
+	}
+}
@@ -102,6 +102,84 @@ const (
 	OwaspConstrainedAdditionalProperties = "owasp-constrained-additionalProperties"
 	OwaspSecurityHostsHttpsOAS2          = "owasp-security-hosts-https-oas2"
 	OwaspSecurityHostsHttpsOAS3          = "owasp-security-hosts-https-oas3"
+	DeprecatedOperationSunset            = "deprecated-operation-sunset"
+	Oas3ServerVariables                  = "oas3-server-variables"
+	NoUnresolvedRefs                     = "no-unresolved-refs"
+	NoCircularRefs                       = "no-circular-refs"
+	NoPathCollisions                     = "no-path-collisions"
+	OperationSummaryLength               = "operation-summary-length"
+	SchemaKeywordConflicts               = "schema-keyword-conflicts"
+	ParameterNamingStyle                 = "parameter-naming-style"
+	Oas3NoConflictingExamples            = "oas3-no-conflicting-examples"
+	OAuth2ScopesDeclared                 = "oauth2-scopes-declared"
+	Oas3RequestBodyRequired              = "oas3-request-body-required"
+	SchemaKnownFormats                   = "schema-known-formats"
+	NoEmptySchemasResponses              = "no-empty-schemas-responses"
+	Oas3MediaTypeSchemaMismatch          = "oas3-media-type-schema-mismatch"
+	EnumConsistentCasing                 = "enum-consistent-casing"
+	ParameterRedefinitionConflict        = "parameter-redefinition-conflict"
+	CollectionPagination                 = "collection-pagination"
+	SecuredOperationAuthResponses        = "secured-operation-auth-responses"
+	SchemaTimestampFormat                = "schema-timestamp-format"
+	SchemaRequiredPropertiesDefined      = "schema-required-properties-defined"
+	ExampleStructuredString              = "example-structured-string"
+	OperationDuplicateText               = "operation-duplicate-text"
+	DescriptionTrailingWhitespace        = "description-trailing-whitespace"
+	SchemaPropertyIdentifiers            = "schema-property-identifiers"
+	SchemaMultipleOfPositive             = "schema-multiple-of-positive"
+	OperationSecurityRequired            = "operation-security-required"
+	OperationGetCacheHeaders             = "operation-get-cache-headers"
+	SchemaRequiredNullable               = "schema-required-nullable"
+	ResponseNoBareArray                  = "response-no-bare-array"
+	SchemaCollectionLimits               = "schema-collection-limits"
+	InfoContactComplete                  = "info-contact-complete"
+	InfoLicenseComplete                  = "info-license-complete"
+	InfoLicenseSPDX                      = "info-license-spdx"
+	SchemaSetUniqueItems                 = "schema-set-unique-items"
+	SchemaDeprecatedConsistency          = "schema-deprecated-consistency"
+	ResponseDeprecatedProperties         = "response-deprecated-properties"
+	OperationOperationIdConvention       = "operation-operationId-convention"
+	SchemaExamplesArray                  = "schema-examples-array"
+	Oas3IgnoredRefSiblings               = "oas3-ignored-$ref-siblings"
+	SchemaNumericBounds                  = "schema-numeric-bounds"
+	PathKeysPathOnly                     = "path-keys-path-only"
+	MediaTypesRegistered                 = "media-types-registered"
+	SchemaOneOfAmbiguous                 = "schema-oneOf-ambiguous"
+	OperationSuccessStatusCodes          = "operation-success-status-codes"
+	SchemaNestingDepth                   = "schema-nesting-depth"
+	Oas3DiscriminatorRequired            = "oas3-discriminator-required"
+	SchemaEnumTypes                      = "schema-enum-types"
+	ParameterAllowEmptyValue             = "parameter-allow-empty-value"
+	Oas3ParameterStyle                   = "oas3-parameter-style"
+	ServersUseHTTPS                      = "servers-use-https"
+	OperationBodyContent                 = "operation-body-content"
+	ParameterIntegerBounds               = "parameter-integer-bounds"
+	SchemaClosedObjects                  = "schema-closed-objects"
+	RequestBodyReadOnlyRequired          = "request-body-read-only-required"
+	DescriptionQuality                   = "description-quality"
+	MediaTypeExampleKeys                 = "media-type-example-keys"
+	TagNameURLSafe                       = "tag-name-url-safe"
+	ComponentDefinitionDrift             = "component-definition-drift"
+	SchemaBooleanEnum                    = "schema-boolean-enum"
+	OperationContentNegotiation          = "operation-content-negotiation"
+	PaginationResponseMetadata           = "pagination-response-metadata"
+	JSONBinaryFormat                     = "json-binary-format"
+	PathsPluralCollections               = "paths-plural-collections"
+	WebhookSecurityRequired              = "webhook-security-required"
+	PostCreatedStatus                    = "post-created-status"
+	SchemaArrayItems                     = "schema-array-items"
+	SchemaEnumExamples                   = "schema-enum-examples"
+	InfoTermsOfServiceURL                = "info-terms-of-service-url"
+	OperationDeprecatedSchemas           = "operation-deprecated-schemas"
+	SchemaPatternRedos                   = "schema-pattern-redos"
+	OperationParameterCount              = "operation-parameter-count"
+	SensitiveCookieParameters            = "sensitive-cookie-parameters"
+	SchemaPropertyCount                  = "schema-property-count"
+	OperationDefaultResponse             = "operation-default-response"
+	OperationResponseStatusCodes         = "operation-response-status-codes"
+	SchemaAllOfBounds                    = "schema-allof-bounds"
+	Oas2OperationProduces                = "oas2-operation-produces"
+	ExtensionPrefix                      = "extension-prefix"
 	SpectralOpenAPI                      = "spectral:oas"
 	SpectralOwasp                        = "spectral:owasp"
 	VacuumOwasp                          = "vacuum:owasp"
@@ -227,6 +305,23 @@ func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSet(ruleset *RuleSet) *R
 	// add definitions.
 	rs.RuleDefinitions = ruleset.RuleDefinitions
 
+	// add any custom categories, built-in categories cannot be re-declared.
+	rs.Categories = make(map[string]*model.RuleCategory)
+	for id, cat := range ruleset.Categories {
+		if model.RuleCategories[id] != nil {
+			rsm.logger.Warn("Custom category clashes with a built-in category, ignoring it", "category", id)
+			continue
+		}
+		custom := &model.RuleCategory{Id: id, Name: id}
+		if cat != nil {
+			if cat.Name != "" {
+				custom.Name = cat.Name
+			}
+			custom.Description = cat.Description
+		}
+		rs.Categories[id] = custom
+	}
+
 	// now all the base rules are in, let's run through the raw definitions and decide
 	// what we need to add, enable, disable, replace or change severity on.
 	for k, v := range rs.RuleDefinitions {
@@ -277,9 +372,16 @@ func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSet(ruleset *RuleSet) *R
 			if dErr != nil {
 				rsm.logger.Error("Unable to decode rule", "error", dErr.Error())
 			}
-			dErr = mapstructure.Decode(newRule["category"], &rc)
-			if dErr != nil {
-				rsm.logger.Error("Unable to decode rule category", "error", dErr.Error())
+
+			// a category can be referenced by id, or supplied as an object.
+			switch cat := newRule["category"].(type) {
+			case string:
+				rc.Id = cat
+			case map[string]interface{}:
+				dErr = mapstructure.Decode(cat, &rc)
+				if dErr != nil {
+					rsm.logger.Error("Unable to decode rule category", "error", dErr.Error())
+				}
 			}
 
 			// add to validation category if it's not supplied
@@ -289,6 +391,15 @@ func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSet(ruleset *RuleSet) *R
 			} else {
 				if model.RuleCategories[rc.Id] != nil {
 					nr.RuleCategory = model.RuleCategories[rc.Id]
+				} else if rs.Categories[rc.Id] != nil {
+					nr.RuleCategory = rs.Categories[rc.Id]
+				} else {
+					rsm.logger.Warn("Rule category has not been declared, using validation instead",
+						"rule", k, "category", rc.Id)
+					nr.RuleCategory = model.RuleCategories[model.CategoryValidation]
+				}
+				if nr.Id == "" {
+					nr.Id = k
 				}
 			}
 
@@ -373,6 +484,84 @@ func GetAllBuiltInRules() map[string]*model.Rule {
 	rules[OperationErrorResponse] = GetOperationErrorResponseRule()
 	rules[Oas2Schema] = GetOAS2SchemaRule()
 	rules[Oas3Schema] = GetOAS3SchemaRule()
+	rules[DeprecatedOperationSunset] = GetDeprecatedOperationSunsetRule()
+	rules[Oas3ServerVariables] = GetOAS3ServerVariablesRule()
+	rules[NoUnresolvedRefs] = GetNoUnresolvedRefsRule()
+	rules[NoCircularRefs] = GetNoCircularRefsRule()
+	rules[NoPathCollisions] = GetNoPathCollisionsRule()
+	rules[OperationSummaryLength] = GetOperationSummaryLengthRule()
+	rules[SchemaKeywordConflicts] = GetSchemaKeywordConflictsRule()
+	rules[ParameterNamingStyle] = GetParameterNamingStyleRule()
+	rules[Oas3NoConflictingExamples] = GetOAS3NoConflictingExamplesRule()
+	rules[OAuth2ScopesDeclared] = GetOAuth2ScopesDeclaredRule()
+	rules[Oas3RequestBodyRequired] = GetOAS3RequestBodyRequiredRule()
+	rules[SchemaKnownFormats] = GetSchemaKnownFormatsRule()
+	rules[NoEmptySchemasResponses] = GetNoEmptySchemasResponsesRule()
+	rules[Oas3MediaTypeSchemaMismatch] = GetOAS3MediaTypeSchemaMismatchRule()
+	rules[EnumConsistentCasing] = GetEnumConsistentCasingRule()
+	rules[ParameterRedefinitionConflict] = GetParameterRedefinitionConflictRule()
+	rules[CollectionPagination] = GetCollectionPaginationRule()
+	rules[SecuredOperationAuthResponses] = GetSecuredOperationAuthResponsesRule()
+	rules[SchemaTimestampFormat] = GetSchemaTimestampFormatRule()
+	rules[SchemaRequiredPropertiesDefined] = GetSchemaRequiredPropertiesDefinedRule()
+	rules[ExampleStructuredString] = GetExampleStructuredStringRule()
+	rules[OperationDuplicateText] = GetOperationDuplicateTextRule()
+	rules[DescriptionTrailingWhitespace] = GetDescriptionTrailingWhitespaceRule()
+	rules[SchemaPropertyIdentifiers] = GetSchemaPropertyIdentifiersRule()
+	rules[SchemaMultipleOfPositive] = GetSchemaMultipleOfPositiveRule()
+	rules[OperationSecurityRequired] = GetOperationSecurityRequiredRule()
+	rules[OperationGetCacheHeaders] = GetOperationGetCacheHeadersRule()
+	rules[SchemaRequiredNullable] = GetSchemaRequiredNullableRule()
+	rules[ResponseNoBareArray] = GetResponseNoBareArrayRule()
+	rules[SchemaCollectionLimits] = GetSchemaCollectionLimitsRule()
+	rules[InfoContactComplete] = GetInfoContactCompleteRule()
+	rules[InfoLicenseComplete] = GetInfoLicenseCompleteRule()
+	rules[InfoLicenseSPDX] = GetInfoLicenseSPDXRule()
+	rules[SchemaSetUniqueItems] = GetSchemaSetUniqueItemsRule()
+	rules[SchemaDeprecatedConsistency] = GetSchemaDeprecatedConsistencyRule()
+	rules[ResponseDeprecatedProperties] = GetResponseDeprecatedPropertiesRule()
+	rules[OperationOperationIdConvention] = GetOperationIdConventionRule()
+	rules[SchemaExamplesArray] = GetSchemaExamplesArrayRule()
+	rules[Oas3IgnoredRefSiblings] = GetOAS3IgnoredRefSiblingsRule()
+	rules[SchemaNumericBounds] = GetSchemaNumericBoundsRule()
+	rules[PathKeysPathOnly] = GetPathKeysPathOnlyRule()
+	rules[MediaTypesRegistered] = GetMediaTypesRegisteredRule()
+	rules[SchemaOneOfAmbiguous] = GetSchemaOneOfAmbiguousRule()
+	rules[OperationSuccessStatusCodes] = GetOperationSuccessStatusCodesRule()
+	rules[SchemaNestingDepth] = GetSchemaNestingDepthRule()
+	rules[Oas3DiscriminatorRequired] = GetOAS3DiscriminatorRequiredRule()
+	rules[SchemaEnumTypes] = GetSchemaEnumTypesRule()
+	rules[ParameterAllowEmptyValue] = GetParameterAllowEmptyValueRule()
+	rules[Oas3ParameterStyle] = GetOAS3ParameterStyleRule()
+	rules[ServersUseHTTPS] = GetServersUseHTTPSRule()
+	rules[OperationBodyContent] = GetOperationBodyContentRule()
+	rules[ParameterIntegerBounds] = GetParameterIntegerBoundsRule()
+	rules[SchemaClosedObjects] = GetSchemaClosedObjectsRule()
+	rules[RequestBodyReadOnlyRequired] = GetRequestBodyReadOnlyRequiredRule()
+	rules[DescriptionQuality] = GetDescriptionQualityRule()
+	rules[MediaTypeExampleKeys] = GetMediaTypeExampleKeysRule()
+	rules[TagNameURLSafe] = GetTagNameURLSafeRule()
+	rules[ComponentDefinitionDrift] = GetComponentDefinitionDriftRule()
+	rules[SchemaBooleanEnum] = GetSchemaBooleanEnumRule()
+	rules[OperationContentNegotiation] = GetOperationContentNegotiationRule()
+	rules[PaginationResponseMetadata] = GetPaginationResponseMetadataRule()
+	rules[JSONBinaryFormat] = GetJSONBinaryFormatRule()
+	rules[PathsPluralCollections] = GetPathsPluralCollectionsRule()
+	rules[WebhookSecurityRequired] = GetWebhookSecurityRequiredRule()
+	rules[PostCreatedStatus] = GetPostCreatedStatusRule()
+	rules[SchemaArrayItems] = GetSchemaArrayItemsRule()
+	rules[SchemaEnumExamples] = GetSchemaEnumExamplesRule()
+	rules[InfoTermsOfServiceURL] = GetInfoTermsOfServiceURLRule()
+	rules[OperationDeprecatedSchemas] = GetOperationDeprecatedSchemasRule()
+	rules[SchemaPatternRedos] = GetSchemaPatternRedosRule()
+	rules[OperationParameterCount] = GetOperationParameterCountRule()
+	rules[SensitiveCookieParameters] = GetSensitiveCookieParametersRule()
+	rules[SchemaPropertyCount] = GetSchemaPropertyCountRule()
+	rules[OperationDefaultResponse] = GetOperationDefaultResponseRule()
+	rules[OperationResponseStatusCodes] = GetOperationResponseStatusCodesRule()
+	rules[SchemaAllOfBounds] = GetSchemaAllOfBoundsRule()
+	rules[Oas2OperationProduces] = GetOAS2OperationProducesRule()
+	rules[ExtensionPrefix] = GetExtensionPrefixRule()
 
 	return rules
 }
@@ -426,12 +615,14 @@ func GenerateDefaultOpenAPIRuleSet() *RuleSet {
 
 // RuleSet represents a collection of Rule definitions.
 type RuleSet struct {
-	Description      string                 `json:"description,omitempty" yaml:"description,omitempty"`
-	DocumentationURI string                 `json:"documentationUrl,omitempty" yaml:"documentationUrl,omitempty"`
-	Formats          []string               `json:"formats,omitempty" yaml:"formats,omitempty"`
-	RuleDefinitions  map[string]interface{} `json:"rules" yaml:"rules"` // this can be either a string, or an entire rule (super annoying, stoplight).
-	Rules            map[string]*model.Rule `json:"-" yaml:"-"`
-	Extends          interface{}            `json:"extends,omitempty" yaml:"extends,omitempty"` // can be string or tuple (again... why stoplight?)
+	Description      string                         `json:"description,omitempty" yaml:"description,omitempty"`
+	DocumentationURI string                         `json:"documentationUrl,omitempty" yaml:"documentationUrl,omitempty"`
+	Formats          []string                       `json:"formats,omitempty" yaml:"formats,omitempty"`
+	RuleDefinitions  map[string]interface{}         `json:"rules" yaml:"rules"` // this can be either a string, or an entire rule (super annoying, stoplight).
+	Rules            map[string]*model.Rule         `json:"-" yaml:"-"`
+	Extends          interface{}                    `json:"extends,omitempty" yaml:"extends,omitempty"`       // can be string or tuple (again... why stoplight?)
+	Categories       map[string]*model.RuleCategory `json:"categories,omitempty" yaml:"categories,omitempty"` // custom categories, keyed by id.
+	Aliases          map[string]interface{}         `json:"aliases,omitempty" yaml:"aliases,omitempty"`       // named paths, that rules can use in their given.
 	extendsMeta      map[string]string
 }
 
@@ -502,6 +693,18 @@ func CreateRuleSetUsingJSON(jsonData []byte) (*RuleSet, error) {
 		return nil, uErr
 	}
 
+	if dErr := rs.unpackRuleDefinitions(); dErr != nil {
+		return nil, dErr
+	}
+	return rs, nil
+}
+
+// unpackRuleDefinitions decodes the raw rule definitions of the ruleset into rules.
+func (rs *RuleSet) unpackRuleDefinitions() error {
+	if err := rs.resolveAliases(); err != nil {
+		return err
+	}
+
 	// raw rules are unpacked, lets copy them over
 	rs.Rules = make(map[string]*model.Rule)
 	for k, v := range rs.RuleDefinitions {
@@ -509,7 +712,7 @@ func CreateRuleSetUsingJSON(jsonData []byte) (*RuleSet, error) {
 			var rule model.Rule
 			dErr := mapstructure.Decode(b, &rule)
 			if dErr != nil {
-				return nil, dErr
+				return dErr
 			}
 			rs.Rules[k] = &rule
 			rule.Resolved = true // default resolved.
@@ -520,7 +723,7 @@ func CreateRuleSetUsingJSON(jsonData []byte) (*RuleSet, error) {
 			b.Resolved = true // default resolved
 		}
 	}
-	return rs, nil
+	return nil
 }
 
 // CreateRuleSetFromData will create a new RuleSet instance from either a JSON or YAML input
@@ -8,9 +8,9 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-var totalRules = 53
+var totalRules = 131
 var totalOwaspRules = 25
-var totalRecommendedRules = 42
+var totalRecommendedRules = 45
 
 func TestBuildDefaultRuleSets(t *testing.T) {
 
@@ -386,3 +386,44 @@ func TestCreateRuleSetFromRuleMap(t *testing.T) {
 	rs := CreateRuleSetFromRuleMap(rules)
 	assert.Len(t, rs.Rules, totalRules)
 }
+
+func TestRuleSetsModel_GenerateRuleSetFromConfig_CustomCategories(t *testing.T) {
+
+	yaml := `extends: [[spectral:oas, off]]
+categories:
+  pci:
+    name: PCI
+    description: Payment card industry rules
+  internal: {}
+rules:
+  check-title:
+    given: $.info.title
+    then:
+      function: truthy
+    category: pci
+  check-description:
+    given: $.info.description
+    then:
+      function: truthy
+    category:
+      id: internal
+  check-contact:
+    given: $.info.contact
+    then:
+      function: truthy
+    category: not-declared`
+
+	def := BuildDefaultRuleSets()
+	rs, err := CreateRuleSetFromData([]byte(yaml))
+	assert.NoError(t, err)
+	repl := def.GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.Len(t, repl.Rules, 3)
+	assert.Len(t, repl.Categories, 2)
+	assert.Equal(t, "PCI", repl.Rules["check-title"].RuleCategory.Name)
+	assert.Equal(t, "Payment card industry rules", repl.Rules["check-title"].RuleCategory.Description)
+	assert.Equal(t, "internal", repl.Rules["check-description"].RuleCategory.Name)
+	assert.Equal(t, "check-description", repl.Rules["check-description"].Id)
+
+	// undeclared categories fall back to validation.
+	assert.Equal(t, model.CategoryValidation, repl.Rules["check-contact"].RuleCategory.Id)
+}
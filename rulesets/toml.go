@@ -0,0 +1,65 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// IsTOMLRuleSet returns true if the location of a ruleset (a file path or a URL) has a `.toml` extension.
+func IsTOMLRuleSet(location string) bool {
+	if i := strings.IndexAny(location, "?#"); i >= 0 && IsRemoteRuleSet(location) {
+		location = location[:i]
+	}
+	return strings.EqualFold(path.Ext(strings.ReplaceAll(location, "\\", "/")), ".toml")
+}
+
+// CreateRuleSetFromTOML will create a new RuleSet instance from a TOML input. TOML tables and arrays map to the same
+// structure as their YAML equivalents, so a rule's `given` can be a string or an array, and its `then` a table, or
+// an array of tables (`[[rules.my-rule.then]]`).
+func CreateRuleSetFromTOML(data []byte) (*RuleSet, error) {
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse TOML ruleset: %w", err)
+	}
+	j, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return CreateRuleSetUsingJSON(j)
+}
+
+// ParseRuleSet will create a new RuleSet instance from the contents of the ruleset at a location. The format is
+// selected by the extension of the location, `.toml` is TOML, `.json`, `.yaml` and `.yml` are JSON or YAML. When the
+// location doesn't name a format (like when it's empty, because the ruleset was not read from a file), the data is
+// parsed as JSON or YAML, and then as TOML if that fails.
+func ParseRuleSet(location string, data []byte) (*RuleSet, error) {
+	if IsTOMLRuleSet(location) {
+		return CreateRuleSetFromTOML(data)
+	}
+	rs, err := CreateRuleSetFromData(data)
+	if err != nil && !isJSONOrYAMLRuleSet(location) {
+		if tomlRS, tomlErr := CreateRuleSetFromTOML(data); tomlErr == nil {
+			return tomlRS, nil
+		}
+	}
+	return rs, err
+}
+
+// isJSONOrYAMLRuleSet returns true if the location of a ruleset has a `.json`, `.yaml` or `.yml` extension.
+func isJSONOrYAMLRuleSet(location string) bool {
+	if i := strings.IndexAny(location, "?#"); i >= 0 && IsRemoteRuleSet(location) {
+		location = location[:i]
+	}
+	switch strings.ToLower(path.Ext(strings.ReplaceAll(location, "\\", "/"))) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
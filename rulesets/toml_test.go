@@ -0,0 +1,144 @@
+package rulesets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTOMLRuleSet(t *testing.T) {
+	assert.True(t, IsTOMLRuleSet("ruleset.toml"))
+	assert.True(t, IsTOMLRuleSet("rules/RULESET.TOML"))
+	assert.True(t, IsTOMLRuleSet("https://quobix.com/vacuum/ruleset.toml?version=2"))
+	assert.False(t, IsTOMLRuleSet("ruleset.yaml"))
+	assert.False(t, IsTOMLRuleSet("https://quobix.com/vacuum/ruleset.yaml#toml"))
+}
+
+func TestCreateRuleSetFromTOML_MatchesYAML(t *testing.T) {
+
+	yml := `extends: [[spectral:oas, recommended]]
+rules:
+  operation-tags: off
+  no-x-internal:
+    description: Operations must not be marked internal
+    severity: warn
+    given: ['$.paths[*][*]', '$.components.schemas[*]']
+    then:
+      - field: x-internal
+        function: falsy
+      - field: x-private
+        function: undefined
+  summary-length:
+    description: Summaries must be short
+    severity: info
+    formats: [oas3]
+    resolved: false
+    given: '$.paths[*][*]'
+    then:
+      field: summary
+      function: length
+      functionOptions:
+        max: 50`
+
+	tml := `extends = [["spectral:oas", "recommended"]]
+
+[rules]
+operation-tags = "off"
+
+[rules.no-x-internal]
+description = "Operations must not be marked internal"
+severity = "warn"
+given = ["$.paths[*][*]", "$.components.schemas[*]"]
+
+[[rules.no-x-internal.then]]
+field = "x-internal"
+function = "falsy"
+
+[[rules.no-x-internal.then]]
+field = "x-private"
+function = "undefined"
+
+[rules.summary-length]
+description = "Summaries must be short"
+severity = "info"
+formats = ["oas3"]
+resolved = false
+given = "$.paths[*][*]"
+then = { field = "summary", function = "length", functionOptions = { max = 50 } }`
+
+	fromYAML, err := CreateRuleSetFromData([]byte(yml))
+	assert.NoError(t, err)
+	fromTOML, err := CreateRuleSetFromTOML([]byte(tml))
+	assert.NoError(t, err)
+
+	assert.Equal(t, fromYAML.Extends, fromTOML.Extends)
+	assert.Equal(t, fromYAML.RuleDefinitions, fromTOML.RuleDefinitions)
+	assert.Equal(t, fromYAML.Rules, fromTOML.Rules)
+	assert.Len(t, fromTOML.Rules["no-x-internal"].Given, 2)
+	assert.Len(t, fromTOML.Rules["no-x-internal"].Then, 2)
+
+	rs := BuildDefaultRuleSets()
+	assert.Equal(t, rs.GenerateRuleSetFromSuppliedRuleSet(fromYAML).Rules,
+		rs.GenerateRuleSetFromSuppliedRuleSet(fromTOML).Rules)
+}
+
+func TestCreateRuleSetFromTOML_Invalid(t *testing.T) {
+	_, err := CreateRuleSetFromTOML([]byte("rules = [oops"))
+	assert.ErrorContains(t, err, "unable to parse TOML ruleset")
+}
+
+func TestParseRuleSet(t *testing.T) {
+	fromYAML, err := ParseRuleSet("examples/custom-ruleset.yaml", mustRead(t, "examples/custom-ruleset.yaml"))
+	assert.NoError(t, err)
+	fromTOML, err := ParseRuleSet("examples/custom-ruleset.toml", mustRead(t, "examples/custom-ruleset.toml"))
+	assert.NoError(t, err)
+	assert.Equal(t, fromYAML.Rules, fromTOML.Rules)
+	assert.Equal(t, fromYAML.DocumentationURI, fromTOML.DocumentationURI)
+
+	// TOML isn't YAML, the extension selects the format.
+	_, err = ParseRuleSet("ruleset.yaml", mustRead(t, "examples/custom-ruleset.toml"))
+	assert.Error(t, err)
+
+	// without an extension, TOML is tried when the data isn't JSON or YAML.
+	fromData, err := ParseRuleSet("", mustRead(t, "examples/custom-ruleset.toml"))
+	assert.NoError(t, err)
+	assert.Equal(t, fromTOML.Rules, fromData.Rules)
+	fromData, err = ParseRuleSet("", mustRead(t, "examples/custom-ruleset.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, fromYAML.Rules, fromData.Rules)
+	_, err = ParseRuleSet("", []byte("rules: [oops"))
+	assert.Error(t, err)
+}
+
+func TestResolveExtends_TOML(t *testing.T) {
+	dir := writeRuleSets(t, map[string]string{
+		"parent.yaml": `rules:
+  parent-rule:
+    description: parent
+    given: $.info
+    then:
+      function: truthy`,
+		"child.toml": `extends = ["./parent.yaml"]
+
+[rules.child-rule]
+description = "child"
+given = "$.info"
+then = { field = "title", function = "truthy" }`,
+	})
+
+	location := filepath.Join(dir, "child.toml")
+	rs, err := ParseRuleSet(location, mustRead(t, location))
+	assert.NoError(t, err)
+	rs, err = ResolveExtends(rs, location, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, rs.Rules, "parent-rule")
+	assert.Contains(t, rs.Rules, "child-rule")
+}
+
+func mustRead(t *testing.T, file string) []byte {
+	b, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	return b
+}
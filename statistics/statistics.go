@@ -15,7 +15,7 @@ func CreateReportStatistics(index *index.SpecIndex, info *datamodel.SpecInfo, re
 	cPCount := index.GetComponentParameterCount()
 
 	var catStats []*reports.CategoryStatistic
-	for _, cat := range model.RuleCategoriesOrdered {
+	for _, cat := range results.GetRuleCategories() {
 		var numIssues, numWarnings, numErrors, numInfo, numHints int
 		numIssues = len(results.GetResultsByRuleCategory(cat.Id))
 		numWarnings = len(results.GetWarningsByRuleCategory(cat.Id))
@@ -2,6 +2,7 @@ package statistics
 
 import (
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
 	"github.com/daveshanley/vacuum/motor"
 	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/stretchr/testify/assert"
@@ -64,3 +65,64 @@ func TestCreateReportStatistics_BigLoadOfIssues(t *testing.T) {
 	assert.Equal(t, 10, stats.OverallScore)
 
 }
+
+func TestCreateReportStatistics_CustomCategories(t *testing.T) {
+
+	rules := `extends: [[spectral:oas, off]]
+categories:
+  pci:
+    name: PCI
+  internal:
+    name: Internal
+  audit:
+    name: Audit
+rules:
+  pci-title:
+    given: $.info.title
+    severity: error
+    then:
+      function: pattern
+      functionOptions:
+        match: 'no chance'
+    category: pci
+  internal-paths:
+    given: $.paths[*]
+    severity: warn
+    then:
+      field: x-internal
+      function: truthy
+    category: internal`
+
+	defaultRuleSets := rulesets.BuildDefaultRuleSets()
+	rs, err := rulesets.CreateRuleSetFromData([]byte(rules))
+	assert.NoError(t, err)
+	selectedRS := defaultRuleSets.GenerateRuleSetFromSuppliedRuleSet(rs)
+	specBytes, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+
+	ruleset := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet: selectedRS,
+		Spec:    specBytes,
+	})
+
+	resultSet := model.NewRuleResultSet(ruleset.Results)
+	resultSet.AddRuleCategories(selectedRS.Categories)
+	stats := CreateReportStatistics(ruleset.Index, ruleset.SpecInfo, resultSet)
+
+	custom := make(map[string]*reports.CategoryStatistic)
+	for _, cat := range stats.CategoryStatistics {
+		custom[cat.CategoryId] = cat
+	}
+	assert.NotNil(t, custom["pci"])
+	assert.NotNil(t, custom["internal"])
+	assert.Equal(t, "PCI", custom["pci"].CategoryName)
+	assert.Equal(t, 1, custom["pci"].NumIssues)
+	assert.Equal(t, 1, custom["pci"].Errors)
+	assert.Equal(t, "Internal", custom["internal"].CategoryName)
+	assert.Equal(t, resultSet.GetWarnCount(), custom["internal"].Warnings)
+	assert.Greater(t, custom["internal"].NumIssues, 1)
+
+	// categories without results are still reported.
+	assert.NotNil(t, custom["audit"])
+	assert.Equal(t, "Audit", custom["audit"].CategoryName)
+	assert.Equal(t, 0, custom["audit"].NumIssues)
+}
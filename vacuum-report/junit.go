@@ -48,7 +48,7 @@ func BuildJUnitReport(resultSet *model.RuleResultSet, t time.Time) []byte {
 	since := time.Since(t)
 	var suites []*TestSuite
 
-	var cats = model.RuleCategoriesOrdered
+	var cats = resultSet.GetRuleCategories()
 
 	tmpl := `
 	{{ .Message }}
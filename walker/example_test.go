@@ -0,0 +1,68 @@
+package walker_test
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/walker"
+	"gopkg.in/yaml.v3"
+)
+
+var exampleSpec = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      operationId: listPizzas
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pizza'
+    delete:
+      responses: {}
+    x-internal: true
+components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        name:
+          type: string`
+
+func ExampleForEachOperation() {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(exampleSpec), &root)
+
+	walker.ForEachOperation(&root, func(op walker.Operation) bool {
+		for i := 0; i < len(op.Node.Content)-1; i += 2 {
+			if op.Node.Content[i].Value == "operationId" {
+				return true
+			}
+		}
+		fmt.Printf("%s %s has no operationId (line %d)\n", op.Method, op.Path, op.KeyNode.Line)
+		return true
+	})
+	// Output: delete /pizza has no operationId (line 15)
+}
+
+func ExampleForEachSchema() {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(exampleSpec), &root)
+
+	walker.ForEachSchema(&root, func(schema walker.Schema) bool {
+		if schema.Ref != "" {
+			fmt.Printf("%s references %s\n", schema.JSONPath, schema.Ref)
+		} else {
+			fmt.Println(schema.JSONPath)
+		}
+		return true
+	})
+	// Output:
+	// $.components.schemas.Pizza
+	// $.components.schemas.Pizza.properties.name
+	// $.paths./pizza.get.responses.200.content.application/json.schema
+	// $.paths./pizza.get.responses.200.content.application/json.schema.items references #/components/schemas/Pizza
+}
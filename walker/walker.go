@@ -0,0 +1,330 @@
+// Copyright 2023 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+// Package walker iterates over the operations, responses and schemas of an OpenAPI or Swagger document, so custom
+// rule functions don't need to re-implement the traversal. The walkers work on the raw *yaml.Node tree a rule
+// function is given, they skip extensions (`x-*` keys) and never follow a `$ref` into a schema, so every schema is
+// visited exactly once, where it is defined.
+package walker
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Operation is an operation found by ForEachOperation.
+type Operation struct {
+	Path     string     // The path the operation belongs to, like `/pizza/{id}`
+	Method   string     // The method of the operation, like `get`
+	PathItem *yaml.Node // The path item holding the operation, resolved if it is a local reference
+	KeyNode  *yaml.Node // The method key of the operation
+	Node     *yaml.Node // The operation itself
+	JSONPath string     // The JSON path of the operation, like `$.paths./pizza/{id}.get`
+}
+
+// Response is a response found by ForEachResponse.
+type Response struct {
+	Code     string     // The status code of the response, like `404`, a range, like `4XX`, or `default`
+	KeyNode  *yaml.Node // The status code key of the response
+	Node     *yaml.Node // The response itself, references are not followed
+	JSONPath string     // The JSON path of the response, like `$.paths./pizza/{id}.get.responses.404`
+}
+
+// Schema is a schema found by ForEachSchema.
+type Schema struct {
+	KeyNode  *yaml.Node // The key holding the schema, nil for schemas in a list (like `allOf` members)
+	Node     *yaml.Node // The schema itself
+	Ref      string     // The `$ref` of the schema, if it is a reference
+	JSONPath string     // The JSON path of the schema, like `$.components.schemas.Pizza.properties.name`
+}
+
+// methods are the path item keys that are operations. OpenAPI keys are case-sensitive, `GET` is not an operation.
+var methods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "options": true, "head": true, "patch": true, "trace": true,
+}
+
+// IsMethod returns true if a path item key is an operation method.
+func IsMethod(key string) bool {
+	return methods[key]
+}
+
+// IsExtension returns true if a key is a specification extension (it starts with `x-`).
+func IsExtension(key string) bool {
+	return strings.HasPrefix(key, "x-")
+}
+
+// ForEachOperation calls fn for every operation under `paths`, in document order, until fn returns false. Path items
+// that are local references (like `$ref: '#/components/pathItems/Pizza'`) are resolved first.
+func ForEachOperation(doc *yaml.Node, fn func(op Operation) bool) {
+	root := documentRoot(doc)
+	paths := mapValue(root, "paths")
+	if paths == nil {
+		return
+	}
+	for i := 0; i < len(paths.Content)-1; i += 2 {
+		opPath, pathItem := paths.Content[i].Value, resolve(root, paths.Content[i+1])
+		if IsExtension(opPath) || pathItem == nil || pathItem.Kind != yaml.MappingNode {
+			continue
+		}
+		for m := 0; m < len(pathItem.Content)-1; m += 2 {
+			key, operation := pathItem.Content[m], pathItem.Content[m+1]
+			if !IsMethod(key.Value) || operation.Kind != yaml.MappingNode {
+				continue
+			}
+			if !fn(Operation{
+				Path:     opPath,
+				Method:   key.Value,
+				PathItem: pathItem,
+				KeyNode:  key,
+				Node:     operation,
+				JSONPath: fmt.Sprintf("$.paths.%s.%s", opPath, key.Value),
+			}) {
+				return
+			}
+		}
+	}
+}
+
+// ForEachResponse calls fn for every response of an operation, in document order, until fn returns false.
+// Extensions are skipped, every other key (including `default`, and keys that are not valid status codes) is passed
+// to fn.
+func ForEachResponse(op Operation, fn func(response Response) bool) {
+	responses := mapValue(op.Node, "responses")
+	if responses == nil || responses.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i < len(responses.Content)-1; i += 2 {
+		key := responses.Content[i]
+		if IsExtension(key.Value) {
+			continue
+		}
+		if !fn(Response{
+			Code:     key.Value,
+			KeyNode:  key,
+			Node:     responses.Content[i+1],
+			JSONPath: fmt.Sprintf("%s.responses.%s", op.JSONPath, key.Value),
+		}) {
+			return
+		}
+	}
+}
+
+// StatusCodeClass returns the class of a response status code, its first digit (4 for `404`), and whether it is a
+// range. OpenAPI 3 allows the ranges `1XX` to `5XX` (the `X` is upper case), a range covers every status code of
+// its class. Keys that are not status codes or ranges, like `default`, `4xx`, `45X` or `600`, have class 0.
+func StatusCodeClass(code string) (class int, isRange bool) {
+	if len(code) != 3 || code[0] < '1' || code[0] > '5' {
+		return 0, false
+	}
+	switch {
+	case code[1:] == "XX":
+		return int(code[0] - '0'), true
+	case code[1] >= '0' && code[1] <= '9' && code[2] >= '0' && code[2] <= '9':
+		return int(code[0] - '0'), false
+	}
+	return 0, false
+}
+
+// ForEachSchema calls fn for every schema in the document until fn returns false. Component (or `definitions`)
+// schemas are visited first, then the schemas of parameters, request bodies, responses and headers, in components,
+// then in path items and operations. Nested schemas (properties, items, compositions and so on) are visited after the schema that
+// holds them. References are passed to fn, but not followed.
+func ForEachSchema(doc *yaml.Node, fn func(schema Schema) bool) {
+	root := documentRoot(doc)
+	if root == nil || root.Kind != yaml.MappingNode {
+		return
+	}
+	w := &schemaWalker{fn: fn}
+
+	components := mapValue(root, "components")
+	w.eachValue(mapValue(components, "schemas"), "$.components.schemas", w.schema)
+	w.eachValue(mapValue(root, "definitions"), "$.definitions", w.schema)
+
+	w.eachValue(mapValue(components, "parameters"), "$.components.parameters", w.parameter)
+	w.eachValue(mapValue(root, "parameters"), "$.parameters", w.parameter)
+	w.eachValue(mapValue(components, "requestBodies"), "$.components.requestBodies", w.requestBody)
+	w.eachValue(mapValue(components, "responses"), "$.components.responses", w.response)
+	w.eachValue(mapValue(root, "responses"), "$.responses", w.response)
+	w.eachValue(mapValue(components, "headers"), "$.components.headers", w.parameter)
+
+	// referenced path items are walked where they are defined.
+	w.eachValue(mapValue(root, "paths"), "$.paths", w.pathItem)
+	w.eachValue(mapValue(components, "pathItems"), "$.components.pathItems", w.pathItem)
+}
+
+// schemaWalker holds the state of ForEachSchema.
+type schemaWalker struct {
+	fn      func(schema Schema) bool
+	stopped bool
+}
+
+type visitor func(key, node *yaml.Node, path string)
+
+// eachValue visits the values of a map, extensions are skipped.
+func (w *schemaWalker) eachValue(node *yaml.Node, path string, visit visitor) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i < len(node.Content)-1 && !w.stopped; i += 2 {
+		if key := node.Content[i]; !IsExtension(key.Value) {
+			visit(key, node.Content[i+1], fmt.Sprintf("%s.%s", path, key.Value))
+		}
+	}
+}
+
+// eachItem visits the items of a list.
+func (w *schemaWalker) eachItem(node *yaml.Node, path string, visit visitor) {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return
+	}
+	for i := 0; i < len(node.Content) && !w.stopped; i++ {
+		visit(nil, node.Content[i], fmt.Sprintf("%s[%d]", path, i))
+	}
+}
+
+// pathItem visits the schemas of the parameters of a path item, and of its operations. References are skipped.
+func (w *schemaWalker) pathItem(_, node *yaml.Node, path string) {
+	if node.Kind != yaml.MappingNode || mapValue(node, "$ref") != nil {
+		return
+	}
+	w.eachItem(mapValue(node, "parameters"), path+".parameters", w.parameter)
+	for i := 0; i < len(node.Content)-1 && !w.stopped; i += 2 {
+		method, operation := node.Content[i].Value, node.Content[i+1]
+		if !IsMethod(method) {
+			continue
+		}
+		opPath := fmt.Sprintf("%s.%s", path, method)
+		w.eachItem(mapValue(operation, "parameters"), opPath+".parameters", w.parameter)
+		if requestBody := mapValue(operation, "requestBody"); requestBody != nil {
+			w.requestBody(nil, requestBody, opPath+".requestBody")
+		}
+		w.eachValue(mapValue(operation, "responses"), opPath+".responses", w.response)
+	}
+}
+
+// parameter visits the schemas of a parameter or a header.
+func (w *schemaWalker) parameter(_, node *yaml.Node, path string) {
+	if schema := mapValue(node, "schema"); schema != nil {
+		w.schema(keyNode(node, "schema"), schema, path+".schema")
+	}
+	w.eachValue(mapValue(node, "content"), path+".content", w.mediaType)
+}
+
+// requestBody visits the schemas of a request body.
+func (w *schemaWalker) requestBody(_, node *yaml.Node, path string) {
+	w.eachValue(mapValue(node, "content"), path+".content", w.mediaType)
+}
+
+// response visits the schemas of a response, and of its headers.
+func (w *schemaWalker) response(_, node *yaml.Node, path string) {
+	if schema := mapValue(node, "schema"); schema != nil {
+		w.schema(keyNode(node, "schema"), schema, path+".schema")
+	}
+	w.eachValue(mapValue(node, "headers"), path+".headers", w.parameter)
+	w.eachValue(mapValue(node, "content"), path+".content", w.mediaType)
+}
+
+// mediaType visits the schema of a media type, and the headers of its encodings.
+func (w *schemaWalker) mediaType(_, node *yaml.Node, path string) {
+	if schema := mapValue(node, "schema"); schema != nil {
+		w.schema(keyNode(node, "schema"), schema, path+".schema")
+	}
+	w.eachValue(mapValue(node, "encoding"), path+".encoding", func(_, encoding *yaml.Node, path string) {
+		w.eachValue(mapValue(encoding, "headers"), path+".headers", w.parameter)
+	})
+}
+
+// schema visits a schema, then the schemas nested in it. Boolean schemas are not visited.
+func (w *schemaWalker) schema(key, node *yaml.Node, path string) {
+	if w.stopped || node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	ref := ""
+	if r := mapValue(node, "$ref"); r != nil {
+		ref = r.Value
+	}
+	if !w.fn(Schema{KeyNode: key, Node: node, Ref: ref, JSONPath: path}) {
+		w.stopped = true
+		return
+	}
+	for i := 0; i < len(node.Content)-1 && !w.stopped; i += 2 {
+		k, v := node.Content[i], node.Content[i+1]
+		childPath := fmt.Sprintf("%s.%s", path, k.Value)
+		switch k.Value {
+		case "properties", "patternProperties", "dependentSchemas", "$defs", "definitions":
+			// property names are not extensions, even if they start with `x-`.
+			if v.Kind == yaml.MappingNode {
+				for p := 0; p < len(v.Content)-1 && !w.stopped; p += 2 {
+					w.schema(v.Content[p], v.Content[p+1], fmt.Sprintf("%s.%s", childPath, v.Content[p].Value))
+				}
+			}
+		case "allOf", "anyOf", "oneOf", "prefixItems":
+			w.eachItem(v, childPath, w.schema)
+		case "items":
+			if v.Kind == yaml.SequenceNode {
+				w.eachItem(v, childPath, w.schema)
+			} else {
+				w.schema(k, v, childPath)
+			}
+		case "not", "additionalProperties", "additionalItems", "unevaluatedProperties", "unevaluatedItems",
+			"contains", "propertyNames", "if", "then", "else":
+			w.schema(k, v, childPath)
+		}
+	}
+}
+
+// documentRoot returns the root mapping of a document.
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc != nil && doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		return doc.Content[0]
+	}
+	return doc
+}
+
+// keyNode returns the key node of a key in a map, or nil.
+func keyNode(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+// mapValue returns the value of a key in a map, or nil.
+func mapValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// resolve follows a local (`#/...`) reference, other nodes (and references that can't be followed) are returned as
+// they are. Chains of references are followed, up to a limit, in case they loop.
+func resolve(root, node *yaml.Node) *yaml.Node {
+	for hops := 0; hops < 10; hops++ {
+		ref := mapValue(node, "$ref")
+		if ref == nil || !strings.HasPrefix(ref.Value, "#/") {
+			return node
+		}
+		target := root
+		for _, segment := range strings.Split(strings.TrimPrefix(ref.Value, "#/"), "/") {
+			segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+			if target = mapValue(target, segment); target == nil {
+				return node
+			}
+		}
+		node = target
+	}
+	return node
+}
@@ -0,0 +1,251 @@
+package walker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var walkerTestSpec = `openapi: 3.1.0
+paths:
+  x-internal:
+    get:
+      responses: {}
+  /pizza:
+    summary: pizzas
+    parameters:
+      - name: region
+        in: query
+        schema:
+          type: string
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+      responses:
+        "200":
+          description: ok
+          headers:
+            ETag:
+              schema:
+                type: string
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pizza'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pizza'
+      responses: {}
+    x-get:
+      responses: {}
+    GET:
+      responses: {}
+  /pizza/{id}:
+    $ref: '#/components/pathItems/PizzaById'
+components:
+  pathItems:
+    PizzaById:
+      delete:
+        responses:
+          "204":
+            description: deleted
+            content:
+              application/json:
+                schema:
+                  type: object
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+  schemas:
+    x-ignored:
+      type: string
+    Pizza:
+      type: object
+      x-schema: true
+      properties:
+        x-name:
+          type: string
+        toppings:
+          type: array
+          items:
+            allOf:
+              - $ref: '#/components/schemas/Topping'
+              - type: object
+        size:
+          oneOf:
+            - type: integer
+            - type: string
+        extra: true
+    Topping:
+      type: string`
+
+func parseWalkerTestSpec(t *testing.T) *yaml.Node {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(walkerTestSpec), &root))
+	return &root
+}
+
+func TestForEachOperation(t *testing.T) {
+	var found []string
+	ForEachOperation(parseWalkerTestSpec(t), func(op Operation) bool {
+		found = append(found, op.JSONPath)
+		return true
+	})
+	assert.Equal(t, []string{"$.paths./pizza.get", "$.paths./pizza.post", "$.paths./pizza/{id}.delete"}, found)
+}
+
+func TestForEachOperation_ResolvesPathItems(t *testing.T) {
+	var operation Operation
+	ForEachOperation(parseWalkerTestSpec(t), func(op Operation) bool {
+		operation = op
+		return true
+	})
+	assert.Equal(t, "/pizza/{id}", operation.Path)
+	assert.Equal(t, "delete", operation.Method)
+	assert.Equal(t, 45, operation.KeyNode.Line)
+	assert.Equal(t, yaml.MappingNode, operation.Node.Kind)
+}
+
+func TestForEachOperation_Stop(t *testing.T) {
+	count := 0
+	ForEachOperation(parseWalkerTestSpec(t), func(op Operation) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count)
+}
+
+func TestForEachOperation_NoPaths(t *testing.T) {
+	ForEachOperation(nil, func(op Operation) bool {
+		t.Fail()
+		return true
+	})
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte("openapi: 3.1.0"), &root)
+	ForEachOperation(&root, func(op Operation) bool {
+		t.Fail()
+		return true
+	})
+}
+
+func TestForEachSchema(t *testing.T) {
+	var found []string
+	ForEachSchema(parseWalkerTestSpec(t), func(schema Schema) bool {
+		found = append(found, schema.JSONPath)
+		return true
+	})
+	assert.Equal(t, []string{
+		"$.components.schemas.Pizza",
+		"$.components.schemas.Pizza.properties.x-name",
+		"$.components.schemas.Pizza.properties.toppings",
+		"$.components.schemas.Pizza.properties.toppings.items",
+		"$.components.schemas.Pizza.properties.toppings.items.allOf[0]",
+		"$.components.schemas.Pizza.properties.toppings.items.allOf[1]",
+		"$.components.schemas.Pizza.properties.size",
+		"$.components.schemas.Pizza.properties.size.oneOf[0]",
+		"$.components.schemas.Pizza.properties.size.oneOf[1]",
+		"$.components.schemas.Topping",
+		"$.components.parameters.Limit.schema",
+		"$.paths./pizza.parameters[0].schema",
+		"$.paths./pizza.get.responses.200.headers.ETag.schema",
+		"$.paths./pizza.get.responses.200.content.application/json.schema",
+		"$.paths./pizza.get.responses.200.content.application/json.schema.items",
+		"$.paths./pizza.post.requestBody.content.application/json.schema",
+		"$.components.pathItems.PizzaById.delete.responses.204.content.application/json.schema",
+	}, found)
+}
+
+func TestForEachSchema_References(t *testing.T) {
+	var refs []string
+	ForEachSchema(parseWalkerTestSpec(t), func(schema Schema) bool {
+		if schema.Ref != "" {
+			refs = append(refs, schema.Ref)
+		}
+		return true
+	})
+	assert.Equal(t, []string{"#/components/schemas/Topping", "#/components/schemas/Pizza",
+		"#/components/schemas/Pizza"}, refs)
+}
+
+func TestForEachSchema_Stop(t *testing.T) {
+	count := 0
+	ForEachSchema(parseWalkerTestSpec(t), func(schema Schema) bool {
+		count++
+		return count < 3
+	})
+	assert.Equal(t, 3, count)
+}
+
+func TestForEachSchema_Swagger(t *testing.T) {
+	spec := `swagger: "2.0"
+definitions:
+  Pizza:
+    type: object
+paths:
+  /pizza:
+    post:
+      parameters:
+        - in: body
+          name: pizza
+          schema:
+            $ref: '#/definitions/Pizza'
+      responses:
+        "200":
+          description: ok
+          schema:
+            type: string`
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &root)
+
+	var found []string
+	ForEachSchema(&root, func(schema Schema) bool {
+		found = append(found, schema.JSONPath)
+		return true
+	})
+	assert.Equal(t, []string{
+		"$.definitions.Pizza",
+		"$.paths./pizza.post.parameters[0].schema",
+		"$.paths./pizza.post.responses.200.schema",
+	}, found)
+}
+
+func TestIsMethod(t *testing.T) {
+	assert.True(t, IsMethod("get"))
+	assert.True(t, IsMethod("trace"))
+	assert.False(t, IsMethod("GET"))
+	assert.False(t, IsMethod("parameters"))
+	assert.False(t, IsMethod("x-get"))
+}
+
+func TestForEachResponse(t *testing.T) {
+	var found []string
+	ForEachOperation(parseWalkerTestSpec(t), func(op Operation) bool {
+		ForEachResponse(op, func(response Response) bool {
+			found = append(found, response.JSONPath)
+			return true
+		})
+		return true
+	})
+	assert.Equal(t, []string{"$.paths./pizza.get.responses.200", "$.paths./pizza/{id}.delete.responses.204"}, found)
+}
+
+func TestStatusCodeClass(t *testing.T) {
+	for code, expected := range map[string][2]any{
+		"200": {2, false}, "404": {4, false}, "599": {5, false}, "4XX": {4, true}, "1XX": {1, true},
+		"default": {0, false}, "4xx": {0, false}, "45X": {0, false}, "600": {0, false}, "099": {0, false}, "40": {0, false},
+	} {
+		class, isRange := StatusCodeClass(code)
+		assert.Equal(t, expected[0], class, code)
+		assert.Equal(t, expected[1], isRange, code)
+	}
+}